@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentController serves the files attached to the tasks, under /tasks/:id/attachments.
+type AttachmentController struct {
+	AttachmentUseCase domain.AttachmentUseCase
+}
+
+// attachmentFormField is the field of the multipart form carrying the uploaded file.
+const attachmentFormField = "file"
+
+// GetAttachments lists the attachments of the task with the specified ID, oldest first.
+func (ac *AttachmentController) GetAttachments(c *gin.Context) {
+	attachments, err := ac.AttachmentUseCase.GetAttachments(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"attachments": attachments})
+}
+
+// CreateAttachment attaches the file sent in the "file" field of a multipart/form-data body
+// to the task with the specified ID and returns the attachment with a created status code.
+// The file is streamed to the blob store as it is read, never buffered whole in memory nor on disk.
+// It returns a request entity too large response for a file over the maximum size and
+// an unsupported media type response for a file of a type that is not allowed.
+func (ac *AttachmentController) CreateAttachment(c *gin.Context) {
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		errorResponse(c, domain.NewError(domain.ErrValidation, "the file must be sent as multipart/form-data"))
+		return
+	}
+	part, err := reader.NextPart()
+	if err == io.EOF {
+		errorResponse(c, domain.NewError(domain.ErrValidation, "the form has no %s field", attachmentFormField))
+		return
+	}
+	if err != nil {
+		errorResponse(c, domain.NewError(domain.ErrValidation, "malformed multipart body"))
+		return
+	}
+	defer part.Close()
+	// the file must come first, so the form is never read past it
+	if part.FormName() != attachmentFormField || part.FileName() == "" {
+		errorResponse(c, domain.NewError(domain.ErrValidation, "the first field of the form must be the %s", attachmentFormField))
+		return
+	}
+
+	attachment, err := ac.AttachmentUseCase.AddAttachment(c, c.Param("id"), part.FileName(), part)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// DownloadAttachment streams the content of an attachment of the task with the specified ID.
+// The response carries the detected content type and the file name in its Content-Disposition header.
+func (ac *AttachmentController) DownloadAttachment(c *gin.Context) {
+	attachment, content, err := ac.AttachmentUseCase.OpenAttachment(c, c.Param("id"), c.Param("attachment_id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	defer content.Close()
+
+	headers := map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, headers)
+}
+
+// DeleteAttachment deletes an attachment of the task with the specified ID.
+// It returns a forbidden response unless the caller uploaded the attachment or is an admin.
+func (ac *AttachmentController) DeleteAttachment(c *gin.Context) {
+	err := ac.AttachmentUseCase.DeleteAttachment(c, c.Param("id"), c.Param("attachment_id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChecklistController serves the checklists of the tasks, under /tasks/:id/checklist.
+type ChecklistController struct {
+	ChecklistUseCase domain.ChecklistUseCase
+}
+
+// checklistItemRequest is the body of a new checklist item.
+type checklistItemRequest struct {
+	Title    string `json:"title" binding:"required"`
+	Required bool   `json:"required"`
+}
+
+// checklistOrderRequest is the body of a new order of the checklist.
+type checklistOrderRequest struct {
+	ItemIDs []string `json:"item_ids" binding:"required"`
+}
+
+// GetChecklist retrieves the items of the checklist of the task with the specified ID, in order,
+// in a JSON envelope.
+func (cc *ChecklistController) GetChecklist(c *gin.Context) {
+	items, err := cc.ChecklistUseCase.GetChecklist(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// AddChecklistItem appends the item in the request body to the checklist of the task with the specified ID
+// and returns it with a created status code.
+func (cc *ChecklistController) AddChecklistItem(c *gin.Context) {
+	var req checklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	item, err := cc.ChecklistUseCase.AddChecklistItem(c, c.Param("id"), req.Title, req.Required)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// ReorderChecklist puts the items of the checklist in the order of the item IDs in the request body
+// and returns the reordered checklist in the same envelope as GetChecklist.
+func (cc *ChecklistController) ReorderChecklist(c *gin.Context) {
+	var req checklistOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	items, err := cc.ChecklistUseCase.ReorderChecklist(c, c.Param("id"), req.ItemIDs)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+// CompleteChecklistItem marks an item of the checklist as done and returns it.
+func (cc *ChecklistController) CompleteChecklistItem(c *gin.Context) {
+	cc.setDone(c, true)
+}
+
+// ReopenChecklistItem marks a done item of the checklist as open again and returns it.
+func (cc *ChecklistController) ReopenChecklistItem(c *gin.Context) {
+	cc.setDone(c, false)
+}
+
+// setDone completes or reopens the checklist item named in the path.
+func (cc *ChecklistController) setDone(c *gin.Context, done bool) {
+	item, err := cc.ChecklistUseCase.CompleteChecklistItem(c, c.Param("id"), c.Param("item_id"), done)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteChecklistItem deletes an item of the checklist of a task.
+func (cc *ChecklistController) DeleteChecklistItem(c *gin.Context) {
+	err := cc.ChecklistUseCase.DeleteChecklistItem(c, c.Param("id"), c.Param("item_id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentController serves the comments of the tasks, under /tasks/:id/comments.
+type CommentController struct {
+	CommentUseCase domain.CommentUseCase
+}
+
+// commentRequest is the body of a new or edited comment.
+type commentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// GetComments retrieves one page of the comments of the task with the specified ID, oldest first.
+// It accepts the cursor and limit query parameters and returns a JSON envelope with the comments
+// and the cursor of the next page.
+func (cc *CommentController) GetComments(c *gin.Context) {
+	query := domain.CommentQuery{Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			errorResponse(c, domain.NewError(domain.ErrValidation, "invalid limit %q", limit))
+			return
+		}
+		query.Limit = n
+	}
+
+	page, err := cc.CommentUseCase.GetComments(c, c.Param("id"), query)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// CreateComment posts the comment in the request body on the task with the specified ID
+// and returns it with a created status code.
+func (cc *CommentController) CreateComment(c *gin.Context) {
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	comment, err := cc.CommentUseCase.AddComment(c, c.Param("id"), req.Body)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdateComment replaces the body of a comment with the one in the request body and returns the comment.
+// It returns a forbidden response unless the caller is the author of the comment or an admin.
+func (cc *CommentController) UpdateComment(c *gin.Context) {
+	var req commentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	comment, err := cc.CommentUseCase.EditComment(c, c.Param("id"), c.Param("comment_id"), req.Body)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteComment deletes a comment of a task.
+// It returns a forbidden response unless the caller is the author of the comment or an admin.
+func (cc *CommentController) DeleteComment(c *gin.Context) {
+	err := cc.CommentUseCase.DeleteComment(c, c.Param("id"), c.Param("comment_id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
@@ -3,6 +3,9 @@ package controllers
 import (
 	domain "example/go-clean-architecture/Domain"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -17,66 +20,279 @@ type UserController struct {
 }
 var validate = validator.New()
 
+// credentials is the body of the sign-up, login and account creation requests. The password is bound from it
+// rather than from domain.User, which never reads nor writes the password as JSON.
+type credentials struct {
+	OrgID    string `json:"org_id"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
 func (uc *UserController) CreateAccount(c *gin.Context) {
 
-	var newUser domain.User
-	if err := c.ShouldBindJSON(&newUser); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var req credentials
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
 		return
 	}
+	newUser := domain.User{OrgID: req.OrgID, Username: req.Username, Password: req.Password}
 	err := validate.Struct(newUser)
 	if err != nil{
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorResponse(c, validationError(err))
+		return
 	}
 	user, err := uc.UserUseCase.CreateAccount(c, &newUser)
 
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, user)
 }
 
+// CreateUser creates an account for a regular user in the organization of the caller from the username
+// and password in the request body, and returns it with 201 Created.
+func (uc *UserController) CreateUser(c *gin.Context) {
+	var req credentials
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+	newUser := domain.User{Username: req.Username, Password: req.Password}
+	if err := validate.Struct(newUser); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+	user, err := uc.UserUseCase.CreateUser(c, &newUser)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, user)
+}
+
 func (uc *UserController) Login(c *gin.Context) {
-	// by using username and password
-	var user domain.User
+	// by using organization, username and password
+	var req credentials
 
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
 		return
 	}
 
-	user, token, err := uc.UserUseCase.AuthenticateUser(c, user.Username, user.Password)
+	user, tokens, err := uc.UserUseCase.AuthenticateUser(c, req.OrgID, req.Username, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		errorResponse(c, err)
 		return 
 	}
-	c.JSON(http.StatusAccepted, gin.H{"token": token, "user": user})
+	c.JSON(http.StatusAccepted, gin.H{
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
+		"user":          user,
+	})
+}
+
+// refreshTokenRequest is the body of the token refresh and logout requests.
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// RefreshToken exchanges the refresh token in the request body for a new token pair.
+// The presented refresh token can not be used again afterwards.
+func (uc *UserController) RefreshToken(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	tokens, err := uc.UserUseCase.RefreshSession(c, req.RefreshToken)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout revokes the session of the refresh token in the request body,
+// including the access tokens issued for it.
+func (uc *UserController) Logout(c *gin.Context) {
+	var req refreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	if err := uc.UserUseCase.Logout(c, req.RefreshToken); err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
 }
 
 func (uc *UserController) PromoteUser(c *gin.Context) {
 	userId := c.Param("id")
 	err := uc.UserUseCase.UpdateUserRole(c, userId)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		errorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "promoted to admin"})
 }
 
-// GetTasks retrieves all tasks from the database.
-// It returns a JSON response with the fetched tasks.
-// If there is an error fetching the tasks, it returns a JSON response with an error message.
+// roleAssignment is the body of a change of the role of a user.
+type roleAssignment struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// AssignRole gives the role in the request body to the user with the ID in the path.
+// It returns the ID of the user with its new role.
+func (uc *UserController) AssignRole(c *gin.Context) {
+	var req roleAssignment
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+	user, err := uc.UserUseCase.AssignUserRole(c, c.Param("id"), req.Role)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"id": user.ID, "role": user.Role})
+}
+
+// GetUsers retrieves one page of the users of the organization of the caller, in the order they signed up.
+// It accepts the search, role, cursor and limit query parameters and returns a JSON envelope with the users
+// and the cursor of the next page.
+func (uc *UserController) GetUsers(c *gin.Context) {
+	query := domain.UserQuery{Search: c.Query("search"), Role: c.Query("role"), Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			errorResponse(c, domain.NewError(domain.ErrValidation, "invalid limit %q", limit))
+			return
+		}
+		query.Limit = n
+	}
+
+	page, err := uc.UserUseCase.GetUsers(c, query)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// GetUser retrieves the user with the ID in the path.
+func (uc *UserController) GetUser(c *gin.Context) {
+	user, err := uc.UserUseCase.GetUser(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// DeactivateUser blocks the logins of the user with the ID in the path and returns it.
+func (uc *UserController) DeactivateUser(c *gin.Context) {
+	user, err := uc.UserUseCase.DeactivateUser(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// ActivateUser lets the user with the ID in the path log in again and returns it.
+func (uc *UserController) ActivateUser(c *gin.Context) {
+	user, err := uc.UserUseCase.ActivateUser(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser deletes the user with the ID in the path. Its tasks are reassigned to the user
+// in the reassign_to query parameter, or to the caller if it is missing.
+func (uc *UserController) DeleteUser(c *gin.Context) {
+	err := uc.UserUseCase.DeleteUser(c, c.Param("id"), c.Query("reassign_to"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
+
+// GetTasks retrieves one page of tasks.
+// It accepts the status, due_from, due_to, title_prefix, sort, cursor and limit query parameters
+// and returns a JSON envelope with the tasks and the cursor of the next page.
+// If the query is invalid it returns a bad request response.
 func (tc *TaskController) GetTasks(c *gin.Context) {
-	tasks, err := tc.TaskUseCase.GetAllTasks(c)
+	query, err := parseTaskQuery(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	page, err := tc.TaskUseCase.GetTasks(c, query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "failed to fetch tasks"})
+		errorResponse(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "the data is", "tasks" :tasks })
+	c.JSON(http.StatusOK, page)
+}
+
+// parseTaskQuery reads the task list filters from the request query string.
+// Due dates are expected in RFC 3339 format and labels as a comma-separated list.
+func parseTaskQuery(c *gin.Context) (domain.TaskQuery, error) {
+	query := domain.TaskQuery{
+		Status:      c.Query("status"),
+		TitlePrefix: c.Query("title_prefix"),
+		Sort:        c.Query("sort"),
+		Cursor:      c.Query("cursor"),
+		ParentID:    c.Query("parent_id"),
+		LabelMatch:  c.Query("label_match"),
+	}
+	if labels := c.Query("label"); labels != "" {
+		query.Labels = strings.Split(labels, ",")
+	}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return domain.TaskQuery{}, domain.NewError(domain.ErrValidation, "invalid limit %q", limit)
+		}
+		query.Limit = n
+	}
+	dueFrom, err := parseTimeParam(c, "due_from")
+	if err != nil {
+		return domain.TaskQuery{}, err
+	}
+	dueTo, err := parseTimeParam(c, "due_to")
+	if err != nil {
+		return domain.TaskQuery{}, err
+	}
+	query.DueFrom, query.DueTo = dueFrom, dueTo
+	return query, nil
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter.
+// It returns nil when the parameter is absent.
+func parseTimeParam(c *gin.Context, param string) (*time.Time, error) {
+	value := c.Query(param)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "invalid %s %q, expected RFC 3339", param, value)
+	}
+	return &t, nil
 }
 
 // GetTask retrieves a task by its ID.
+// The ETag header of the response carries the version of the task, to send back in If-Match when changing it.
 //
 // Parameters:
 // - c: The gin context.
@@ -88,9 +304,10 @@ func (tc *TaskController) GetTask(c *gin.Context) {
 	id := c.Param("id")
 	task, err := tc.TaskUseCase.GetTaskByID(c, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		errorResponse(c, err)
 		return
 	}
+	setTaskETag(c, task)
 	c.JSON(http.StatusOK, task)
 }
 
@@ -98,25 +315,28 @@ func (tc *TaskController) GetTask(c *gin.Context) {
 // It binds the JSON data from the request body to a newTask variable.
 // If the JSON binding fails, it returns a JSON response with a bad request status code and an error message.
 // Otherwise, it calls the AddNewTask method of the TaskUseCase to add the new task.
-// If an error occurs during the task creation, it returns a JSON response with an internal server error status code and an error message.
+// If an error occurs during the task creation, it returns the error response matching the error kind.
 // Finally, it returns a JSON response with a created status code and the created task.
 func (tc *TaskController) CreateTask(c *gin.Context) {
 	var newTask domain.Task
 
 	if err := c.ShouldBindJSON(&newTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		errorResponse(c, validationError(err))
 		return
 	}
 
 	createdTask, err := tc.TaskUseCase.AddNewTask(c, newTask)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		errorResponse(c, err)
 		return
 	}
+	setTaskETag(c, createdTask)
 	c.JSON(http.StatusCreated, createdTask)
 }
 
 // UpdateTask updates a task with the specified ID.
+// With an If-Match header the task is only updated if it is still at that version,
+// otherwise the response is 412 Precondition Failed.
 //
 // Parameters:
 // - c: The gin context.
@@ -129,19 +349,151 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 
 	var newTask domain.Task
 	if err := c.ShouldBindJSON(&newTask); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		errorResponse(c, validationError(err))
 		return
 	}
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	newTask.Version = version
 
 	res, err := tc.TaskUseCase.ModifyTaskById(c, newTask, id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		errorResponse(c, err)
 		return
 	}
+	setTaskETag(c, res)
 	c.JSON(http.StatusOK, res)
 }
 
-// DeleteTask deletes a task by its ID.
+// PatchTask applies a JSON Merge Patch (RFC 7396) to the task with the specified ID.
+// Only the fields present in the patch are changed, so clients do not need to resend the whole task.
+// It returns the updated task, a bad request response for an invalid patch and an
+// unsupported media type response unless the body is sent as application/merge-patch+json or application/json.
+// Like UpdateTask, it honours the If-Match header.
+func (tc *TaskController) PatchTask(c *gin.Context) {
+	if ct := c.ContentType(); ct != mergePatchContentType && ct != jsonContentType {
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "the patch must be sent as " + mergePatchContentType,
+			"code":  "unsupported_media_type",
+		})
+		return
+	}
+	patch, err := parseTaskMergePatch(c.Request.Body)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	if patch.Version, err = ifMatchVersion(c); err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	task, err := tc.TaskUseCase.PatchTaskById(c, patch, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, task)
+}
+
+// transitionRequest is the body of a status transition: the status the task moves to.
+type transitionRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// TransitionTask moves the task with the specified ID to another status of the workflow.
+// It returns the updated task, a bad request response for an unknown status and
+// a conflict response if the workflow does not allow the transition.
+func (tc *TaskController) TransitionTask(c *gin.Context) {
+	var req transitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	task, err := tc.TaskUseCase.TransitionTask(c, c.Param("id"), req.Status)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, task)
+}
+
+// dependencyRequest is the body of a new dependency: the task the task depends on.
+type dependencyRequest struct {
+	DependsOnID string `json:"depends_on_id" binding:"required"`
+}
+
+// AddDependency makes the task with the specified ID depend on the task in the request body.
+// It returns the task, blocked if the other task is unresolved, and a conflict response
+// if the dependency already exists or would create a cycle.
+func (tc *TaskController) AddDependency(c *gin.Context) {
+	var req dependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	task, err := tc.TaskUseCase.AddDependency(c, c.Param("id"), req.DependsOnID)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, task)
+}
+
+// RemoveDependency removes the dependency of the task with the specified ID on the task named by depends_on_id
+// and returns the task.
+func (tc *TaskController) RemoveDependency(c *gin.Context) {
+	task, err := tc.TaskUseCase.RemoveDependency(c, c.Param("id"), c.Param("depends_on_id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, task)
+}
+
+// GetTaskGraph retrieves the dependency graph around the task with the specified ID.
+func (tc *TaskController) GetTaskGraph(c *gin.Context) {
+	graph, err := tc.TaskUseCase.GetTaskGraph(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, graph)
+}
+
+// GetTaskHistory retrieves one page of the history of the task with the specified ID, oldest changes first.
+// It accepts the cursor and limit query parameters and returns a JSON envelope with the entries
+// and the cursor of the next page.
+func (tc *TaskController) GetTaskHistory(c *gin.Context) {
+	query := domain.TaskHistoryQuery{Cursor: c.Query("cursor")}
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			errorResponse(c, domain.NewError(domain.ErrValidation, "invalid limit %q", limit))
+			return
+		}
+		query.Limit = n
+	}
+
+	page, err := tc.TaskUseCase.GetTaskHistory(c, c.Param("id"), query)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// DeleteTask moves a task to the trash by its ID.
+// With an If-Match header the task is only deleted if it is still at that version.
 //
 // Parameters:
 // - c: The gin context.
@@ -152,10 +504,131 @@ func (tc *TaskController) UpdateTask(c *gin.Context) {
 func (tc *TaskController) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
 
-	err := tc.TaskUseCase.DeleteTaskById(c, id)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	err = tc.TaskUseCase.DeleteTaskById(c, id, version)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+		errorResponse(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
 }
+
+// GetDeletedTasks retrieves one page of the tasks in the trash.
+// It accepts the same query parameters as GetTasks and returns the same JSON envelope;
+// the tasks carry the deleted_at and deleted_by fields.
+func (tc *TaskController) GetDeletedTasks(c *gin.Context) {
+	query, err := parseTaskQuery(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+
+	page, err := tc.TaskUseCase.GetDeletedTasks(c, query)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// RestoreTask takes the task with the specified ID out of the trash and returns it.
+// It returns a not found response if the task is not in the trash.
+func (tc *TaskController) RestoreTask(c *gin.Context) {
+	task, err := tc.TaskUseCase.RestoreTaskById(c, c.Param("id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, task)
+}
+
+// GetProjectTasks retrieves one page of the tasks of the project with the ID in the path.
+// It accepts the same query parameters as GetTasks and returns the same JSON envelope.
+func (tc *TaskController) GetProjectTasks(c *gin.Context) {
+	query, err := parseTaskQuery(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	query.ProjectID = c.Param("pid")
+
+	page, err := tc.TaskUseCase.GetTasks(c, query)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// CreateProjectTask creates the task in the request body in the project with the ID in the path,
+// and returns it like CreateTask.
+func (tc *TaskController) CreateProjectTask(c *gin.Context) {
+	var newTask domain.Task
+	if err := c.ShouldBindJSON(&newTask); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+	newTask.ProjectID = c.Param("pid")
+
+	createdTask, err := tc.TaskUseCase.AddNewTask(c, newTask)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	setTaskETag(c, createdTask)
+	c.JSON(http.StatusCreated, createdTask)
+}
+
+// GetProjectTask retrieves the task with the specified ID like GetTask,
+// with a not found response if it is not a task of the project with the ID in the path.
+func (tc *TaskController) GetProjectTask(c *gin.Context) {
+	task, ok := tc.projectTask(c)
+	if !ok {
+		return
+	}
+	setTaskETag(c, task)
+	c.JSON(http.StatusOK, task)
+}
+
+// UpdateProjectTask updates the task with the specified ID like UpdateTask,
+// if it is a task of the project with the ID in the path.
+func (tc *TaskController) UpdateProjectTask(c *gin.Context) {
+	if _, ok := tc.projectTask(c); ok {
+		tc.UpdateTask(c)
+	}
+}
+
+// PatchProjectTask patches the task with the specified ID like PatchTask,
+// if it is a task of the project with the ID in the path.
+func (tc *TaskController) PatchProjectTask(c *gin.Context) {
+	if _, ok := tc.projectTask(c); ok {
+		tc.PatchTask(c)
+	}
+}
+
+// DeleteProjectTask moves the task with the specified ID to the trash like DeleteTask,
+// if it is a task of the project with the ID in the path.
+func (tc *TaskController) DeleteProjectTask(c *gin.Context) {
+	if _, ok := tc.projectTask(c); ok {
+		tc.DeleteTask(c)
+	}
+}
+
+// projectTask retrieves the task with the specified ID and checks it belongs to the project with the ID in the path.
+// Otherwise it writes the error response and reports false.
+func (tc *TaskController) projectTask(c *gin.Context) (domain.Task, bool) {
+	task, err := tc.TaskUseCase.GetTaskByID(c, c.Param("id"))
+	if err == nil && task.ProjectID != c.Param("pid") {
+		err = domain.ErrTaskNotFound
+	}
+	if err != nil {
+		errorResponse(c, err)
+		return domain.Task{}, false
+	}
+	return task, true
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"bytes"
+	"example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// AttachmentControllerSuite tests the AttachmentController against a mocked use case.
+type AttachmentControllerSuite struct {
+	suite.Suite
+	mockAttachmentUseCase *mocks.AttachmentUseCase
+	attachmentController  AttachmentController
+}
+
+func (suite *AttachmentControllerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockAttachmentUseCase = new(mocks.AttachmentUseCase)
+	suite.attachmentController = AttachmentController{AttachmentUseCase: suite.mockAttachmentUseCase}
+}
+
+// serve runs handler on a request with the task and attachment ids as path parameters.
+func (suite *AttachmentControllerSuite) serve(handler gin.HandlerFunc, req *http.Request, attachmentID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}, {Key: "attachment_id", Value: attachmentID}}
+	handler(c)
+	return w
+}
+
+// uploadRequest builds a multipart upload of content as the given form field.
+func uploadRequest(field, filename, content string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, _ := form.CreateFormFile(field, filename)
+	io.WriteString(part, content)
+	form.Close()
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/"+taskID.String()+"/attachments", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+// TestCreateAttachment tests that CreateAttachment streams the file field to the use case
+// and maps the rejections of the use case to their status codes.
+func (suite *AttachmentControllerSuite) TestCreateAttachment() {
+	attachment := Domain.Attachment{ID: Domain.NewID(), TaskID: taskID.String(), Filename: "notes.txt"}
+	suite.mockAttachmentUseCase.On("AddAttachment", mock.Anything, taskID.String(), "notes.txt", mock.MatchedBy(func(r io.Reader) bool {
+		content, _ := io.ReadAll(r)
+		return string(content) == "some notes"
+	})).Return(attachment, nil).Once()
+
+	w := suite.serve(suite.attachmentController.CreateAttachment, uploadRequest("file", "notes.txt", "some notes"), "")
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), attachment.ID.String())
+
+	suite.mockAttachmentUseCase.On("AddAttachment", mock.Anything, taskID.String(), "huge.bin", mock.Anything).
+		Return(Domain.Attachment{}, fmt.Errorf("%w: the maximum is 10 bytes", Domain.ErrAttachmentTooLarge))
+	w = suite.serve(suite.attachmentController.CreateAttachment, uploadRequest("file", "huge.bin", "0123456789A"), "")
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"too_large"`)
+
+	suite.mockAttachmentUseCase.On("AddAttachment", mock.Anything, taskID.String(), "page.html", mock.Anything).
+		Return(Domain.Attachment{}, Domain.ErrAttachmentType)
+	w = suite.serve(suite.attachmentController.CreateAttachment, uploadRequest("file", "page.html", "<html>"), "")
+	assert.Equal(suite.T(), http.StatusUnsupportedMediaType, w.Code)
+
+	w = suite.serve(suite.attachmentController.CreateAttachment, uploadRequest("document", "notes.txt", "some notes"), "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	req, _ := http.NewRequest(http.MethodPost, "/tasks/"+taskID.String()+"/attachments", strings.NewReader(`{"file":"notes"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = suite.serve(suite.attachmentController.CreateAttachment, req, "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockAttachmentUseCase.AssertNumberOfCalls(suite.T(), "AddAttachment", 3)
+}
+
+// TestDownloadAttachment tests that DownloadAttachment streams the content with its type and file name.
+func (suite *AttachmentControllerSuite) TestDownloadAttachment() {
+	attachment := Domain.Attachment{ID: Domain.NewID(), TaskID: taskID.String(), Filename: "rapport été.txt", ContentType: "text/plain; charset=utf-8", Size: 5}
+	suite.mockAttachmentUseCase.On("OpenAttachment", mock.Anything, taskID.String(), attachment.ID.String()).
+		Return(attachment, io.NopCloser(strings.NewReader("notes")), nil)
+	suite.mockAttachmentUseCase.On("OpenAttachment", mock.Anything, taskID.String(), "missing").
+		Return(Domain.Attachment{}, nil, Domain.ErrAttachmentNotFound)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	w := suite.serve(suite.attachmentController.DownloadAttachment, req, attachment.ID.String())
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "notes", w.Body.String())
+	assert.Equal(suite.T(), "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), "5", w.Header().Get("Content-Length"))
+	assert.Equal(suite.T(), "attachment; filename*=utf-8''rapport%20%C3%A9t%C3%A9.txt", w.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), "nosniff", w.Header().Get("X-Content-Type-Options"))
+
+	w = suite.serve(suite.attachmentController.DownloadAttachment, req, "missing")
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+// TestDeleteAttachment tests that DeleteAttachment reports the refusal of the use case as forbidden.
+func (suite *AttachmentControllerSuite) TestDeleteAttachment() {
+	suite.mockAttachmentUseCase.On("DeleteAttachment", mock.Anything, taskID.String(), "mine").Return(nil)
+	suite.mockAttachmentUseCase.On("DeleteAttachment", mock.Anything, taskID.String(), "theirs").Return(Domain.ErrNotUploader)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/", nil)
+	w := suite.serve(suite.attachmentController.DeleteAttachment, req, "mine")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.attachmentController.DeleteAttachment, req, "theirs")
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+// TestGetAttachments tests that GetAttachments wraps the attachments in a JSON envelope.
+func (suite *AttachmentControllerSuite) TestGetAttachments() {
+	attachments := []Domain.Attachment{{ID: Domain.NewID(), TaskID: taskID.String(), Filename: "notes.txt"}}
+	suite.mockAttachmentUseCase.On("GetAttachments", mock.Anything, taskID.String()).Return(attachments, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	w := suite.serve(suite.attachmentController.GetAttachments, req, "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"attachments":[{`)
+	assert.Contains(suite.T(), w.Body.String(), `"filename":"notes.txt"`)
+}
+
+func TestAttachmentControllerSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentControllerSuite))
+}
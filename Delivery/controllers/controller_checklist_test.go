@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"bytes"
+	"example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// ChecklistControllerSuite tests the ChecklistController against a mocked use case.
+type ChecklistControllerSuite struct {
+	suite.Suite
+	mockChecklistUseCase *mocks.ChecklistUseCase
+	checklistController  ChecklistController
+}
+
+func (suite *ChecklistControllerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockChecklistUseCase = new(mocks.ChecklistUseCase)
+	suite.checklistController = ChecklistController{ChecklistUseCase: suite.mockChecklistUseCase}
+}
+
+// serve runs handler on a request with the task and item ids as path parameters.
+func (suite *ChecklistControllerSuite) serve(handler gin.HandlerFunc, method string, body string, itemID string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}, {Key: "item_id", Value: itemID}}
+	handler(c)
+	return w
+}
+
+// TestAddChecklistItem tests that AddChecklistItem returns the new item and maps a full checklist to 409.
+func (suite *ChecklistControllerSuite) TestAddChecklistItem() {
+	item := Domain.ChecklistItem{ID: Domain.NewID(), TaskID: taskID.String(), Title: "ship", Required: true}
+	suite.mockChecklistUseCase.On("AddChecklistItem", mock.Anything, taskID.String(), "ship", true).Return(item, nil).Once()
+	suite.mockChecklistUseCase.On("AddChecklistItem", mock.Anything, taskID.String(), "ship", false).Return(Domain.ChecklistItem{}, Domain.ErrChecklistFull)
+
+	w := suite.serve(suite.checklistController.AddChecklistItem, http.MethodPost, `{"title":"ship","required":true}`, "")
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), item.ID.String())
+
+	w = suite.serve(suite.checklistController.AddChecklistItem, http.MethodPost, `{"title":"ship"}`, "")
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.serve(suite.checklistController.AddChecklistItem, http.MethodPost, `{}`, "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockChecklistUseCase.AssertExpectations(suite.T())
+}
+
+// TestCompleteChecklistItem tests that the complete and reopen endpoints set the completion of the item.
+func (suite *ChecklistControllerSuite) TestCompleteChecklistItem() {
+	itemID := Domain.NewID().String()
+	suite.mockChecklistUseCase.On("CompleteChecklistItem", mock.Anything, taskID.String(), itemID, true).Return(Domain.ChecklistItem{Done: true}, nil)
+	suite.mockChecklistUseCase.On("CompleteChecklistItem", mock.Anything, taskID.String(), itemID, false).Return(Domain.ChecklistItem{}, nil)
+
+	w := suite.serve(suite.checklistController.CompleteChecklistItem, http.MethodPost, "", itemID)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"done":true`)
+	w = suite.serve(suite.checklistController.ReopenChecklistItem, http.MethodPost, "", itemID)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"done":false`)
+	suite.mockChecklistUseCase.AssertExpectations(suite.T())
+}
+
+// TestReorderChecklist tests that ReorderChecklist passes the order to the use case and requires one.
+func (suite *ChecklistControllerSuite) TestReorderChecklist() {
+	first, second := Domain.NewID().String(), Domain.NewID().String()
+	suite.mockChecklistUseCase.On("ReorderChecklist", mock.Anything, taskID.String(), []string{second, first}).Return([]Domain.ChecklistItem{}, nil)
+
+	w := suite.serve(suite.checklistController.ReorderChecklist, http.MethodPut, `{"item_ids":["`+second+`","`+first+`"]}`, "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"items":[]}`, w.Body.String())
+	w = suite.serve(suite.checklistController.ReorderChecklist, http.MethodPut, `{}`, "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockChecklistUseCase.AssertExpectations(suite.T())
+}
+
+func TestChecklistControllerSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistControllerSuite))
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"bytes"
+	"example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// CommentControllerSuite tests the CommentController against a mocked use case.
+type CommentControllerSuite struct {
+	suite.Suite
+	mockCommentUseCase *mocks.CommentUseCase
+	commentController  CommentController
+}
+
+func (suite *CommentControllerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockCommentUseCase = new(mocks.CommentUseCase)
+	suite.commentController = CommentController{CommentUseCase: suite.mockCommentUseCase}
+}
+
+// serve runs handler on a request with the task and comment ids as path parameters.
+func (suite *CommentControllerSuite) serve(handler gin.HandlerFunc, method string, target string, body string, commentID string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "id", Value: taskID.String()}, {Key: "comment_id", Value: commentID}}
+	handler(c)
+	return w
+}
+
+// TestGetComments tests that GetComments passes the page window to the use case.
+func (suite *CommentControllerSuite) TestGetComments() {
+	page := Domain.CommentPage{Comments: []Domain.Comment{{ID: Domain.NewID(), TaskID: taskID.String(), Body: "on it"}}, NextCursor: "next"}
+	suite.mockCommentUseCase.On("GetComments", mock.Anything, taskID.String(), Domain.CommentQuery{Cursor: "abc", Limit: 1}).Return(page, nil)
+
+	w := suite.serve(suite.commentController.GetComments, http.MethodGet, "/tasks/"+taskID.String()+"/comments?cursor=abc&limit=1", "", "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"body":"on it"`)
+	assert.Contains(suite.T(), w.Body.String(), `"next_cursor":"next"`)
+
+	w = suite.serve(suite.commentController.GetComments, http.MethodGet, "/tasks/"+taskID.String()+"/comments?limit=ten", "", "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockCommentUseCase.AssertExpectations(suite.T())
+}
+
+// TestCreateComment tests that CreateComment returns the new comment and rejects a body without one.
+func (suite *CommentControllerSuite) TestCreateComment() {
+	comment := Domain.Comment{ID: Domain.NewID(), TaskID: taskID.String(), Body: "on it"}
+	suite.mockCommentUseCase.On("AddComment", mock.Anything, taskID.String(), "on it").Return(comment, nil)
+
+	w := suite.serve(suite.commentController.CreateComment, http.MethodPost, "/tasks/"+taskID.String()+"/comments", `{"body":"on it"}`, "")
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), comment.ID.String())
+
+	w = suite.serve(suite.commentController.CreateComment, http.MethodPost, "/tasks/"+taskID.String()+"/comments", `{}`, "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockCommentUseCase.AssertExpectations(suite.T())
+}
+
+// TestUpdateComment tests that UpdateComment maps a caller who may not change the comment to 403.
+func (suite *CommentControllerSuite) TestUpdateComment() {
+	mine, theirs := Domain.NewID().String(), Domain.NewID().String()
+	suite.mockCommentUseCase.On("EditComment", mock.Anything, taskID.String(), mine, "done").Return(Domain.Comment{Body: "done"}, nil)
+	suite.mockCommentUseCase.On("EditComment", mock.Anything, taskID.String(), theirs, "done").Return(Domain.Comment{}, Domain.ErrNotCommentAuthor)
+
+	w := suite.serve(suite.commentController.UpdateComment, http.MethodPut, "/", `{"body":"done"}`, mine)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.commentController.UpdateComment, http.MethodPut, "/", `{"body":"done"}`, theirs)
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"code":"forbidden"`)
+	suite.mockCommentUseCase.AssertExpectations(suite.T())
+}
+
+// TestDeleteComment tests that DeleteComment reports a missing comment as not found.
+func (suite *CommentControllerSuite) TestDeleteComment() {
+	existing, missing := Domain.NewID().String(), Domain.NewID().String()
+	suite.mockCommentUseCase.On("DeleteComment", mock.Anything, taskID.String(), existing).Return(nil)
+	suite.mockCommentUseCase.On("DeleteComment", mock.Anything, taskID.String(), missing).Return(Domain.ErrCommentNotFound)
+
+	w := suite.serve(suite.commentController.DeleteComment, http.MethodDelete, "/", "", existing)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.commentController.DeleteComment, http.MethodDelete, "/", "", missing)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.mockCommentUseCase.AssertExpectations(suite.T())
+}
+
+func TestCommentControllerSuite(t *testing.T) {
+	suite.Run(t, new(CommentControllerSuite))
+}
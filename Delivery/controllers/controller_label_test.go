@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"bytes"
+	"example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// LabelControllerSuite tests the LabelController against a mocked use case.
+type LabelControllerSuite struct {
+	suite.Suite
+	mockLabelUseCase *mocks.LabelUseCase
+	labelController  LabelController
+}
+
+func (suite *LabelControllerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockLabelUseCase = new(mocks.LabelUseCase)
+	suite.labelController = LabelController{LabelUseCase: suite.mockLabelUseCase}
+}
+
+// serve runs handler on a request with the label name as path parameter.
+func (suite *LabelControllerSuite) serve(handler gin.HandlerFunc, method string, body string, name string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{{Key: "name", Value: name}}
+	handler(c)
+	return w
+}
+
+// TestGetLabels tests that the catalog is returned in an envelope.
+func (suite *LabelControllerSuite) TestGetLabels() {
+	suite.mockLabelUseCase.On("GetLabels", mock.Anything).Return([]Domain.Label{{Name: "bug", OrgID: "sales", Color: "#d62728"}}, nil)
+
+	w := suite.serve(suite.labelController.GetLabels, http.MethodGet, "", "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"labels":[{"name":"bug","org_id":"sales","color":"#d62728","description":"","created_at":"0001-01-01T00:00:00Z"}]}`, w.Body.String())
+}
+
+// TestCreateLabel tests that CreateLabel returns the new label and maps a duplicate to 409.
+func (suite *LabelControllerSuite) TestCreateLabel() {
+	label := Domain.Label{Name: "bug", Color: "#d62728"}
+	suite.mockLabelUseCase.On("CreateLabel", mock.Anything, label).Return(label, nil).Once()
+	suite.mockLabelUseCase.On("CreateLabel", mock.Anything, label).Return(Domain.Label{}, Domain.ErrLabelExists)
+
+	w := suite.serve(suite.labelController.CreateLabel, http.MethodPost, `{"name":"bug","color":"#d62728"}`, "")
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	w = suite.serve(suite.labelController.CreateLabel, http.MethodPost, `{"name":"bug","color":"#d62728"}`, "")
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.serve(suite.labelController.CreateLabel, http.MethodPost, `{"name":"bug"}`, "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockLabelUseCase.AssertNumberOfCalls(suite.T(), "CreateLabel", 2)
+}
+
+// TestUpdateLabel tests that the label named in the path is updated.
+func (suite *LabelControllerSuite) TestUpdateLabel() {
+	label := Domain.Label{Color: "#1f77b4", Description: "broken"}
+	suite.mockLabelUseCase.On("UpdateLabel", mock.Anything, "bug", label).Return(Domain.Label{Name: "bug", Color: "#1f77b4"}, nil)
+	suite.mockLabelUseCase.On("UpdateLabel", mock.Anything, "missing", label).Return(Domain.Label{}, Domain.ErrLabelNotFound)
+
+	w := suite.serve(suite.labelController.UpdateLabel, http.MethodPut, `{"color":"#1f77b4","description":"broken"}`, "bug")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.labelController.UpdateLabel, http.MethodPut, `{"color":"#1f77b4","description":"broken"}`, "missing")
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+// TestDeleteLabel tests that a label in use is reported as a conflict.
+func (suite *LabelControllerSuite) TestDeleteLabel() {
+	suite.mockLabelUseCase.On("DeleteLabel", mock.Anything, "frontend").Return(nil)
+	suite.mockLabelUseCase.On("DeleteLabel", mock.Anything, "bug").Return(Domain.ErrLabelInUse)
+
+	w := suite.serve(suite.labelController.DeleteLabel, http.MethodDelete, "", "frontend")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.labelController.DeleteLabel, http.MethodDelete, "", "bug")
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func TestLabelControllerSuite(t *testing.T) {
+	suite.Run(t, new(LabelControllerSuite))
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"bytes"
+	"example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProjectControllerSuite tests the ProjectController and the project routes of the TaskController
+// against mocked use cases.
+type ProjectControllerSuite struct {
+	suite.Suite
+	mockProjectUseCase *mocks.ProjectUseCase
+	mockTaskUseCase    *mocks.TaskUseCase
+	projectController  ProjectController
+	taskController     TaskController
+	projectID          string
+}
+
+func (suite *ProjectControllerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockProjectUseCase = new(mocks.ProjectUseCase)
+	suite.mockTaskUseCase = new(mocks.TaskUseCase)
+	suite.projectController = ProjectController{ProjectUseCase: suite.mockProjectUseCase}
+	suite.taskController = TaskController{TaskUseCase: suite.mockTaskUseCase}
+	suite.projectID = Domain.NewID().String()
+}
+
+// serve runs handler on a request with the given path parameters.
+func (suite *ProjectControllerSuite) serve(handler gin.HandlerFunc, method string, body string, params gin.Params) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	handler(c)
+	return w
+}
+
+// TestCreateProject tests that CreateProject returns the new project and rejects a project without a name.
+func (suite *ProjectControllerSuite) TestCreateProject() {
+	project := Domain.Project{ID: Domain.ID(suite.projectID), Name: "website"}
+	suite.mockProjectUseCase.On("CreateProject", mock.Anything, Domain.Project{Name: "website", Description: "the public site"}).Return(project, nil)
+
+	w := suite.serve(suite.projectController.CreateProject, http.MethodPost, `{"name":"website","description":"the public site"}`, nil)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	w = suite.serve(suite.projectController.CreateProject, http.MethodPost, `{"description":"the public site"}`, nil)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockProjectUseCase.AssertNumberOfCalls(suite.T(), "CreateProject", 1)
+}
+
+// TestGetProjects tests that the projects of the caller are returned in an envelope.
+func (suite *ProjectControllerSuite) TestGetProjects() {
+	suite.mockProjectUseCase.On("GetProjects", mock.Anything).Return([]Domain.Project{}, nil)
+
+	w := suite.serve(suite.projectController.GetProjects, http.MethodGet, "", nil)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"projects":[]}`, w.Body.String())
+}
+
+// TestMembers tests that the member routes pass the project and user of the path and map the errors of the use case.
+func (suite *ProjectControllerSuite) TestMembers() {
+	pid := gin.Param{Key: "pid", Value: suite.projectID}
+	member := Domain.ProjectMember{ProjectID: suite.projectID, UserID: "u1", Role: Domain.ProjectRoleEditor}
+	suite.mockProjectUseCase.On("AddMember", mock.Anything, suite.projectID, "u1", "editor").Return(member, nil)
+	suite.mockProjectUseCase.On("AddMember", mock.Anything, suite.projectID, "u1", "viewer").Return(Domain.ProjectMember{}, Domain.ErrProjectRole)
+	suite.mockProjectUseCase.On("UpdateMember", mock.Anything, suite.projectID, "u1", "viewer").Return(Domain.ProjectMember{}, Domain.ErrLastOwner)
+	suite.mockProjectUseCase.On("RemoveMember", mock.Anything, suite.projectID, "u1").Return(nil)
+
+	w := suite.serve(suite.projectController.AddMember, http.MethodPost, `{"user_id":"u1","role":"editor"}`, gin.Params{pid})
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	w = suite.serve(suite.projectController.AddMember, http.MethodPost, `{"user_id":"u1","role":"viewer"}`, gin.Params{pid})
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	w = suite.serve(suite.projectController.AddMember, http.MethodPost, `{"role":"viewer"}`, gin.Params{pid})
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+
+	userID := gin.Param{Key: "user_id", Value: "u1"}
+	w = suite.serve(suite.projectController.UpdateMember, http.MethodPut, `{"role":"viewer"}`, gin.Params{pid, userID})
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.serve(suite.projectController.RemoveMember, http.MethodDelete, "", gin.Params{pid, userID})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+// TestProjectTasks tests that the tasks of a project are listed and created in the project of the path,
+// and that a task of another project is not found under it.
+func (suite *ProjectControllerSuite) TestProjectTasks() {
+	pid := gin.Param{Key: "pid", Value: suite.projectID}
+	suite.mockTaskUseCase.On("GetTasks", mock.Anything, Domain.TaskQuery{ProjectID: suite.projectID, Status: "TODO"}).Return(Domain.TaskPage{}, nil)
+	suite.mockTaskUseCase.On("AddNewTask", mock.Anything, mock.MatchedBy(func(task Domain.Task) bool {
+		return task.Title == "landing page" && task.ProjectID == suite.projectID
+	})).Return(Domain.Task{ID: Domain.NewID(), Title: "landing page", ProjectID: suite.projectID, Version: 1}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/?status=TODO", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{pid}
+	suite.taskController.GetProjectTasks(c)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	w = suite.serve(suite.taskController.CreateProjectTask, http.MethodPost, `{"title":"landing page","description":"d","due_date":"2030-01-01T00:00:00Z","status":"TODO","project_id":"other"}`, gin.Params{pid})
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+
+	inProject, elsewhere := Domain.NewID().String(), Domain.NewID().String()
+	suite.mockTaskUseCase.On("GetTaskByID", mock.Anything, inProject).Return(Domain.Task{ID: Domain.ID(inProject), ProjectID: suite.projectID, Version: 2}, nil)
+	suite.mockTaskUseCase.On("GetTaskByID", mock.Anything, elsewhere).Return(Domain.Task{ID: Domain.ID(elsewhere), Version: 1}, nil)
+	suite.mockTaskUseCase.On("DeleteTaskById", mock.Anything, inProject, int64(0)).Return(nil)
+
+	w = suite.serve(suite.taskController.GetProjectTask, http.MethodGet, "", gin.Params{pid, {Key: "id", Value: inProject}})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"2"`, w.Header().Get("ETag"))
+	w = suite.serve(suite.taskController.GetProjectTask, http.MethodGet, "", gin.Params{pid, {Key: "id", Value: elsewhere}})
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	w = suite.serve(suite.taskController.DeleteProjectTask, http.MethodDelete, "", gin.Params{pid, {Key: "id", Value: inProject}})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.taskController.DeleteProjectTask, http.MethodDelete, "", gin.Params{pid, {Key: "id", Value: elsewhere}})
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+	suite.mockTaskUseCase.AssertNumberOfCalls(suite.T(), "DeleteTaskById", 1)
+}
+
+func TestProjectControllerSuite(t *testing.T) {
+	suite.Run(t, new(ProjectControllerSuite))
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"bytes"
+	"example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// RoleControllerSuite tests the RoleController and the role assignment of the UserController against mocked use cases.
+type RoleControllerSuite struct {
+	suite.Suite
+	mockRoleUseCase *mocks.RoleUseCase
+	mockUserUseCase *mocks.UserUseCase
+	roleController  RoleController
+	userController  UserController
+}
+
+func (suite *RoleControllerSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.mockRoleUseCase = new(mocks.RoleUseCase)
+	suite.mockUserUseCase = new(mocks.UserUseCase)
+	suite.roleController = RoleController{RoleUseCase: suite.mockRoleUseCase}
+	suite.userController = UserController{UserUseCase: suite.mockUserUseCase}
+}
+
+// serve runs handler on a request with the given path parameters.
+func (suite *RoleControllerSuite) serve(handler gin.HandlerFunc, method string, body string, params gin.Params) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, "/", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = params
+	handler(c)
+	return w
+}
+
+// TestGetRoles tests that the roles are returned in an envelope.
+func (suite *RoleControllerSuite) TestGetRoles() {
+	suite.mockRoleUseCase.On("GetRoles", mock.Anything).Return([]Domain.Role{{Name: "USER", Permissions: []string{}, Builtin: true}}, nil)
+
+	w := suite.serve(suite.roleController.GetRoles, http.MethodGet, "", nil)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"roles":[{"name":"USER","org_id":"","description":"","permissions":[],"builtin":true,"created_at":"0001-01-01T00:00:00Z"}]}`, w.Body.String())
+}
+
+// TestCreateRole tests that CreateRole returns the new role, requires its permissions
+// and maps a permission the caller does not have to 403.
+func (suite *RoleControllerSuite) TestCreateRole() {
+	role := Domain.Role{Name: "triage", Permissions: []string{"task:update"}}
+	suite.mockRoleUseCase.On("CreateRole", mock.Anything, role).Return(Domain.Role{Name: "TRIAGE", Permissions: role.Permissions}, nil).Once()
+	suite.mockRoleUseCase.On("CreateRole", mock.Anything, role).Return(Domain.Role{}, Domain.ErrPermissionNotHeld)
+
+	w := suite.serve(suite.roleController.CreateRole, http.MethodPost, `{"name":"triage","permissions":["task:update"]}`, nil)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	w = suite.serve(suite.roleController.CreateRole, http.MethodPost, `{"name":"triage","permissions":["task:update"]}`, nil)
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	w = suite.serve(suite.roleController.CreateRole, http.MethodPost, `{"name":"triage"}`, nil)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockRoleUseCase.AssertNumberOfCalls(suite.T(), "CreateRole", 2)
+}
+
+// TestUpdateRole tests that the role named in the path is updated and that the built-in roles are a conflict.
+func (suite *RoleControllerSuite) TestUpdateRole() {
+	role := Domain.Role{Description: "sorts the backlog", Permissions: []string{}}
+	suite.mockRoleUseCase.On("UpdateRole", mock.Anything, "triage", role).Return(Domain.Role{Name: "TRIAGE"}, nil)
+	suite.mockRoleUseCase.On("UpdateRole", mock.Anything, "admin", role).Return(Domain.Role{}, Domain.ErrBuiltinRole)
+
+	body := `{"description":"sorts the backlog","permissions":[]}`
+	w := suite.serve(suite.roleController.UpdateRole, http.MethodPut, body, gin.Params{{Key: "name", Value: "triage"}})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.roleController.UpdateRole, http.MethodPut, body, gin.Params{{Key: "name", Value: "admin"}})
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+// TestDeleteRole tests that a role given to users is reported as a conflict.
+func (suite *RoleControllerSuite) TestDeleteRole() {
+	suite.mockRoleUseCase.On("DeleteRole", mock.Anything, "auditor").Return(nil)
+	suite.mockRoleUseCase.On("DeleteRole", mock.Anything, "triage").Return(Domain.ErrRoleInUse)
+
+	w := suite.serve(suite.roleController.DeleteRole, http.MethodDelete, "", gin.Params{{Key: "name", Value: "auditor"}})
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = suite.serve(suite.roleController.DeleteRole, http.MethodDelete, "", gin.Params{{Key: "name", Value: "triage"}})
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+// TestAssignRole tests that the role in the body is given to the user in the path,
+// and that demoting the last admin is a conflict.
+func (suite *RoleControllerSuite) TestAssignRole() {
+	id := Domain.NewID()
+	suite.mockUserUseCase.On("AssignUserRole", mock.Anything, id.String(), "triage").Return(Domain.User{ID: id, Role: "TRIAGE"}, nil)
+	suite.mockUserUseCase.On("AssignUserRole", mock.Anything, id.String(), "USER").Return(Domain.User{}, Domain.ErrLastAdmin)
+
+	params := gin.Params{{Key: "id", Value: id.String()}}
+	w := suite.serve(suite.userController.AssignRole, http.MethodPut, `{"role":"triage"}`, params)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"id":"`+id.String()+`","role":"TRIAGE"}`, w.Body.String())
+	w = suite.serve(suite.userController.AssignRole, http.MethodPut, `{"role":"USER"}`, params)
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = suite.serve(suite.userController.AssignRole, http.MethodPut, `{}`, params)
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func TestRoleControllerSuite(t *testing.T) {
+	suite.Run(t, new(RoleControllerSuite))
+}
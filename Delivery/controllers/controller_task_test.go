@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"example/go-clean-architecture/Domain"
 	"example/go-clean-architecture/mocks"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,10 +15,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
-var taskID = primitive.NewObjectID()
+var taskID = Domain.NewID()
 var taskTitle = "Task Title"
 var taskDescription = "Task Description"
 var taskStatus = "Started"
@@ -56,7 +56,7 @@ func (suite *TestSuite) TestGetTasks() {
 			DueDate:    taskDueDate,
 		},
 		{
-			ID:           primitive.NewObjectID(),
+			ID:           Domain.NewID(),
 			Title:       "second task",
 			Description: "description for second task",
 			Status:      "ongoing",
@@ -64,12 +64,15 @@ func (suite *TestSuite) TestGetTasks() {
 		},
 	}
 
-	// Mock the GetAllTasks method
-	suite.mockTaskUseCase.On("GetAllTasks", mock.Anything).Return(tasks, nil)
+	query := Domain.TaskQuery{Status: taskStatus, Sort: "-due_date", Limit: 2}
+	page := Domain.TaskPage{Tasks: tasks, NextCursor: "next"}
+
+	// Mock the GetTasks method
+	suite.mockTaskUseCase.On("GetTasks", mock.Anything, query).Return(page, nil)
 
 	// Create a new gin context
 	gin.SetMode(gin.TestMode)
-	req, _ := http.NewRequest(http.MethodGet, "/tasks", nil)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?status="+taskStatus+"&sort=-due_date&limit=2", nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -79,6 +82,60 @@ func (suite *TestSuite) TestGetTasks() {
 
 	// Assert the status code and response
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var body Domain.TaskPage
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(suite.T(), body.Tasks, 2)
+	assert.Equal(suite.T(), "next", body.NextCursor)
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestGetTasks_Labels tests that the label filter is read as a comma-separated list with its match mode.
+func (suite *TestSuite) TestGetTasks_Labels() {
+	query := Domain.TaskQuery{Labels: []string{"bug", "frontend"}, LabelMatch: "all"}
+	suite.mockTaskUseCase.On("GetTasks", mock.Anything, query).Return(Domain.TaskPage{Tasks: []Domain.Task{}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?label=bug,frontend&label_match=all", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.taskController.GetTasks(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestGetTasks_InvalidQuery tests that malformed query parameters are rejected
+func (suite *TestSuite) TestGetTasks_InvalidQuery() {
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?due_from=yesterday", nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.taskController.GetTasks(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockTaskUseCase.AssertNotCalled(suite.T(), "GetTasks", mock.Anything, mock.Anything)
+}
+
+// TestGetTasks_InvalidCursor tests that query errors from the use case are returned as bad requests
+func (suite *TestSuite) TestGetTasks_InvalidCursor() {
+	query := Domain.TaskQuery{Cursor: "garbage"}
+	suite.mockTaskUseCase.On("GetTasks", mock.Anything, query).Return(Domain.TaskPage{}, Domain.ErrInvalidTaskQuery)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks?cursor=garbage", nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.taskController.GetTasks(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
 	suite.mockTaskUseCase.AssertExpectations(suite.T())
 }
 
@@ -91,24 +148,44 @@ func (suite *TestSuite) TestGetTask() {
 		Description: taskDescription,
 		Status:      taskStatus,
 		DueDate:    taskDueDate,
+		Version:     3,
 	}
 
 	// Mock the GetTaskByID method
-	suite.mockTaskUseCase.On("GetTaskByID", mock.Anything, taskID.Hex()).Return(task, nil)
+	suite.mockTaskUseCase.On("GetTaskByID", mock.Anything, taskID.String()).Return(task, nil)
 
 	// Create a new gin context
 	gin.SetMode(gin.TestMode)
-	req, _ := http.NewRequest(http.MethodGet, "/tasks/" + taskID.Hex(), nil)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/" + taskID.String(), nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.Hex()}}
+	c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
 
 	suite.taskController.GetTask(c)
 
 	// Assert the status code and response
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"3"`, w.Header().Get("ETag"))
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestGetTask_NotFound tests that GetTask responds with 404 for missing or invisible tasks
+func (suite *TestSuite) TestGetTask_NotFound() {
+	suite.mockTaskUseCase.On("GetTaskByID", mock.Anything, taskID.String()).Return(Domain.Task{}, Domain.ErrTaskNotFound)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/tasks/"+taskID.String(), nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
+
+	suite.taskController.GetTask(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
 	suite.mockTaskUseCase.AssertExpectations(suite.T())
 }
 
@@ -122,7 +199,7 @@ func (suite *TestSuite) TestCreateTask() {
 		DueDate:  time.Date(2024, time.August, 15, 21, 19, 31, 703415278, time.UTC),
 	}
 	createdTask := Domain.Task{
-		ID: primitive.NewObjectID(), 
+		ID: Domain.NewID(), 
 		Title: "New Task", 
 		Description: "This is a new task",
 		DueDate:  time.Date(2024, time.August, 15, 21, 19, 31, 703415278, time.UTC),
@@ -154,18 +231,18 @@ func (suite *TestSuite) TestUpdateTask() {
 	updatedTask := Domain.Task{Title: "Updated Task", Description: "This task is updated"}
 
 	// Mock the ModifyTaskById method
-	suite.mockTaskUseCase.On("ModifyTaskById", mock.Anything, updatedTask,  taskID.Hex()).Return(updatedTask, nil)
+	suite.mockTaskUseCase.On("ModifyTaskById", mock.Anything, updatedTask,  taskID.String()).Return(updatedTask, nil)
 
 	// Create a new gin context
 	gin.SetMode(gin.TestMode)
 	jsonValue, _ := json.Marshal(updatedTask)
-	req, _ := http.NewRequest(http.MethodPut, "/tasks/" +  taskID.Hex(), bytes.NewBuffer(jsonValue))
+	req, _ := http.NewRequest(http.MethodPut, "/tasks/" +  taskID.String(), bytes.NewBuffer(jsonValue))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value:  taskID.Hex()}}
+	c.Params = gin.Params{gin.Param{Key: "id", Value:  taskID.String()}}
 
 	suite.taskController.UpdateTask(c)
 
@@ -177,16 +254,16 @@ func (suite *TestSuite) TestUpdateTask() {
 // TestDeleteTask tests the DeleteTask method
 func (suite *TestSuite) TestDeleteTask() {
 	// Mock the DeleteTaskById method
-	suite.mockTaskUseCase.On("DeleteTaskById", mock.Anything, taskID.Hex()).Return(nil)
+	suite.mockTaskUseCase.On("DeleteTaskById", mock.Anything, taskID.String(), int64(0)).Return(nil)
 
 	// Create a new gin context
 	gin.SetMode(gin.TestMode)
-	req, _ := http.NewRequest(http.MethodDelete, "/tasks/" + taskID.Hex(), nil)
+	req, _ := http.NewRequest(http.MethodDelete, "/tasks/" + taskID.String(), nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = req
-	c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.Hex()}}
+	c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
 
 	suite.taskController.DeleteTask(c)
 
@@ -195,6 +272,254 @@ func (suite *TestSuite) TestDeleteTask() {
 	suite.mockTaskUseCase.AssertExpectations(suite.T())
 }
 
+// TestUpdateTask_IfMatch tests that the version in the If-Match header reaches the use case,
+// that the ETag of the response is the new version and that a stale version is answered with 412.
+func (suite *TestSuite) TestUpdateTask_IfMatch() {
+	body := Domain.Task{Title: "Updated Task", Description: "This task is updated"}
+	current, stale := body, body
+	current.Version, stale.Version = 2, 1
+	updated := current
+	updated.Version = 3
+	suite.mockTaskUseCase.On("ModifyTaskById", mock.Anything, current, taskID.String()).Return(updated, nil)
+	suite.mockTaskUseCase.On("ModifyTaskById", mock.Anything, stale, taskID.String()).Return(Domain.Task{}, Domain.ErrVersionMismatch)
+
+	gin.SetMode(gin.TestMode)
+	cases := []struct {
+		ifMatch string
+		status  int
+	}{
+		{`"2"`, http.StatusOK},
+		{`"1"`, http.StatusPreconditionFailed},
+		{`W/"2"`, http.StatusPreconditionFailed},
+		{`W/"1"`, http.StatusPreconditionFailed},
+		{`2`, http.StatusBadRequest},
+		{`"1", "2"`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		jsonValue, _ := json.Marshal(body)
+		req, _ := http.NewRequest(http.MethodPut, "/tasks/"+taskID.String(), bytes.NewBuffer(jsonValue))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", tc.ifMatch)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
+
+		suite.taskController.UpdateTask(c)
+
+		assert.Equal(suite.T(), tc.status, w.Code, tc.ifMatch)
+		if tc.status == http.StatusOK {
+			assert.Equal(suite.T(), `"3"`, w.Header().Get("ETag"))
+		}
+		if tc.status == http.StatusPreconditionFailed {
+			assert.Contains(suite.T(), w.Body.String(), `"code":"precondition_failed"`)
+		}
+	}
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestDeleteTask_IfMatch tests that a delete passes the If-Match version to the use case, "*" meaning any version.
+func (suite *TestSuite) TestDeleteTask_IfMatch() {
+	suite.mockTaskUseCase.On("DeleteTaskById", mock.Anything, taskID.String(), int64(4)).Return(Domain.ErrVersionMismatch).Once()
+	suite.mockTaskUseCase.On("DeleteTaskById", mock.Anything, taskID.String(), int64(0)).Return(nil).Once()
+
+	gin.SetMode(gin.TestMode)
+	for ifMatch, status := range map[string]int{`"4"`: http.StatusPreconditionFailed, "*": http.StatusOK} {
+		req, _ := http.NewRequest(http.MethodDelete, "/tasks/"+taskID.String(), nil)
+		req.Header.Set("If-Match", ifMatch)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
+
+		suite.taskController.DeleteTask(c)
+
+		assert.Equal(suite.T(), status, w.Code, ifMatch)
+	}
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestPatchTask tests that the PatchTask method passes only the members of the merge patch to the use case
+// and rejects invalid patches and other media types before reaching it.
+func (suite *TestSuite) TestPatchTask() {
+	status := "DONE"
+	patched := Domain.Task{ID: taskID, Title: taskTitle, Status: status}
+	suite.mockTaskUseCase.On("PatchTaskById", mock.Anything, Domain.TaskPatch{Status: &status}, taskID.String()).Return(patched, nil).Twice()
+
+	gin.SetMode(gin.TestMode)
+	cases := []struct {
+		contentType string
+		body        string
+		status      int
+	}{
+		{"application/merge-patch+json", `{"status":"DONE"}`, http.StatusOK},
+		{"application/json", `{"status":"DONE"}`, http.StatusOK},
+		{"application/merge-patch+json", `{"title":null}`, http.StatusBadRequest},
+		{"application/merge-patch+json", `[{"op":"replace","path":"/status","value":"DONE"}]`, http.StatusBadRequest},
+		{"application/json-patch+json", `[{"op":"replace","path":"/status","value":"DONE"}]`, http.StatusUnsupportedMediaType},
+	}
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodPatch, "/admin/tasks/"+taskID.String(), bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", tc.contentType)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
+
+		suite.taskController.PatchTask(c)
+
+		assert.Equal(suite.T(), tc.status, w.Code, tc.body)
+	}
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestTransitionTask tests that the TransitionTask method returns the moved task
+// and reports an illegal transition as a conflict.
+func (suite *TestSuite) TestTransitionTask() {
+	moved := Domain.Task{ID: taskID, Title: taskTitle, Status: Domain.TaskStatusReview}
+	suite.mockTaskUseCase.On("TransitionTask", mock.Anything, taskID.String(), "review").Return(moved, nil)
+	illegal := fmt.Errorf("%w: cannot move a task from REVIEW to TODO", Domain.ErrIllegalTransition)
+	suite.mockTaskUseCase.On("TransitionTask", mock.Anything, taskID.String(), "todo").Return(Domain.Task{}, illegal)
+
+	gin.SetMode(gin.TestMode)
+	cases := []struct {
+		body   string
+		status int
+	}{
+		{`{"status":"review"}`, http.StatusOK},
+		{`{"status":"todo"}`, http.StatusConflict},
+		{`{}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req, _ := http.NewRequest(http.MethodPost, "/tasks/"+taskID.String()+"/transition", bytes.NewBufferString(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
+
+		suite.taskController.TransitionTask(c)
+
+		assert.Equal(suite.T(), tc.status, w.Code, tc.body)
+	}
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestDependencies tests that the dependency endpoints pass both tasks to the use case
+// and report a cycle as a conflict.
+func (suite *TestSuite) TestDependencies() {
+	other := Domain.NewID().String()
+	blocked := Domain.Task{ID: taskID, Title: taskTitle, Status: Domain.TaskStatusBlocked}
+	suite.mockTaskUseCase.On("AddDependency", mock.Anything, taskID.String(), other).Return(blocked, nil)
+	suite.mockTaskUseCase.On("AddDependency", mock.Anything, taskID.String(), taskID.String()).Return(Domain.Task{}, Domain.ErrDependencyCycle)
+	suite.mockTaskUseCase.On("RemoveDependency", mock.Anything, taskID.String(), other).Return(Domain.Task{ID: taskID}, nil)
+	graph := Domain.TaskGraph{TaskID: taskID.String(), Upstream: []Domain.TaskGraphNode{{ID: other, Depth: 1}}}
+	suite.mockTaskUseCase.On("GetTaskGraph", mock.Anything, taskID.String()).Return(graph, nil)
+
+	gin.SetMode(gin.TestMode)
+	serve := func(handler gin.HandlerFunc, method string, body string, dependsOn string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, "/", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{{Key: "id", Value: taskID.String()}, {Key: "depends_on_id", Value: dependsOn}}
+		handler(c)
+		return w
+	}
+
+	w := serve(suite.taskController.AddDependency, http.MethodPost, `{"depends_on_id":"`+other+`"}`, "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"status":"BLOCKED"`)
+	w = serve(suite.taskController.AddDependency, http.MethodPost, `{"depends_on_id":"`+taskID.String()+`"}`, "")
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	w = serve(suite.taskController.AddDependency, http.MethodPost, `{}`, "")
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	w = serve(suite.taskController.RemoveDependency, http.MethodDelete, "", other)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	w = serve(suite.taskController.GetTaskGraph, http.MethodGet, "", "")
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"upstream":[{"id":"`+other+`","depth":1}]`)
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestGetTaskHistory tests that GetTaskHistory passes the page window to the use case
+// and rejects a limit that is not a number.
+func (suite *TestSuite) TestGetTaskHistory() {
+	page := Domain.TaskHistoryPage{
+		Entries:    []Domain.TaskHistoryEntry{{ID: Domain.NewID(), TaskID: taskID.String(), Action: Domain.TaskActionCreated}},
+		NextCursor: "next",
+	}
+	query := Domain.TaskHistoryQuery{Cursor: "abc", Limit: 1}
+	suite.mockTaskUseCase.On("GetTaskHistory", mock.Anything, taskID.String(), query).Return(page, nil)
+
+	gin.SetMode(gin.TestMode)
+	for target, status := range map[string]int{"?cursor=abc&limit=1": http.StatusOK, "?limit=ten": http.StatusBadRequest} {
+		req, _ := http.NewRequest(http.MethodGet, "/tasks/"+taskID.String()+"/history"+target, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: taskID.String()}}
+
+		suite.taskController.GetTaskHistory(c)
+
+		assert.Equal(suite.T(), status, w.Code, target)
+		if status == http.StatusOK {
+			assert.Contains(suite.T(), w.Body.String(), `"next_cursor":"next"`)
+		}
+	}
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestGetDeletedTasks tests that GetDeletedTasks reads the same filters as GetTasks and returns the deletion fields.
+func (suite *TestSuite) TestGetDeletedTasks() {
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	page := Domain.TaskPage{Tasks: []Domain.Task{{ID: taskID, Title: taskTitle, DeletedAt: &deletedAt, DeletedBy: "admin"}}}
+	suite.mockTaskUseCase.On("GetDeletedTasks", mock.Anything, Domain.TaskQuery{TitlePrefix: "Task", Limit: 5}).Return(page, nil)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/admin/trash?title_prefix=Task&limit=5", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.taskController.GetDeletedTasks(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"deleted_at":"2024-06-01T12:00:00Z","deleted_by":"admin"`)
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
+// TestRestoreTask tests that RestoreTask returns the restored task with its new version,
+// and not found for a task that is not in the trash.
+func (suite *TestSuite) TestRestoreTask() {
+	missing := Domain.NewID()
+	suite.mockTaskUseCase.On("RestoreTaskById", mock.Anything, taskID.String()).Return(Domain.Task{ID: taskID, Title: taskTitle, Version: 3}, nil)
+	suite.mockTaskUseCase.On("RestoreTaskById", mock.Anything, missing.String()).Return(Domain.Task{}, Domain.ErrTaskNotFound)
+
+	gin.SetMode(gin.TestMode)
+	for id, status := range map[Domain.ID]int{taskID: http.StatusOK, missing: http.StatusNotFound} {
+		req, _ := http.NewRequest(http.MethodPost, "/admin/tasks/"+id.String()+"/restore", nil)
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = req
+		c.Params = gin.Params{gin.Param{Key: "id", Value: id.String()}}
+
+		suite.taskController.RestoreTask(c)
+
+		assert.Equal(suite.T(), status, w.Code)
+		if status == http.StatusOK {
+			assert.Equal(suite.T(), `"3"`, w.Header().Get("ETag"))
+		}
+	}
+	suite.mockTaskUseCase.AssertExpectations(suite.T())
+}
+
 // Run the test suite
 func TestControllerSuite(t *testing.T) {
 	suite.Run(t, new(TestSuite))
@@ -19,7 +19,6 @@ func (suite *TestSuite) TestCreateAccount() {
 	newUser := Domain.User{
 		Username: "testuser",
 		Password: "123456789",
-		Role: "USER",
 	}
 
 	// Mock the CreateAccount method
@@ -27,7 +26,7 @@ func (suite *TestSuite) TestCreateAccount() {
 
 	// Create a new gin context
 	gin.SetMode(gin.TestMode)
-	jsonValue, _ := json.Marshal(newUser)
+	jsonValue, _ := json.Marshal(credentials{Username: newUser.Username, Password: newUser.Password})
 	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonValue))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -39,6 +38,7 @@ func (suite *TestSuite) TestCreateAccount() {
 
 	// Assert the status code and response
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.NotContains(suite.T(), w.Body.String(), newUser.Password)
 	suite.mockUserUseCase.AssertExpectations(suite.T())
 }
 
@@ -49,14 +49,14 @@ func (suite *TestSuite) TestLogin() {
 		Username: "testuser",
 		Password: "password",
 	}
-	mockToken := "mockToken"
+	mockTokens := Domain.TokenPair{AccessToken: "mockToken", RefreshToken: "mockRefreshToken", ExpiresIn: 900}
 
 	// Mock the AuthenticateUser method
-	suite.mockUserUseCase.On("AuthenticateUser", mock.Anything, user.Username, user.Password).Return(user, mockToken, nil)
+	suite.mockUserUseCase.On("AuthenticateUser", mock.Anything, user.OrgID, user.Username, user.Password).Return(user, mockTokens, nil)
 
 	// Create a new gin context
 	gin.SetMode(gin.TestMode)
-	jsonValue, _ := json.Marshal(user)
+	jsonValue, _ := json.Marshal(credentials{Username: user.Username, Password: user.Password})
 	req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(jsonValue))
 	req.Header.Set("Content-Type", "application/json")
 
@@ -68,6 +68,67 @@ func (suite *TestSuite) TestLogin() {
 
 	// Assert the status code and response
 	assert.Equal(suite.T(), http.StatusAccepted, w.Code)
+	var body map[string]interface{}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(suite.T(), "mockToken", body["token"])
+	assert.Equal(suite.T(), "mockRefreshToken", body["refresh_token"])
+	assert.NotContains(suite.T(), body["user"], "password", "the password hash is never returned")
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestRefreshToken tests the RefreshToken method
+func (suite *TestSuite) TestRefreshToken() {
+	tokens := Domain.TokenPair{AccessToken: "newToken", RefreshToken: "newRefreshToken", ExpiresIn: 900}
+	suite.mockUserUseCase.On("RefreshSession", mock.Anything, "oldRefreshToken").Return(tokens, nil)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refresh_token":"oldRefreshToken"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.RefreshToken(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"token":"newToken","refresh_token":"newRefreshToken","expires_in":900}`, w.Body.String())
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestRefreshToken_Reused tests that a reused refresh token is answered with 401 Unauthorized
+func (suite *TestSuite) TestRefreshToken_Reused() {
+	suite.mockUserUseCase.On("RefreshSession", mock.Anything, "usedRefreshToken").Return(Domain.TokenPair{}, Domain.ErrRefreshTokenReused)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodPost, "/token/refresh", bytes.NewBufferString(`{"refresh_token":"usedRefreshToken"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.RefreshToken(c)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestLogout tests the Logout method
+func (suite *TestSuite) TestLogout() {
+	suite.mockUserUseCase.On("Logout", mock.Anything, "refreshToken").Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodPost, "/logout", bytes.NewBufferString(`{"refresh_token":"refreshToken"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.Logout(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
 	suite.mockUserUseCase.AssertExpectations(suite.T())
 }
 
@@ -89,4 +150,120 @@ func (suite *TestSuite) TestPromoteUser() {
 	// Assert the status code and response
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
 	suite.mockUserUseCase.AssertExpectations(suite.T())
-}
\ No newline at end of file
+}
+// TestCreateAccount_Conflict tests that a taken username is reported as 409 Conflict
+func (suite *TestSuite) TestCreateAccount_Conflict() {
+	newUser := Domain.User{
+		Username: "testuser",
+		Password: "123456789",
+	}
+	suite.mockUserUseCase.On("CreateAccount", mock.Anything, &newUser).Return(Domain.User{}, Domain.ErrUsernameTaken)
+
+	gin.SetMode(gin.TestMode)
+	jsonValue, _ := json.Marshal(credentials{Username: newUser.Username, Password: newUser.Password})
+	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.CreateAccount(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.JSONEq(suite.T(), `{"error":"username already exists","code":"conflict"}`, w.Body.String())
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestCreateAccount_MissingPassword tests that the account is not created when validation fails.
+// The role is assigned by the service, so an unknown one in the request is not a validation failure.
+func (suite *TestSuite) TestCreateAccount_MissingPassword() {
+	gin.SetMode(gin.TestMode)
+	jsonValue, _ := json.Marshal(map[string]string{"username": "testuser", "role": "ROOT"})
+	req, _ := http.NewRequest(http.MethodPost, "/register", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.CreateAccount(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	suite.mockUserUseCase.AssertNotCalled(suite.T(), "CreateAccount", mock.Anything, mock.Anything)
+}
+
+// TestCreateUser tests that an account created by an admin is returned with 201 Created,
+// without the organization named in the request body.
+func (suite *TestSuite) TestCreateUser() {
+	newUser := Domain.User{Username: "bob", Password: "123456789"}
+	suite.mockUserUseCase.On("CreateUser", mock.Anything, &newUser).Return(Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "bob", Role: Domain.RoleUser}, nil)
+
+	gin.SetMode(gin.TestMode)
+	jsonValue, _ := json.Marshal(credentials{OrgID: "legal", Username: newUser.Username, Password: newUser.Password})
+	req, _ := http.NewRequest(http.MethodPost, "/admin/users", bytes.NewBuffer(jsonValue))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.CreateUser(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"org_id":"sales"`)
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestGetUsers tests that the query parameters are passed to the use case and that the page is returned as is.
+func (suite *TestSuite) TestGetUsers() {
+	id := Domain.NewID()
+	query := Domain.UserQuery{Search: "al", Role: "admin", Cursor: "", Limit: 5}
+	suite.mockUserUseCase.On("GetUsers", mock.Anything, query).Return(Domain.UserPage{Users: []Domain.User{{ID: id, Username: "alice", Password: "hash", Role: "ADMIN"}}}, nil)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users?search=al&role=admin&limit=5", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	suite.userController.GetUsers(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"users":[{"id":"`+id.String()+`","org_id":"","username":"alice","role":"ADMIN","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}],"next_cursor":""}`, w.Body.String())
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestDeactivateUser_Admin tests that deactivating an admin is reported as 409 Conflict
+func (suite *TestSuite) TestDeactivateUser_Admin() {
+	suite.mockUserUseCase.On("DeactivateUser", mock.Anything, "1").Return(Domain.User{}, Domain.ErrUserIsAdmin)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodPost, "/admin/users/1/deactivate", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	suite.userController.DeactivateUser(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
+
+// TestDeleteUser tests that the user in the path is deleted and its tasks reassigned to the user in reassign_to
+func (suite *TestSuite) TestDeleteUser() {
+	suite.mockUserUseCase.On("DeleteUser", mock.Anything, "1", "2").Return(nil)
+
+	gin.SetMode(gin.TestMode)
+	req, _ := http.NewRequest(http.MethodDelete, "/admin/users/1?reassign_to=2", nil)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+
+	suite.userController.DeleteUser(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	suite.mockUserUseCase.AssertExpectations(suite.T())
+}
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorKinds maps the domain error kinds to their HTTP status and the code reported to clients.
+var errorKinds = []struct {
+	kind   error
+	status int
+	code   string
+}{
+	{domain.ErrNotFound, http.StatusNotFound, "not_found"},
+	{domain.ErrConflict, http.StatusConflict, "conflict"},
+	{domain.ErrInvalidID, http.StatusBadRequest, "invalid_id"},
+	{domain.ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+	{domain.ErrForbidden, http.StatusForbidden, "forbidden"},
+	{domain.ErrValidation, http.StatusBadRequest, "validation_failed"},
+	{domain.ErrPreconditionFailed, http.StatusPreconditionFailed, "precondition_failed"},
+	{domain.ErrTooLarge, http.StatusRequestEntityTooLarge, "too_large"},
+	{domain.ErrUnsupportedMediaType, http.StatusUnsupportedMediaType, "unsupported_media_type"},
+}
+
+// errorResponse writes the JSON error body for err and aborts the request.
+// The body always has the form {"error": "<message>", "code": "<code>"}.
+// Errors that do not wrap a domain error kind are reported as 500 without their details,
+// which are attached to the gin context for logging instead.
+func errorResponse(c *gin.Context, err error) {
+	for _, ek := range errorKinds {
+		if errors.Is(err, ek.kind) {
+			c.AbortWithStatusJSON(ek.status, gin.H{"error": err.Error(), "code": ek.code})
+			return
+		}
+	}
+	_ = c.Error(err)
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error", "code": "internal"})
+}
+
+// validationError turns a request binding or validation failure into a domain validation error.
+func validationError(err error) error {
+	return domain.NewError(domain.ErrValidation, "%s", err.Error())
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"errors"
+	"example/go-clean-architecture/Domain"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrorResponse tests that every domain error kind is translated to its HTTP status and code,
+// including errors wrapped with additional context, and that unknown errors do not leak their details.
+func TestErrorResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cases := []struct {
+		err    error
+		status int
+		body   string
+	}{
+		{Domain.ErrTaskNotFound, http.StatusNotFound, `{"error":"task not found","code":"not_found"}`},
+		{Domain.ErrUsernameTaken, http.StatusConflict, `{"error":"username already exists","code":"conflict"}`},
+		{Domain.NewError(Domain.ErrInvalidID, "invalid id %q", "42"), http.StatusBadRequest, `{"error":"invalid id \"42\"","code":"invalid_id"}`},
+		{Domain.ErrUnauthenticated, http.StatusUnauthorized, `{"error":"authentication required","code":"unauthorized"}`},
+		{Domain.NewError(Domain.ErrForbidden, "not yours"), http.StatusForbidden, `{"error":"not yours","code":"forbidden"}`},
+		{fmt.Errorf("%w: unknown sort key", Domain.ErrInvalidTaskQuery), http.StatusBadRequest, `{"error":"invalid task query: unknown sort key","code":"validation_failed"}`},
+		{Domain.NewError(Domain.ErrTooLarge, "too big"), http.StatusRequestEntityTooLarge, `{"error":"too big","code":"too_large"}`},
+		{Domain.NewError(Domain.ErrUnsupportedMediaType, "no executables"), http.StatusUnsupportedMediaType, `{"error":"no executables","code":"unsupported_media_type"}`},
+		{errors.New("connection reset by peer"), http.StatusInternalServerError, `{"error":"internal server error","code":"internal"}`},
+	}
+
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		errorResponse(c, tc.err)
+
+		assert.Equal(t, tc.status, w.Code, tc.err.Error())
+		assert.JSONEq(t, tc.body, w.Body.String())
+		assert.True(t, c.IsAborted())
+	}
+}
@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setTaskETag sets the ETag header of a response to the version of the task, as a strong entity tag:
+// every change to a task gives it a new version, so the version identifies its representation exactly.
+func setTaskETag(c *gin.Context, task domain.Task) {
+	c.Header("ETag", fmt.Sprintf(`"%d"`, task.Version))
+}
+
+// ifMatchVersion reads the task version a write is conditional on from the If-Match header.
+// It returns zero if the header is absent or "*", so the write applies to any version.
+// The tag is compared strongly, as RFC 7232 requires for If-Match: a weak tag never matches, like an
+// unknown one, and gives ErrVersionMismatch; a malformed header or a list of several tags is an
+// ErrValidation error.
+func ifMatchVersion(c *gin.Context) (int64, error) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	weak := strings.HasPrefix(header, "W/")
+	header = strings.TrimPrefix(header, "W/")
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' || strings.Contains(header, ",") {
+		return 0, domain.NewError(domain.ErrValidation, "If-Match must be a single entity tag such as %q", `"1"`)
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if weak || err != nil || version <= 0 {
+		return 0, domain.ErrVersionMismatch
+	}
+	return version, nil
+}
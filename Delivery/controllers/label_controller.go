@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LabelController serves the label catalog: GET /labels for every user, /admin/labels for the admins changing it.
+type LabelController struct {
+	LabelUseCase domain.LabelUseCase
+}
+
+// labelRequest is the body of a new label.
+type labelRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Color       string `json:"color" binding:"required"`
+	Description string `json:"description"`
+}
+
+// labelUpdateRequest is the body of an edited label, whose name cannot change.
+type labelUpdateRequest struct {
+	Color       string `json:"color" binding:"required"`
+	Description string `json:"description"`
+}
+
+// GetLabels retrieves every label of the catalog, ordered by name, in a JSON envelope.
+func (lc *LabelController) GetLabels(c *gin.Context) {
+	labels, err := lc.LabelUseCase.GetLabels(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"labels": labels})
+}
+
+// CreateLabel adds the label in the request body to the catalog and returns it with a created status code.
+func (lc *LabelController) CreateLabel(c *gin.Context) {
+	var req labelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	label, err := lc.LabelUseCase.CreateLabel(c, domain.Label{Name: req.Name, Color: req.Color, Description: req.Description})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, label)
+}
+
+// UpdateLabel replaces the colour and description of the label named in the path and returns it.
+func (lc *LabelController) UpdateLabel(c *gin.Context) {
+	var req labelUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	label, err := lc.LabelUseCase.UpdateLabel(c, c.Param("name"), domain.Label{Color: req.Color, Description: req.Description})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, label)
+}
+
+// DeleteLabel deletes the label named in the path from the catalog.
+func (lc *LabelController) DeleteLabel(c *gin.Context) {
+	err := lc.LabelUseCase.DeleteLabel(c, c.Param("name"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
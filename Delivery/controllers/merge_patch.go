@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	domain "example/go-clean-architecture/Domain"
+	"io"
+	"sort"
+	"time"
+)
+
+// Media types accepted for a JSON Merge Patch; plain JSON is accepted for clients that cannot set the former.
+const (
+	mergePatchContentType = "application/merge-patch+json"
+	jsonContentType       = "application/json"
+)
+
+// parseTaskMergePatch reads an RFC 7396 JSON Merge Patch of a task.
+// Members of the patch replace the fields of the same name; a null assignee_ids or labels removes every assignee
+// or label, while the other fields are required and cannot be removed. The id, created_by, parent_id, project_id
+// and org_id fields are read-only.
+// Every problem is reported as an ErrValidation error naming the field.
+func parseTaskMergePatch(body io.Reader) (domain.TaskPatch, error) {
+	var members map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&members); err != nil || members == nil {
+		return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "the patch must be a JSON object")
+	}
+
+	// sorted so the same invalid patch always reports the same field
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var patch domain.TaskPatch
+	for _, name := range names {
+		raw := members[name]
+		null := bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+		if null && name != "assignee_ids" && name != "labels" {
+			return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "%s cannot be removed", name)
+		}
+
+		var err error
+		switch name {
+		case "title":
+			patch.Title, err = decodeStringMember(name, raw)
+		case "description":
+			patch.Description, err = decodeStringMember(name, raw)
+		case "status":
+			patch.Status, err = decodeStringMember(name, raw)
+		case "due_date":
+			var dueDate time.Time
+			if json.Unmarshal(raw, &dueDate) != nil {
+				return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "due_date must be a date in RFC 3339 format")
+			}
+			patch.DueDate = &dueDate
+		case "assignee_ids":
+			assignees := []string{}
+			if !null && json.Unmarshal(raw, &assignees) != nil {
+				return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "assignee_ids must be an array of user ids")
+			}
+			patch.AssigneeIDs = &assignees
+		case "labels":
+			labels := []string{}
+			if !null && json.Unmarshal(raw, &labels) != nil {
+				return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "labels must be an array of label names")
+			}
+			patch.Labels = &labels
+		case "id", "created_by", "parent_id", "project_id", "org_id":
+			return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "%s cannot be changed", name)
+		default:
+			return domain.TaskPatch{}, domain.NewError(domain.ErrValidation, "unknown field %q", name)
+		}
+		if err != nil {
+			return domain.TaskPatch{}, err
+		}
+	}
+	return patch, nil
+}
+
+// decodeStringMember decodes a member of a patch that must be a string.
+func decodeStringMember(name string, raw json.RawMessage) (*string, error) {
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, domain.NewError(domain.ErrValidation, "%s must be a string", name)
+	}
+	return &value, nil
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"example/go-clean-architecture/Domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseTaskMergePatch tests that the members of a merge patch become the fields of the task patch.
+func TestParseTaskMergePatch(t *testing.T) {
+	patch, err := parseTaskMergePatch(strings.NewReader(`{"title": "Renamed", "due_date": "2024-06-01T12:00:00Z", "assignee_ids": ["a"]}`))
+	require.NoError(t, err)
+	require.NotNil(t, patch.Title)
+	assert.Equal(t, "Renamed", *patch.Title)
+	require.NotNil(t, patch.DueDate)
+	assert.True(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC).Equal(*patch.DueDate))
+	assert.Equal(t, &[]string{"a"}, patch.AssigneeIDs)
+	assert.Nil(t, patch.Description)
+	assert.Nil(t, patch.Status)
+
+	patch, err = parseTaskMergePatch(strings.NewReader(`{"assignee_ids": null}`))
+	require.NoError(t, err)
+	assert.Equal(t, &[]string{}, patch.AssigneeIDs, "null removes every assignee")
+
+	patch, err = parseTaskMergePatch(strings.NewReader(`{"labels": ["bug"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, &[]string{"bug"}, patch.Labels)
+	patch, err = parseTaskMergePatch(strings.NewReader(`{"labels": null}`))
+	require.NoError(t, err)
+	assert.Equal(t, &[]string{}, patch.Labels, "null removes every label")
+
+	patch, err = parseTaskMergePatch(strings.NewReader(`{}`))
+	require.NoError(t, err)
+	assert.True(t, patch.IsEmpty())
+}
+
+// TestParseTaskMergePatch_Errors tests that invalid patches are rejected with a message naming the field.
+func TestParseTaskMergePatch_Errors(t *testing.T) {
+	cases := map[string]string{
+		`not json`:                      "the patch must be a JSON object",
+		`null`:                          "the patch must be a JSON object",
+		`["title"]`:                     "the patch must be a JSON object",
+		`{"title": null}`:               "title cannot be removed",
+		`{"title": 42}`:                 "title must be a string",
+		`{"due_date": "tomorrow"}`:      "due_date must be a date in RFC 3339 format",
+		`{"assignee_ids": "a"}`:         "assignee_ids must be an array of user ids",
+		`{"labels": [1]}`:               "labels must be an array of label names",
+		`{"created_by": "someone"}`:     "created_by cannot be changed",
+		`{"parent_id": "a"}`:            "parent_id cannot be changed",
+		`{"project_id": "a"}`:           "project_id cannot be changed",
+		`{"org_id": "a"}`:               "org_id cannot be changed",
+		`{"priority": 1, "title": "x"}`: `unknown field "priority"`,
+	}
+	for body, message := range cases {
+		_, err := parseTaskMergePatch(strings.NewReader(body))
+		assert.ErrorIs(t, err, Domain.ErrValidation, body)
+		assert.EqualError(t, err, message, body)
+	}
+}
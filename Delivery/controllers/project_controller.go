@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectController serves the projects and their members under /projects.
+// The tasks of a project are served by the TaskController.
+type ProjectController struct {
+	ProjectUseCase domain.ProjectUseCase
+}
+
+// projectRequest is the body of a new or edited project.
+type projectRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+}
+
+// memberRequest is the body of a new member of a project.
+type memberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required"`
+}
+
+// memberRoleRequest is the body of a change of the role of a member.
+type memberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// GetProjects retrieves the projects the caller is a member of in a JSON envelope.
+func (pc *ProjectController) GetProjects(c *gin.Context) {
+	projects, err := pc.ProjectUseCase.GetProjects(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+// CreateProject creates the project in the request body, owned by the caller, and returns it with a created status code.
+func (pc *ProjectController) CreateProject(c *gin.Context) {
+	var req projectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	project, err := pc.ProjectUseCase.CreateProject(c, domain.Project{Name: req.Name, Description: req.Description})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, project)
+}
+
+// GetProject retrieves the project with the ID in the path.
+func (pc *ProjectController) GetProject(c *gin.Context) {
+	project, err := pc.ProjectUseCase.GetProject(c, c.Param("pid"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// UpdateProject replaces the name and description of the project with the ID in the path and returns it.
+func (pc *ProjectController) UpdateProject(c *gin.Context) {
+	var req projectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	project, err := pc.ProjectUseCase.UpdateProject(c, c.Param("pid"), domain.Project{Name: req.Name, Description: req.Description})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject deletes the project with the ID in the path, which must have no tasks left.
+func (pc *ProjectController) DeleteProject(c *gin.Context) {
+	err := pc.ProjectUseCase.DeleteProject(c, c.Param("pid"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
+
+// GetMembers retrieves the members of the project with the ID in the path in a JSON envelope.
+func (pc *ProjectController) GetMembers(c *gin.Context) {
+	members, err := pc.ProjectUseCase.GetMembers(c, c.Param("pid"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"members": members})
+}
+
+// AddMember adds the user in the request body to the project with the ID in the path
+// and returns the new member with a created status code.
+func (pc *ProjectController) AddMember(c *gin.Context) {
+	var req memberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	member, err := pc.ProjectUseCase.AddMember(c, c.Param("pid"), req.UserID, req.Role)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, member)
+}
+
+// UpdateMember changes the role of the member with the user ID in the path and returns the member.
+func (pc *ProjectController) UpdateMember(c *gin.Context) {
+	var req memberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	member, err := pc.ProjectUseCase.UpdateMember(c, c.Param("pid"), c.Param("user_id"), req.Role)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember removes the member with the user ID in the path from the project.
+func (pc *ProjectController) RemoveMember(c *gin.Context) {
+	err := pc.ProjectUseCase.RemoveMember(c, c.Param("pid"), c.Param("user_id"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "removed successfully"})
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleController serves /admin/roles, where the users allowed to manage the roles of their organization
+// list, create, change and delete them, and /admin/permissions listing the permissions a role can grant.
+type RoleController struct {
+	RoleUseCase domain.RoleUseCase
+}
+
+// roleRequest is the body of a new role.
+type roleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// roleUpdateRequest is the body of an edited role, whose name cannot change.
+type roleUpdateRequest struct {
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// GetPermissions lists every permission a role can grant in a JSON envelope.
+func (rc *RoleController) GetPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"permissions": domain.AllPermissions})
+}
+
+// GetRoles retrieves the built-in roles and the roles of the organization of the caller in a JSON envelope.
+func (rc *RoleController) GetRoles(c *gin.Context) {
+	roles, err := rc.RoleUseCase.GetRoles(c)
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+// GetRole retrieves the role named in the path.
+func (rc *RoleController) GetRole(c *gin.Context) {
+	role, err := rc.RoleUseCase.GetRole(c, c.Param("name"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// CreateRole adds the role in the request body to the organization of the caller
+// and returns it with a created status code.
+func (rc *RoleController) CreateRole(c *gin.Context) {
+	var req roleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	role, err := rc.RoleUseCase.CreateRole(c, domain.Role{Name: req.Name, Description: req.Description, Permissions: req.Permissions})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRole replaces the description and the permissions of the role named in the path and returns it.
+func (rc *RoleController) UpdateRole(c *gin.Context) {
+	var req roleUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, validationError(err))
+		return
+	}
+
+	role, err := rc.RoleUseCase.UpdateRole(c, c.Param("name"), domain.Role{Description: req.Description, Permissions: req.Permissions})
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole deletes the role named in the path.
+func (rc *RoleController) DeleteRole(c *gin.Context) {
+	err := rc.RoleUseCase.DeleteRole(c, c.Param("name"))
+	if err != nil {
+		errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "deleted successfully"})
+}
@@ -1,23 +1,86 @@
 package main
 
 import (
+	"context"
 	"example/go-clean-architecture/Delivery/router"
-	"example/go-clean-architecture/db"
-	"time"
+	"example/go-clean-architecture/Delivery/server"
+	infrastructure "example/go-clean-architecture/Infrastructure"
+	"example/go-clean-architecture/config"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/gin-gonic/gin"
 )
 
 // main is the entry point of the application.
-// It sets up the Gin router, connects to the MongoDB database,
-// sets up the router with the database connection, and starts the server.
-// The server listens on localhost:8080.
+// It loads the configuration, sets up the Gin router, opens the configured storage backend,
+// loads the token signing keys, opens the blob store of the attachments, builds the use cases on the repositories, sets up the router with them, and runs the server,
+// with the job purging the trash in the background, until it receives SIGINT or SIGTERM.
+// The server listens on the configured address, localhost:8080 by default.
 func main() {
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := run(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run starts the application and blocks until it has shut down.
+// A first SIGINT or SIGTERM starts a graceful shutdown, a second one terminates the process.
+func run(cfg config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	keys, err := loadKeyManager(cfg.JWT)
+	if err != nil {
+		return err
+	}
+	tokens := infrastructure.NewJWTService(keys, cfg.JWT.AccessTokenTTL, cfg.JWT.RefreshTokenTTL)
+
+	connectCtx, cancel := context.WithTimeout(ctx, cfg.Server.ConnectTimeout)
+	defer cancel()
+	backend, err := router.OpenBackend(connectCtx, cfg.Database)
+	if err != nil {
+		return err
+	}
+
+	blobs, err := backend.OpenBlobStore(cfg.Attachments)
+	if err != nil {
+		backend.Close(context.Background())
+		return err
+	}
+
+	gin.SetMode(cfg.Server.Mode)
+	health := infrastructure.NewHealthChecker(cfg.Server.HealthCheckTimeout, backend.HealthChecks...)
 	r := gin.Default()
-	databse := db.ConnectDB("mongodb://localhost:27017")
-	defer db.DisconnectDB()
+	useCases := router.NewUseCases(backend.Store, tokens, cfg.Workflow.TaskWorkflow(), blobs, cfg.Attachments.Limits(), cfg.Server.ContextTimeout)
+	router.SetUpRouter(r, useCases, backend.Store.RefreshTokens, tokens, health)
+
+	srv := server.New(cfg.Server.Address, r, cfg.Server.DrainTimeout)
+	srv.SetShutdownDelay(cfg.Server.ShutdownDelay)
+	srv.OnShutdownStart(health.SetShuttingDown)
+	srv.OnShutdown("database", backend.Close)
 
-	router.SetUpRouter(r, *databse, 100 * time.Second)
+	srv.AddWorker(infrastructure.NewTrashPurger(useCases.Tasks, cfg.Trash.Retention, cfg.Trash.PurgeInterval))
+
+	go func() {
+		// restore the default signal handling once shutdown starts, so a second signal kills the process
+		<-ctx.Done()
+		stop()
+	}()
+	return srv.Run(ctx)
+}
 
-	r.Run("localhost:8080")
+// loadKeyManager loads the configured token signing keys.
+// Without configured keys it falls back to an ephemeral key, which is only suitable for development.
+func loadKeyManager(cfg config.JWTConfig) (*infrastructure.KeyManager, error) {
+	if len(cfg.Keys) == 0 {
+		log.Println("no JWT keys are configured, signing tokens with an ephemeral key")
+		return infrastructure.NewEphemeralKeyManager()
+	}
+	return infrastructure.NewKeyManager(cfg.SigningKeyID, cfg.KeyConfigs())
 }
@@ -0,0 +1,98 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	domain "example/go-clean-architecture/Domain"
+	infrastructure "example/go-clean-architecture/Infrastructure"
+	repository "example/go-clean-architecture/Repositories"
+	"example/go-clean-architecture/config"
+	"example/go-clean-architecture/db"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Backend is the storage backend the repositories of the application are built on.
+type Backend struct {
+	Store repository.Store
+	// HealthChecks are the readiness checks of the database server, if the backend has one.
+	HealthChecks []infrastructure.HealthCheck
+	// Close releases the connection to the database.
+	Close func(ctx context.Context) error
+	// mongo is the database of the mongodb backend, which can also keep the attachments.
+	mongo *mongo.Database
+}
+
+// OpenBackend opens the storage backend selected by the configuration.
+// It returns an error if the backend is unknown or its database cannot be reached before ctx is done.
+func OpenBackend(ctx context.Context, cfg config.DatabaseConfig) (*Backend, error) {
+	switch cfg.Backend {
+	case config.BackendMongoDB:
+		database, err := db.ConnectDB(ctx, cfg.URI, cfg.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := repository.MigrateMongo(ctx, *database); err != nil {
+			db.DisconnectDB(ctx)
+			return nil, err
+		}
+		return &Backend{
+			Store:        repository.NewMongoStore(*database),
+			HealthChecks: []infrastructure.HealthCheck{{Name: "mongodb", Check: db.Ping}},
+			Close:        db.DisconnectDB,
+			mongo:        database,
+		}, nil
+	case config.BackendPostgres:
+		return openSQLBackend(ctx, db.Postgres, cfg.DSN)
+	case config.BackendSQLite:
+		return openSQLBackend(ctx, db.SQLite, db.SQLiteDSN(cfg.Path))
+	case config.BackendMemory:
+		return &Backend{
+			Store: repository.NewMemoryStore(),
+			Close: func(ctx context.Context) error { return nil },
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown database backend %q", cfg.Backend)
+	}
+}
+
+// OpenBlobStore opens the blob store the content of the attachments is kept in, as selected by the configuration.
+// The gridfs storage is only available with the mongodb backend.
+func (b *Backend) OpenBlobStore(cfg config.AttachmentsConfig) (domain.BlobStore, error) {
+	switch cfg.Storage {
+	case config.StorageLocal:
+		return repository.NewLocalBlobStore(cfg.Dir)
+	case config.StorageGridFS:
+		if b.mongo == nil {
+			return nil, fmt.Errorf("the %s attachment storage requires the mongodb backend", cfg.Storage)
+		}
+		return repository.NewGridFSBlobStore(*b.mongo, "attachments"), nil
+	default:
+		return nil, fmt.Errorf("unknown attachment storage %q", cfg.Storage)
+	}
+}
+
+// openSQLBackend connects to a SQL database and migrates its schema before building the repositories.
+func openSQLBackend(ctx context.Context, dialect db.Dialect, dsn string) (*Backend, error) {
+	conn, err := db.OpenSQL(ctx, dialect, dsn)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := db.Migrate(ctx, conn, dialect)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if applied > 0 {
+		log.Printf("applied %d %s migrations", applied, dialect)
+	}
+	return &Backend{
+		Store:        repository.NewSQLStore(conn, dialect),
+		HealthChecks: []infrastructure.HealthCheck{{Name: string(dialect), Check: conn.PingContext}},
+		Close: func(ctx context.Context) error {
+			return conn.Close()
+		},
+	}, nil
+}
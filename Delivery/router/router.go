@@ -2,53 +2,113 @@ package router
 
 import (
 	controllers "example/go-clean-architecture/Delivery/controllers"
+	domain "example/go-clean-architecture/Domain"
 	infrastructure "example/go-clean-architecture/Infrastructure"
-	repository "example/go-clean-architecture/Repositories"
-	usecases "example/go-clean-architecture/Usecases"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 // SetUpRouter sets up the router for the application.
 // It configures the routes and middleware for different endpoints.
 // The router parameter is a pointer to a gin.Engine instance.
-// The db parameter is a mongo.Database instance representing the database connection.
-// The time parameter is a time.Duration value representing the duration for certain operations.
-func SetUpRouter(router *gin.Engine, db mongo.Database, time time.Duration) {
+// The useCases parameter holds the use cases built with NewUseCases the routes are served by.
+// The refreshTokens parameter is the repository of the sessions, whose revocation rejects their access tokens.
+// The tokens parameter is the JWTService issuing and verifying the access tokens.
+// The health parameter is the HealthChecker serving the liveness and readiness endpoints.
+func SetUpRouter(router *gin.Engine, useCases UseCases, refreshTokens domain.RefreshTokenRepository, tokens *infrastructure.JWTService, health *infrastructure.HealthChecker) {
+	tc := controllers.TaskController{TaskUseCase: useCases.Tasks}
+	cc := controllers.CommentController{CommentUseCase: useCases.Comments}
+	clc := controllers.ChecklistController{ChecklistUseCase: useCases.Checklists}
+	ac := controllers.AttachmentController{AttachmentUseCase: useCases.Attachments}
+	pc := controllers.ProjectController{ProjectUseCase: useCases.Projects}
+	lc := controllers.LabelController{LabelUseCase: useCases.Labels}
+	uc := controllers.UserController{UserUseCase: useCases.Users}
+	rc := controllers.RoleController{RoleUseCase: useCases.Roles}
 
-	tr := repository.NewTaskRepository(db, "tasks")
-	ur := repository.NewUserRepository(db, "users")
+	// Health endpoints for the orchestrator
+	router.GET("/healthz", health.Liveness)
+	router.GET("/readyz", health.Readiness)
 
-	tc := controllers.TaskController{
-		TaskUseCase: usecases.NewTaskUsecase(tr, time),
-	}
-	uc := controllers.UserController{
-		UserUseCase: usecases.NewUserUsecase(ur, time),
-	}
 	// Public routes
 	public := router.Group("/")
 	{
 		public.POST("/register", uc.CreateAccount)
 		public.POST("/login", uc.Login)
+		public.POST("/token/refresh", uc.RefreshToken)
+		public.POST("/logout", uc.Logout)
+		public.GET("/.well-known/jwks.json", infrastructure.JWKSHandler(tokens.Keys()))
 	}
 
 	// Authenticated routes
 	authorized := router.Group("/")
-	authorized.Use(infrastructure.AuthMiddleware())
+	authorized.Use(infrastructure.AuthMiddleware(tokens, refreshTokens))
 	{
 		authorized.GET("/tasks", tc.GetTasks)
+		authorized.GET("/labels", lc.GetLabels)
 		authorized.GET("/tasks/:id", tc.GetTask)
+		authorized.POST("/tasks/:id/transition", tc.TransitionTask)
+		authorized.GET("/tasks/:id/history", tc.GetTaskHistory)
+		authorized.POST("/tasks/:id/dependencies", tc.AddDependency)
+		authorized.DELETE("/tasks/:id/dependencies/:depends_on_id", tc.RemoveDependency)
+		authorized.GET("/tasks/:id/graph", tc.GetTaskGraph)
+		authorized.GET("/tasks/:id/comments", cc.GetComments)
+		authorized.POST("/tasks/:id/comments", cc.CreateComment)
+		authorized.PUT("/tasks/:id/comments/:comment_id", cc.UpdateComment)
+		authorized.DELETE("/tasks/:id/comments/:comment_id", cc.DeleteComment)
+		authorized.GET("/tasks/:id/checklist", clc.GetChecklist)
+		authorized.POST("/tasks/:id/checklist", clc.AddChecklistItem)
+		authorized.PUT("/tasks/:id/checklist/order", clc.ReorderChecklist)
+		authorized.POST("/tasks/:id/checklist/:item_id/complete", clc.CompleteChecklistItem)
+		authorized.POST("/tasks/:id/checklist/:item_id/reopen", clc.ReopenChecklistItem)
+		authorized.DELETE("/tasks/:id/checklist/:item_id", clc.DeleteChecklistItem)
+		authorized.GET("/tasks/:id/attachments", ac.GetAttachments)
+		authorized.POST("/tasks/:id/attachments", ac.CreateAttachment)
+		authorized.GET("/tasks/:id/attachments/:attachment_id", ac.DownloadAttachment)
+		authorized.DELETE("/tasks/:id/attachments/:attachment_id", ac.DeleteAttachment)
+		authorized.GET("/projects", pc.GetProjects)
+		authorized.POST("/projects", pc.CreateProject)
+		authorized.GET("/projects/:pid", pc.GetProject)
+		authorized.PUT("/projects/:pid", pc.UpdateProject)
+		authorized.DELETE("/projects/:pid", pc.DeleteProject)
+		authorized.GET("/projects/:pid/members", pc.GetMembers)
+		authorized.POST("/projects/:pid/members", pc.AddMember)
+		authorized.PUT("/projects/:pid/members/:user_id", pc.UpdateMember)
+		authorized.DELETE("/projects/:pid/members/:user_id", pc.RemoveMember)
+		authorized.GET("/projects/:pid/tasks", tc.GetProjectTasks)
+		authorized.POST("/projects/:pid/tasks", tc.CreateProjectTask)
+		authorized.GET("/projects/:pid/tasks/:id", tc.GetProjectTask)
+		authorized.PUT("/projects/:pid/tasks/:id", tc.UpdateProjectTask)
+		authorized.PATCH("/projects/:pid/tasks/:id", tc.PatchProjectTask)
+		authorized.DELETE("/projects/:pid/tasks/:id", tc.DeleteProjectTask)
 	}
 
-	// Admin routes (require admin privileges)
+	// Admin routes (each requires a permission of the role of the caller)
+	can := infrastructure.RequirePermission
 	admin := router.Group("/admin")
-	admin.Use(infrastructure.AuthMiddleware(), infrastructure.AuthAdminMiddleware())
+	admin.Use(infrastructure.AuthMiddleware(tokens, refreshTokens))
 	{
-		admin.PUT("/promote/:id", uc.PromoteUser)
-		admin.POST("/tasks", tc.CreateTask)
-		admin.PUT("/tasks/:id", tc.UpdateTask)
-		admin.DELETE("/tasks/:id", tc.DeleteTask)
+		admin.PUT("/promote/:id", can(domain.PermissionUserPromote), uc.PromoteUser)
+		admin.GET("/users", can(domain.PermissionUserManage), uc.GetUsers)
+		admin.POST("/users", can(domain.PermissionUserManage), uc.CreateUser)
+		admin.GET("/users/:id", can(domain.PermissionUserManage), uc.GetUser)
+		admin.PUT("/users/:id/role", can(domain.PermissionUserPromote), uc.AssignRole)
+		admin.POST("/users/:id/deactivate", can(domain.PermissionUserManage), uc.DeactivateUser)
+		admin.POST("/users/:id/activate", can(domain.PermissionUserManage), uc.ActivateUser)
+		admin.DELETE("/users/:id", can(domain.PermissionUserManage), uc.DeleteUser)
+		admin.POST("/tasks", can(domain.PermissionTaskCreate), tc.CreateTask)
+		admin.PUT("/tasks/:id", can(domain.PermissionTaskUpdate), tc.UpdateTask)
+		admin.PATCH("/tasks/:id", can(domain.PermissionTaskUpdate), tc.PatchTask)
+		admin.DELETE("/tasks/:id", can(domain.PermissionTaskDelete), tc.DeleteTask)
+		admin.GET("/trash", can(domain.PermissionTaskRestore), tc.GetDeletedTasks)
+		admin.POST("/tasks/:id/restore", can(domain.PermissionTaskRestore), tc.RestoreTask)
+		admin.POST("/labels", can(domain.PermissionLabelManage), lc.CreateLabel)
+		admin.PUT("/labels/:name", can(domain.PermissionLabelManage), lc.UpdateLabel)
+		admin.DELETE("/labels/:name", can(domain.PermissionLabelManage), lc.DeleteLabel)
+		admin.GET("/permissions", can(domain.PermissionRoleManage), rc.GetPermissions)
+		admin.GET("/roles", can(domain.PermissionRoleManage), rc.GetRoles)
+		admin.POST("/roles", can(domain.PermissionRoleManage), rc.CreateRole)
+		admin.GET("/roles/:name", can(domain.PermissionRoleManage), rc.GetRole)
+		admin.PUT("/roles/:name", can(domain.PermissionRoleManage), rc.UpdateRole)
+		admin.DELETE("/roles/:name", can(domain.PermissionRoleManage), rc.DeleteRole)
 	}
 }
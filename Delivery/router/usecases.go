@@ -0,0 +1,44 @@
+package router
+
+import (
+	domain "example/go-clean-architecture/Domain"
+	infrastructure "example/go-clean-architecture/Infrastructure"
+	repository "example/go-clean-architecture/Repositories"
+	usecases "example/go-clean-architecture/Usecases"
+	"time"
+)
+
+// UseCases holds the use cases of the application. They are built once, so the routes and the background jobs
+// share the same hooks, workflow and repositories.
+type UseCases struct {
+	Tasks       domain.TaskUseCase
+	Comments    domain.CommentUseCase
+	Checklists  domain.ChecklistUseCase
+	Attachments domain.AttachmentUseCase
+	Projects    domain.ProjectUseCase
+	Labels      domain.LabelUseCase
+	Users       domain.UserUseCase
+	Roles       domain.RoleUseCase
+}
+
+// NewUseCases builds the use cases on the repositories of the store.
+// The tokens parameter is the JWTService issuing the access tokens of the users.
+// The workflow parameter is the state machine the status of every task follows.
+// The blobs parameter is the BlobStore keeping the content of the attachments, which must respect the limits.
+// The timeout parameter bounds every operation of the use cases.
+// The attachments of the tasks purged from the trash are deleted with them.
+func NewUseCases(store repository.Store, tokens *infrastructure.JWTService, workflow domain.TaskWorkflow, blobs domain.BlobStore, limits domain.AttachmentLimits, timeout time.Duration) UseCases {
+	tasks := usecases.NewTaskUsecase(store.Tasks, store.TaskHistory, store.Comments, store.Checklists, store.Dependencies, store.Labels, store.Projects, workflow, timeout)
+	attachments := usecases.NewAttachmentUsecase(store.Attachments, store.Tasks, store.Projects, blobs, limits, timeout)
+	tasks.OnPurge(attachments.DeleteTaskAttachments)
+	return UseCases{
+		Tasks:       tasks,
+		Comments:    usecases.NewCommentUsecase(store.Comments, store.Tasks, store.Projects, timeout),
+		Checklists:  usecases.NewChecklistUsecase(store.Checklists, store.Tasks, store.Projects, timeout),
+		Attachments: attachments,
+		Projects:    usecases.NewProjectUsecase(store.Projects, store.Tasks, store.Users, timeout),
+		Labels:      usecases.NewLabelUsecase(store.Labels, store.Tasks, timeout),
+		Users:       usecases.NewUserUsecase(store.Users, store.Roles, store.Tasks, store.Projects, store.RefreshTokens, tokens, timeout),
+		Roles:       usecases.NewRoleUsecase(store.Roles, store.Users, timeout),
+	}
+}
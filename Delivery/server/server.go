@@ -0,0 +1,155 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Worker is a background job that runs for the lifetime of the server.
+// Run must return once ctx is cancelled.
+type Worker interface {
+	Run(ctx context.Context) error
+}
+
+// WorkerFunc adapts a function to the Worker interface.
+type WorkerFunc func(ctx context.Context) error
+
+// Run calls f(ctx).
+func (f WorkerFunc) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+// closer is a resource released after the server and its workers have stopped.
+type closer struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// Server runs the HTTP server and the background workers of the application and shuts them down in order.
+// On shutdown the listener is closed and in-flight requests are drained first, then the workers are stopped,
+// and finally the registered resources, such as the database client, are closed.
+type Server struct {
+	httpServer    *http.Server
+	drainTimeout  time.Duration
+	shutdownDelay time.Duration
+	onShutdown    []func()
+	workers       []Worker
+	closers       []closer
+}
+
+// New creates a Server listening on addr and serving handler.
+// The drainTimeout parameter bounds the whole shutdown: requests still running when it expires are cut off.
+func New(addr string, handler http.Handler, drainTimeout time.Duration) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+		drainTimeout: drainTimeout,
+	}
+}
+
+// OnShutdownStart registers a function called as soon as a shutdown starts, while requests are still served.
+// It is used to report the application as not ready.
+func (s *Server) OnShutdownStart(fn func()) {
+	s.onShutdown = append(s.onShutdown, fn)
+}
+
+// SetShutdownDelay makes the server keep accepting requests for delay once a shutdown starts,
+// which gives load balancers polling the readiness endpoint time to stop routing traffic to it.
+// The delay is not part of the drain timeout.
+func (s *Server) SetShutdownDelay(delay time.Duration) {
+	s.shutdownDelay = delay
+}
+
+// AddWorker registers a background worker, started when the server runs and stopped after the HTTP server.
+func (s *Server) AddWorker(worker Worker) {
+	s.workers = append(s.workers, worker)
+}
+
+// OnShutdown registers a resource to close once the HTTP server and the workers have stopped.
+// Resources are closed in the order they were registered.
+func (s *Server) OnShutdown(name string, close func(ctx context.Context) error) {
+	s.closers = append(s.closers, closer{name: name, close: close})
+}
+
+// Run listens on the server address and serves until ctx is cancelled, then shuts the server down.
+// It returns an error if the server could not listen or did not shut down cleanly.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	return s.serve(ctx, listener)
+}
+
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var workers sync.WaitGroup
+	for _, worker := range s.workers {
+		workers.Add(1)
+		go func(worker Worker) {
+			defer workers.Done()
+			if err := worker.Run(workerCtx); err != nil && !errors.Is(err, context.Canceled) {
+				log.Printf("background worker stopped: %v", err)
+			}
+		}(worker)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("listening on %s", listener.Addr())
+		serveErr <- s.httpServer.Serve(listener)
+	}()
+
+	var errs []error
+	select {
+	case err := <-serveErr:
+		errs = append(errs, err)
+	case <-ctx.Done():
+		log.Println("shutting down")
+		for _, fn := range s.onShutdown {
+			fn()
+		}
+		if s.shutdownDelay > 0 {
+			time.Sleep(s.shutdownDelay)
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("http server: %w", err))
+		// cut off the requests that did not finish in time
+		s.httpServer.Close()
+	}
+
+	stopWorkers()
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		errs = append(errs, errors.New("background workers did not stop before the drain timeout"))
+	}
+
+	for _, c := range s.closers {
+		if err := c.close(shutdownCtx); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer serves s on a random local port until the returned cancel function is called.
+// It returns the base URL of the server and a channel receiving the result of serve.
+func startServer(t *testing.T, s *Server) (string, context.CancelFunc, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.serve(ctx, listener)
+	}()
+	return "http://" + listener.Addr().String(), cancel, done
+}
+
+// TestServer_DrainsInFlightRequests tests that a request running when shutdown starts completes,
+// and that the workers and resources are stopped after it, in order.
+func TestServer_DrainsInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})
+
+	var mu sync.Mutex
+	var events []string
+	record := func(event string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+	}
+
+	s := New("", handler, 5*time.Second)
+	s.AddWorker(WorkerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		record("worker")
+		return ctx.Err()
+	}))
+	s.OnShutdown("database", func(ctx context.Context) error {
+		record("database")
+		return nil
+	})
+
+	url, cancel, done := startServer(t, s)
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(url)
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+
+	<-started
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	record("request")
+	close(release)
+
+	assert.Equal(t, "done", <-response)
+	assert.NoError(t, <-done)
+	assert.Equal(t, []string{"request", "worker", "database"}, events)
+}
+
+// TestServer_DrainTimeout tests that shutdown gives up on requests that outlive the drain timeout
+// and still closes the registered resources.
+func TestServer_DrainTimeout(t *testing.T) {
+	started := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	})
+	closed := false
+	s := New("", handler, 50*time.Millisecond)
+	s.OnShutdown("database", func(ctx context.Context) error {
+		closed = true
+		return errors.New("disconnect failed")
+	})
+
+	url, cancel, done := startServer(t, s)
+	go http.Get(url)
+	<-started
+	cancel()
+
+	err := <-done
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "database: disconnect failed")
+	assert.True(t, closed)
+}
+
+// TestServer_ListenError tests that Run reports an address it cannot listen on.
+func TestServer_ListenError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	s := New(listener.Addr().String(), http.NotFoundHandler(), time.Second)
+	assert.Error(t, s.Run(context.Background()))
+}
+
+// TestServer_ShutdownDelay tests that the shutdown hooks run first and that requests are still
+// served during the shutdown delay.
+func TestServer_ShutdownDelay(t *testing.T) {
+	var shuttingDown atomic.Bool
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if shuttingDown.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	})
+	s := New("", handler, time.Second)
+	s.SetShutdownDelay(200 * time.Millisecond)
+	s.OnShutdownStart(func() { shuttingDown.Store(true) })
+
+	url, cancel, done := startServer(t, s)
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,113 @@
+package Domain
+
+import (
+	"context"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Attachment is a file attached to a task. Its content is kept in a BlobStore under the ID of the attachment.
+// ContentType is detected from the content, not taken from the client.
+type Attachment struct {
+	ID          ID        `json:"id" bson:"_id"`
+	TaskID      string    `json:"task_id" bson:"task_id"`
+	Filename    string    `json:"filename" bson:"filename"`
+	ContentType string    `json:"content_type" bson:"content_type"`
+	Size        int64     `json:"size" bson:"size"`
+	UploadedBy  string    `json:"uploaded_by" bson:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// CanDelete reports whether the user may delete the attachment: only its uploader and the users
+// with PermissionAttachmentModerate can.
+func (a Attachment) CanDelete(user AuthUser) bool {
+	return user.Can(PermissionAttachmentModerate) || a.UploadedBy == user.ID
+}
+
+var (
+	ErrAttachmentNotFound = NewError(ErrNotFound, "attachment not found")
+	ErrBlobNotFound       = NewError(ErrNotFound, "blob not found")
+	// ErrAttachmentTooLarge is returned when an attachment exceeds AttachmentLimits.MaxSize.
+	ErrAttachmentTooLarge = NewError(ErrTooLarge, "the attachment is too large")
+	// ErrAttachmentType is returned when the content of an attachment is not of one of AttachmentLimits.AllowedTypes.
+	ErrAttachmentType = NewError(ErrUnsupportedMediaType, "the type of the attachment is not allowed")
+	// ErrNotUploader is returned when a user other than the uploader or an admin deletes an attachment.
+	ErrNotUploader = NewError(ErrForbidden, "only the uploader of an attachment or a moderator can delete it")
+)
+
+// maxFilenameLength is the maximum number of bytes kept of the name of an attached file.
+const maxFilenameLength = 255
+
+// CleanFilename returns the base name of a file sent by a client, without any directory and cut to 255 bytes,
+// so it can be sent back in a Content-Disposition header. It returns an ErrValidation error for an empty name.
+func CleanFilename(name string) (string, error) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.TrimSpace(filepath.Base("/" + name))
+	if name == "/" || name == "." || name == ".." || name == "" {
+		return "", NewError(ErrValidation, "the attachment needs a file name")
+	}
+	if len(name) > maxFilenameLength {
+		name = strings.ToValidUTF8(name[:maxFilenameLength], "")
+	}
+	return name, nil
+}
+
+// AttachmentLimits bounds the files that can be attached to tasks.
+type AttachmentLimits struct {
+	// MaxSize is the maximum size of an attachment in bytes.
+	MaxSize int64
+	// AllowedTypes are the accepted media types, without parameters, such as image/png.
+	AllowedTypes []string
+}
+
+// Allows reports whether content of the given media type may be attached. Parameters such as charset are ignored.
+func (l AttachmentLimits) Allows(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.AllowedTypes {
+		if strings.EqualFold(mediaType, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// BlobStore keeps the content of the attachments. Keys are attachment IDs.
+type BlobStore interface {
+	// Put stores the content read from r under key and returns its size.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get opens the content stored under key. It returns ErrBlobNotFound if there is none.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// AttachmentRepository stores the metadata of the attachments.
+type AttachmentRepository interface {
+	// CreateAttachment stores a new attachment with the ID it was given.
+	CreateAttachment(ctx context.Context, attachment Attachment) (Attachment, error)
+	// FindAttachmentById returns ErrAttachmentNotFound if no attachment has this ID.
+	FindAttachmentById(ctx context.Context, attachmentId string) (Attachment, error)
+	// FindTaskAttachments returns the attachments of a task, oldest first.
+	FindTaskAttachments(ctx context.Context, taskId string) ([]Attachment, error)
+	DeleteAttachment(ctx context.Context, attachmentId string) error
+	// DeleteTaskAttachments deletes every attachment of the given tasks.
+	DeleteTaskAttachments(ctx context.Context, taskIds []string) error
+}
+
+// AttachmentUseCase manages the files attached to the tasks. Users can list, download and attach files
+// on the tasks they can see and delete the attachments they uploaded; admins can delete any attachment.
+type AttachmentUseCase interface {
+	GetAttachments(ctx context.Context, taskId string) ([]Attachment, error)
+	AddAttachment(ctx context.Context, taskId string, filename string, content io.Reader) (Attachment, error)
+	// OpenAttachment returns an attachment with its content, which the caller must close.
+	OpenAttachment(ctx context.Context, taskId string, attachmentId string) (Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, taskId string, attachmentId string) error
+	// DeleteTaskAttachments deletes the attachments of the given tasks with their content, once the tasks are purged.
+	DeleteTaskAttachments(ctx context.Context, taskIds []string) error
+}
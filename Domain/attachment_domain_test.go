@@ -0,0 +1,53 @@
+package Domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCleanFilename tests that only the base name of a file is kept, whatever the path separator of the client.
+func TestCleanFilename(t *testing.T) {
+	cases := map[string]string{
+		"report.pdf":            "report.pdf",
+		"../../etc/passwd":      "passwd",
+		`C:\Users\me\notes.txt`: "notes.txt",
+		" spaced name.png ":     "spaced name.png",
+		"/tmp/dir/":             "dir",
+		"naïve.txt":             "naïve.txt",
+	}
+	for name, expected := range cases {
+		cleaned, err := CleanFilename(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, expected, cleaned, name)
+	}
+
+	for _, name := range []string{"", " ", "/", ".", "..", `\`} {
+		_, err := CleanFilename(name)
+		assert.ErrorIs(t, err, ErrValidation, name)
+	}
+
+	long, err := CleanFilename(strings.Repeat("é", 200))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(long), 255)
+	assert.True(t, strings.HasPrefix(strings.Repeat("é", 200), long), "multibyte characters are not split")
+}
+
+// TestAttachmentLimits_Allows tests that media types are matched without their parameters and regardless of case.
+func TestAttachmentLimits_Allows(t *testing.T) {
+	limits := AttachmentLimits{AllowedTypes: []string{"image/png", "text/plain"}}
+	assert.True(t, limits.Allows("image/png"))
+	assert.True(t, limits.Allows("text/plain; charset=utf-8"))
+	assert.True(t, limits.Allows("IMAGE/PNG"))
+	assert.False(t, limits.Allows("text/html; charset=utf-8"))
+	assert.False(t, limits.Allows("not a type"))
+}
+
+// TestAttachment_CanDelete tests that only the uploader and admins can delete an attachment.
+func TestAttachment_CanDelete(t *testing.T) {
+	attachment := Attachment{UploadedBy: "uploader"}
+	assert.True(t, attachment.CanDelete(AuthUser{ID: "uploader", Role: RoleUser}))
+	assert.True(t, attachment.CanDelete(AuthUser{ID: "moderator", Role: "MODERATOR", Permissions: []string{PermissionAttachmentModerate}}))
+	assert.False(t, attachment.CanDelete(AuthUser{ID: "other", Role: RoleUser}))
+}
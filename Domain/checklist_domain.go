@@ -0,0 +1,124 @@
+package Domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ChecklistItem is a step of a task. The items of a task are ordered by Position, then by ID.
+// Required items must be done before the task can move to DONE.
+type ChecklistItem struct {
+	ID        ID         `json:"id" bson:"_id"`
+	TaskID    string     `json:"task_id" bson:"task_id"`
+	Title     string     `json:"title" bson:"title"`
+	Required  bool       `json:"required" bson:"required"`
+	Position  int        `json:"position" bson:"position"`
+	Done      bool       `json:"done" bson:"done"`
+	DoneBy    string     `json:"done_by,omitempty" bson:"done_by,omitempty"`
+	DoneAt    *time.Time `json:"done_at,omitempty" bson:"done_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+}
+
+// Limits of the checklist of a task.
+const (
+	MaxChecklistItems       = 100
+	MaxChecklistTitleLength = 500
+)
+
+var (
+	ErrChecklistItemNotFound = NewError(ErrNotFound, "checklist item not found")
+	// ErrChecklistFull is returned when an item is added to a checklist of MaxChecklistItems items.
+	ErrChecklistFull = NewError(ErrConflict, "the checklist is full")
+	// ErrOpenSteps is returned when a task is moved to DONE while required checklist items or subtasks are open.
+	ErrOpenSteps = NewError(ErrConflict, "the task has open required checklist items or subtasks")
+)
+
+// ValidateChecklistTitle checks that the title of a checklist item is neither blank
+// nor longer than MaxChecklistTitleLength characters.
+func ValidateChecklistTitle(title string) error {
+	if strings.TrimSpace(title) == "" {
+		return NewError(ErrValidation, "title cannot be blank")
+	}
+	if utf8.RuneCountInString(title) > MaxChecklistTitleLength {
+		return NewError(ErrValidation, "title cannot be longer than %d characters", MaxChecklistTitleLength)
+	}
+	return nil
+}
+
+// TaskSteps counts the steps of a task: its checklist items and its subtasks.
+// OpenRequired is the number of steps that keep the task from moving to DONE.
+type TaskSteps struct {
+	Total        int
+	Done         int
+	OpenRequired int
+}
+
+// Add returns the sum of two counts.
+func (s TaskSteps) Add(other TaskSteps) TaskSteps {
+	return TaskSteps{
+		Total:        s.Total + other.Total,
+		Done:         s.Done + other.Done,
+		OpenRequired: s.OpenRequired + other.OpenRequired,
+	}
+}
+
+// Progress returns the percentage of the steps that are done, rounded down, or nil for a task without steps.
+func (s TaskSteps) Progress() *int {
+	if s.Total == 0 {
+		return nil
+	}
+	progress := s.Done * 100 / s.Total
+	return &progress
+}
+
+// SubtaskSteps counts the subtasks of a task from their number in each status.
+// Every subtask is required: a subtask is done once DONE, and CANCELLED subtasks are not counted at all.
+func SubtaskSteps(statuses map[string]int) TaskSteps {
+	var steps TaskSteps
+	for status, n := range statuses {
+		switch status {
+		case TaskStatusCancelled:
+		case TaskStatusDone:
+			steps.Total += n
+			steps.Done += n
+		default:
+			steps.Total += n
+			steps.OpenRequired += n
+		}
+	}
+	return steps
+}
+
+// ChecklistRepository stores the checklist items of the tasks.
+type ChecklistRepository interface {
+	// CreateChecklistItem stores a new item at the position it was given and returns it with its ID set.
+	CreateChecklistItem(ctx context.Context, item ChecklistItem) (ChecklistItem, error)
+	// FindChecklistItemById returns ErrChecklistItemNotFound if no item has this ID.
+	FindChecklistItemById(ctx context.Context, itemId string) (ChecklistItem, error)
+	// FindChecklist returns the items of a task in order.
+	FindChecklist(ctx context.Context, taskId string) ([]ChecklistItem, error)
+	// UpdateChecklistItem replaces the title, required flag and completion of an item and returns the updated item.
+	UpdateChecklistItem(ctx context.Context, item ChecklistItem) (ChecklistItem, error)
+	// ReorderChecklist moves every listed item of the task to its index in itemIds.
+	ReorderChecklist(ctx context.Context, taskId string, itemIds []string) error
+	DeleteChecklistItem(ctx context.Context, itemId string) error
+	// DeleteTaskChecklists deletes every item of the given tasks.
+	DeleteTaskChecklists(ctx context.Context, taskIds []string) error
+	// CountChecklists counts the items of each of the given tasks that has some.
+	CountChecklists(ctx context.Context, taskIds []string) (map[string]TaskSteps, error)
+}
+
+// ChecklistUseCase manages the checklists of the tasks.
+// Users can read and change the checklist of the tasks they can see.
+type ChecklistUseCase interface {
+	GetChecklist(ctx context.Context, taskId string) ([]ChecklistItem, error)
+	// AddChecklistItem appends an item to the checklist of a task.
+	AddChecklistItem(ctx context.Context, taskId string, title string, required bool) (ChecklistItem, error)
+	// CompleteChecklistItem marks an item as done, or as open again when done is false.
+	CompleteChecklistItem(ctx context.Context, taskId string, itemId string, done bool) (ChecklistItem, error)
+	// ReorderChecklist puts the items of a task in the given order, which must list every item once.
+	ReorderChecklist(ctx context.Context, taskId string, itemIds []string) ([]ChecklistItem, error)
+	DeleteChecklistItem(ctx context.Context, taskId string, itemId string) error
+}
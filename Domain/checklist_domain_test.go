@@ -0,0 +1,36 @@
+package Domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateChecklistTitle tests that blank and oversized titles are rejected, counting characters rather than bytes.
+func TestValidateChecklistTitle(t *testing.T) {
+	assert.NoError(t, ValidateChecklistTitle("write the tests"))
+	assert.NoError(t, ValidateChecklistTitle(strings.Repeat("é", MaxChecklistTitleLength)))
+	assert.ErrorIs(t, ValidateChecklistTitle(" \n\t"), ErrValidation)
+	assert.ErrorIs(t, ValidateChecklistTitle(strings.Repeat("a", MaxChecklistTitleLength+1)), ErrValidation)
+}
+
+// TestTaskSteps_Progress tests that the progress is rounded down and absent for a task without steps.
+func TestTaskSteps_Progress(t *testing.T) {
+	assert.Nil(t, TaskSteps{}.Progress())
+	assert.Equal(t, 0, *TaskSteps{Total: 2, OpenRequired: 1}.Progress())
+	assert.Equal(t, 66, *TaskSteps{Total: 3, Done: 2}.Progress())
+	assert.Equal(t, 100, *TaskSteps{Total: 1, Done: 1}.Add(TaskSteps{Total: 1, Done: 1}).Progress())
+}
+
+// TestSubtaskSteps tests that every open subtask is required, DONE subtasks are done
+// and CANCELLED subtasks are left out.
+func TestSubtaskSteps(t *testing.T) {
+	steps := SubtaskSteps(map[string]int{
+		TaskStatusTodo:      2,
+		TaskStatusBlocked:   1,
+		TaskStatusDone:      3,
+		TaskStatusCancelled: 4,
+	})
+	assert.Equal(t, TaskSteps{Total: 6, Done: 3, OpenRequired: 3}, steps)
+}
@@ -0,0 +1,85 @@
+package Domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Comment is a message posted on a task by one of the users who can see it.
+type Comment struct {
+	ID        ID        `json:"id" bson:"_id"`
+	TaskID    string    `json:"task_id" bson:"task_id"`
+	AuthorID  string    `json:"author_id" bson:"author_id"`
+	Body      string    `json:"body" bson:"body"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// MaxCommentLength is the maximum number of characters of the body of a comment.
+const MaxCommentLength = 10000
+
+// ErrCommentNotFound is returned when a comment does not exist or does not belong to the task it is addressed with.
+var ErrCommentNotFound = NewError(ErrNotFound, "comment not found")
+
+// ErrNotCommentAuthor is returned when a user other than the author or an admin edits or deletes a comment.
+var ErrNotCommentAuthor = NewError(ErrForbidden, "only the author of a comment or a moderator can change it")
+
+// ValidateCommentBody checks that the body of a comment is not blank and not longer than MaxCommentLength.
+func ValidateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return NewError(ErrValidation, "body cannot be blank")
+	}
+	if utf8.RuneCountInString(body) > MaxCommentLength {
+		return NewError(ErrValidation, "body cannot be longer than %d characters", MaxCommentLength)
+	}
+	return nil
+}
+
+// CanChange reports whether the user may edit or delete the comment: its author and the users
+// with PermissionCommentModerate can.
+func (c Comment) CanChange(user AuthUser) bool {
+	return user.Can(PermissionCommentModerate) || c.AuthorID == user.ID
+}
+
+// CommentQuery selects one page of the comments of a task, oldest first.
+// Cursor is the opaque NextCursor of a previous CommentPage.
+type CommentQuery struct {
+	TaskID string
+	Cursor string
+	Limit  int
+}
+
+// CommentPage is a single page of the comments of a task.
+// NextCursor is empty when there are no more comments.
+type CommentPage struct {
+	Comments   []Comment `json:"comments"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+// CommentRepository stores the comments of the tasks.
+type CommentRepository interface {
+	// CreateComment stores a new comment and returns it with its ID set.
+	CreateComment(ctx context.Context, comment Comment) (Comment, error)
+	// FindCommentById returns ErrCommentNotFound if no comment has this ID.
+	FindCommentById(ctx context.Context, commentId string) (Comment, error)
+	// FindComments returns one page of the comments of a task. It expects a normalized query with a positive limit.
+	FindComments(ctx context.Context, query CommentQuery) (CommentPage, error)
+	// UpdateComment replaces the body and the update time of a comment and returns it.
+	UpdateComment(ctx context.Context, comment Comment) (Comment, error)
+	DeleteComment(ctx context.Context, commentId string) error
+	// DeleteTaskComments deletes every comment of the given tasks.
+	DeleteTaskComments(ctx context.Context, taskIds []string) error
+	// CountComments returns the number of comments of each of the given tasks; tasks without comments may be missing.
+	CountComments(ctx context.Context, taskIds []string) (map[string]int, error)
+}
+
+// CommentUseCase is the discussion on the tasks. Users can read and post comments on the tasks they can see,
+// and edit or delete their own comments; admins can change any comment.
+type CommentUseCase interface {
+	GetComments(ctx context.Context, taskId string, query CommentQuery) (CommentPage, error)
+	AddComment(ctx context.Context, taskId string, body string) (Comment, error)
+	EditComment(ctx context.Context, taskId string, commentId string, body string) (Comment, error)
+	DeleteComment(ctx context.Context, taskId string, commentId string) error
+}
@@ -0,0 +1,24 @@
+package Domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestValidateCommentBody tests that blank and oversized bodies are rejected, counting characters rather than bytes.
+func TestValidateCommentBody(t *testing.T) {
+	assert.NoError(t, ValidateCommentBody("on it"))
+	assert.NoError(t, ValidateCommentBody(strings.Repeat("é", MaxCommentLength)))
+	assert.ErrorIs(t, ValidateCommentBody(" \n\t"), ErrValidation)
+	assert.ErrorIs(t, ValidateCommentBody(strings.Repeat("a", MaxCommentLength+1)), ErrValidation)
+}
+
+// TestComment_CanChange tests that only the author and admins can change a comment.
+func TestComment_CanChange(t *testing.T) {
+	comment := Comment{AuthorID: "author"}
+	assert.True(t, comment.CanChange(AuthUser{ID: "author", Role: RoleUser}))
+	assert.True(t, comment.CanChange(AuthUser{ID: "moderator", Role: "MODERATOR", Permissions: []string{PermissionCommentModerate}}))
+	assert.False(t, comment.CanChange(AuthUser{ID: "other", Role: RoleUser}))
+}
@@ -0,0 +1,69 @@
+package Domain
+
+import (
+	"context"
+	"time"
+)
+
+// TaskDependency is an edge of the dependency graph: the task TaskID is blocked by the task DependsOnID
+// until DependsOnID is resolved.
+type TaskDependency struct {
+	TaskID      string    `json:"task_id" bson:"task_id"`
+	DependsOnID string    `json:"depends_on_id" bson:"depends_on_id"`
+	CreatedBy   string    `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// MaxTaskGraphSize is the number of tasks a dependency graph lists on each side of its task at most.
+const MaxTaskGraphSize = 200
+
+var (
+	ErrDependencyNotFound = NewError(ErrNotFound, "dependency not found")
+	ErrDependencyExists   = NewError(ErrConflict, "the dependency already exists")
+	// ErrDependencyCycle is returned when a task would end up depending on itself, directly or not.
+	ErrDependencyCycle = NewError(ErrConflict, "the dependency would create a cycle")
+	// ErrTaskBlocked is returned when a task with unresolved dependencies is moved to a status other than
+	// BLOCKED or CANCELLED.
+	ErrTaskBlocked = NewError(ErrConflict, "the task is blocked by unresolved dependencies")
+)
+
+// IsTaskResolved reports whether a task in the given status no longer blocks the tasks depending on it:
+// it is either DONE or CANCELLED.
+func IsTaskResolved(status string) bool {
+	return status == TaskStatusDone || status == TaskStatusCancelled
+}
+
+// TaskGraphNode is a task of a dependency graph, at Depth dependencies from the task of the graph.
+// The title and status are left out for the tasks the caller may not see.
+type TaskGraphNode struct {
+	ID     string `json:"id"`
+	Title  string `json:"title,omitempty"`
+	Status string `json:"status,omitempty"`
+	Depth  int    `json:"depth"`
+}
+
+// TaskGraph is the dependency graph around a task: Upstream lists the tasks it depends on, directly or not,
+// Downstream the tasks depending on it, both closest first, and Edges the dependencies between all of them.
+// Truncated is set when either side has more than MaxTaskGraphSize tasks.
+type TaskGraph struct {
+	TaskID     string           `json:"task_id"`
+	Upstream   []TaskGraphNode  `json:"upstream"`
+	Downstream []TaskGraphNode  `json:"downstream"`
+	Edges      []TaskDependency `json:"edges"`
+	Truncated  bool             `json:"truncated,omitempty"`
+}
+
+// DependencyRepository stores the dependencies between tasks.
+// The dependencies are returned ordered by task, then by the task depended on.
+type DependencyRepository interface {
+	// AddDependency returns ErrDependencyExists if the task already depends on the other one.
+	AddDependency(ctx context.Context, dependency TaskDependency) error
+	// RemoveDependency returns ErrDependencyNotFound if the task does not depend on the other one.
+	RemoveDependency(ctx context.Context, taskId string, dependsOnId string) error
+	// FindDependencies returns the dependencies of the given tasks on other tasks.
+	FindDependencies(ctx context.Context, taskIds []string) ([]TaskDependency, error)
+	// FindDependents returns the dependencies of other tasks on the given tasks.
+	FindDependents(ctx context.Context, taskIds []string) ([]TaskDependency, error)
+	// DeleteTaskDependencies deletes every dependency from or to the given tasks.
+	DeleteTaskDependencies(ctx context.Context, taskIds []string) error
+}
@@ -0,0 +1,16 @@
+package Domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIsTaskResolved tests that only DONE and CANCELLED tasks stop blocking the tasks depending on them.
+func TestIsTaskResolved(t *testing.T) {
+	assert.True(t, IsTaskResolved(TaskStatusDone))
+	assert.True(t, IsTaskResolved(TaskStatusCancelled))
+	for _, status := range []string{TaskStatusTodo, TaskStatusInProgress, TaskStatusReview, TaskStatusBlocked, "ARCHIVED"} {
+		assert.False(t, IsTaskResolved(status), status)
+	}
+}
@@ -0,0 +1,44 @@
+package Domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error kinds shared by every layer.
+// Repositories and use cases return errors wrapping one of these kinds and the
+// delivery layer picks the response status with errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrInvalidID    = errors.New("invalid id")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrValidation   = errors.New("validation failed")
+	// ErrPreconditionFailed is returned when a conditional write finds the entity in another state than expected.
+	ErrPreconditionFailed = errors.New("precondition failed")
+	// ErrTooLarge is returned when uploaded content exceeds its size limit.
+	ErrTooLarge = errors.New("too large")
+	// ErrUnsupportedMediaType is returned when uploaded content is of a type that is not accepted.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Error is a domain error of a given kind with a message meant for the client.
+type Error struct {
+	Kind    error
+	Message string
+}
+
+// NewError returns an *Error of the given kind with a formatted message.
+func NewError(kind error, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap makes errors.Is(err, kind) report true for the kind of the error.
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
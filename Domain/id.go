@@ -0,0 +1,68 @@
+package Domain
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ID identifies a stored entity independently of the storage backend.
+// IDs are 24 character hexadecimal strings that sort in the order they were created.
+// MongoDB stores them as ObjectIDs, the SQL backends as text.
+type ID string
+
+// NewID returns a new unique ID.
+func NewID() ID {
+	return ID(primitive.NewObjectID().Hex())
+}
+
+// ParseID validates an ID received from the outside world.
+// It returns an ErrInvalidID error if id is not a well-formed ID.
+func ParseID(id string) (ID, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return "", NewError(ErrInvalidID, "invalid id %q", id)
+	}
+	return ID(oid.Hex()), nil
+}
+
+// String returns the ID as a string.
+func (id ID) String() string {
+	return string(id)
+}
+
+// IsZero reports whether the ID is unset.
+func (id ID) IsZero() bool {
+	return id == ""
+}
+
+// MarshalBSONValue stores well-formed IDs as ObjectIDs, keeping documents compatible with
+// the ones written before IDs were storage-neutral.
+func (id ID) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if id == "" {
+		return bson.TypeNull, nil, nil
+	}
+	if oid, err := primitive.ObjectIDFromHex(string(id)); err == nil {
+		return bson.TypeObjectID, bsoncore.AppendObjectID(nil, oid), nil
+	}
+	return bson.TypeString, bsoncore.AppendString(nil, string(id)), nil
+}
+
+// UnmarshalBSONValue reads an ID stored as an ObjectID or a string.
+func (id *ID) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bson.TypeObjectID:
+		*id = ID(value.ObjectID().Hex())
+	case bson.TypeString:
+		*id = ID(value.StringValue())
+	case bson.TypeNull, bson.TypeUndefined:
+		*id = ""
+	default:
+		return fmt.Errorf("cannot decode BSON %s into an ID", t)
+	}
+	return nil
+}
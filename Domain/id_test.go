@@ -0,0 +1,48 @@
+package Domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestParseID tests that only well-formed IDs are accepted.
+func TestParseID(t *testing.T) {
+	id := NewID()
+	parsed, err := ParseID(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	for _, invalid := range []string{"", "not-an-id", id.String() + "0"} {
+		_, err := ParseID(invalid)
+		assert.ErrorIs(t, err, ErrInvalidID, invalid)
+	}
+}
+
+// TestID_BSON tests that IDs are stored as ObjectIDs, so documents written before IDs were
+// storage-neutral can still be read.
+func TestID_BSON(t *testing.T) {
+	oid := primitive.NewObjectID()
+	task := Task{ID: ID(oid.Hex()), Title: "task"}
+
+	data, err := bson.Marshal(task)
+	require.NoError(t, err)
+	var raw struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	require.NoError(t, bson.Unmarshal(data, &raw))
+	assert.Equal(t, oid, raw.ID)
+
+	var decoded Task
+	require.NoError(t, bson.Unmarshal(data, &decoded))
+	assert.Equal(t, task.ID, decoded.ID)
+
+	// unset IDs are left out of user documents, so MongoDB generates one
+	data, err = bson.Marshal(User{Username: "alice"})
+	require.NoError(t, err)
+	_, err = bson.Raw(data).LookupErr("_id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package Domain
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Label is an entry of the label catalog of the organization of OrgID, which its admins manage.
+// Tasks can only be given labels of the catalog of their organization.
+// Names are unique within an organization, compared after NormalizeLabelName, and never change;
+// Color is a "#rrggbb" hex colour.
+type Label struct {
+	Name        string    `json:"name" bson:"name"`
+	OrgID       string    `json:"org_id" bson:"org_id"`
+	Color       string    `json:"color" bson:"color"`
+	Description string    `json:"description" bson:"description"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Limits of the labels.
+const (
+	MaxLabelNameLength        = 50
+	MaxLabelDescriptionLength = 500
+	MaxTaskLabels             = 20
+)
+
+// Ways a TaskQuery matches the labels of the tasks.
+const (
+	// LabelMatchAny matches the tasks with at least one of the labels.
+	LabelMatchAny = "any"
+	// LabelMatchAll matches the tasks with every one of the labels.
+	LabelMatchAll = "all"
+)
+
+var (
+	ErrLabelNotFound = NewError(ErrNotFound, "label not found")
+	ErrLabelExists   = NewError(ErrConflict, "the label already exists")
+	// ErrLabelInUse is returned when a label still given to live tasks is deleted from the catalog.
+	ErrLabelInUse = NewError(ErrConflict, "the label is used by tasks")
+)
+
+var labelColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// NormalizeLabelName trims and lowercases the name of a label and checks it is neither blank nor longer than
+// MaxLabelNameLength characters, and only holds letters, digits, spaces and the characters "-_.:/".
+func NormalizeLabelName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", NewError(ErrValidation, "label name cannot be blank")
+	}
+	if utf8.RuneCountInString(name) > MaxLabelNameLength {
+		return "", NewError(ErrValidation, "label name cannot be longer than %d characters", MaxLabelNameLength)
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && !strings.ContainsRune("-_.:/", r) {
+			return "", NewError(ErrValidation, "label name %q cannot contain %q", name, r)
+		}
+	}
+	return name, nil
+}
+
+// NormalizeLabels normalizes the names of the labels of a task, drops the duplicates and sorts them.
+// A task has at most MaxTaskLabels labels; a nil list is returned as an empty one.
+func NormalizeLabels(labels []string) ([]string, error) {
+	normalized := make([]string, 0, len(labels))
+	seen := map[string]bool{}
+	for _, label := range labels {
+		name, err := NormalizeLabelName(label)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	if len(normalized) > MaxTaskLabels {
+		return nil, NewError(ErrValidation, "a task cannot have more than %d labels", MaxTaskLabels)
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// Validate normalizes the name and colour of the label, lowercasing the colour, and checks its description
+// is not longer than MaxLabelDescriptionLength characters.
+func (l *Label) Validate() error {
+	name, err := NormalizeLabelName(l.Name)
+	if err != nil {
+		return err
+	}
+	if !labelColorPattern.MatchString(l.Color) {
+		return NewError(ErrValidation, "color must be a hex colour like #1f77b4")
+	}
+	if utf8.RuneCountInString(l.Description) > MaxLabelDescriptionLength {
+		return NewError(ErrValidation, "description cannot be longer than %d characters", MaxLabelDescriptionLength)
+	}
+	l.Name, l.Color = name, strings.ToLower(l.Color)
+	return nil
+}
+
+// LabelRepository stores the label catalogs of the organizations, keyed by organization and normalized label name.
+// Every method works on the catalog of the organization of ctx.
+type LabelRepository interface {
+	// CreateLabel returns ErrLabelExists if a label of the organization already has this name.
+	CreateLabel(ctx context.Context, label Label) (Label, error)
+	// FindLabels returns every label of the catalog, ordered by name.
+	FindLabels(ctx context.Context) ([]Label, error)
+	// FindLabelByName returns ErrLabelNotFound if no label of the organization has this name.
+	FindLabelByName(ctx context.Context, name string) (Label, error)
+	// UpdateLabel replaces the colour and description of a label and returns it.
+	// It returns ErrLabelNotFound if no label of the organization has this name.
+	UpdateLabel(ctx context.Context, label Label) (Label, error)
+	// DeleteLabel returns ErrLabelNotFound if no label of the organization has this name.
+	DeleteLabel(ctx context.Context, name string) error
+}
+
+// LabelUseCase manages the label catalog of the organization of the caller.
+// Every user can list the labels, only admins can change them.
+type LabelUseCase interface {
+	GetLabels(ctx context.Context) ([]Label, error)
+	CreateLabel(ctx context.Context, label Label) (Label, error)
+	// UpdateLabel replaces the colour and description of the label with the given name.
+	UpdateLabel(ctx context.Context, name string, label Label) (Label, error)
+	// DeleteLabel deletes a label from the catalog. It returns ErrLabelInUse while live tasks have the label.
+	DeleteLabel(ctx context.Context, name string) error
+}
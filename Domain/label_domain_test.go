@@ -0,0 +1,46 @@
+package Domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNormalizeLabels tests that label names are trimmed, lowercased, deduplicated and sorted,
+// and that invalid names and too many labels are rejected.
+func TestNormalizeLabels(t *testing.T) {
+	labels, err := NormalizeLabels([]string{" Frontend", "bug", "customer-X", "BUG"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bug", "customer-x", "frontend"}, labels)
+
+	labels, err = NormalizeLabels(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, labels)
+
+	for _, name := range []string{" ", "a,b", "tab\there", strings.Repeat("é", MaxLabelNameLength+1)} {
+		_, err := NormalizeLabels([]string{name})
+		assert.ErrorIs(t, err, ErrValidation, name)
+	}
+	many := make([]string, MaxTaskLabels+1)
+	for i := range many {
+		many[i] = strings.Repeat("l", i+1)
+	}
+	_, err = NormalizeLabels(many)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+// TestLabel_Validate tests that the name and colour of a label are normalized and malformed colours rejected.
+func TestLabel_Validate(t *testing.T) {
+	label := Label{Name: " Bug ", Color: "#D62728"}
+	require.NoError(t, label.Validate())
+	assert.Equal(t, Label{Name: "bug", Color: "#d62728"}, label)
+
+	for _, color := range []string{"", "d62728", "#d6272", "#d62728ff", "red"} {
+		invalid := Label{Name: "bug", Color: color}
+		assert.ErrorIs(t, invalid.Validate(), ErrValidation, color)
+	}
+	long := Label{Name: "bug", Color: "#d62728", Description: strings.Repeat("a", MaxLabelDescriptionLength+1)}
+	assert.ErrorIs(t, long.Validate(), ErrValidation)
+}
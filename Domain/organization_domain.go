@@ -0,0 +1,82 @@
+package Domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// Organizations are the tenants of the service. Every user, task and project belongs to exactly one organization,
+// identified by a short lowercase slug, and the repositories only ever return the records of the organization
+// of the caller. Signing up creates a new organization, whose first user becomes its admin;
+// the later users are added by its admins.
+
+// DefaultOrganization is the organization of the users signing up without naming one,
+// and of the data stored before the service was multi-tenant.
+const DefaultOrganization = "default"
+
+// OrganizationKey is the context key under which the organization of a request not yet authenticated is stored.
+const OrganizationKey = "org_id"
+
+// withoutTenantKey is the context key marking the contexts made by ContextWithoutTenant.
+const withoutTenantKey = "without_tenant"
+
+// ErrOrganizationRequired is returned by the repositories for a query made with a context that has no organization
+// and was not made by ContextWithoutTenant. It is a bug of the service, never of the client.
+var ErrOrganizationRequired = errors.New("the query is not scoped to an organization")
+
+// ErrOrganizationExists is returned when signing up to an organization that already has users.
+var ErrOrganizationExists = NewError(ErrConflict, "the organization already exists")
+
+// MaxOrganizationIDLength is the length limit of the organization IDs.
+const MaxOrganizationIDLength = 63
+
+var organizationIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// NormalizeOrganizationID trims and lowercases the ID of an organization and checks it only holds letters, digits
+// and dashes, starts with a letter or a digit and is not longer than MaxOrganizationIDLength characters.
+// A blank ID is the DefaultOrganization.
+func NormalizeOrganizationID(orgId string) (string, error) {
+	orgId = strings.ToLower(strings.TrimSpace(orgId))
+	if orgId == "" {
+		return DefaultOrganization, nil
+	}
+	if len(orgId) > MaxOrganizationIDLength {
+		return "", NewError(ErrValidation, "organization cannot be longer than %d characters", MaxOrganizationIDLength)
+	}
+	if !organizationIDPattern.MatchString(orgId) {
+		return "", NewError(ErrValidation, "organization must only hold letters, digits and dashes")
+	}
+	return orgId, nil
+}
+
+// ContextWithOrganization returns a copy of ctx scoped to the organization, for the requests made before
+// the caller is authenticated, like signing up and logging in.
+func ContextWithOrganization(ctx context.Context, orgId string) context.Context {
+	return context.WithValue(ctx, OrganizationKey, orgId)
+}
+
+// OrganizationFromContext returns the organization the repositories restrict the queries made with ctx to:
+// the one of the authenticated caller, or else the one set by ContextWithOrganization.
+func OrganizationFromContext(ctx context.Context) (string, bool) {
+	if user, ok := AuthUserFromContext(ctx); ok && user.OrgID != "" {
+		return user.OrgID, true
+	}
+	orgId, ok := ctx.Value(OrganizationKey).(string)
+	return orgId, ok && orgId != ""
+}
+
+// ContextWithoutTenant returns a copy of ctx whose queries span every organization, for the work the service does
+// on its own account, like purging the trash, and the lookups made before the organization is known, like the one
+// of the user of a refresh token. A context without an organization that was not made by it cannot be used to
+// query the repositories.
+func ContextWithoutTenant(ctx context.Context) context.Context {
+	return context.WithValue(ctx, withoutTenantKey, true)
+}
+
+// IsWithoutTenant reports whether ctx was made by ContextWithoutTenant.
+func IsWithoutTenant(ctx context.Context) bool {
+	without, _ := ctx.Value(withoutTenantKey).(bool)
+	return without
+}
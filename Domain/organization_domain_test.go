@@ -0,0 +1,50 @@
+package Domain
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalizeOrganizationID tests that organization IDs are trimmed and lowercased,
+// that a blank one is the default organization and that malformed ones are rejected.
+func TestNormalizeOrganizationID(t *testing.T) {
+	for input, want := range map[string]string{
+		"":            DefaultOrganization,
+		"  ":          DefaultOrganization,
+		" Sales ":     "sales",
+		"legal-team2": "legal-team2",
+	} {
+		got, err := NormalizeOrganizationID(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+	for _, input := range []string{"-sales", "sales team", "sales/legal", "ünicode", strings.Repeat("a", MaxOrganizationIDLength+1)} {
+		_, err := NormalizeOrganizationID(input)
+		assert.ErrorIs(t, err, ErrValidation, input)
+	}
+}
+
+// TestOrganizationFromContext tests that the organization of the authenticated caller wins over the one
+// set before authentication, and that a context without either, like one made by ContextWithoutTenant,
+// has no organization.
+func TestOrganizationFromContext(t *testing.T) {
+	_, ok := OrganizationFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := ContextWithOrganization(context.Background(), "sales")
+	org, ok := OrganizationFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "sales", org)
+
+	org, ok = OrganizationFromContext(ContextWithAuthUser(ctx, AuthUser{ID: "alice", OrgID: "legal"}))
+	assert.True(t, ok)
+	assert.Equal(t, "legal", org)
+
+	assert.False(t, IsWithoutTenant(ctx))
+	_, ok = OrganizationFromContext(ContextWithoutTenant(context.Background()))
+	assert.False(t, ok, "a context spanning every organization has none")
+	assert.True(t, IsWithoutTenant(ContextWithoutTenant(context.Background())))
+}
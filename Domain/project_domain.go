@@ -0,0 +1,135 @@
+package Domain
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Project groups tasks. Its members can see every task of the project, whoever created it or is assigned to it,
+// and what else they can do depends on their role in the project. OrgID is the organization of the project,
+// the one of the user who created it; only users of that organization can be members.
+type Project struct {
+	ID          ID        `json:"id" bson:"_id"`
+	OrgID       string    `json:"org_id" bson:"org_id"`
+	Name        string    `json:"name" bson:"name"`
+	Description string    `json:"description" bson:"description"`
+	CreatedBy   string    `json:"created_by" bson:"created_by"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// ProjectMember is the membership of a user in a project, with the role the user has in it.
+type ProjectMember struct {
+	ProjectID string    `json:"project_id" bson:"project_id"`
+	UserID    string    `json:"user_id" bson:"user_id"`
+	Role      string    `json:"role" bson:"role"`
+	AddedAt   time.Time `json:"added_at" bson:"added_at"`
+}
+
+// Roles of the members of a project.
+// Viewers read the project and its tasks, editors also create and change its tasks,
+// and owners also change the project and its members.
+const (
+	ProjectRoleOwner  = "owner"
+	ProjectRoleEditor = "editor"
+	ProjectRoleViewer = "viewer"
+)
+
+// Limits of the projects.
+const (
+	MaxProjectNameLength        = 100
+	MaxProjectDescriptionLength = 2000
+)
+
+var (
+	// ErrProjectNotFound is returned when a project does not exist or the caller is not one of its members.
+	ErrProjectNotFound = NewError(ErrNotFound, "project not found")
+	// ErrProjectNotEmpty is returned when a project that still has tasks, in the trash or not, is deleted.
+	ErrProjectNotEmpty = NewError(ErrConflict, "the project still has tasks")
+	ErrMemberNotFound  = NewError(ErrNotFound, "project member not found")
+	ErrMemberExists    = NewError(ErrConflict, "the user is already a member of the project")
+	// ErrLastOwner is returned when the last owner of a project would leave it or lose the owner role.
+	ErrLastOwner = NewError(ErrConflict, "a project must keep at least one owner")
+	// ErrProjectRole is returned when a member does something its role in the project does not allow.
+	ErrProjectRole = NewError(ErrForbidden, "your role in the project does not allow this")
+)
+
+// ParseProjectRole returns the project role with the given name, in any case.
+// It returns an ErrValidation error for any other name.
+func ParseProjectRole(role string) (string, error) {
+	switch normalized := strings.ToLower(strings.TrimSpace(role)); normalized {
+	case ProjectRoleOwner, ProjectRoleEditor, ProjectRoleViewer:
+		return normalized, nil
+	}
+	return "", NewError(ErrValidation, "role must be %q, %q or %q", ProjectRoleOwner, ProjectRoleEditor, ProjectRoleViewer)
+}
+
+// CanEdit reports whether the member may create and change the tasks of the project.
+func (m ProjectMember) CanEdit() bool {
+	return m.Role == ProjectRoleOwner || m.Role == ProjectRoleEditor
+}
+
+// CanManage reports whether the member may change the project and its members.
+func (m ProjectMember) CanManage() bool {
+	return m.Role == ProjectRoleOwner
+}
+
+// Validate trims the name of the project and checks it is neither blank nor longer than MaxProjectNameLength
+// characters, and that the description is not longer than MaxProjectDescriptionLength characters.
+func (p *Project) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return NewError(ErrValidation, "name cannot be blank")
+	}
+	if utf8.RuneCountInString(p.Name) > MaxProjectNameLength {
+		return NewError(ErrValidation, "name cannot be longer than %d characters", MaxProjectNameLength)
+	}
+	if utf8.RuneCountInString(p.Description) > MaxProjectDescriptionLength {
+		return NewError(ErrValidation, "description cannot be longer than %d characters", MaxProjectDescriptionLength)
+	}
+	return nil
+}
+
+// ProjectRepository stores the projects and their members.
+type ProjectRepository interface {
+	// CreateProject stores a new project and returns it with its ID set.
+	CreateProject(ctx context.Context, project Project) (Project, error)
+	// FindProjectById returns ErrProjectNotFound if no project of the organization of ctx has this ID.
+	FindProjectById(ctx context.Context, projectId string) (Project, error)
+	// FindMemberProjects returns the projects the user is a member of, in the order they were created.
+	FindMemberProjects(ctx context.Context, userId string) ([]Project, error)
+	// UpdateProject replaces the name and description of a project and returns it.
+	UpdateProject(ctx context.Context, project Project) (Project, error)
+	// DeleteProject deletes a project with its members.
+	DeleteProject(ctx context.Context, projectId string) error
+	// AddMember returns ErrMemberExists if the user is already a member of the project.
+	AddMember(ctx context.Context, member ProjectMember) error
+	// FindMember returns ErrMemberNotFound if the user is not a member of the project.
+	FindMember(ctx context.Context, projectId string, userId string) (ProjectMember, error)
+	// FindMembers returns the members of a project, ordered by user ID.
+	FindMembers(ctx context.Context, projectId string) ([]ProjectMember, error)
+	// UpdateMember changes the role of a member. It returns ErrMemberNotFound if the user is not a member of the project.
+	UpdateMember(ctx context.Context, member ProjectMember) error
+	// RemoveMember returns ErrMemberNotFound if the user is not a member of the project.
+	RemoveMember(ctx context.Context, projectId string, userId string) error
+}
+
+// ProjectUseCase manages the projects and their members. Users only see the projects they are members of,
+// and admins act as owners of every project.
+type ProjectUseCase interface {
+	// CreateProject stores a new project owned by the caller.
+	CreateProject(ctx context.Context, project Project) (Project, error)
+	GetProjects(ctx context.Context) ([]Project, error)
+	GetProject(ctx context.Context, projectId string) (Project, error)
+	UpdateProject(ctx context.Context, projectId string, project Project) (Project, error)
+	// DeleteProject returns ErrProjectNotEmpty while the project has tasks.
+	DeleteProject(ctx context.Context, projectId string) error
+	GetMembers(ctx context.Context, projectId string) ([]ProjectMember, error)
+	AddMember(ctx context.Context, projectId string, userId string, role string) (ProjectMember, error)
+	// UpdateMember changes the role of a member. It returns ErrLastOwner if the project would have no owner left.
+	UpdateMember(ctx context.Context, projectId string, userId string, role string) (ProjectMember, error)
+	// RemoveMember removes a member from a project; members can remove themselves.
+	// It returns ErrLastOwner if the project would have no owner left.
+	RemoveMember(ctx context.Context, projectId string, userId string) error
+}
@@ -0,0 +1,50 @@
+package Domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseProjectRole tests that project roles are parsed in any case and unknown roles rejected.
+func TestParseProjectRole(t *testing.T) {
+	role, err := ParseProjectRole(" Editor")
+	require.NoError(t, err)
+	assert.Equal(t, ProjectRoleEditor, role)
+
+	for _, role := range []string{"", "admin", "own"} {
+		_, err := ParseProjectRole(role)
+		assert.ErrorIs(t, err, ErrValidation, role)
+	}
+}
+
+// TestProjectMember_Roles tests what each project role allows.
+func TestProjectMember_Roles(t *testing.T) {
+	cases := map[string][2]bool{
+		ProjectRoleOwner:  {true, true},
+		ProjectRoleEditor: {true, false},
+		ProjectRoleViewer: {false, false},
+	}
+	for role, allowed := range cases {
+		member := ProjectMember{Role: role}
+		assert.Equal(t, allowed[0], member.CanEdit(), role)
+		assert.Equal(t, allowed[1], member.CanManage(), role)
+	}
+}
+
+// TestProject_Validate tests that the name of a project is trimmed and that blank or too long fields are rejected.
+func TestProject_Validate(t *testing.T) {
+	project := Project{Name: " website "}
+	require.NoError(t, project.Validate())
+	assert.Equal(t, "website", project.Name)
+
+	for _, invalid := range []Project{
+		{Name: " "},
+		{Name: strings.Repeat("é", MaxProjectNameLength+1)},
+		{Name: "website", Description: strings.Repeat("a", MaxProjectDescriptionLength+1)},
+	} {
+		assert.ErrorIs(t, invalid.Validate(), ErrValidation)
+	}
+}
@@ -0,0 +1,201 @@
+package Domain
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Permissions a role can grant. Every authenticated user reads and works on the tasks it created or is assigned to,
+// and on the tasks of its projects; the permissions grant what goes beyond that.
+const (
+	// PermissionTaskCreate allows creating tasks outside of a project.
+	PermissionTaskCreate = "task:create"
+	// PermissionTaskReadAll allows reading every task of the organization.
+	PermissionTaskReadAll = "task:read_all"
+	// PermissionTaskUpdate allows replacing, patching and moving every task of the organization.
+	PermissionTaskUpdate = "task:update"
+	// PermissionTaskDelete allows deleting every task of the organization.
+	PermissionTaskDelete = "task:delete"
+	// PermissionTaskRestore allows listing the trash and restoring its tasks.
+	PermissionTaskRestore = "task:restore"
+	// PermissionCommentModerate allows editing and deleting the comments of other users.
+	PermissionCommentModerate = "comment:moderate"
+	// PermissionAttachmentModerate allows deleting the attachments of other users.
+	PermissionAttachmentModerate = "attachment:moderate"
+	// PermissionProjectManage allows acting as an owner of every project of the organization.
+	PermissionProjectManage = "project:manage"
+	// PermissionLabelManage allows changing the label catalog.
+	PermissionLabelManage = "label:manage"
+	// PermissionUserPromote allows giving roles to the users of the organization.
+	PermissionUserPromote = "user:promote"
+	// PermissionUserManage allows listing, deactivating and deleting the users of the organization.
+	PermissionUserManage = "user:manage"
+	// PermissionRoleManage allows creating, changing and deleting the roles of the organization.
+	PermissionRoleManage = "role:manage"
+)
+
+// AllPermissions lists every permission, in the order they are documented.
+var AllPermissions = []string{
+	PermissionTaskCreate,
+	PermissionTaskReadAll,
+	PermissionTaskUpdate,
+	PermissionTaskDelete,
+	PermissionTaskRestore,
+	PermissionCommentModerate,
+	PermissionAttachmentModerate,
+	PermissionProjectManage,
+	PermissionLabelManage,
+	PermissionUserPromote,
+	PermissionUserManage,
+	PermissionRoleManage,
+}
+
+// Role is a named set of permissions given to users. The built-in ADMIN and USER roles exist in every
+// organization and never change: ADMIN has every permission and USER none. The other roles belong to
+// the organization of OrgID, where their names are unique.
+type Role struct {
+	Name        string    `json:"name" bson:"name"`
+	OrgID       string    `json:"org_id" bson:"org_id"`
+	Description string    `json:"description" bson:"description"`
+	Permissions []string  `json:"permissions" bson:"permissions"`
+	Builtin     bool      `json:"builtin" bson:"-"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
+
+// Limits of the roles.
+const (
+	MaxRoleNameLength        = 50
+	MaxRoleDescriptionLength = 500
+)
+
+var (
+	ErrRoleNotFound = NewError(ErrNotFound, "role not found")
+	ErrRoleExists   = NewError(ErrConflict, "the role already exists")
+	// ErrRoleInUse is returned when a role still given to users is deleted.
+	ErrRoleInUse = NewError(ErrConflict, "the role is given to users")
+	// ErrBuiltinRole is returned when a built-in role would be changed or deleted.
+	ErrBuiltinRole = NewError(ErrConflict, "the built-in roles cannot be changed")
+	// ErrLastAdmin is returned when the last admin of an organization would lose the ADMIN role.
+	ErrLastAdmin = NewError(ErrConflict, "an organization must keep at least one admin")
+	// ErrPermissionNotHeld is returned when a caller grants a permission it does not have itself,
+	// through a role it creates, changes or gives to a user.
+	ErrPermissionNotHeld = NewError(ErrForbidden, "you cannot grant a permission you do not have")
+)
+
+var roleNamePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
+
+// NormalizeRoleName trims and uppercases the name of a role and checks it starts with a letter, only holds
+// letters, digits, dashes and underscores and is not longer than MaxRoleNameLength characters.
+func NormalizeRoleName(name string) (string, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "" {
+		return "", NewError(ErrValidation, "role name cannot be blank")
+	}
+	if utf8.RuneCountInString(name) > MaxRoleNameLength {
+		return "", NewError(ErrValidation, "role name cannot be longer than %d characters", MaxRoleNameLength)
+	}
+	if !roleNamePattern.MatchString(name) {
+		return "", NewError(ErrValidation, "role name %q must start with a letter and only hold letters, digits, dashes and underscores", name)
+	}
+	return name, nil
+}
+
+// NormalizePermissions checks every permission is one of AllPermissions, drops the duplicates and sorts them.
+// A nil list is returned as an empty one.
+func NormalizePermissions(permissions []string) ([]string, error) {
+	normalized := make([]string, 0, len(permissions))
+	seen := map[string]bool{}
+	for _, permission := range permissions {
+		permission = strings.ToLower(strings.TrimSpace(permission))
+		if !IsPermission(permission) {
+			return nil, NewError(ErrValidation, "unknown permission %q", permission)
+		}
+		if !seen[permission] {
+			seen[permission] = true
+			normalized = append(normalized, permission)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+// IsPermission reports whether the permission is one of AllPermissions.
+func IsPermission(permission string) bool {
+	for _, known := range AllPermissions {
+		if known == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate normalizes the name and the permissions of the role and checks its description
+// is not longer than MaxRoleDescriptionLength characters.
+func (r *Role) Validate() error {
+	name, err := NormalizeRoleName(r.Name)
+	if err != nil {
+		return err
+	}
+	permissions, err := NormalizePermissions(r.Permissions)
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(r.Description) > MaxRoleDescriptionLength {
+		return NewError(ErrValidation, "description cannot be longer than %d characters", MaxRoleDescriptionLength)
+	}
+	r.Name, r.Permissions = name, permissions
+	return nil
+}
+
+// BuiltinRoles returns the built-in roles, ordered by name.
+func BuiltinRoles() []Role {
+	all := append([]string{}, AllPermissions...)
+	sort.Strings(all)
+	return []Role{
+		{Name: RoleAdmin, Description: "Every permission", Permissions: all, Builtin: true},
+		{Name: RoleUser, Description: "The tasks and projects of the user only", Permissions: []string{}, Builtin: true},
+	}
+}
+
+// BuiltinRole returns the built-in role with the given name, if there is one.
+func BuiltinRole(name string) (Role, bool) {
+	for _, role := range BuiltinRoles() {
+		if role.Name == name {
+			return role, true
+		}
+	}
+	return Role{}, false
+}
+
+// RoleRepository stores the roles of the organizations other than the built-in ones, keyed by organization
+// and normalized name. Every method works on the roles of the organization of ctx.
+type RoleRepository interface {
+	// CreateRole returns ErrRoleExists if the organization already has a role with this name.
+	CreateRole(ctx context.Context, role Role) (Role, error)
+	// FindRoles returns the roles of the organization, ordered by name.
+	FindRoles(ctx context.Context) ([]Role, error)
+	// FindRoleByName returns ErrRoleNotFound if the organization has no role with this name.
+	FindRoleByName(ctx context.Context, name string) (Role, error)
+	// UpdateRole replaces the description and the permissions of a role and returns it.
+	// It returns ErrRoleNotFound if the organization has no role with this name.
+	UpdateRole(ctx context.Context, role Role) (Role, error)
+	// DeleteRole returns ErrRoleNotFound if the organization has no role with this name.
+	DeleteRole(ctx context.Context, name string) error
+}
+
+// RoleUseCase manages the roles of the organization of the caller, built-in ones included.
+type RoleUseCase interface {
+	GetRoles(ctx context.Context) ([]Role, error)
+	GetRole(ctx context.Context, name string) (Role, error)
+	// CreateRole returns ErrRoleExists if the name is taken, by a built-in role or not.
+	CreateRole(ctx context.Context, role Role) (Role, error)
+	// UpdateRole replaces the description and the permissions of the role with the given name.
+	// It returns ErrBuiltinRole for the built-in roles.
+	UpdateRole(ctx context.Context, name string, role Role) (Role, error)
+	// DeleteRole returns ErrBuiltinRole for the built-in roles and ErrRoleInUse while users have the role.
+	DeleteRole(ctx context.Context, name string) error
+}
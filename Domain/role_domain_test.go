@@ -0,0 +1,63 @@
+package Domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNormalizeRoleName tests that role names are trimmed and uppercased and that malformed names are rejected.
+func TestNormalizeRoleName(t *testing.T) {
+	name, err := NormalizeRoleName(" triage_lead-2 ")
+	require.NoError(t, err)
+	assert.Equal(t, "TRIAGE_LEAD-2", name)
+
+	for _, name := range []string{"", " ", "2FA", "-X", "TRIAGE LEAD", "RÔLE", strings.Repeat("R", MaxRoleNameLength+1)} {
+		_, err := NormalizeRoleName(name)
+		assert.ErrorIs(t, err, ErrValidation, name)
+	}
+}
+
+// TestRole_Validate tests that the permissions of a role are normalized, deduplicated and sorted,
+// and that unknown permissions and too long descriptions are rejected.
+func TestRole_Validate(t *testing.T) {
+	role := Role{Name: "triage", Permissions: []string{" Task:Update", PermissionTaskReadAll, "task:update"}}
+	require.NoError(t, role.Validate())
+	assert.Equal(t, Role{Name: "TRIAGE", Permissions: []string{PermissionTaskReadAll, PermissionTaskUpdate}}, role)
+
+	role = Role{Name: "auditor"}
+	require.NoError(t, role.Validate())
+	assert.Equal(t, []string{}, role.Permissions)
+
+	role = Role{Name: "root", Permissions: []string{"*"}}
+	assert.ErrorIs(t, role.Validate(), ErrValidation)
+	role = Role{Name: "auditor", Description: strings.Repeat("d", MaxRoleDescriptionLength+1)}
+	assert.ErrorIs(t, role.Validate(), ErrValidation)
+}
+
+// TestBuiltinRoles tests that ADMIN has every permission and USER none.
+func TestBuiltinRoles(t *testing.T) {
+	admin, ok := BuiltinRole(RoleAdmin)
+	require.True(t, ok)
+	assert.ElementsMatch(t, AllPermissions, admin.Permissions)
+	assert.True(t, admin.Builtin)
+	user, ok := BuiltinRole(RoleUser)
+	require.True(t, ok)
+	assert.Empty(t, user.Permissions)
+	_, ok = BuiltinRole("TRIAGE")
+	assert.False(t, ok)
+
+	admin.Permissions[0] = "changed"
+	again, _ := BuiltinRole(RoleAdmin)
+	assert.NotEqual(t, "changed", again.Permissions[0], "the built-in roles cannot be changed through a copy")
+}
+
+// TestAuthUser_Can tests that a caller has the permissions of its token only, whatever the name of its role.
+func TestAuthUser_Can(t *testing.T) {
+	user := AuthUser{Role: RoleAdmin, Permissions: []string{PermissionTaskReadAll}}
+	assert.True(t, user.Can(PermissionTaskReadAll))
+	assert.False(t, user.Can(PermissionTaskDelete))
+	assert.False(t, AuthUser{}.Can(PermissionTaskReadAll))
+}
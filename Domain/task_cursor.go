@@ -0,0 +1,87 @@
+package Domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Sort keys accepted by TaskQuery.Sort.
+const (
+	TaskSortID      = "id"
+	TaskSortDueDate = "due_date"
+	TaskSortTitle   = "title"
+	TaskSortStatus  = "status"
+)
+
+// ParseTaskSort splits a TaskQuery.Sort value into its sort key and direction.
+// An empty value sorts by id, which follows creation order.
+func ParseTaskSort(sort string) (key string, descending bool, err error) {
+	key = strings.TrimPrefix(sort, "-")
+	descending = key != sort
+	if key == "" {
+		key = TaskSortID
+	}
+	switch key {
+	case TaskSortID, TaskSortDueDate, TaskSortTitle, TaskSortStatus:
+		return key, descending, nil
+	}
+	return "", false, fmt.Errorf("%w: unknown sort key %q", ErrInvalidTaskQuery, key)
+}
+
+// TaskCursor is the decoded form of TaskPage.NextCursor.
+// It records the sort it was issued for together with the sort value and id
+// of the last task on the page, so the next page starts right after it.
+type TaskCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// NewTaskCursor builds the cursor pointing after the given task for the given sort.
+func NewTaskCursor(sort string, last Task) TaskCursor {
+	key, _, _ := ParseTaskSort(sort)
+	return TaskCursor{
+		Sort:  sort,
+		Value: TaskSortValue(last, key),
+		ID:    last.ID.String(),
+	}
+}
+
+// Encode returns the opaque string handed out to clients.
+func (tc TaskCursor) Encode() string {
+	data, _ := json.Marshal(tc)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTaskCursor parses an opaque cursor and checks it was issued for the given sort.
+func DecodeTaskCursor(cursor string, sort string) (TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return TaskCursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidTaskQuery)
+	}
+	var tc TaskCursor
+	if err := json.Unmarshal(data, &tc); err != nil || tc.ID == "" {
+		return TaskCursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidTaskQuery)
+	}
+	if tc.Sort != sort {
+		return TaskCursor{}, fmt.Errorf("%w: cursor was issued for a different sort", ErrInvalidTaskQuery)
+	}
+	return tc, nil
+}
+
+// TaskSortValue returns the string form of the field a task is sorted on.
+// Due dates use RFC 3339 with nanoseconds so they can be parsed back losslessly.
+func TaskSortValue(task Task, key string) string {
+	switch key {
+	case TaskSortDueDate:
+		return task.DueDate.UTC().Format(time.RFC3339Nano)
+	case TaskSortTitle:
+		return task.Title
+	case TaskSortStatus:
+		return task.Status
+	}
+	return task.ID.String()
+}
@@ -3,30 +3,154 @@ package Domain
 import (
 	"context"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Task struct {
-	ID          primitive.ObjectID `json:"id" bson:"_id"`
-	Title       string             `json:"title" bson:"title" validate:"required"`
-	Description string             `json:"description" bson:"description" validate:"required"`
-	DueDate     time.Time          `json:"due_date" bson:"due_date" validate:"required"`
-	Status      string             `json:"status" bson:"status" validate:"required"`
+	ID          ID        `json:"id" bson:"_id"`
+	Title       string    `json:"title" bson:"title" validate:"required"`
+	Description string    `json:"description" bson:"description" validate:"required"`
+	DueDate     time.Time `json:"due_date" bson:"due_date" validate:"required"`
+	Status      string    `json:"status" bson:"status" validate:"required"`
+	CreatedBy   string    `json:"created_by" bson:"created_by"`
+	AssigneeIDs []string  `json:"assignee_ids" bson:"assignee_ids"`
+	// Labels are names of the label catalog, normalized and sorted.
+	Labels []string `json:"labels" bson:"labels"`
+	// ParentID is the ID of the task this one is a subtask of. It is set when the task is created and never changed.
+	ParentID string `json:"parent_id,omitempty" bson:"parent_id,omitempty"`
+	// ProjectID is the ID of the project the task belongs to, if any. It is set when the task is created and never changed.
+	ProjectID string `json:"project_id,omitempty" bson:"project_id,omitempty"`
+	// OrgID is the organization of the task, the one of the user who created it. It is never changed.
+	OrgID string `json:"org_id" bson:"org_id"`
+	// Version starts at 1 and is incremented by every update of the task.
+	Version int64 `json:"version" bson:"version"`
+	// DeletedAt and DeletedBy are set while the task is in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	DeletedBy string     `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+	// CommentCount is the number of comments on the task. It is computed when the task is read, never stored.
+	CommentCount int `json:"comment_count" bson:"-"`
+	// Progress is the percentage of the checklist items and subtasks of the task that are done,
+	// or nil if it has none. It is computed when the task is read, never stored.
+	Progress *int `json:"progress,omitempty" bson:"-"`
+}
+
+// ErrTaskNotFound is returned when a task does not exist or is not visible to the caller.
+var ErrTaskNotFound = NewError(ErrNotFound, "task not found")
+
+// ErrVersionMismatch is returned when a task has been modified since the version an update or delete was based on.
+var ErrVersionMismatch = NewError(ErrPreconditionFailed, "the task has been modified since it was read")
+
+// IsVisibleTo reports whether the user may read the task on its own account.
+// Users with PermissionTaskReadAll see every task, other users the tasks they created or are assigned to;
+// the members of the project of a task see it too, which the use cases check with the project repository.
+func (t Task) IsVisibleTo(user AuthUser) bool {
+	return user.Can(PermissionTaskReadAll) || t.IsInvolved(user)
+}
+
+// IsInvolved reports whether the user created the task or is assigned to it.
+func (t Task) IsInvolved(user AuthUser) bool {
+	if t.CreatedBy == user.ID {
+		return true
+	}
+	for _, assignee := range t.AssigneeIDs {
+		if assignee == user.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// Page size limits applied to TaskQuery.Limit.
+const (
+	DefaultTaskPageSize = 20
+	MaxTaskPageSize     = 100
+)
+
+// ErrInvalidTaskQuery is returned when a TaskQuery has an unknown sort key,
+// a malformed cursor or an out of range limit.
+var ErrInvalidTaskQuery = NewError(ErrValidation, "invalid task query")
+
+// TaskQuery holds the filters, ordering and page window used to list tasks.
+// Sort is one of the TaskSortKeys, optionally prefixed with "-" for descending order.
+// Cursor is the opaque NextCursor of a previous TaskPage and must be used with the same Sort.
+// VisibleTo, when set, restricts the results to tasks created by or assigned to that user id.
+// Deleted selects the tasks in the trash instead of the live ones.
+// ParentID, when set, restricts the results to the subtasks of that task.
+// ProjectID, when set, restricts the results to the tasks of that project.
+// Labels, when set, restricts the results to the tasks with any of these labels, or all of them if LabelMatch
+// is LabelMatchAll.
+type TaskQuery struct {
+	VisibleTo   string
+	Status      string
+	DueFrom     *time.Time
+	DueTo       *time.Time
+	TitlePrefix string
+	Sort        string
+	Cursor      string
+	Limit       int
+	Deleted     bool
+	ParentID    string
+	ProjectID   string
+	Labels      []string
+	LabelMatch  string
+}
+
+// TaskPage is a single page of tasks returned by a TaskQuery.
+// NextCursor is empty when there are no more tasks.
+type TaskPage struct {
+	Tasks      []Task `json:"tasks"`
+	NextCursor string `json:"next_cursor"`
 }
 
+// TaskRepository stores the tasks. Writes to an existing task are conditional: they only apply to
+// the version of the task they are given and return ErrVersionMismatch if the stored task has another one.
+// Deleted tasks stay in the trash until they are purged; apart from FindTasks with TaskQuery.Deleted,
+// FindDeletedTaskById and RestoreTask, the methods treat them as if they did not exist.
 type TaskRepository interface {
 	FindAlltasks(ctx context.Context) ([]Task, error)
+	FindTasks(ctx context.Context, query TaskQuery) (TaskPage, error)
 	FindTaskById(ctx context.Context, taskId string) (Task, error)
+	// FindDeletedTaskById returns a task of the trash. It returns ErrTaskNotFound if the task is not in the trash.
+	FindDeletedTaskById(ctx context.Context, taskId string) (Task, error)
 	CreateTask(ctx context.Context, task Task) (Task, error)
+	// UpdateTaskById replaces the editable fields of the task if it is still at task.Version.
 	UpdateTaskById(ctx context.Context, task Task, id string) (Task, error)
-	DeleteTask(ctx context.Context, taskId string) error
+	// PatchTaskById changes only the fields set in the patch if the task is still at patch.Version.
+	PatchTaskById(ctx context.Context, patch TaskPatch, id string) (Task, error)
+	// DeleteTask moves the task to the trash if it is still at the given version and returns the deleted task.
+	DeleteTask(ctx context.Context, taskId string, version int64, deletedBy string, deletedAt time.Time) (Task, error)
+	// RestoreTask takes a task out of the trash and returns it. It returns ErrTaskNotFound if the task is not in the trash.
+	RestoreTask(ctx context.Context, taskId string) (Task, error)
+	// StartPurge marks the tasks moved to the trash before the given time as being purged, after which they are
+	// no longer listed in the trash nor can be restored, and returns the IDs of every task being purged,
+	// including the ones of a purge that did not finish.
+	StartPurge(ctx context.Context, deletedBefore time.Time) ([]string, error)
+	// PurgeTasks permanently deletes the given tasks being purged. Their subtasks are detached from them
+	// and become top-level tasks, at a new version.
+	PurgeTasks(ctx context.Context, taskIds []string) error
+	// CountSubtasks returns the number of subtasks of each of the given tasks in each status.
+	// Subtasks in the trash are not counted and tasks without subtasks are left out.
+	CountSubtasks(ctx context.Context, parentIds []string) (map[string]map[string]int, error)
+	// ReassignTasks replaces the user fromUserId by toUserId as the creator and as an assignee of every task
+	// of the organization of ctx, including the ones in the trash, and returns the number of tasks changed.
+	// The version of every changed task is incremented.
+	ReassignTasks(ctx context.Context, fromUserId string, toUserId string) (int64, error)
 }
 
+// TaskUseCase manages the tasks. The version given to an update or delete is the one the caller read,
+// typically from an If-Match header; a zero version applies the change to the current version of the task.
 type TaskUseCase interface {
-	GetAllTasks(ctx context.Context) ([]Task, error)
+	GetTasks(ctx context.Context, query TaskQuery) (TaskPage, error)
 	GetTaskByID(ctx context.Context, taskId string) (Task, error)
 	AddNewTask(ctx context.Context, task Task) (Task, error)
 	ModifyTaskById(ctx context.Context, task Task, taskId string) (Task, error)
-	DeleteTaskById(ctx context.Context, taskId string) error
+	PatchTaskById(ctx context.Context, patch TaskPatch, taskId string) (Task, error)
+	TransitionTask(ctx context.Context, taskId string, status string) (Task, error)
+	DeleteTaskById(ctx context.Context, taskId string, version int64) error
+	GetTaskHistory(ctx context.Context, taskId string, query TaskHistoryQuery) (TaskHistoryPage, error)
+	GetDeletedTasks(ctx context.Context, query TaskQuery) (TaskPage, error)
+	RestoreTaskById(ctx context.Context, taskId string) (Task, error)
+	PurgeDeletedTasks(ctx context.Context, retention time.Duration) (int64, error)
+	AddDependency(ctx context.Context, taskId string, dependsOnId string) (Task, error)
+	RemoveDependency(ctx context.Context, taskId string, dependsOnId string) (Task, error)
+	GetTaskGraph(ctx context.Context, taskId string) (TaskGraph, error)
 }
@@ -0,0 +1,111 @@
+package Domain
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded in the history of a task.
+const (
+	TaskActionCreated      = "created"
+	TaskActionUpdated      = "updated"
+	TaskActionTransitioned = "transitioned"
+	TaskActionDeleted      = "deleted"
+	TaskActionRestored     = "restored"
+)
+
+// FieldChange is the value of a task field before and after a change.
+// Values are JSON values: a string, a list of strings, or nil when the task did not exist on that side of the change.
+// Due dates are RFC 3339 strings.
+type FieldChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+// TaskHistoryEntry records one change of a task: who made it, when, and the fields it changed.
+// Version is the version of the task after the change.
+// Entries are never modified nor deleted, not even with the task.
+type TaskHistoryEntry struct {
+	ID      ID            `json:"id" bson:"_id"`
+	TaskID  string        `json:"task_id" bson:"task_id"`
+	Action  string        `json:"action" bson:"action"`
+	ActorID string        `json:"actor_id" bson:"actor_id"`
+	At      time.Time     `json:"at" bson:"at"`
+	Version int64         `json:"version" bson:"version"`
+	Changes []FieldChange `json:"changes" bson:"changes"`
+}
+
+// DiffTasks returns the fields that differ between two states of a task, in a fixed order.
+// A nil state stands for a task that does not exist, before it is created or after it is deleted,
+// in which case every field is reported.
+func DiffTasks(before, after *Task) []FieldChange {
+	fields := []struct {
+		name  string
+		value func(Task) interface{}
+	}{
+		{"title", func(t Task) interface{} { return t.Title }},
+		{"description", func(t Task) interface{} { return t.Description }},
+		{"due_date", func(t Task) interface{} { return t.DueDate.UTC().Format(time.RFC3339Nano) }},
+		{"status", func(t Task) interface{} { return t.Status }},
+		{"assignee_ids", func(t Task) interface{} { return append([]string{}, t.AssigneeIDs...) }},
+		{"labels", func(t Task) interface{} { return append([]string{}, t.Labels...) }},
+	}
+
+	changes := []FieldChange{}
+	for _, field := range fields {
+		change := FieldChange{Field: field.name}
+		if before != nil {
+			change.Before = field.value(*before)
+		}
+		if after != nil {
+			change.After = field.value(*after)
+		}
+		if before != nil && after != nil && equalValues(change.Before, change.After) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// equalValues compares the values DiffTasks reports: strings or lists of strings.
+func equalValues(a, b interface{}) bool {
+	as, aIsList := a.([]string)
+	bs, bIsList := b.([]string)
+	if !aIsList || !bIsList {
+		return a == b
+	}
+	if len(as) != len(bs) {
+		return false
+	}
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TaskHistoryQuery selects one page of the history of a task, oldest entries first.
+// Cursor is the opaque NextCursor of a previous TaskHistoryPage.
+type TaskHistoryQuery struct {
+	TaskID string
+	Cursor string
+	Limit  int
+}
+
+// TaskHistoryPage is a single page of the history of a task.
+// NextCursor is empty when there are no more entries.
+type TaskHistoryPage struct {
+	Entries    []TaskHistoryEntry `json:"entries"`
+	NextCursor string             `json:"next_cursor"`
+}
+
+// TaskHistoryRepository stores the history of the tasks. It only ever appends entries.
+type TaskHistoryRepository interface {
+	// AppendTaskHistory stores a new entry and returns it with its ID set.
+	AppendTaskHistory(ctx context.Context, entry TaskHistoryEntry) (TaskHistoryEntry, error)
+	// FindTaskHistory returns one page of the entries of a task. It expects a normalized query with a positive limit.
+	FindTaskHistory(ctx context.Context, query TaskHistoryQuery) (TaskHistoryPage, error)
+}
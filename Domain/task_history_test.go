@@ -0,0 +1,32 @@
+package Domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiffTasks tests that only the changed fields are reported, and every field when the task is created or deleted.
+func TestDiffTasks(t *testing.T) {
+	due := time.Date(2024, 6, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	before := Task{Title: "report", Description: "quarterly", DueDate: due, Status: TaskStatusTodo, AssigneeIDs: []string{"a", "b"}}
+
+	after := before
+	after.DueDate = due.UTC()
+	after.AssigneeIDs = []string{"a", "b"}
+	assert.Empty(t, DiffTasks(&before, &after), "the same instant in another zone and an equal list are no change")
+
+	after.Status = TaskStatusInProgress
+	after.AssigneeIDs = []string{"b", "a"}
+	assert.Equal(t, []FieldChange{
+		{Field: "status", Before: TaskStatusTodo, After: TaskStatusInProgress},
+		{Field: "assignee_ids", Before: []string{"a", "b"}, After: []string{"b", "a"}},
+	}, DiffTasks(&before, &after))
+
+	created := DiffTasks(nil, &before)
+	assert.Len(t, created, 6)
+	assert.Equal(t, FieldChange{Field: "due_date", Before: nil, After: "2024-06-01T10:00:00Z"}, created[2])
+	deleted := DiffTasks(&before, nil)
+	assert.Equal(t, FieldChange{Field: "assignee_ids", Before: []string{"a", "b"}, After: nil}, deleted[4])
+}
@@ -0,0 +1,68 @@
+package Domain
+
+import (
+	"strings"
+	"time"
+)
+
+// TaskPatch is a partial update of a task: only the fields that are set are changed.
+// A nil AssigneeIDs or Labels leaves the assignees or labels alone, a pointer to an empty slice removes them all.
+// Version is the version of the task the patch applies to.
+type TaskPatch struct {
+	Title       *string
+	Description *string
+	DueDate     *time.Time
+	Status      *string
+	AssigneeIDs *[]string
+	Labels      *[]string
+	Version     int64
+}
+
+// IsEmpty reports whether the patch changes no field.
+func (p TaskPatch) IsEmpty() bool {
+	return p.Title == nil && p.Description == nil && p.DueDate == nil && p.Status == nil && p.AssigneeIDs == nil && p.Labels == nil
+}
+
+// Validate checks the fields the patch sets: a title and a description cannot be blank and a due date cannot be zero.
+// It returns an ErrValidation error naming the first invalid field.
+func (p TaskPatch) Validate() error {
+	if p.IsEmpty() {
+		return NewError(ErrValidation, "the patch changes no field")
+	}
+	if p.Title != nil && strings.TrimSpace(*p.Title) == "" {
+		return NewError(ErrValidation, "title cannot be blank")
+	}
+	if p.Description != nil && strings.TrimSpace(*p.Description) == "" {
+		return NewError(ErrValidation, "description cannot be blank")
+	}
+	if p.DueDate != nil && p.DueDate.IsZero() {
+		return NewError(ErrValidation, "due_date cannot be empty")
+	}
+	if p.Status != nil && *p.Status == "" {
+		return NewError(ErrValidation, "status cannot be blank")
+	}
+	return nil
+}
+
+// Apply returns a copy of the task with the fields of the patch set.
+func (p TaskPatch) Apply(task Task) Task {
+	if p.Title != nil {
+		task.Title = *p.Title
+	}
+	if p.Description != nil {
+		task.Description = *p.Description
+	}
+	if p.DueDate != nil {
+		task.DueDate = *p.DueDate
+	}
+	if p.Status != nil {
+		task.Status = *p.Status
+	}
+	if p.AssigneeIDs != nil {
+		task.AssigneeIDs = append([]string{}, *p.AssigneeIDs...)
+	}
+	if p.Labels != nil {
+		task.Labels = append([]string{}, *p.Labels...)
+	}
+	return task
+}
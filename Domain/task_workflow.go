@@ -0,0 +1,119 @@
+package Domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Task statuses of the default workflow.
+const (
+	TaskStatusTodo       = "TODO"
+	TaskStatusInProgress = "IN_PROGRESS"
+	TaskStatusReview     = "REVIEW"
+	TaskStatusDone       = "DONE"
+	TaskStatusBlocked    = "BLOCKED"
+	TaskStatusCancelled  = "CANCELLED"
+)
+
+// Errors returned when a task status does not follow the workflow.
+var (
+	ErrUnknownTaskStatus = NewError(ErrValidation, "unknown task status")
+	ErrIllegalTransition = NewError(ErrConflict, "illegal status transition")
+)
+
+// TaskWorkflow is the state machine the status of a task follows.
+// Transitions maps every status to the statuses a task in that status may move to,
+// and new tasks without a status start in Initial.
+type TaskWorkflow struct {
+	Initial     string
+	Transitions map[string][]string
+}
+
+// DefaultTaskWorkflow returns the workflow TODO → IN_PROGRESS → REVIEW → DONE, where active tasks can be
+// BLOCKED or CANCELLED, blocked tasks resume, and done or cancelled tasks can be reopened.
+func DefaultTaskWorkflow() TaskWorkflow {
+	return TaskWorkflow{
+		Initial: TaskStatusTodo,
+		Transitions: map[string][]string{
+			TaskStatusTodo:       {TaskStatusInProgress, TaskStatusBlocked, TaskStatusCancelled},
+			TaskStatusInProgress: {TaskStatusReview, TaskStatusTodo, TaskStatusBlocked, TaskStatusCancelled},
+			TaskStatusReview:     {TaskStatusDone, TaskStatusInProgress, TaskStatusBlocked, TaskStatusCancelled},
+			TaskStatusBlocked:    {TaskStatusTodo, TaskStatusInProgress, TaskStatusCancelled},
+			TaskStatusDone:       {TaskStatusInProgress},
+			TaskStatusCancelled:  {TaskStatusTodo},
+		},
+	}
+}
+
+// NormalizeTaskStatus returns the canonical spelling of a status: upper case, with words separated by underscores.
+// "in progress", "In-Progress" and "IN_PROGRESS" all name the same status.
+func NormalizeTaskStatus(status string) string {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	return strings.Join(strings.FieldsFunc(status, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_'
+	}), "_")
+}
+
+// Statuses returns every status of the workflow in alphabetical order.
+func (w TaskWorkflow) Statuses() []string {
+	statuses := make([]string, 0, len(w.Transitions))
+	for status := range w.Transitions {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	return statuses
+}
+
+// Validate checks that the workflow is a well-formed state machine: the initial status and
+// the targets of every transition must be statuses of the workflow.
+func (w TaskWorkflow) Validate() error {
+	if len(w.Transitions) == 0 {
+		return errors.New("workflow has no statuses")
+	}
+	if _, ok := w.Transitions[w.Initial]; !ok {
+		return fmt.Errorf("initial status %q is not a status of the workflow", w.Initial)
+	}
+	for _, from := range w.Statuses() {
+		if from == "" || NormalizeTaskStatus(from) != from {
+			return fmt.Errorf("status %q must be upper case with words separated by underscores", from)
+		}
+		for _, to := range w.Transitions[from] {
+			if _, ok := w.Transitions[to]; !ok {
+				return fmt.Errorf("transition from %s to unknown status %q", from, to)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseStatus normalizes a status received from a client.
+// It returns an ErrUnknownTaskStatus error if the status is not part of the workflow.
+func (w TaskWorkflow) ParseStatus(status string) (string, error) {
+	normalized := NormalizeTaskStatus(status)
+	if _, ok := w.Transitions[normalized]; !ok {
+		return "", fmt.Errorf("%w: %q is not one of %s", ErrUnknownTaskStatus, status, strings.Join(w.Statuses(), ", "))
+	}
+	return normalized, nil
+}
+
+// CheckTransition reports whether a task may move from one status to another.
+// Staying in the same status is always allowed, and so is leaving a status that is not part of the workflow,
+// so tasks stored before the workflow was introduced or changed can be brought back into it.
+// It returns an ErrIllegalTransition error listing the allowed statuses otherwise.
+func (w TaskWorkflow) CheckTransition(from string, to string) error {
+	allowed, ok := w.Transitions[from]
+	if from == to || !ok {
+		return nil
+	}
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("%w: a task in %s cannot change status", ErrIllegalTransition, from)
+	}
+	return fmt.Errorf("%w: cannot move a task from %s to %s, allowed: %s", ErrIllegalTransition, from, to, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,77 @@
+package Domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNormalizeTaskStatus tests that the spellings clients use map to the canonical status.
+func TestNormalizeTaskStatus(t *testing.T) {
+	for input, expected := range map[string]string{
+		"done":         TaskStatusDone,
+		"DONE":         TaskStatusDone,
+		" Done ":       TaskStatusDone,
+		"in progress":  TaskStatusInProgress,
+		"In-Progress":  TaskStatusInProgress,
+		"IN__PROGRESS": TaskStatusInProgress,
+		"Started":      "STARTED",
+		"":             "",
+	} {
+		assert.Equal(t, expected, NormalizeTaskStatus(input), input)
+	}
+}
+
+// TestDefaultTaskWorkflow tests the transitions of the default workflow.
+func TestDefaultTaskWorkflow(t *testing.T) {
+	w := DefaultTaskWorkflow()
+	require.NoError(t, w.Validate())
+
+	path := []string{TaskStatusTodo, TaskStatusInProgress, TaskStatusReview, TaskStatusDone}
+	for i := 1; i < len(path); i++ {
+		assert.NoError(t, w.CheckTransition(path[i-1], path[i]))
+	}
+	for _, status := range []string{TaskStatusTodo, TaskStatusInProgress, TaskStatusReview} {
+		assert.NoError(t, w.CheckTransition(status, TaskStatusBlocked))
+		assert.NoError(t, w.CheckTransition(status, TaskStatusCancelled))
+	}
+	assert.NoError(t, w.CheckTransition(TaskStatusDone, TaskStatusDone))
+	assert.NoError(t, w.CheckTransition("Pending", TaskStatusDone), "statuses from before the workflow can be left")
+
+	err := w.CheckTransition(TaskStatusTodo, TaskStatusDone)
+	assert.ErrorIs(t, err, ErrIllegalTransition)
+	assert.EqualError(t, err, "illegal status transition: cannot move a task from TODO to DONE, allowed: IN_PROGRESS, BLOCKED, CANCELLED")
+	assert.ErrorIs(t, w.CheckTransition(TaskStatusCancelled, TaskStatusDone), ErrIllegalTransition)
+}
+
+// TestTaskWorkflow_ParseStatus tests that statuses are normalized and unknown ones rejected.
+func TestTaskWorkflow_ParseStatus(t *testing.T) {
+	w := DefaultTaskWorkflow()
+	status, err := w.ParseStatus("review")
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusReview, status)
+
+	_, err = w.ParseStatus("in review")
+	assert.ErrorIs(t, err, ErrUnknownTaskStatus)
+	_, err = w.ParseStatus("Started")
+	assert.ErrorIs(t, err, ErrUnknownTaskStatus)
+	assert.ErrorIs(t, err, ErrValidation)
+}
+
+// TestTaskWorkflow_Validate tests that malformed workflows are rejected.
+func TestTaskWorkflow_Validate(t *testing.T) {
+	cases := map[string]TaskWorkflow{
+		"empty":           {},
+		"unknown initial": {Initial: "NEW", Transitions: map[string][]string{"OPEN": {}}},
+		"unknown target":  {Initial: "OPEN", Transitions: map[string][]string{"OPEN": {"CLOSED"}}},
+		"lower case":      {Initial: "open", Transitions: map[string][]string{"open": {}}},
+	}
+	for name, w := range cases {
+		assert.Error(t, w.Validate(), name)
+	}
+
+	terminal := TaskWorkflow{Initial: "OPEN", Transitions: map[string][]string{"OPEN": {"CLOSED"}, "CLOSED": {}}}
+	require.NoError(t, terminal.Validate())
+	assert.EqualError(t, terminal.CheckTransition("CLOSED", "OPEN"), "illegal status transition: a task in CLOSED cannot change status")
+}
@@ -0,0 +1,49 @@
+package Domain
+
+import (
+	"context"
+	"time"
+)
+
+// RefreshToken is a long-lived credential that can be exchanged once for a new token pair.
+// Every token issued from the same login shares a FamilyID, which is also the session id
+// carried by the access tokens of that login. Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        ID         `bson:"_id"`
+	UserID    string     `bson:"user_id"`
+	FamilyID  string     `bson:"family_id"`
+	TokenHash string     `bson:"token_hash"`
+	CreatedAt time.Time  `bson:"created_at"`
+	ExpiresAt time.Time  `bson:"expires_at"`
+	UsedAt    *time.Time `bson:"used_at"`
+	RevokedAt *time.Time `bson:"revoked_at"`
+}
+
+// TokenPair is the set of credentials handed to a client after login or refresh.
+// ExpiresIn is the lifetime of the access token in seconds.
+type TokenPair struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// Errors returned by the refresh token repositories and use cases.
+var (
+	ErrRefreshTokenNotFound = NewError(ErrNotFound, "refresh token not found")
+	ErrInvalidRefreshToken  = NewError(ErrUnauthorized, "invalid refresh token")
+	ErrRefreshTokenExpired  = NewError(ErrUnauthorized, "refresh token has expired")
+	ErrRefreshTokenReused   = NewError(ErrUnauthorized, "refresh token reuse detected")
+	ErrSessionRevoked       = NewError(ErrUnauthorized, "session has been revoked")
+)
+
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token RefreshToken) (RefreshToken, error)
+	FindRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error)
+	// MarkRefreshTokenUsed flags an unused token as used and returns ErrRefreshTokenReused
+	// if it had already been used, so concurrent refreshes cannot both succeed.
+	MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) error
+	RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	// RevokeUserTokens revokes every session of the user with the given ID.
+	RevokeUserTokens(ctx context.Context, userID string, revokedAt time.Time) error
+	IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+}
@@ -3,27 +3,143 @@ package Domain
 import (
 	"context"
 	"time"
-
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// User is an account of the service. OrgID is the organization of the user;
+// usernames are unique within an organization. Role names a built-in role or a Role of the organization,
+// and is always assigned by the service, never taken from a sign-up.
+// Password is the hash of the password of the user and is never written to JSON.
 type User struct {
-	ID        primitive.ObjectID `bson:"_id,omitempty"`
-	Username  string             `bson:"username" json:"username" validate:"required"`
-	Password  string             `bson:"password" json:"password" validate:"required"`
-	Role      string             `bson:"role" json:"role" validate:"required,eq=ADMIN|eq=USER"`
-	CreatedAt time.Time          `bson:"created_at" json:"created_at"`
-	UpdatedAt time.Time          `bson:"updated_at" json:"updated_at"`
+	ID       ID     `bson:"_id,omitempty" json:"id"`
+	OrgID    string `bson:"org_id" json:"org_id"`
+	Username string `bson:"username" json:"username" validate:"required"`
+	Password string `bson:"password" json:"-" validate:"required"`
+	Role     string `bson:"role" json:"role"`
+	// DeactivatedAt is set while the account is deactivated. Deactivated users cannot log in.
+	DeactivatedAt *time.Time `bson:"deactivated_at,omitempty" json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time  `bson:"updated_at" json:"updated_at"`
+}
+
+// The built-in roles. The first user of an organization gets RoleAdmin and every later one RoleUser.
+const (
+	RoleAdmin = "ADMIN"
+	RoleUser  = "USER"
+)
+
+// AuthUserKey is the context key under which the authenticated caller is stored.
+const AuthUserKey = "auth_user"
+
+// Errors returned by the user repositories and use cases.
+var (
+	ErrUserNotFound    = NewError(ErrNotFound, "user not found")
+	ErrUsernameTaken   = NewError(ErrConflict, "username already exists")
+	ErrUnauthenticated = NewError(ErrUnauthorized, "authentication required")
+	// ErrUserDeactivated is returned when a deactivated user logs in or is given a role.
+	ErrUserDeactivated = NewError(ErrForbidden, "the account is deactivated")
+	// ErrUserIsAdmin is returned when an admin is deactivated or deleted; it must be given another role first,
+	// which keeps the last admin of an organization.
+	ErrUserIsAdmin = NewError(ErrConflict, "an admin must be given another role first")
+)
+
+// Page size limits applied to UserQuery.Limit.
+const (
+	DefaultUserPageSize = 20
+	MaxUserPageSize     = 100
+)
+
+// UserQuery selects one page of the users of an organization, in the order they signed up.
+// Search, when set, restricts the results to the users whose username starts with it.
+// Role, when set, restricts the results to the users having that role.
+// Cursor is the opaque NextCursor of a previous UserPage.
+type UserQuery struct {
+	Search string
+	Role   string
+	Cursor string
+	Limit  int
+}
+
+// UserPage is a single page of users. NextCursor is empty when there are no more users.
+type UserPage struct {
+	Users      []User `json:"users"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// AuthUser is the authenticated caller of a request, as identified by its access token.
+// SessionID is the refresh token family the access token was issued for.
+// OrgID is the organization of the caller, which every query of the request is restricted to.
+// Permissions are the permissions of the role of the caller when the access token was issued.
+type AuthUser struct {
+	ID          string
+	Username    string
+	Role        string
+	SessionID   string
+	OrgID       string
+	Permissions []string
+}
+
+// Can reports whether the caller has the given permission.
+func (u AuthUser) Can(permission string) bool {
+	for _, granted := range u.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ContextWithAuthUser returns a copy of ctx carrying the authenticated caller.
+func ContextWithAuthUser(ctx context.Context, user AuthUser) context.Context {
+	return context.WithValue(ctx, AuthUserKey, user)
+}
+
+// AuthUserFromContext returns the authenticated caller stored in ctx.
+// A *gin.Context works as well, since AuthMiddleware stores the caller under AuthUserKey.
+func AuthUserFromContext(ctx context.Context) (AuthUser, bool) {
+	user, ok := ctx.Value(AuthUserKey).(AuthUser)
+	return user, ok
 }
 
 type UserRepository interface {
 	FindUser(ctx context.Context, username string) (User, error)
+	FindUserById(ctx context.Context, userId string) (User, error)
 	CreateNewUser(ctx context.Context, user *User) (User, error)
+	// CreateOrganization stores the first user of a new organization as its admin.
+	// It returns ErrOrganizationExists if the organization already has users.
+	CreateOrganization(ctx context.Context, admin *User) (User, error)
 	PromoteUser(ctx context.Context, userId string) error
+	// SetUserRole gives a role to a user. It returns ErrLastAdmin if the user is the last admin
+	// of its organization and the role is another one.
+	SetUserRole(ctx context.Context, userId string, role string) error
+	// CountUsersWithRole counts the users of the organization of ctx having the given role.
+	CountUsersWithRole(ctx context.Context, role string) (int64, error)
+	// FindUsers returns one page of the users of the organization of ctx. It expects a normalized query with a positive limit.
+	FindUsers(ctx context.Context, query UserQuery) (UserPage, error)
+	// SetUserDeactivated deactivates the user with the given ID at the given time, or activates it again if nil.
+	SetUserDeactivated(ctx context.Context, userId string, deactivatedAt *time.Time) error
+	// DeleteUser returns ErrUserNotFound if no user of the organization of ctx has this ID.
+	DeleteUser(ctx context.Context, userId string) error
 }
 
 type UserUseCase interface {
+	// CreateAccount signs up the first user of a new organization, who becomes its admin.
 	CreateAccount(ctx context.Context, user *User) (User, error)
-	AuthenticateUser(ctx context.Context, userName string, password string) (User, string, error)
+	// CreateUser creates an account for a regular user in the organization of the caller.
+	CreateUser(ctx context.Context, user *User) (User, error)
+	// AuthenticateUser checks the password of a user of the given organization and opens a session for it.
+	AuthenticateUser(ctx context.Context, orgId string, userName string, password string) (User, TokenPair, error)
+	RefreshSession(ctx context.Context, refreshToken string) (TokenPair, error)
+	Logout(ctx context.Context, refreshToken string) error
 	UpdateUserRole(ctx context.Context, id string) error
+	// AssignUserRole gives a role of the organization of the caller to a user. The caller must hold
+	// every permission of the role, and the last admin of an organization cannot be given another role.
+	AssignUserRole(ctx context.Context, id string, role string) (User, error)
+	GetUsers(ctx context.Context, query UserQuery) (UserPage, error)
+	GetUser(ctx context.Context, id string) (User, error)
+	// DeactivateUser blocks the logins of a user and ends its sessions. Admins cannot be deactivated.
+	DeactivateUser(ctx context.Context, id string) (User, error)
+	ActivateUser(ctx context.Context, id string) (User, error)
+	// DeleteUser deletes a user after making the user with the ID reassignTo, or the caller if blank,
+	// the creator and an assignee of its tasks instead. Admins cannot be deleted.
+	DeleteUser(ctx context.Context, id string, reassignTo string) error
 }
@@ -1,19 +1,31 @@
 package Infrastructure
 
 import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"errors"
 	"net/http"
 	"strings"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/gin-gonic/gin"
 )
 
+// SessionChecker reports whether the session an access token was issued for has been revoked.
+// It is implemented by domain.RefreshTokenRepository.
+type SessionChecker interface {
+	IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+}
+
 // AuthMiddleware is a middleware function that handles authentication for incoming requests.
-// It checks the "Authorization" header in the request and validates the token.
+// It checks the "Authorization" header in the request and validates the token with the keys of the JWTService.
 // If the header is missing or the token is invalid, it returns a 401 Unauthorized response.
-// If the token has expired, it returns a 401 Unauthorized response.
-// If the token is valid, it sets the "user_id" and "role" values in the context and allows the request to proceed.
-func AuthMiddleware() gin.HandlerFunc {
+// If the token has expired or its session has been revoked, it returns a 401 Unauthorized response.
+// Tokens without an organization, issued before the service was multi-tenant, are rejected as invalid.
+// If the token is valid, it sets the "user_id", "username", "role" and "org_id" values and the domain.AuthUser
+// of the caller, with the permissions of the token, in the context and allows the request to proceed.
+// Tokens issued before roles had permissions grant none until they are refreshed.
+func AuthMiddleware(tokens *JWTService, sessions SessionChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -29,13 +41,11 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		token, err := jwt.Parse(authParts[1], func(token *jwt.Token) (interface{}, error) {
-			return []byte("MY-Secret-Key"), nil
-		})
-
+		claims, err := tokens.ParseToken(authParts[1])
 		if err != nil {
-			// Check if the error is due to token expiration
-			if ve, ok := err.(*jwt.ValidationError); ok && ve.Errors == jwt.ValidationErrorExpired {
+			// Check if the error is due to token expiration only, so forged tokens are never reported as expired
+			var ve *jwt.ValidationError
+			if errors.As(err, &ve) && ve.Errors == jwt.ValidationErrorExpired {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has expired"})
 			} else {
 				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
@@ -44,33 +54,66 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("user_id", claims["user_id"])
-			c.Set("role", claims["role"])
-		} else {
+		if claims.ID == "" || claims.SessionID == "" || claims.OrgID == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
 			return
 		}
 
+		revoked, err := sessions.IsTokenFamilyRevoked(c, claims.SessionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to verify session"})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", claims.ID)
+		c.Set("username", claims.Username)
+		c.Set("role", claims.Role)
+		c.Set(domain.OrganizationKey, claims.OrgID)
+		c.Set(domain.AuthUserKey, domain.AuthUser{
+			ID:          claims.ID,
+			Username:    claims.Username,
+			Role:        claims.Role,
+			SessionID:   claims.SessionID,
+			OrgID:       claims.OrgID,
+			Permissions: claims.Permissions,
+		})
+
 		c.Next()
 	}
 }
 
 
-// AuthAdminMiddleware is a middleware function that checks if the user has admin access.
-// It retrieves the user's role from the context and verifies if it is set to "ADMIN".
-// If the role is not set or is not "ADMIN", it returns a JSON response with a 403 Forbidden status
-// and an error message indicating that admin access is required.
-// If the role is "ADMIN", it allows the request to proceed to the next middleware or handler.
-func AuthAdminMiddleware() gin.HandlerFunc {
+// RequirePermission is a middleware function that checks the caller authenticated by AuthMiddleware
+// has every one of the given permissions.
+// If the caller is missing one, it returns a JSON response with a 403 Forbidden status
+// and an error message naming the missing permission.
+// Otherwise it allows the request to proceed to the next middleware or handler.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists || role != "ADMIN" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
-			c.Abort()
-			return
+		user, _ := domain.AuthUserFromContext(c)
+		for _, permission := range permissions {
+			if !user.Can(permission) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Permission " + permission + " required"})
+				c.Abort()
+				return
+			}
 		}
 		c.Next()
 	}
 }
+
+// JWKSHandler serves the public verification keys as a JWKS document,
+// so other services can verify the access tokens issued by this one.
+func JWKSHandler(keys *KeyManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, keys.JWKS())
+	}
+}
@@ -1,6 +1,7 @@
 package Infrastructure
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -8,32 +9,48 @@ import (
 
 	domain "example/go-clean-architecture/Domain"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v4"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// newTestJWTService returns a JWTService signing with an ephemeral key and the default lifetimes.
+func newTestJWTService(t *testing.T) *JWTService {
+	keys, err := NewEphemeralKeyManager()
+	assert.Nil(t, err)
+	return NewJWTService(keys, DefaultAccessTokenTTL, DefaultRefreshTokenTTL)
+}
+
+// revokedSessions is a SessionChecker reporting the sessions in the map as revoked.
+type revokedSessions map[string]bool
+
+func (rs revokedSessions) IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	return rs[familyID], nil
+}
+
 // TestAuthMiddleware_ValidToken tests the AuthMiddleware function when a valid token is provided.
 // It creates a valid user instance and generates a valid token for the user.
 // Then, it creates a request with the valid token and sends it to the "/test" endpoint.
 // Finally, it asserts that the response code is 200 and the response body contains the expected JSON message.
 func TestAuthMiddleware_ValidToken(t *testing.T) {
+	tokens := newTestJWTService(t)
+
 	// Create a valid user instance
 	user := domain.User{
-		ID:       primitive.NewObjectID(), // Replace with actual MongoDB ObjectID.Hex() if applicable
+		ID:       domain.NewID(), // Replace with actual MongoDB ObjectID.String() if applicable
+		OrgID:    "sales",
 		Username: "testuser",
 		Role:     "USER",
 	}
 
 	// Generate a valid token for the user
-	tokenString, err := GenerateToken(user)
+	tokenString, err := tokens.GenerateToken(user, nil, "session-1")
 	assert.Nil(t, err)
 
 	// Create a request with the valid token
 	w := httptest.NewRecorder()
 	_, r := gin.CreateTestContext(w)
-	r.Use(AuthMiddleware())
+	r.Use(AuthMiddleware(tokens, revokedSessions{}))
 	r.GET("/test", func(c *gin.Context) {
 		c.JSON(200, gin.H{"message": "Success"})
 	})
@@ -46,15 +63,127 @@ func TestAuthMiddleware_ValidToken(t *testing.T) {
 	assert.JSONEq(t, `{"message":"Success"}`, w.Body.String())
 }
 
+// TestAuthMiddleware_SetsAuthUser tests that the AuthMiddleware function stores the caller identified by the token
+// in the context, both as individual values and as a domain.AuthUser usable by the use cases.
+func TestAuthMiddleware_SetsAuthUser(t *testing.T) {
+	tokens := newTestJWTService(t)
+
+	user := domain.User{
+		ID:       domain.NewID(),
+		OrgID:    "sales",
+		Username: "testuser",
+		Role:     "TRIAGE",
+	}
+	tokenString, err := tokens.GenerateToken(user, []string{domain.PermissionTaskReadAll}, "session-1")
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.Use(AuthMiddleware(tokens, revokedSessions{}))
+	r.GET("/test", func(c *gin.Context) {
+		authUser, ok := domain.AuthUserFromContext(c)
+		assert.True(t, ok)
+		assert.Equal(t, domain.AuthUser{ID: user.ID.String(), Username: user.Username, Role: user.Role, SessionID: "session-1", OrgID: "sales", Permissions: []string{domain.PermissionTaskReadAll}}, authUser)
+		assert.Equal(t, user.ID.String(), c.GetString("user_id"))
+		org, ok := domain.OrganizationFromContext(c)
+		assert.True(t, ok)
+		assert.Equal(t, "sales", org)
+		c.JSON(200, gin.H{"message": "Success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+}
+
+// TestAuthMiddleware_RevokedSession tests that tokens of a revoked session are rejected
+// with a 401 Unauthorized response even though they have not expired.
+func TestAuthMiddleware_RevokedSession(t *testing.T) {
+	tokens := newTestJWTService(t)
+
+	user := domain.User{
+		ID:       domain.NewID(),
+		OrgID:    "sales",
+		Username: "testuser",
+		Role:     "USER",
+	}
+	tokenString, err := tokens.GenerateToken(user, nil, "session-1")
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.Use(AuthMiddleware(tokens, revokedSessions{"session-1": true}))
+	r.GET("/test", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "Success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 401, w.Code)
+	assert.JSONEq(t, `{"error":"Session has been revoked"}`, w.Body.String())
+}
+
+// TestAuthMiddleware_TokenWithoutSession tests that tokens which are not bound to a session,
+// such as the ones issued before sessions existed, are rejected as invalid.
+func TestAuthMiddleware_TokenWithoutSession(t *testing.T) {
+	tokens := newTestJWTService(t)
+
+	tokenString, err := tokens.GenerateToken(domain.User{ID: domain.NewID(), OrgID: "sales", Role: "USER"}, nil, "")
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.Use(AuthMiddleware(tokens, revokedSessions{}))
+	r.GET("/test", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "Success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 401, w.Code)
+	assert.JSONEq(t, `{"error":"Invalid token"}`, w.Body.String())
+}
+
+// TestAuthMiddleware_TokenWithoutOrganization tests that tokens without an organization,
+// such as the ones issued before organizations existed, are rejected as invalid.
+func TestAuthMiddleware_TokenWithoutOrganization(t *testing.T) {
+	tokens := newTestJWTService(t)
+
+	tokenString, err := tokens.GenerateToken(domain.User{ID: domain.NewID(), Role: "USER"}, nil, "session-1")
+	assert.Nil(t, err)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.Use(AuthMiddleware(tokens, revokedSessions{}))
+	r.GET("/test", func(c *gin.Context) {
+		c.JSON(200, gin.H{"message": "Success"})
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 401, w.Code)
+	assert.JSONEq(t, `{"error":"Invalid token"}`, w.Body.String())
+}
+
 // TestAuthMiddleware_InvalidToken tests the behavior of the AuthMiddleware function when an invalid token is provided.
 // It creates a request with an invalid token and sends it to the server. The server should respond with a
 // 401 Unauthorized status code and an error message indicating that the token is invalid.
 // The test asserts that the response code is 401 and the response body is `{"error":"Invalid token"}`.
 func TestAuthMiddleware_InvalidToken(t *testing.T) {
+	tokens := newTestJWTService(t)
+
 	// Create a request with an invalid token
 	w := httptest.NewRecorder()
 	_, r := gin.CreateTestContext(w)
-	r.Use(AuthMiddleware())
+	r.Use(AuthMiddleware(tokens, revokedSessions{}))
 	r.GET("/test", func(c *gin.Context) {
 		c.JSON(200, gin.H{"message": "Success"})
 	})
@@ -73,18 +202,20 @@ func TestAuthMiddleware_InvalidToken(t *testing.T) {
 //Then, it sends the request to the "/test" endpoint and checks if the response code is 401 Unauthorized and the response body contains the error message "Token has expired".
 // This test ensures that the AuthMiddleware function correctly handles expired tokens and returns the appropriate error response.
 func TestAuthMiddleware_ExpiredToken(t *testing.T) {
+	tokens := newTestJWTService(t)
+
 	// Create an expired token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": primitive.NewObjectID().Hex(),
-		"role":    "USER",
-		"exp":     time.Now().Add(-time.Hour).Unix(),
+	tokenString, _ := tokens.Keys().Sign(jwt.MapClaims{
+		"id":   domain.NewID().String(),
+		"role": "USER",
+		"sid":  "session-1",
+		"exp":  time.Now().Add(-time.Hour).Unix(),
 	})
-	tokenString, _ := token.SignedString(SECRET_KEY)
 
 	// Create a request with the expired token
 	w := httptest.NewRecorder()
 	_, r := gin.CreateTestContext(w)
-	r.Use(AuthMiddleware())
+	r.Use(AuthMiddleware(tokens, revokedSessions{}))
 	r.GET("/test", func(c *gin.Context) {
 		c.JSON(200, gin.H{"message": "Success"})
 	})
@@ -97,53 +228,95 @@ func TestAuthMiddleware_ExpiredToken(t *testing.T) {
 	assert.JSONEq(t, `{"error":"Token has expired"}`, w.Body.String())
 }
 
-// TestAuthAdminMiddleware_ValidAdmin tests the AuthAdminMiddleware function when a valid "ADMIN" role is set in the context.
-//
-// It creates a request with an "ADMIN" role in the context and sends a GET request to "/admin" endpoint.
-// The expected response is a JSON object with a message indicating that admin access is granted.
+// TestAuthMiddleware_ForeignKey tests that tokens signed with a key the JWTService does not know are rejected,
+// including tokens signed with HS256 using a known key id.
+func TestAuthMiddleware_ForeignKey(t *testing.T) {
+	tokens := newTestJWTService(t)
+	claims := jwt.MapClaims{
+		"id":  domain.NewID().String(),
+		"sid": "session-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	foreign := newTestJWTService(t)
+	foreignToken, err := foreign.Keys().Sign(claims)
+	assert.Nil(t, err)
+
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmacToken.Header["kid"] = tokens.Keys().SigningKeyID()
+	hmacTokenString, err := hmacToken.SignedString([]byte("MY-Secret-Key"))
+	assert.Nil(t, err)
+
+	for _, tokenString := range []string{foreignToken, hmacTokenString} {
+		w := httptest.NewRecorder()
+		_, r := gin.CreateTestContext(w)
+		r.Use(AuthMiddleware(tokens, revokedSessions{}))
+		r.GET("/test", func(c *gin.Context) {
+			c.JSON(200, gin.H{"message": "Success"})
+		})
+
+		req, _ := http.NewRequest("GET", "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+tokenString)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, 401, w.Code)
+		assert.JSONEq(t, `{"error":"Invalid token"}`, w.Body.String())
+	}
+}
+
+// TestRequirePermission_Granted tests the RequirePermission function when the caller has every required permission.
 //
+// It stores a caller with the permissions in the context and sends a GET request to the "/admin" endpoint.
 // This test asserts that the HTTP status code is 200 and the response body matches the expected JSON.
-func TestAuthAdminMiddleware_ValidAdmin(t *testing.T) {
-	// Create a request with an "ADMIN" role in the context
+func TestRequirePermission_Granted(t *testing.T) {
 	w := httptest.NewRecorder()
 	_, r := gin.CreateTestContext(w)
 	r.Use(func(c *gin.Context) {
-		c.Set("role", "ADMIN")
+		c.Set(domain.AuthUserKey, domain.AuthUser{ID: "1", Role: "TRIAGE", Permissions: []string{domain.PermissionTaskReadAll, domain.PermissionTaskRestore}})
 		c.Next()
 	})
-	r.Use(AuthAdminMiddleware())
+	r.Use(RequirePermission(domain.PermissionTaskRestore, domain.PermissionTaskReadAll))
 	r.GET("/admin", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "Admin access granted"})
+		c.JSON(200, gin.H{"message": "Access granted"})
 	})
 
 	req, _ := http.NewRequest("GET", "/admin", nil)
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, 200, w.Code)
-	assert.JSONEq(t, `{"message":"Admin access granted"}`, w.Body.String())
+	assert.JSONEq(t, `{"message":"Access granted"}`, w.Body.String())
 }
 
-// TestAuthAdminMiddleware_InvalidRole tests the behavior of the AuthAdminMiddleware function when the user has an invalid role.
-//
-// This test creates a request with a non-admin role in the context and sends it to the "/admin" endpoint.
-// The AuthAdminMiddleware function is used as a middleware to check if the user has admin access.
-// The expected behavior is that the response status code should be 403 (Forbidden) and the response body should contain the error message "Admin access required".
-func TestAuthAdminMiddleware_InvalidRole(t *testing.T) {
-	// Create a request with a non-admin role in the context
-	w := httptest.NewRecorder()
-	_, r := gin.CreateTestContext(w)
-	r.Use(func(c *gin.Context) {
-		c.Set("role", "USER")
-		c.Next()
-	})
-	r.Use(AuthAdminMiddleware())
-	r.GET("/admin", func(c *gin.Context) {
-		c.JSON(200, gin.H{"message": "Admin access granted"})
-	})
+// TestRequirePermission_Missing tests that RequirePermission rejects with a 403 Forbidden response naming
+// the missing permission a caller lacking one of the required permissions, whatever the name of its role,
+// as well as a request without an authenticated caller.
+func TestRequirePermission_Missing(t *testing.T) {
+	for _, tc := range []struct {
+		user    *domain.AuthUser
+		missing string
+	}{
+		{&domain.AuthUser{ID: "1", Role: domain.RoleAdmin, Permissions: []string{domain.PermissionTaskReadAll}}, domain.PermissionTaskRestore},
+		{&domain.AuthUser{ID: "1", Role: domain.RoleUser, Permissions: []string{}}, domain.PermissionTaskReadAll},
+		{nil, domain.PermissionTaskReadAll},
+	} {
+		user := tc.user
+		w := httptest.NewRecorder()
+		_, r := gin.CreateTestContext(w)
+		r.Use(func(c *gin.Context) {
+			if user != nil {
+				c.Set(domain.AuthUserKey, *user)
+			}
+			c.Next()
+		})
+		r.Use(RequirePermission(domain.PermissionTaskReadAll, domain.PermissionTaskRestore))
+		r.GET("/admin", func(c *gin.Context) {
+			c.JSON(200, gin.H{"message": "Access granted"})
+		})
 
-	req, _ := http.NewRequest("GET", "/admin", nil)
-	r.ServeHTTP(w, req)
+		req, _ := http.NewRequest("GET", "/admin", nil)
+		r.ServeHTTP(w, req)
 
-	assert.Equal(t, 403, w.Code)
-	assert.JSONEq(t, `{"error":"Admin access required"}`, w.Body.String())
+		assert.Equal(t, 403, w.Code)
+		assert.JSONEq(t, `{"error":"Permission `+tc.missing+` required"}`, w.Body.String())
+	}
 }
@@ -0,0 +1,113 @@
+package Infrastructure
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Statuses reported by the health endpoints.
+const (
+	HealthStatusOK           = "ok"
+	HealthStatusUnavailable  = "unavailable"
+	HealthStatusShuttingDown = "shutting_down"
+)
+
+// HealthCheck checks that a dependency the application needs to serve requests is reachable.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// DependencyStatus is the result of one health check.
+type DependencyStatus struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// HealthReport is the body of the readiness endpoint.
+type HealthReport struct {
+	Status string                      `json:"status"`
+	Checks map[string]DependencyStatus `json:"checks,omitempty"`
+}
+
+// HealthChecker serves the liveness and readiness endpoints.
+// The application is ready while all its dependencies answer within the timeout and it is not shutting down.
+type HealthChecker struct {
+	checks       []HealthCheck
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewHealthChecker creates a HealthChecker running the given checks, each bounded by timeout.
+func NewHealthChecker(timeout time.Duration, checks ...HealthCheck) *HealthChecker {
+	return &HealthChecker{checks: checks, timeout: timeout}
+}
+
+// SetShuttingDown marks the application as shutting down, after which it is reported as not ready.
+func (h *HealthChecker) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Liveness reports that the process is up and serving requests.
+func (h *HealthChecker) Liveness(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"status": HealthStatusOK})
+}
+
+// Readiness runs the health checks concurrently and reports the status and latency of every dependency.
+// It responds with 503 Service Unavailable if a check fails or the application is shutting down.
+func (h *HealthChecker) Readiness(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	if h.shuttingDown.Load() {
+		c.JSON(http.StatusServiceUnavailable, HealthReport{Status: HealthStatusShuttingDown})
+		return
+	}
+
+	report := h.Check(c.Request.Context())
+	code := http.StatusOK
+	if report.Status != HealthStatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	c.JSON(code, report)
+}
+
+// Check runs the health checks concurrently and returns their results.
+func (h *HealthChecker) Check(ctx context.Context) HealthReport {
+	report := HealthReport{Status: HealthStatusOK, Checks: make(map[string]DependencyStatus, len(h.checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check HealthCheck) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(ctx)
+			status := DependencyStatus{
+				Status:    HealthStatusOK,
+				LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				status.Status = HealthStatusUnavailable
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = status
+			if err != nil {
+				report.Status = HealthStatusUnavailable
+			}
+		}(check)
+	}
+	wg.Wait()
+	return report
+}
@@ -0,0 +1,96 @@
+package Infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveHealth registers the health endpoints of h on a test router and performs a GET request on path.
+func serveHealth(h *HealthChecker, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/healthz", h.Liveness)
+	r.GET("/readyz", h.Readiness)
+
+	req, _ := http.NewRequest("GET", path, nil)
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestHealthChecker_Liveness tests that the liveness endpoint does not depend on the health checks.
+func TestHealthChecker_Liveness(t *testing.T) {
+	h := NewHealthChecker(time.Second, HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+
+	w := serveHealth(h, "/healthz")
+
+	assert.Equal(t, 200, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+// TestHealthChecker_Ready tests that the readiness endpoint reports every dependency when all checks pass.
+func TestHealthChecker_Ready(t *testing.T) {
+	h := NewHealthChecker(time.Second,
+		HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error { return nil }},
+		HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return nil }},
+	)
+
+	w := serveHealth(h, "/readyz")
+
+	assert.Equal(t, 200, w.Code)
+	var report HealthReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, HealthStatusOK, report.Status)
+	assert.Len(t, report.Checks, 2)
+	assert.Equal(t, HealthStatusOK, report.Checks["mongodb"].Status)
+	assert.Empty(t, report.Checks["mongodb"].Error)
+}
+
+// TestHealthChecker_DependencyDown tests that a failing or hanging dependency makes the application not ready,
+// and that a hanging check is cut off by the timeout.
+func TestHealthChecker_DependencyDown(t *testing.T) {
+	h := NewHealthChecker(50*time.Millisecond,
+		HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		HealthCheck{Name: "cache", Check: func(ctx context.Context) error { return nil }},
+	)
+
+	start := time.Now()
+	w := serveHealth(h, "/readyz")
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, 503, w.Code)
+	var report HealthReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.Equal(t, HealthStatusUnavailable, report.Status)
+	assert.Equal(t, HealthStatusUnavailable, report.Checks["mongodb"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["mongodb"].Error)
+	assert.GreaterOrEqual(t, report.Checks["mongodb"].LatencyMs, float64(50))
+	assert.Equal(t, HealthStatusOK, report.Checks["cache"].Status)
+}
+
+// TestHealthChecker_ShuttingDown tests that the application stops being ready once shutdown starts,
+// while it is still alive.
+func TestHealthChecker_ShuttingDown(t *testing.T) {
+	h := NewHealthChecker(time.Second, HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error { return nil }})
+	h.SetShuttingDown()
+
+	w := serveHealth(h, "/readyz")
+	assert.Equal(t, 503, w.Code)
+	assert.JSONEq(t, `{"status":"shutting_down"}`, w.Body.String())
+
+	w = serveHealth(h, "/healthz")
+	assert.Equal(t, 200, w.Code)
+}
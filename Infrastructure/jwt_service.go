@@ -1,38 +1,104 @@
 package Infrastructure
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	domain "example/go-clean-architecture/Domain"
 	"time"
 
-	"github.com/dgrijalva/jwt-go"
+	"github.com/golang-jwt/jwt/v4"
 )
 
-var SECRET_KEY = []byte("MY-Secret-Key")
+// Default lifetimes of the issued credentials.
+// Access tokens are short-lived since they are only checked against revocation by session;
+// refresh tokens are rotated on every use.
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
 
+// Claims are the claims of the access tokens. OrgID is the organization of the user,
+// which every request made with the token is restricted to. Permissions are the ones of the role
+// of the user when the token was issued, so a change to a role applies from the next refresh on.
 type Claims struct {
-	ID       string `json:"id"`
-	Username string `json:"username"`
-	Role     string `json:"role"`
-	jwt.StandardClaims
+	ID          string   `json:"id"`
+	Username    string   `json:"username"`
+	Role        string   `json:"role"`
+	SessionID   string   `json:"sid"`
+	OrgID       string   `json:"org_id"`
+	Permissions []string `json:"perms"`
+	jwt.RegisteredClaims
+}
+
+// JWTService issues and verifies access tokens with the keys of a KeyManager.
+type JWTService struct {
+	keys            *KeyManager
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// NewJWTService creates a JWTService signing with the given keys and issuing credentials with the given lifetimes.
+func NewJWTService(keys *KeyManager, accessTokenTTL time.Duration, refreshTokenTTL time.Duration) *JWTService {
+	return &JWTService{
+		keys:            keys,
+		AccessTokenTTL:  accessTokenTTL,
+		RefreshTokenTTL: refreshTokenTTL,
+	}
 }
 
-func GenerateToken(user domain.User) (string, error) {
-	expirationTime := time.Now().Add(24 * time.Hour) // Token valid for 24 hours
+// Keys returns the KeyManager holding the signing and verification keys.
+func (js *JWTService) Keys() *KeyManager {
+	return js.keys
+}
 
+// GenerateToken issues a signed access token for the user, granting the given permissions and bound to the given session.
+func (js *JWTService) GenerateToken(user domain.User, permissions []string, sessionID string) (string, error) {
+	if permissions == nil {
+		permissions = []string{}
+	}
+	now := time.Now()
 	claims := &Claims{
-		ID:       user.ID.Hex(),
-		Username: user.Username,
-		Role:     user.Role,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: expirationTime.Unix(),
+		ID:          user.ID.String(),
+		Username:    user.Username,
+		Role:        user.Role,
+		SessionID:   sessionID,
+		OrgID:       user.OrgID,
+		Permissions: permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(js.AccessTokenTTL)),
 		},
 	}
-	//generate token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	// Sign the token with the secret key
-	tokenString, err := token.SignedString(SECRET_KEY)
+	return js.keys.Sign(claims)
+}
+
+// ParseToken verifies an access token and returns its claims.
+// Failures are reported as *jwt.ValidationError.
+func (js *JWTService) ParseToken(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, js.keys.Keyfunc)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return tokenString, nil
+	return claims, nil
+}
+
+// GenerateRefreshToken returns a new random refresh token together with the hash to store for it.
+// The token itself is only ever given to the client.
+func GenerateRefreshToken() (token string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken returns the hex encoded SHA-256 hash under which a refresh token is stored.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
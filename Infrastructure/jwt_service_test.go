@@ -0,0 +1,22 @@
+package Infrastructure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGenerateRefreshToken tests that refresh tokens are random and that the returned hash
+// is the one HashRefreshToken computes for the token, so it can be looked up later.
+func TestGenerateRefreshToken(t *testing.T) {
+	token, hash, err := GenerateRefreshToken()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, token, hash)
+	assert.Equal(t, HashRefreshToken(token), hash)
+
+	other, otherHash, err := GenerateRefreshToken()
+	assert.Nil(t, err)
+	assert.NotEqual(t, token, other)
+	assert.NotEqual(t, hash, otherHash)
+}
@@ -0,0 +1,218 @@
+package Infrastructure
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing keys.
+const minRSAKeyBits = 2048
+
+// KeyConfig describes one key loaded by the KeyManager.
+// File is a PEM file holding either a private key, which can sign and verify tokens,
+// or a public key, which can only verify tokens signed before a key rotation.
+// RSA keys are used with RS256 and Ed25519 keys with EdDSA.
+type KeyConfig struct {
+	ID   string
+	File string
+}
+
+// managedKey is one key of the KeyManager. private is nil for verification only keys.
+type managedKey struct {
+	id      string
+	method  jwt.SigningMethod
+	public  crypto.PublicKey
+	private crypto.Signer
+}
+
+// KeyManager holds the keys access tokens are signed and verified with.
+// Tokens are signed with a single signing key and carry its id in the "kid" header;
+// any of the loaded keys is accepted for verification, which lets a new signing key be
+// rolled out while tokens signed with the previous one are still valid.
+type KeyManager struct {
+	signing *managedKey
+	keys    map[string]*managedKey
+	order   []string
+}
+
+// NewKeyManager loads the configured key files and selects the key with signingKeyID for signing.
+// The signing key must be a private key.
+func NewKeyManager(signingKeyID string, configs []KeyConfig) (*KeyManager, error) {
+	keys := make([]*managedKey, 0, len(configs))
+	for _, config := range configs {
+		data, err := os.ReadFile(config.File)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", config.ID, err)
+		}
+		key, err := parseKey(config.ID, data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return newKeyManager(signingKeyID, keys)
+}
+
+// NewEphemeralKeyManager generates a random Ed25519 signing key that only lives as long as the process.
+// It is meant for development and tests, since tokens become invalid on restart.
+func NewEphemeralKeyManager() (*KeyManager, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	kid := make([]byte, 8)
+	if _, err := rand.Read(kid); err != nil {
+		return nil, err
+	}
+	key := &managedKey{
+		id:      "ephemeral-" + hex.EncodeToString(kid),
+		method:  jwt.SigningMethodEdDSA,
+		public:  public,
+		private: private,
+	}
+	return newKeyManager(key.id, []*managedKey{key})
+}
+
+func newKeyManager(signingKeyID string, keys []*managedKey) (*KeyManager, error) {
+	km := &KeyManager{keys: make(map[string]*managedKey, len(keys))}
+	for _, key := range keys {
+		if key.id == "" {
+			return nil, errors.New("key id is required")
+		}
+		if _, ok := km.keys[key.id]; ok {
+			return nil, fmt.Errorf("duplicate key id %q", key.id)
+		}
+		km.keys[key.id] = key
+		km.order = append(km.order, key.id)
+	}
+	signing, ok := km.keys[signingKeyID]
+	if !ok {
+		return nil, fmt.Errorf("signing key %q is not configured", signingKeyID)
+	}
+	if signing.private == nil {
+		return nil, fmt.Errorf("signing key %q has no private key", signingKeyID)
+	}
+	km.signing = signing
+	return km, nil
+}
+
+// parseKey decodes a PEM encoded PKCS#8 or PKCS#1 private key, or a PKIX or PKCS#1 public key.
+func parseKey(id string, data []byte) (*managedKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %q: no PEM data found", id)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", id, err)
+	}
+
+	key := &managedKey{id: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.public, key.private = jwt.SigningMethodRS256, &k.PublicKey, k
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.public, key.private = jwt.SigningMethodEdDSA, k.Public(), k
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T, expected RSA or Ed25519", id, parsed)
+	}
+	if public, ok := key.public.(*rsa.PublicKey); ok && public.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("key %q: RSA keys must have at least %d bits", id, minRSAKeyBits)
+	}
+	return key, nil
+}
+
+// SigningKeyID returns the id of the key new tokens are signed with.
+func (km *KeyManager) SigningKeyID() string {
+	return km.signing.id
+}
+
+// Sign returns the signed token for the claims, with the signing key id in the "kid" header.
+func (km *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(km.signing.method, claims)
+	token.Header["kid"] = km.signing.id
+	return token.SignedString(km.signing.private)
+}
+
+// Keyfunc selects the verification key of a token from its "kid" header.
+// Tokens whose algorithm does not match the key are rejected, so a public key can never be
+// used as an HMAC secret.
+func (km *KeyManager) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := km.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %q for key %q", token.Method.Alg(), kid)
+	}
+	return key.public, nil
+}
+
+// JSONWebKey is the public part of a key as published in a JWKS document (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is a JWKS document.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// JWKS returns the public keys other services need to verify the issued tokens.
+func (km *KeyManager) JWKS() JSONWebKeySet {
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, id := range km.order {
+		key := km.keys[id]
+		jwk := JSONWebKey{KeyID: key.id, Use: "sig", Algorithm: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
@@ -0,0 +1,162 @@
+package Infrastructure
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePEM writes a PEM block to a file in dir and returns its path.
+func writePEM(t *testing.T, dir string, name string, blockType string, der []byte) string {
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+// testKeyFiles writes an RSA private key, an Ed25519 private key and the public half of
+// a second Ed25519 key and returns their key configs.
+func testKeyFiles(t *testing.T) (rsaKey KeyConfig, edKey KeyConfig, oldKey KeyConfig, oldPrivate ed25519.PrivateKey) {
+	dir := t.TempDir()
+
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaKey = KeyConfig{ID: "rsa-1", File: writePEM(t, dir, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivate))}
+
+	_, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(edPrivate)
+	require.NoError(t, err)
+	edKey = KeyConfig{ID: "ed-1", File: writePEM(t, dir, "ed.pem", "PRIVATE KEY", der)}
+
+	oldPublic, oldPrivate, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err = x509.MarshalPKIXPublicKey(oldPublic)
+	require.NoError(t, err)
+	oldKey = KeyConfig{ID: "ed-0", File: writePEM(t, dir, "old.pem", "PUBLIC KEY", der)}
+	return rsaKey, edKey, oldKey, oldPrivate
+}
+
+// TestNewKeyManager_SignAndVerify tests that tokens signed with RSA and Ed25519 keys
+// carry the key id and the matching algorithm and are verified by the JWTService.
+func TestNewKeyManager_SignAndVerify(t *testing.T) {
+	rsaKey, edKey, oldKey, _ := testKeyFiles(t)
+
+	for _, signing := range []struct {
+		id  string
+		alg string
+	}{{"rsa-1", "RS256"}, {"ed-1", "EdDSA"}} {
+		keys, err := NewKeyManager(signing.id, []KeyConfig{rsaKey, edKey, oldKey})
+		require.NoError(t, err)
+		assert.Equal(t, signing.id, keys.SigningKeyID())
+
+		tokens := NewJWTService(keys, DefaultAccessTokenTTL, DefaultRefreshTokenTTL)
+		tokenString, err := keys.Sign(&Claims{ID: "user-1", SessionID: "session-1"})
+		require.NoError(t, err)
+
+		token, _, err := new(jwt.Parser).ParseUnverified(tokenString, &Claims{})
+		require.NoError(t, err)
+		assert.Equal(t, signing.id, token.Header["kid"])
+		assert.Equal(t, signing.alg, token.Header["alg"])
+
+		claims, err := tokens.ParseToken(tokenString)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims.ID)
+	}
+}
+
+// TestNewKeyManager_Rotation tests that tokens signed with a retired key are still accepted
+// while its public key is configured, and rejected once it is removed.
+func TestNewKeyManager_Rotation(t *testing.T) {
+	rsaKey, _, oldKey, oldPrivate := testKeyFiles(t)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, &Claims{
+		ID:               "user-1",
+		SessionID:        "session-1",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	})
+	token.Header["kid"] = oldKey.ID
+	tokenString, err := token.SignedString(oldPrivate)
+	require.NoError(t, err)
+
+	keys, err := NewKeyManager(rsaKey.ID, []KeyConfig{rsaKey, oldKey})
+	require.NoError(t, err)
+	_, err = NewJWTService(keys, DefaultAccessTokenTTL, DefaultRefreshTokenTTL).ParseToken(tokenString)
+	assert.NoError(t, err)
+
+	keys, err = NewKeyManager(rsaKey.ID, []KeyConfig{rsaKey})
+	require.NoError(t, err)
+	_, err = NewJWTService(keys, DefaultAccessTokenTTL, DefaultRefreshTokenTTL).ParseToken(tokenString)
+	assert.Error(t, err)
+}
+
+// TestNewKeyManager_Errors tests that invalid key configurations are rejected at startup.
+func TestNewKeyManager_Errors(t *testing.T) {
+	rsaKey, edKey, oldKey, _ := testKeyFiles(t)
+	dir := t.TempDir()
+
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	weakKey := KeyConfig{ID: "weak", File: writePEM(t, dir, "weak.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(weak))}
+
+	cases := map[string]struct {
+		signing string
+		configs []KeyConfig
+	}{
+		"missing signing key":     {"unknown", []KeyConfig{rsaKey}},
+		"public signing key":      {oldKey.ID, []KeyConfig{rsaKey, oldKey}},
+		"duplicate key id":        {rsaKey.ID, []KeyConfig{rsaKey, {ID: rsaKey.ID, File: edKey.File}}},
+		"empty key id":            {rsaKey.ID, []KeyConfig{rsaKey, {File: edKey.File}}},
+		"missing file":            {"gone", []KeyConfig{{ID: "gone", File: filepath.Join(dir, "gone.pem")}}},
+		"RSA key under 2048 bits": {weakKey.ID, []KeyConfig{weakKey}},
+	}
+	for name, tc := range cases {
+		_, err := NewKeyManager(tc.signing, tc.configs)
+		assert.Error(t, err, name)
+	}
+}
+
+// TestJWKSHandler tests that the JWKS endpoint publishes the public part of every configured key.
+func TestJWKSHandler(t *testing.T) {
+	rsaKey, edKey, oldKey, _ := testKeyFiles(t)
+	keys, err := NewKeyManager(edKey.ID, []KeyConfig{rsaKey, edKey, oldKey})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	_, r := gin.CreateTestContext(w)
+	r.GET("/.well-known/jwks.json", JWKSHandler(keys))
+
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Header().Get("Cache-Control"), "max-age")
+	assert.NotContains(t, w.Body.String(), `"d"`)
+
+	jwks := keys.JWKS()
+	require.Len(t, jwks.Keys, 3)
+	assert.Equal(t, "rsa-1", jwks.Keys[0].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t, jwks.Keys[0].N)
+	assert.Equal(t, "ed-1", jwks.Keys[1].KeyID)
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+	assert.Equal(t, "EdDSA", jwks.Keys[1].Algorithm)
+	assert.NotEmpty(t, jwks.Keys[1].X)
+	assert.Equal(t, "ed-0", jwks.Keys[2].KeyID)
+}
@@ -0,0 +1,50 @@
+package Infrastructure
+
+import (
+	"context"
+	"log"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// TrashPurger is the background job that permanently deletes the tasks that have been in the trash for too long.
+type TrashPurger struct {
+	tasks     domain.TaskUseCase
+	retention time.Duration
+	interval  time.Duration
+}
+
+// NewTrashPurger creates a TrashPurger deleting, every interval, the tasks deleted more than retention ago.
+func NewTrashPurger(tasks domain.TaskUseCase, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{tasks: tasks, retention: retention, interval: interval}
+}
+
+// Run purges the trash once, then every interval until ctx is done.
+// A failed purge is logged and retried at the next interval, so Run only returns when ctx is done.
+func (p *TrashPurger) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		p.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// purge deletes the tasks past their retention, in every organization, and logs how many.
+func (p *TrashPurger) purge(ctx context.Context) {
+	purged, err := p.tasks.PurgeDeletedTasks(domain.ContextWithoutTenant(ctx), p.retention)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("purging the trash: %v", err)
+		}
+		return
+	}
+	if purged > 0 {
+		log.Printf("purged %d tasks from the trash", purged)
+	}
+}
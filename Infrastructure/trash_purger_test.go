@@ -0,0 +1,46 @@
+package Infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTrashPurger_Run tests that the purger purges every organization at startup and at every interval,
+// keeps going after a failure and stops with its context.
+func TestTrashPurger_Run(t *testing.T) {
+	tasks := new(mocks.TaskUseCase)
+	purges := make(chan struct{}, 10)
+	tasks.On("PurgeDeletedTasks", mock.MatchedBy(domain.IsWithoutTenant), 24*time.Hour).Return(int64(0), errors.New("database is down")).Once()
+	tasks.On("PurgeDeletedTasks", mock.MatchedBy(domain.IsWithoutTenant), 24*time.Hour).Return(int64(2), nil).Run(func(mock.Arguments) {
+		purges <- struct{}{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- NewTrashPurger(tasks, 24*time.Hour, 10*time.Millisecond).Run(ctx)
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-purges:
+		case <-time.After(time.Second):
+			t.Fatal("the trash was not purged")
+		}
+	}
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("the purger did not stop with its context")
+	}
+}
@@ -0,0 +1,100 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// attachmentRepository stores the metadata of the attachments in a MongoDB collection.
+type attachmentRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.AttachmentRepository = &attachmentRepository{}
+
+// NewAttachmentRepository creates a new instance of the AttachmentRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewAttachmentRepository(db mongo.Database, collection string) domain.AttachmentRepository {
+	return &attachmentRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// CreateAttachment stores a new attachment with the ID it was given.
+func (ar *attachmentRepository) CreateAttachment(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error) {
+	collection := ar.database.Collection(ar.collection)
+	if _, err := collection.InsertOne(ctx, attachment); err != nil {
+		return domain.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// FindAttachmentById retrieves an attachment by its ID.
+// It returns domain.ErrAttachmentNotFound if no attachment has this ID.
+func (ar *attachmentRepository) FindAttachmentById(ctx context.Context, attachmentId string) (domain.Attachment, error) {
+	collection := ar.database.Collection(ar.collection)
+	objID, err := parseObjectID(attachmentId)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	var attachment domain.Attachment
+	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&attachment)
+	if err == mongo.ErrNoDocuments {
+		return domain.Attachment{}, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// FindTaskAttachments returns the attachments of a task, oldest first.
+func (ar *attachmentRepository) FindTaskAttachments(ctx context.Context, taskId string) ([]domain.Attachment, error) {
+	collection := ar.database.Collection(ar.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"task_id": taskId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	attachments := []domain.Attachment{}
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// DeleteAttachment deletes an attachment by its ID.
+// It returns domain.ErrAttachmentNotFound if no attachment has this ID.
+func (ar *attachmentRepository) DeleteAttachment(ctx context.Context, attachmentId string) error {
+	collection := ar.database.Collection(ar.collection)
+	objID, err := parseObjectID(attachmentId)
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// DeleteTaskAttachments deletes every attachment of the given tasks.
+func (ar *attachmentRepository) DeleteTaskAttachments(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	collection := ar.database.Collection(ar.collection)
+	_, err := collection.DeleteMany(ctx, bson.M{"task_id": bson.M{"$in": taskIds}})
+	return err
+}
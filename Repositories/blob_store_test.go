@@ -0,0 +1,102 @@
+package Repositories
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	domain "example/go-clean-architecture/Domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blobStores returns every BlobStore available to the test, each on an empty location.
+func blobStores(t *testing.T) map[string]domain.BlobStore {
+	local, err := NewLocalBlobStore(filepath.Join(t.TempDir(), "blobs"))
+	require.NoError(t, err)
+	stores := map[string]domain.BlobStore{"local": local}
+
+	client, err := dialTestMongo()
+	if err != nil {
+		t.Logf("skipping the gridfs blob store: %v", err)
+		return stores
+	}
+	database := client.Database("testBlobStore")
+	t.Cleanup(func() {
+		database.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	stores["gridfs"] = NewGridFSBlobStore(*database, "attachments_"+domain.NewID().String())
+	return stores
+}
+
+// TestBlobStores tests that every BlobStore gives back what was put, and forgets it once deleted.
+func TestBlobStores(t *testing.T) {
+	for name, blobs := range blobStores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			key := domain.NewID().String()
+			content := bytes.Repeat([]byte("attachment "), 40000)
+
+			size, err := blobs.Put(ctx, key, bytes.NewReader(content))
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), size)
+
+			r, err := blobs.Get(ctx, key)
+			require.NoError(t, err)
+			read, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			assert.Equal(t, content, read)
+
+			require.NoError(t, blobs.Delete(ctx, key))
+			_, err = blobs.Get(ctx, key)
+			assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+			assert.NoError(t, blobs.Delete(ctx, key), "deleting a missing blob is not an error")
+		})
+	}
+}
+
+// failingReader returns some content, then an error.
+type failingReader struct{ read bool }
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, io.ErrUnexpectedEOF
+	}
+	r.read = true
+	return copy(p, "partial"), nil
+}
+
+// TestLocalBlobStore_FailedPut tests that a failed upload leaves nothing behind in the directory.
+func TestLocalBlobStore_FailedPut(t *testing.T) {
+	dir := t.TempDir()
+	blobs, err := NewLocalBlobStore(dir)
+	require.NoError(t, err)
+	key := domain.NewID().String()
+
+	_, err = blobs.Put(context.Background(), key, &failingReader{})
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	_, err = blobs.Get(context.Background(), key)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+// TestLocalBlobStore_Keys tests that keys cannot reach outside of the directory.
+func TestLocalBlobStore_Keys(t *testing.T) {
+	blobs, err := NewLocalBlobStore(t.TempDir())
+	require.NoError(t, err)
+	for _, key := range []string{"", ".", "..", "../escape", "a/b", `a\b`} {
+		_, err := blobs.Put(context.Background(), key, bytes.NewReader(nil))
+		assert.Error(t, err, key)
+		_, err = blobs.Get(context.Background(), key)
+		assert.Error(t, err, key)
+		assert.Error(t, blobs.Delete(context.Background(), key), key)
+	}
+}
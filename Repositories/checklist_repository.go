@@ -0,0 +1,186 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// checklistRepository stores the checklist items of the tasks in a MongoDB collection.
+type checklistRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.ChecklistRepository = &checklistRepository{}
+
+// NewChecklistRepository creates a new instance of the ChecklistRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewChecklistRepository(db mongo.Database, collection string) domain.ChecklistRepository {
+	return &checklistRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// CreateChecklistItem stores a new item and returns it with its ID set.
+func (cr *checklistRepository) CreateChecklistItem(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	collection := cr.database.Collection(cr.collection)
+	item.ID = domain.NewID()
+	if _, err := collection.InsertOne(ctx, item); err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+// FindChecklistItemById retrieves a checklist item by its ID.
+// It returns domain.ErrChecklistItemNotFound if no item has this ID.
+func (cr *checklistRepository) FindChecklistItemById(ctx context.Context, itemId string) (domain.ChecklistItem, error) {
+	collection := cr.database.Collection(cr.collection)
+	objID, err := parseObjectID(itemId)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	var item domain.ChecklistItem
+	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return domain.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+// FindChecklist returns the items of a task, ordered by position, then by ID.
+func (cr *checklistRepository) FindChecklist(ctx context.Context, taskId string) ([]domain.ChecklistItem, error) {
+	collection := cr.database.Collection(cr.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"task_id": taskId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	items := []domain.ChecklistItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateChecklistItem replaces the title, required flag and completion of an item and returns the updated item.
+// It returns domain.ErrChecklistItemNotFound if no item has this ID.
+func (cr *checklistRepository) UpdateChecklistItem(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	collection := cr.database.Collection(cr.collection)
+	objID, err := parseObjectID(item.ID.String())
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	set := bson.M{"title": item.Title, "required": item.Required, "done": item.Done}
+	update := bson.M{"$set": set}
+	if item.Done {
+		set["done_by"], set["done_at"] = item.DoneBy, item.DoneAt
+	} else {
+		update["$unset"] = bson.M{"done_by": "", "done_at": ""}
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated domain.ChecklistItem
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": objID}, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return updated, nil
+}
+
+// ReorderChecklist moves every listed item of the task to its index in itemIds, in a single bulk write.
+func (cr *checklistRepository) ReorderChecklist(ctx context.Context, taskId string, itemIds []string) error {
+	if len(itemIds) == 0 {
+		return nil
+	}
+	collection := cr.database.Collection(cr.collection)
+	models := make([]mongo.WriteModel, 0, len(itemIds))
+	for position, itemId := range itemIds {
+		objID, err := parseObjectID(itemId)
+		if err != nil {
+			return err
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": objID, "task_id": taskId}).
+			SetUpdate(bson.M{"$set": bson.M{"position": position}}))
+	}
+	_, err := collection.BulkWrite(ctx, models)
+	return err
+}
+
+// DeleteChecklistItem deletes a checklist item by its ID.
+// It returns domain.ErrChecklistItemNotFound if no item has this ID.
+func (cr *checklistRepository) DeleteChecklistItem(ctx context.Context, itemId string) error {
+	collection := cr.database.Collection(cr.collection)
+	objID, err := parseObjectID(itemId)
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+	return nil
+}
+
+// DeleteTaskChecklists deletes every item of the given tasks.
+func (cr *checklistRepository) DeleteTaskChecklists(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	collection := cr.database.Collection(cr.collection)
+	_, err := collection.DeleteMany(ctx, bson.M{"task_id": bson.M{"$in": taskIds}})
+	return err
+}
+
+// CountChecklists counts the items of each of the given tasks that has some.
+func (cr *checklistRepository) CountChecklists(ctx context.Context, taskIds []string) (map[string]domain.TaskSteps, error) {
+	counts := make(map[string]domain.TaskSteps, len(taskIds))
+	if len(taskIds) == 0 {
+		return counts, nil
+	}
+	collection := cr.database.Collection(cr.collection)
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"task_id": bson.M{"$in": taskIds}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$task_id",
+			"total": bson.M{"$sum": 1},
+			"done":  bson.M{"$sum": bson.M{"$cond": bson.A{"$done", 1, 0}}},
+			"open_required": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{"$required", bson.M{"$not": bson.A{"$done"}}}}, 1, 0,
+			}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		TaskID       string `bson:"_id"`
+		Total        int    `bson:"total"`
+		Done         int    `bson:"done"`
+		OpenRequired int    `bson:"open_required"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		counts[group.TaskID] = domain.TaskSteps{Total: group.Total, Done: group.Done, OpenRequired: group.OpenRequired}
+	}
+	return counts, nil
+}
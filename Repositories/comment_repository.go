@@ -0,0 +1,167 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// commentRepository stores the comments of the tasks in a MongoDB collection.
+type commentRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.CommentRepository = &commentRepository{}
+
+// NewCommentRepository creates a new instance of the CommentRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewCommentRepository(db mongo.Database, collection string) domain.CommentRepository {
+	return &commentRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// CreateComment stores a new comment and returns it with its ID set.
+func (cr *commentRepository) CreateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	collection := cr.database.Collection(cr.collection)
+	comment.ID = domain.NewID()
+	if _, err := collection.InsertOne(ctx, comment); err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+// FindCommentById retrieves a comment by its ID.
+// It returns domain.ErrCommentNotFound if no comment has this ID.
+func (cr *commentRepository) FindCommentById(ctx context.Context, commentId string) (domain.Comment, error) {
+	collection := cr.database.Collection(cr.collection)
+	objID, err := parseObjectID(commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	var comment domain.Comment
+	err = collection.FindOne(ctx, bson.M{"_id": objID}).Decode(&comment)
+	if err == mongo.ErrNoDocuments {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+// FindComments returns one page of the comments of a task, oldest first,
+// with keyset pagination on _id like the history of the tasks.
+func (cr *commentRepository) FindComments(ctx context.Context, query domain.CommentQuery) (domain.CommentPage, error) {
+	collection := cr.database.Collection(cr.collection)
+	filter := bson.M{"task_id": query.TaskID}
+	if query.Cursor != "" {
+		lastID, err := parseObjectID(query.Cursor)
+		if err != nil {
+			return domain.CommentPage{}, err
+		}
+		filter["_id"] = bson.M{"$gt": lastID}
+	}
+
+	// fetch one extra comment to know whether another page follows
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(query.Limit + 1))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	comments := []domain.Comment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	page := domain.CommentPage{Comments: comments}
+	if len(comments) > query.Limit {
+		page.Comments = comments[:query.Limit]
+		page.NextCursor = page.Comments[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
+
+// UpdateComment replaces the body and the update time of a comment and returns it.
+func (cr *commentRepository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	collection := cr.database.Collection(cr.collection)
+	objID, err := parseObjectID(comment.ID.String())
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	update := bson.M{"$set": bson.M{"body": comment.Body, "updated_at": comment.UpdatedAt}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated domain.Comment
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": objID}, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return updated, nil
+}
+
+// DeleteComment deletes a comment by its ID.
+// It returns domain.ErrCommentNotFound if no comment has this ID.
+func (cr *commentRepository) DeleteComment(ctx context.Context, commentId string) error {
+	collection := cr.database.Collection(cr.collection)
+	objID, err := parseObjectID(commentId)
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// DeleteTaskComments deletes every comment of the given tasks.
+func (cr *commentRepository) DeleteTaskComments(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	collection := cr.database.Collection(cr.collection)
+	_, err := collection.DeleteMany(ctx, bson.M{"task_id": bson.M{"$in": taskIds}})
+	return err
+}
+
+// CountComments returns the number of comments of each of the given tasks that has some.
+func (cr *commentRepository) CountComments(ctx context.Context, taskIds []string) (map[string]int, error) {
+	counts := make(map[string]int, len(taskIds))
+	if len(taskIds) == 0 {
+		return counts, nil
+	}
+	collection := cr.database.Collection(cr.collection)
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"task_id": bson.M{"$in": taskIds}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$task_id", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		TaskID string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		counts[group.TaskID] = group.Count
+	}
+	return counts, nil
+}
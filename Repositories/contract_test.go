@@ -0,0 +1,1570 @@
+package Repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// dialTestMongo connects to the MongoDB test instance named by MONGODB_TEST_URI, localhost:27017 by default.
+func dialTestMongo() (*mongo.Client, error) {
+	uri := os.Getenv("MONGODB_TEST_URI")
+	if uri == "" {
+		uri = "mongodb://localhost:27017"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("%s: %w", uri, err)
+	}
+	return client, nil
+}
+
+// connectTestMongo connects to the MongoDB test instance, skipping the test when no MongoDB server answers
+// so the repository tests can run without one.
+func connectTestMongo(t *testing.T) *mongo.Client {
+	client, err := dialTestMongo()
+	if err != nil {
+		t.Skipf("MongoDB is not available: %v", err)
+	}
+	return client
+}
+
+// contractStores returns a constructor of empty stores for every backend available to the test.
+func contractStores(t *testing.T) map[string]func() Store {
+	stores := map[string]func() Store{
+		"memory": NewMemoryStore,
+		"sqlite": sqlContractStore(t, db.SQLite, db.SQLiteDSN(":memory:")),
+	}
+
+	if dsn := os.Getenv("POSTGRES_TEST_DSN"); dsn != "" {
+		stores["postgres"] = sqlContractStore(t, db.Postgres, dsn)
+	} else {
+		t.Log("skipping the postgres backend: POSTGRES_TEST_DSN is not set")
+	}
+
+	client, err := dialTestMongo()
+	if err != nil {
+		t.Logf("skipping the mongodb backend: %v", err)
+		return stores
+	}
+	database := client.Database("testRepositoryContract")
+	t.Cleanup(func() {
+		database.Drop(context.Background())
+		client.Disconnect(context.Background())
+	})
+	stores["mongodb"] = func() Store {
+		// every test gets its own collections, so the tests start from an empty store
+		suffix := "_" + domain.NewID().String()
+		return Store{
+			Tasks:         NewTaskRepository(*database, "tasks"+suffix),
+			Users:         NewUserRepository(*database, "users"+suffix, "organizations"+suffix),
+			RefreshTokens: NewRefreshTokenRepository(*database, "refresh_tokens"+suffix),
+			TaskHistory:   NewTaskHistoryRepository(*database, "task_history"+suffix),
+			Comments:      NewCommentRepository(*database, "task_comments"+suffix),
+			Attachments:   NewAttachmentRepository(*database, "task_attachments"+suffix),
+			Checklists:    NewChecklistRepository(*database, "task_checklist_items"+suffix),
+			Dependencies:  NewDependencyRepository(*database, "task_dependencies"+suffix),
+			Labels:        NewLabelRepository(*database, "labels"+suffix),
+			Projects:      NewProjectRepository(*database, "projects"+suffix, "project_members"+suffix),
+			Roles:         NewRoleRepository(*database, "roles"+suffix),
+		}
+	}
+	return stores
+}
+
+// sqlContractStore migrates the SQL test database and returns a constructor of stores on it.
+// The tables are emptied for every test, so the database must not hold data worth keeping.
+func sqlContractStore(t *testing.T, dialect db.Dialect, dsn string) func() Store {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := db.OpenSQL(ctx, dialect, dsn)
+	if err != nil {
+		t.Fatalf("%s: %v", dialect, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	if _, err := db.Migrate(ctx, conn, dialect); err != nil {
+		t.Fatalf("%s: %v", dialect, err)
+	}
+
+	return func() Store {
+		for _, table := range []string{"task_assignees", "task_labels", "tasks", "users", "refresh_tokens", "task_history", "task_comments", "task_attachments", "task_checklist_items", "task_dependencies", "labels", "project_members", "projects", "roles"} {
+			if _, err := conn.Exec("DELETE FROM " + table); err != nil {
+				t.Errorf("failed to empty %s: %v", table, err)
+			}
+		}
+		return NewSQLStore(conn, dialect)
+	}
+}
+
+// RepositoryContractSuite holds the behaviour every storage backend must implement identically.
+type RepositoryContractSuite struct {
+	suite.Suite
+	newStore func() Store
+	store    Store
+}
+
+// inDefaultOrganization returns a context scoped to the default organization, for the tests of a single organization.
+func inDefaultOrganization() context.Context {
+	return domain.ContextWithOrganization(context.Background(), domain.DefaultOrganization)
+}
+
+// SetupTest gives every test an empty store.
+func (suite *RepositoryContractSuite) SetupTest() {
+	suite.store = suite.newStore()
+}
+
+// createTasks stores the tasks and returns them with their IDs set.
+func (suite *RepositoryContractSuite) createTasks(tasks ...domain.Task) []domain.Task {
+	created := make([]domain.Task, 0, len(tasks))
+	for _, task := range tasks {
+		task, err := suite.store.Tasks.CreateTask(inDefaultOrganization(), task)
+		suite.Require().NoError(err)
+		created = append(created, task)
+	}
+	return created
+}
+
+// allPages pages through the tasks matching the query and returns their titles.
+func (suite *RepositoryContractSuite) allPages(query domain.TaskQuery) []string {
+	titles := []string{}
+	for pages := 0; ; pages++ {
+		suite.Require().Less(pages, 20, "pagination does not terminate")
+		page, err := suite.store.Tasks.FindTasks(inDefaultOrganization(), query)
+		suite.Require().NoError(err)
+		for _, task := range page.Tasks {
+			titles = append(titles, task.Title)
+		}
+		if page.NextCursor == "" {
+			return titles
+		}
+		query.Cursor = page.NextCursor
+	}
+}
+
+// TestTaskLifecycle tests that a task can be created, found, updated and deleted.
+func (suite *RepositoryContractSuite) TestTaskLifecycle() {
+	ctx := inDefaultOrganization()
+	due := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	created, err := suite.store.Tasks.CreateTask(ctx, domain.Task{
+		Title:       "write report",
+		Description: "quarterly report",
+		Status:      "Pending",
+		DueDate:     due,
+		CreatedBy:   "owner",
+		AssigneeIDs: []string{"a", "b"},
+	})
+	suite.Require().NoError(err)
+	suite.False(created.ID.IsZero())
+	suite.Equal(int64(1), created.Version)
+
+	found, err := suite.store.Tasks.FindTaskById(ctx, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(created.ID, found.ID)
+	suite.Equal("write report", found.Title)
+	suite.Equal("quarterly report", found.Description)
+	suite.Equal("Pending", found.Status)
+	suite.True(due.Equal(found.DueDate))
+	suite.Equal("owner", found.CreatedBy)
+	suite.Equal([]string{"a", "b"}, found.AssigneeIDs)
+	suite.Equal(int64(1), found.Version)
+
+	updated, err := suite.store.Tasks.UpdateTaskById(ctx, domain.Task{
+		Title:       "write summary",
+		Description: "short summary",
+		Status:      "Completed",
+		DueDate:     due.Add(time.Hour),
+		AssigneeIDs: []string{"c"},
+		Version:     found.Version,
+	}, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(created.ID, updated.ID)
+	suite.Equal(int64(2), updated.Version)
+	suite.Equal("write summary", updated.Title)
+	suite.Equal("owner", updated.CreatedBy, "the creator is not editable")
+	suite.Equal([]string{"c"}, updated.AssigneeIDs)
+
+	found, err = suite.store.Tasks.FindTaskById(ctx, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("Completed", found.Status)
+	suite.True(due.Add(time.Hour).Equal(found.DueDate))
+	suite.Equal(int64(2), found.Version)
+
+	deleted, err := suite.store.Tasks.DeleteTask(ctx, created.ID.String(), found.Version, "admin", due)
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), deleted.Version)
+	_, err = suite.store.Tasks.FindTaskById(ctx, created.ID.String())
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+// TestPatchTask tests that a patch changes only the fields it sets.
+func (suite *RepositoryContractSuite) TestPatchTask() {
+	ctx := inDefaultOrganization()
+	due := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	created := suite.createTasks(domain.Task{
+		Title:       "write report",
+		Description: "quarterly report",
+		Status:      "TODO",
+		DueDate:     due,
+		CreatedBy:   "owner",
+		AssigneeIDs: []string{"a", "b"},
+	})[0]
+
+	status := "IN_PROGRESS"
+	patched, err := suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Status: &status, Version: 1}, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("IN_PROGRESS", patched.Status)
+	suite.Equal("write report", patched.Title)
+	suite.Equal("quarterly report", patched.Description)
+	suite.True(due.Equal(patched.DueDate))
+	suite.Equal([]string{"a", "b"}, patched.AssigneeIDs)
+
+	title, later := "write summary", due.Add(24*time.Hour)
+	patched, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Title: &title, DueDate: &later, AssigneeIDs: &[]string{"c"}, Version: 2}, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("write summary", patched.Title)
+	suite.True(later.Equal(patched.DueDate))
+	suite.Equal([]string{"c"}, patched.AssigneeIDs)
+	suite.Equal("IN_PROGRESS", patched.Status)
+
+	patched, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{AssigneeIDs: &[]string{}, Version: 3}, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal([]string{}, patched.AssigneeIDs)
+	suite.Equal(int64(4), patched.Version, "every patch increments the version")
+
+	found, err := suite.store.Tasks.FindTaskById(ctx, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("write summary", found.Title)
+	suite.Equal("quarterly report", found.Description)
+	suite.Equal("owner", found.CreatedBy)
+	suite.Empty(found.AssigneeIDs)
+}
+
+// TestTaskErrors tests that unknown and malformed ids are reported with the domain error kinds.
+func (suite *RepositoryContractSuite) TestTaskErrors() {
+	ctx := inDefaultOrganization()
+	missing := domain.NewID().String()
+
+	_, err := suite.store.Tasks.FindTaskById(ctx, missing)
+	suite.ErrorIs(err, domain.ErrNotFound)
+	_, err = suite.store.Tasks.UpdateTaskById(ctx, domain.Task{Title: "x"}, missing)
+	suite.ErrorIs(err, domain.ErrNotFound)
+	_, err = suite.store.Tasks.DeleteTask(ctx, missing, 1, "admin", time.Now())
+	suite.ErrorIs(err, domain.ErrNotFound)
+	_, err = suite.store.Tasks.RestoreTask(ctx, missing)
+	suite.ErrorIs(err, domain.ErrNotFound)
+	_, err = suite.store.Tasks.FindDeletedTaskById(ctx, missing)
+	suite.ErrorIs(err, domain.ErrNotFound)
+	title := "x"
+	_, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Title: &title}, missing)
+	suite.ErrorIs(err, domain.ErrNotFound)
+	_, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{AssigneeIDs: &[]string{"a"}}, missing)
+	suite.ErrorIs(err, domain.ErrNotFound)
+
+	_, err = suite.store.Tasks.FindTaskById(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+	_, err = suite.store.Tasks.UpdateTaskById(ctx, domain.Task{Title: "x"}, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+	_, err = suite.store.Tasks.DeleteTask(ctx, "not-an-id", 1, "admin", time.Now())
+	suite.ErrorIs(err, domain.ErrInvalidID)
+	_, err = suite.store.Tasks.RestoreTask(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+	_, err = suite.store.Tasks.FindDeletedTaskById(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+	_, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Title: &title}, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+
+	_, err = suite.store.Tasks.FindTasks(ctx, domain.TaskQuery{Cursor: "garbage", Limit: 10})
+	suite.ErrorIs(err, domain.ErrValidation)
+}
+
+// TestTaskVersionMismatch tests that writes to a task at another version are rejected and change nothing.
+func (suite *RepositoryContractSuite) TestTaskVersionMismatch() {
+	ctx := inDefaultOrganization()
+	created := suite.createTasks(domain.Task{Title: "write report", AssigneeIDs: []string{"a"}})[0]
+	id := created.ID.String()
+
+	_, err := suite.store.Tasks.UpdateTaskById(ctx, domain.Task{Title: "stale", AssigneeIDs: []string{}, Version: 2}, id)
+	suite.ErrorIs(err, domain.ErrVersionMismatch)
+	suite.ErrorIs(err, domain.ErrPreconditionFailed)
+	title := "stale"
+	_, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Title: &title, Version: 2}, id)
+	suite.ErrorIs(err, domain.ErrVersionMismatch)
+	_, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{AssigneeIDs: &[]string{}, Version: 2}, id)
+	suite.ErrorIs(err, domain.ErrVersionMismatch)
+	_, err = suite.store.Tasks.DeleteTask(ctx, id, 2, "admin", time.Now())
+	suite.ErrorIs(err, domain.ErrVersionMismatch)
+
+	found, err := suite.store.Tasks.FindTaskById(ctx, id)
+	suite.Require().NoError(err)
+	suite.Equal("write report", found.Title)
+	suite.Equal([]string{"a"}, found.AssigneeIDs)
+	suite.Equal(int64(1), found.Version)
+}
+
+// TestTaskTrash tests that deleted tasks leave the task lists for the trash, from which they can be restored or purged.
+func (suite *RepositoryContractSuite) TestTaskTrash() {
+	ctx := inDefaultOrganization()
+	tasks := suite.createTasks(
+		domain.Task{Title: "kept", CreatedBy: "owner", AssigneeIDs: []string{}},
+		domain.Task{Title: "restored", CreatedBy: "owner", AssigneeIDs: []string{"a"}},
+		domain.Task{Title: "purged", CreatedBy: "owner", AssigneeIDs: []string{"a"}},
+	)
+	deletedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, task := range tasks[1:] {
+		deleted, err := suite.store.Tasks.DeleteTask(ctx, task.ID.String(), 1, "admin", deletedAt.Add(time.Duration(i)*time.Hour))
+		suite.Require().NoError(err)
+		suite.Equal(int64(2), deleted.Version)
+		suite.Equal("admin", deleted.DeletedBy)
+		suite.Require().NotNil(deleted.DeletedAt)
+		suite.True(deletedAt.Add(time.Duration(i) * time.Hour).Equal(*deleted.DeletedAt))
+	}
+	purged := tasks[2].ID.String()
+
+	all, err := suite.store.Tasks.FindAlltasks(ctx)
+	suite.Require().NoError(err)
+	suite.Len(all, 1)
+	suite.Equal([]string{"kept"}, suite.allPages(domain.TaskQuery{Limit: 10}))
+	suite.Equal([]string{"restored", "purged"}, suite.allPages(domain.TaskQuery{Deleted: true, Limit: 10}))
+	suite.Equal([]string{"purged"}, suite.allPages(domain.TaskQuery{Deleted: true, TitlePrefix: "pur", VisibleTo: "a", Limit: 10}))
+
+	_, err = suite.store.Tasks.DeleteTask(ctx, purged, 2, "admin", time.Now())
+	suite.ErrorIs(err, domain.ErrTaskNotFound, "a task cannot be deleted twice")
+	_, err = suite.store.Tasks.UpdateTaskById(ctx, domain.Task{Title: "x", AssigneeIDs: []string{}, Version: 2}, purged)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	_, err = suite.store.Tasks.RestoreTask(ctx, tasks[0].ID.String())
+	suite.ErrorIs(err, domain.ErrTaskNotFound, "only deleted tasks can be restored")
+	_, err = suite.store.Tasks.FindDeletedTaskById(ctx, tasks[0].ID.String())
+	suite.ErrorIs(err, domain.ErrTaskNotFound, "live tasks are not in the trash")
+
+	restored, err := suite.store.Tasks.RestoreTask(ctx, tasks[1].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), restored.Version)
+	suite.Nil(restored.DeletedAt)
+	suite.Empty(restored.DeletedBy)
+	suite.Equal([]string{"a"}, restored.AssigneeIDs)
+	found, err := suite.store.Tasks.FindTaskById(ctx, tasks[1].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("restored", found.Title)
+
+	ids, err := suite.store.Tasks.StartPurge(ctx, deletedAt.Add(time.Hour))
+	suite.Require().NoError(err)
+	suite.Empty(ids, "only the tasks deleted before the cutoff are purged")
+	ids, err = suite.store.Tasks.StartPurge(ctx, deletedAt.Add(2*time.Hour))
+	suite.Require().NoError(err)
+	suite.Equal([]string{purged}, ids)
+	suite.Empty(suite.allPages(domain.TaskQuery{Deleted: true, Limit: 10}), "the tasks being purged leave the trash")
+	_, err = suite.store.Tasks.RestoreTask(ctx, purged)
+	suite.ErrorIs(err, domain.ErrTaskNotFound, "the tasks being purged cannot be restored")
+	ids, err = suite.store.Tasks.StartPurge(ctx, deletedAt)
+	suite.Require().NoError(err)
+	suite.Equal([]string{purged}, ids, "an unfinished purge is found again")
+
+	suite.Require().NoError(suite.store.Tasks.PurgeTasks(ctx, ids))
+	_, err = suite.store.Tasks.RestoreTask(ctx, purged)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	ids, err = suite.store.Tasks.StartPurge(ctx, deletedAt.Add(2*time.Hour))
+	suite.Require().NoError(err)
+	suite.Empty(ids)
+	suite.Len(suite.allPages(domain.TaskQuery{Limit: 10}), 2)
+}
+
+// TestPurgeSubtasks tests that the subtasks of a purged task become top-level tasks at a new version,
+// and that only the tasks being purged are deleted.
+func (suite *RepositoryContractSuite) TestPurgeSubtasks() {
+	ctx := inDefaultOrganization()
+	parent := suite.createTasks(domain.Task{Title: "parent"})[0]
+	children := suite.createTasks(
+		domain.Task{Title: "live child", ParentID: parent.ID.String()},
+		domain.Task{Title: "deleted child", ParentID: parent.ID.String()},
+	)
+	deletedAt := time.Now().Add(-time.Hour)
+	_, err := suite.store.Tasks.DeleteTask(ctx, children[1].ID.String(), 1, "admin", time.Now())
+	suite.Require().NoError(err)
+	_, err = suite.store.Tasks.DeleteTask(ctx, parent.ID.String(), 1, "admin", deletedAt)
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.store.Tasks.PurgeTasks(ctx, []string{children[0].ID.String()}))
+	_, err = suite.store.Tasks.FindTaskById(ctx, children[0].ID.String())
+	suite.Require().NoError(err, "a task not being purged is kept")
+
+	ids, err := suite.store.Tasks.StartPurge(ctx, time.Now().Add(-time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal([]string{parent.ID.String()}, ids)
+	suite.Require().NoError(suite.store.Tasks.PurgeTasks(ctx, ids))
+
+	child, err := suite.store.Tasks.FindTaskById(ctx, children[0].ID.String())
+	suite.Require().NoError(err)
+	suite.Empty(child.ParentID)
+	suite.Equal(int64(2), child.Version)
+	restored, err := suite.store.Tasks.RestoreTask(ctx, children[1].ID.String())
+	suite.Require().NoError(err)
+	suite.Empty(restored.ParentID, "the subtasks in the trash are detached as well")
+	suite.Empty(suite.allPages(domain.TaskQuery{ParentID: parent.ID.String(), Limit: 10}))
+}
+
+// TestComments tests that comments are listed per task in the order they were posted, can be edited and deleted,
+// and are counted and deleted per task.
+func (suite *RepositoryContractSuite) TestComments() {
+	ctx := inDefaultOrganization()
+	taskA, taskB := domain.NewID().String(), domain.NewID().String()
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var comments []domain.Comment
+	for i, taskID := range []string{taskA, taskA, taskB, taskA} {
+		comment, err := suite.store.Comments.CreateComment(ctx, domain.Comment{
+			TaskID:    taskID,
+			AuthorID:  "author",
+			Body:      fmt.Sprintf("comment %d", i),
+			CreatedAt: at.Add(time.Duration(i) * time.Minute),
+			UpdatedAt: at.Add(time.Duration(i) * time.Minute),
+		})
+		suite.Require().NoError(err)
+		suite.False(comment.ID.IsZero())
+		comments = append(comments, comment)
+	}
+
+	page, err := suite.store.Comments.FindComments(ctx, domain.CommentQuery{TaskID: taskA, Limit: 2})
+	suite.Require().NoError(err)
+	suite.Equal(comments[:2], page.Comments)
+	suite.NotEmpty(page.NextCursor)
+	page, err = suite.store.Comments.FindComments(ctx, domain.CommentQuery{TaskID: taskA, Cursor: page.NextCursor, Limit: 2})
+	suite.Require().NoError(err)
+	suite.Equal(comments[3:], page.Comments)
+	suite.Empty(page.NextCursor)
+
+	edited := comments[0]
+	edited.Body, edited.UpdatedAt, edited.AuthorID = "edited", at.Add(time.Hour), "someone else"
+	updated, err := suite.store.Comments.UpdateComment(ctx, edited)
+	suite.Require().NoError(err)
+	suite.Equal("edited", updated.Body)
+	suite.Equal("author", updated.AuthorID, "only the body is editable")
+	suite.True(at.Equal(updated.CreatedAt))
+	suite.True(at.Add(time.Hour).Equal(updated.UpdatedAt))
+	found, err := suite.store.Comments.FindCommentById(ctx, comments[0].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(updated, found)
+
+	counts, err := suite.store.Comments.CountComments(ctx, []string{taskA, taskB, domain.NewID().String()})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]int{taskA: 3, taskB: 1}, counts)
+
+	suite.Require().NoError(suite.store.Comments.DeleteComment(ctx, comments[1].ID.String()))
+	suite.ErrorIs(suite.store.Comments.DeleteComment(ctx, comments[1].ID.String()), domain.ErrCommentNotFound)
+	_, err = suite.store.Comments.FindCommentById(ctx, comments[1].ID.String())
+	suite.ErrorIs(err, domain.ErrCommentNotFound)
+	_, err = suite.store.Comments.UpdateComment(ctx, comments[1])
+	suite.ErrorIs(err, domain.ErrCommentNotFound)
+	_, err = suite.store.Comments.FindCommentById(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+
+	suite.Require().NoError(suite.store.Comments.DeleteTaskComments(ctx, []string{taskA}))
+	counts, err = suite.store.Comments.CountComments(ctx, []string{taskA, taskB})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]int{taskB: 1}, counts)
+}
+
+// TestAttachments tests that attachments keep the ID they are given, are listed per task in the order they were added
+// and are deleted one by one or per task.
+func (suite *RepositoryContractSuite) TestAttachments() {
+	ctx := inDefaultOrganization()
+	taskA, taskB := domain.NewID().String(), domain.NewID().String()
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var attachments []domain.Attachment
+	for i, taskID := range []string{taskA, taskB, taskA} {
+		attachment := domain.Attachment{
+			ID:          domain.NewID(),
+			TaskID:      taskID,
+			Filename:    fmt.Sprintf("file %d.pdf", i),
+			ContentType: "application/pdf",
+			Size:        int64(1024 * (i + 1)),
+			UploadedBy:  "uploader",
+			CreatedAt:   at.Add(time.Duration(i) * time.Minute),
+		}
+		created, err := suite.store.Attachments.CreateAttachment(ctx, attachment)
+		suite.Require().NoError(err)
+		suite.Equal(attachment, created)
+		attachments = append(attachments, created)
+	}
+
+	listed, err := suite.store.Attachments.FindTaskAttachments(ctx, taskA)
+	suite.Require().NoError(err)
+	suite.Equal([]domain.Attachment{attachments[0], attachments[2]}, listed)
+	found, err := suite.store.Attachments.FindAttachmentById(ctx, attachments[1].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(attachments[1], found)
+	listed, err = suite.store.Attachments.FindTaskAttachments(ctx, domain.NewID().String())
+	suite.Require().NoError(err)
+	suite.Empty(listed)
+
+	suite.Require().NoError(suite.store.Attachments.DeleteAttachment(ctx, attachments[0].ID.String()))
+	suite.ErrorIs(suite.store.Attachments.DeleteAttachment(ctx, attachments[0].ID.String()), domain.ErrAttachmentNotFound)
+	_, err = suite.store.Attachments.FindAttachmentById(ctx, attachments[0].ID.String())
+	suite.ErrorIs(err, domain.ErrAttachmentNotFound)
+	_, err = suite.store.Attachments.FindAttachmentById(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+
+	suite.Require().NoError(suite.store.Attachments.DeleteTaskAttachments(ctx, []string{taskA}))
+	listed, err = suite.store.Attachments.FindTaskAttachments(ctx, taskA)
+	suite.Require().NoError(err)
+	suite.Empty(listed)
+	_, err = suite.store.Attachments.FindAttachmentById(ctx, attachments[1].ID.String())
+	suite.NoError(err, "the attachments of other tasks are kept")
+}
+
+// TestChecklists tests that checklist items are listed per task in order, can be completed, reordered and deleted,
+// and are counted and deleted per task.
+func (suite *RepositoryContractSuite) TestChecklists() {
+	ctx := inDefaultOrganization()
+	taskA, taskB := domain.NewID().String(), domain.NewID().String()
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var items []domain.ChecklistItem
+	for i, taskID := range []string{taskA, taskA, taskB, taskA} {
+		item, err := suite.store.Checklists.CreateChecklistItem(ctx, domain.ChecklistItem{
+			TaskID:    taskID,
+			Title:     fmt.Sprintf("step %d", i),
+			Required:  i%2 == 0,
+			Position:  i,
+			CreatedAt: at,
+		})
+		suite.Require().NoError(err)
+		suite.False(item.ID.IsZero())
+		items = append(items, item)
+	}
+
+	listed, err := suite.store.Checklists.FindChecklist(ctx, taskA)
+	suite.Require().NoError(err)
+	suite.Equal([]domain.ChecklistItem{items[0], items[1], items[3]}, listed)
+
+	done := items[0]
+	doneAt := at.Add(time.Hour)
+	done.Done, done.DoneBy, done.DoneAt, done.Title, done.TaskID = true, "worker", &doneAt, "first step", taskB
+	updated, err := suite.store.Checklists.UpdateChecklistItem(ctx, done)
+	suite.Require().NoError(err)
+	done.TaskID = taskA
+	suite.Equal(done, updated, "the task of an item is not editable")
+	found, err := suite.store.Checklists.FindChecklistItemById(ctx, done.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(done, found)
+
+	counts, err := suite.store.Checklists.CountChecklists(ctx, []string{taskA, taskB, domain.NewID().String()})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]domain.TaskSteps{
+		taskA: {Total: 3, Done: 1},
+		taskB: {Total: 1, OpenRequired: 1},
+	}, counts)
+
+	reopened := updated
+	reopened.Done, reopened.DoneBy, reopened.DoneAt = false, "", nil
+	updated, err = suite.store.Checklists.UpdateChecklistItem(ctx, reopened)
+	suite.Require().NoError(err)
+	suite.Equal(reopened, updated)
+
+	order := []string{items[3].ID.String(), items[0].ID.String(), items[1].ID.String()}
+	suite.Require().NoError(suite.store.Checklists.ReorderChecklist(ctx, taskA, order))
+	listed, err = suite.store.Checklists.FindChecklist(ctx, taskA)
+	suite.Require().NoError(err)
+	var titles []string
+	for _, item := range listed {
+		titles = append(titles, item.Title)
+	}
+	suite.Equal([]string{"step 3", "first step", "step 1"}, titles)
+
+	suite.Require().NoError(suite.store.Checklists.DeleteChecklistItem(ctx, items[1].ID.String()))
+	suite.ErrorIs(suite.store.Checklists.DeleteChecklistItem(ctx, items[1].ID.String()), domain.ErrChecklistItemNotFound)
+	_, err = suite.store.Checklists.FindChecklistItemById(ctx, items[1].ID.String())
+	suite.ErrorIs(err, domain.ErrChecklistItemNotFound)
+	_, err = suite.store.Checklists.UpdateChecklistItem(ctx, items[1])
+	suite.ErrorIs(err, domain.ErrChecklistItemNotFound)
+	_, err = suite.store.Checklists.FindChecklistItemById(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+
+	suite.Require().NoError(suite.store.Checklists.DeleteTaskChecklists(ctx, []string{taskA}))
+	counts, err = suite.store.Checklists.CountChecklists(ctx, []string{taskA, taskB})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]domain.TaskSteps{taskB: {Total: 1, OpenRequired: 1}}, counts)
+}
+
+// TestSubtasks tests that subtasks keep their parent, can be listed by parent
+// and are counted per status, leaving out those in the trash.
+func (suite *RepositoryContractSuite) TestSubtasks() {
+	ctx := inDefaultOrganization()
+	parent := suite.createTasks(domain.Task{Title: "release", Status: domain.TaskStatusTodo})[0]
+	parentID := parent.ID.String()
+	subtasks := suite.createTasks(
+		domain.Task{Title: "build", Status: domain.TaskStatusDone, ParentID: parentID},
+		domain.Task{Title: "test", Status: domain.TaskStatusTodo, ParentID: parentID},
+		domain.Task{Title: "announce", Status: domain.TaskStatusTodo, ParentID: parentID},
+	)
+	suite.Equal(parentID, subtasks[0].ParentID)
+	found, err := suite.store.Tasks.FindTaskById(ctx, subtasks[1].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(parentID, found.ParentID)
+
+	suite.ElementsMatch([]string{"build", "test", "announce"}, suite.allPages(domain.TaskQuery{ParentID: parentID, Limit: 2}))
+	suite.Len(suite.allPages(domain.TaskQuery{Limit: 10}), 4)
+
+	_, err = suite.store.Tasks.DeleteTask(ctx, subtasks[2].ID.String(), subtasks[2].Version, "admin", time.Now())
+	suite.Require().NoError(err)
+	counts, err := suite.store.Tasks.CountSubtasks(ctx, []string{parentID, subtasks[0].ID.String()})
+	suite.Require().NoError(err)
+	suite.Equal(map[string]map[string]int{
+		parentID: {domain.TaskStatusDone: 1, domain.TaskStatusTodo: 1},
+	}, counts)
+}
+
+// TestDependencies tests that a task depends at most once on another one, that dependencies are found
+// from either end in order, and are removed one by one or per task.
+func (suite *RepositoryContractSuite) TestDependencies() {
+	ctx := inDefaultOrganization()
+	ids := []string{domain.NewID().String(), domain.NewID().String(), domain.NewID().String()}
+	sort.Strings(ids)
+	a, b, c := ids[0], ids[1], ids[2]
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	edge := func(taskID, dependsOnID string) domain.TaskDependency {
+		return domain.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID, CreatedBy: "planner", CreatedAt: at}
+	}
+	for _, dependency := range []domain.TaskDependency{edge(c, b), edge(b, a), edge(c, a)} {
+		suite.Require().NoError(suite.store.Dependencies.AddDependency(ctx, dependency))
+	}
+	suite.ErrorIs(suite.store.Dependencies.AddDependency(ctx, edge(b, a)), domain.ErrDependencyExists)
+
+	found, err := suite.store.Dependencies.FindDependencies(ctx, []string{c})
+	suite.Require().NoError(err)
+	suite.Equal([]domain.TaskDependency{edge(c, a), edge(c, b)}, found)
+	found, err = suite.store.Dependencies.FindDependents(ctx, []string{a})
+	suite.Require().NoError(err)
+	suite.Equal([]domain.TaskDependency{edge(b, a), edge(c, a)}, found)
+	found, err = suite.store.Dependencies.FindDependents(ctx, []string{c})
+	suite.Require().NoError(err)
+	suite.Empty(found)
+
+	suite.Require().NoError(suite.store.Dependencies.RemoveDependency(ctx, c, a))
+	suite.ErrorIs(suite.store.Dependencies.RemoveDependency(ctx, c, a), domain.ErrDependencyNotFound)
+	found, err = suite.store.Dependencies.FindDependencies(ctx, ids)
+	suite.Require().NoError(err)
+	suite.Len(found, 2)
+
+	suite.Require().NoError(suite.store.Dependencies.DeleteTaskDependencies(ctx, []string{b}))
+	found, err = suite.store.Dependencies.FindDependencies(ctx, ids)
+	suite.Require().NoError(err)
+	suite.Empty(found, "the dependencies from and to the task are deleted")
+}
+
+// TestTaskLabels tests that the labels of a task are stored, replaced by updates and patches, and filtered on
+// with any or all semantics.
+func (suite *RepositoryContractSuite) TestTaskLabels() {
+	ctx := inDefaultOrganization()
+	tasks := suite.createTasks(
+		domain.Task{Title: "login page", Labels: []string{"bug", "frontend"}},
+		domain.Task{Title: "api errors", Labels: []string{"backend", "bug"}},
+		domain.Task{Title: "dark mode", Labels: []string{"frontend"}},
+		domain.Task{Title: "spring cleaning", Labels: []string{}},
+	)
+	found, err := suite.store.Tasks.FindTaskById(ctx, tasks[0].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal([]string{"bug", "frontend"}, found.Labels)
+
+	cases := []struct {
+		query    domain.TaskQuery
+		expected []string
+	}{
+		{domain.TaskQuery{Labels: []string{"bug"}}, []string{"login page", "api errors"}},
+		{domain.TaskQuery{Labels: []string{"bug", "frontend"}}, []string{"login page", "api errors", "dark mode"}},
+		{domain.TaskQuery{Labels: []string{"bug", "frontend"}, LabelMatch: domain.LabelMatchAny}, []string{"login page", "api errors", "dark mode"}},
+		{domain.TaskQuery{Labels: []string{"bug", "frontend"}, LabelMatch: domain.LabelMatchAll}, []string{"login page"}},
+		{domain.TaskQuery{Labels: []string{"frontend", "frontend"}, LabelMatch: domain.LabelMatchAll}, []string{"login page", "dark mode"}},
+		{domain.TaskQuery{Labels: []string{"customer-x"}}, []string{}},
+		{domain.TaskQuery{Labels: []string{"bug"}, TitlePrefix: "api"}, []string{"api errors"}},
+	}
+	for _, tc := range cases {
+		tc.query.Limit = 1
+		suite.Equal(tc.expected, suite.allPages(tc.query), "%+v", tc.query)
+	}
+
+	updated, err := suite.store.Tasks.UpdateTaskById(ctx, domain.Task{Title: "login page", AssigneeIDs: []string{}, Labels: []string{"frontend"}, Version: 1}, tasks[0].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal([]string{"frontend"}, updated.Labels)
+	patched, err := suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Labels: &[]string{"customer-x", "frontend"}, Version: 2}, tasks[0].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal([]string{"customer-x", "frontend"}, patched.Labels)
+	patched, err = suite.store.Tasks.PatchTaskById(ctx, domain.TaskPatch{Title: &updated.Title, Version: 3}, tasks[0].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal([]string{"customer-x", "frontend"}, patched.Labels, "a patch without labels keeps them")
+	suite.Equal([]string{"login page"}, suite.allPages(domain.TaskQuery{Labels: []string{"customer-x"}, Limit: 10}))
+
+	_, err = suite.store.Tasks.DeleteTask(ctx, tasks[2].ID.String(), 1, "admin", time.Now())
+	suite.Require().NoError(err)
+	suite.Equal([]string{"login page"}, suite.allPages(domain.TaskQuery{Labels: []string{"frontend"}, Limit: 10}))
+	suite.Equal([]string{"dark mode"}, suite.allPages(domain.TaskQuery{Labels: []string{"frontend"}, Deleted: true, Limit: 10}))
+}
+
+// TestLabels tests that the label catalog is listed by name and that labels are unique.
+func (suite *RepositoryContractSuite) TestLabels() {
+	ctx := inDefaultOrganization()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for _, name := range []string{"frontend", "bug"} {
+		_, err := suite.store.Labels.CreateLabel(ctx, domain.Label{Name: name, Color: "#d62728", CreatedAt: now})
+		suite.Require().NoError(err)
+	}
+	_, err := suite.store.Labels.CreateLabel(ctx, domain.Label{Name: "bug", Color: "#1f77b4", CreatedAt: now})
+	suite.ErrorIs(err, domain.ErrLabelExists)
+
+	labels, err := suite.store.Labels.FindLabels(ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(labels, 2)
+	suite.Equal("bug", labels[0].Name)
+	suite.Equal("#d62728", labels[0].Color)
+	suite.True(now.Equal(labels[0].CreatedAt))
+
+	updated, err := suite.store.Labels.UpdateLabel(ctx, domain.Label{Name: "bug", Color: "#1f77b4", Description: "something broken"})
+	suite.Require().NoError(err)
+	suite.Equal("#1f77b4", updated.Color)
+	suite.Equal("something broken", updated.Description)
+	suite.True(now.Equal(updated.CreatedAt), "the creation time is kept")
+	found, err := suite.store.Labels.FindLabelByName(ctx, "bug")
+	suite.Require().NoError(err)
+	suite.Equal(updated, found)
+
+	suite.Require().NoError(suite.store.Labels.DeleteLabel(ctx, "bug"))
+	_, err = suite.store.Labels.FindLabelByName(ctx, "bug")
+	suite.ErrorIs(err, domain.ErrLabelNotFound)
+	suite.ErrorIs(suite.store.Labels.DeleteLabel(ctx, "bug"), domain.ErrLabelNotFound)
+	_, err = suite.store.Labels.UpdateLabel(ctx, domain.Label{Name: "bug", Color: "#1f77b4"})
+	suite.ErrorIs(err, domain.ErrLabelNotFound)
+}
+
+// TestLabelOrganizations tests that every organization has its own label catalog,
+// which the other organizations can neither read nor change.
+func (suite *RepositoryContractSuite) TestLabelOrganizations() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	label, err := suite.store.Labels.CreateLabel(sales, domain.Label{Name: "urgent", Color: "#d62728", CreatedAt: now})
+	suite.Require().NoError(err)
+	suite.Equal("sales", label.OrgID)
+	_, err = suite.store.Labels.CreateLabel(sales, domain.Label{Name: "customer-x", Color: "#1f77b4", CreatedAt: now})
+	suite.Require().NoError(err)
+	_, err = suite.store.Labels.CreateLabel(legal, domain.Label{Name: "urgent", Color: "#2ca02c", CreatedAt: now, OrgID: "sales"})
+	suite.Require().NoError(err, "label names are unique within an organization only")
+
+	labels, err := suite.store.Labels.FindLabels(legal)
+	suite.Require().NoError(err)
+	suite.Require().Len(labels, 1)
+	suite.Equal("legal", labels[0].OrgID)
+	suite.Equal("#2ca02c", labels[0].Color)
+	labels, err = suite.store.Labels.FindLabels(sales)
+	suite.Require().NoError(err)
+	suite.Len(labels, 2)
+
+	_, err = suite.store.Labels.FindLabelByName(legal, "customer-x")
+	suite.ErrorIs(err, domain.ErrLabelNotFound)
+	_, err = suite.store.Labels.UpdateLabel(legal, domain.Label{Name: "customer-x", Color: "#000000"})
+	suite.ErrorIs(err, domain.ErrLabelNotFound)
+	suite.ErrorIs(suite.store.Labels.DeleteLabel(legal, "customer-x"), domain.ErrLabelNotFound)
+	suite.Require().NoError(suite.store.Labels.DeleteLabel(legal, "urgent"))
+	found, err := suite.store.Labels.FindLabelByName(sales, "urgent")
+	suite.Require().NoError(err, "deleting a label leaves the one of the same name of another organization")
+	suite.Equal(label, found)
+
+	_, err = suite.store.Labels.FindLabels(context.Background())
+	suite.ErrorIs(err, domain.ErrOrganizationRequired, "a context without an organization reads none")
+	_, err = suite.store.Labels.CreateLabel(context.Background(), domain.Label{Name: "orphan", Color: "#d62728", CreatedAt: now})
+	suite.ErrorIs(err, domain.ErrOrganizationRequired)
+}
+
+// TestProjects tests that the projects and their members are stored, that the projects of a user are listed
+// in creation order, that a user is a member of a project at most once, and that the tasks are filtered by project.
+func (suite *RepositoryContractSuite) TestProjects() {
+	ctx := inDefaultOrganization()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	website, err := suite.store.Projects.CreateProject(ctx, domain.Project{Name: "website", CreatedBy: "alice", CreatedAt: now})
+	suite.Require().NoError(err)
+	suite.NotEmpty(website.ID)
+	mobile, err := suite.store.Projects.CreateProject(ctx, domain.Project{Name: "mobile", CreatedBy: "bob", CreatedAt: now.Add(time.Second)})
+	suite.Require().NoError(err)
+
+	found, err := suite.store.Projects.FindProjectById(ctx, website.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(website, found)
+	_, err = suite.store.Projects.FindProjectById(ctx, domain.NewID().String())
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+
+	for _, member := range []domain.ProjectMember{
+		{ProjectID: mobile.ID.String(), UserID: "alice", Role: domain.ProjectRoleViewer, AddedAt: now},
+		{ProjectID: website.ID.String(), UserID: "carol", Role: domain.ProjectRoleEditor, AddedAt: now},
+		{ProjectID: website.ID.String(), UserID: "alice", Role: domain.ProjectRoleOwner, AddedAt: now},
+	} {
+		suite.Require().NoError(suite.store.Projects.AddMember(ctx, member))
+	}
+	err = suite.store.Projects.AddMember(ctx, domain.ProjectMember{ProjectID: website.ID.String(), UserID: "carol", Role: domain.ProjectRoleOwner, AddedAt: now})
+	suite.ErrorIs(err, domain.ErrMemberExists)
+
+	projects, err := suite.store.Projects.FindMemberProjects(ctx, "alice")
+	suite.Require().NoError(err)
+	suite.Equal([]domain.Project{website, mobile}, projects)
+	projects, err = suite.store.Projects.FindMemberProjects(ctx, "dave")
+	suite.Require().NoError(err)
+	suite.Empty(projects)
+
+	members, err := suite.store.Projects.FindMembers(ctx, website.ID.String())
+	suite.Require().NoError(err)
+	suite.Require().Len(members, 2)
+	suite.Equal("alice", members[0].UserID)
+	suite.Equal("carol", members[1].UserID)
+	suite.Equal(domain.ProjectRoleEditor, members[1].Role, "adding an existing member keeps its role")
+	suite.True(now.Equal(members[1].AddedAt))
+
+	suite.Require().NoError(suite.store.Projects.UpdateMember(ctx, domain.ProjectMember{ProjectID: website.ID.String(), UserID: "carol", Role: domain.ProjectRoleViewer}))
+	member, err := suite.store.Projects.FindMember(ctx, website.ID.String(), "carol")
+	suite.Require().NoError(err)
+	suite.Equal(domain.ProjectRoleViewer, member.Role)
+	suite.Require().NoError(suite.store.Projects.RemoveMember(ctx, website.ID.String(), "carol"))
+	_, err = suite.store.Projects.FindMember(ctx, website.ID.String(), "carol")
+	suite.ErrorIs(err, domain.ErrMemberNotFound)
+	suite.ErrorIs(suite.store.Projects.RemoveMember(ctx, website.ID.String(), "carol"), domain.ErrMemberNotFound)
+	suite.ErrorIs(suite.store.Projects.UpdateMember(ctx, domain.ProjectMember{ProjectID: website.ID.String(), UserID: "carol", Role: domain.ProjectRoleOwner}), domain.ErrMemberNotFound)
+
+	updated, err := suite.store.Projects.UpdateProject(ctx, domain.Project{ID: website.ID, Name: "web site", Description: "the public site"})
+	suite.Require().NoError(err)
+	suite.Equal("web site", updated.Name)
+	suite.Equal("the public site", updated.Description)
+	suite.Equal("alice", updated.CreatedBy, "the creator is kept")
+
+	suite.createTasks(
+		domain.Task{Title: "landing page", ProjectID: website.ID.String()},
+		domain.Task{Title: "push notifications", ProjectID: mobile.ID.String()},
+		domain.Task{Title: "expenses"},
+	)
+	tasks, err := suite.store.Tasks.FindTasks(ctx, domain.TaskQuery{ProjectID: website.ID.String(), Limit: 10})
+	suite.Require().NoError(err)
+	suite.Require().Len(tasks.Tasks, 1)
+	suite.Equal("landing page", tasks.Tasks[0].Title)
+	suite.Equal(website.ID.String(), tasks.Tasks[0].ProjectID)
+
+	suite.Require().NoError(suite.store.Projects.DeleteProject(ctx, website.ID.String()))
+	_, err = suite.store.Projects.FindProjectById(ctx, website.ID.String())
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+	_, err = suite.store.Projects.FindMember(ctx, website.ID.String(), "alice")
+	suite.ErrorIs(err, domain.ErrMemberNotFound, "the members are deleted with the project")
+	suite.ErrorIs(suite.store.Projects.DeleteProject(ctx, website.ID.String()), domain.ErrProjectNotFound)
+	_, err = suite.store.Projects.UpdateProject(ctx, domain.Project{ID: website.ID, Name: "website"})
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+}
+
+// TestConcurrentTaskUpdates tests that of several writers updating the same version of a task, exactly one succeeds.
+func (suite *RepositoryContractSuite) TestConcurrentTaskUpdates() {
+	created := suite.createTasks(domain.Task{Title: "write report"})[0]
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			title := fmt.Sprintf("title %d", i)
+			_, err := suite.store.Tasks.PatchTaskById(inDefaultOrganization(), domain.TaskPatch{Title: &title, Version: created.Version}, created.ID.String())
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+				return
+			}
+			assert.ErrorIs(suite.T(), err, domain.ErrVersionMismatch)
+		}(i)
+	}
+	wg.Wait()
+	suite.Equal(1, succeeded)
+
+	found, err := suite.store.Tasks.FindTaskById(inDefaultOrganization(), created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(created.Version+1, found.Version)
+}
+
+// TestTaskHistory tests that history entries are stored with their changes and read back
+// per task, oldest first, one page at a time.
+func (suite *RepositoryContractSuite) TestTaskHistory() {
+	ctx := inDefaultOrganization()
+	taskID, otherID := domain.NewID().String(), domain.NewID().String()
+	at := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i, action := range []string{domain.TaskActionCreated, domain.TaskActionUpdated, domain.TaskActionTransitioned, domain.TaskActionDeleted} {
+		entry, err := suite.store.TaskHistory.AppendTaskHistory(ctx, domain.TaskHistoryEntry{
+			TaskID:  taskID,
+			Action:  action,
+			ActorID: "admin",
+			At:      at.Add(time.Duration(i) * time.Minute),
+			Version: int64(i + 1),
+			Changes: []domain.FieldChange{
+				{Field: "status", Before: fmt.Sprint("S", i), After: fmt.Sprint("S", i+1)},
+				{Field: "assignee_ids", Before: nil, After: []string{"a", "b"}},
+			},
+		})
+		suite.Require().NoError(err)
+		suite.False(entry.ID.IsZero())
+	}
+	_, err := suite.store.TaskHistory.AppendTaskHistory(ctx, domain.TaskHistoryEntry{TaskID: otherID, Action: domain.TaskActionCreated, At: at})
+	suite.Require().NoError(err)
+
+	var entries []domain.TaskHistoryEntry
+	query := domain.TaskHistoryQuery{TaskID: taskID, Limit: 3}
+	for pages := 0; ; pages++ {
+		suite.Require().Less(pages, 5, "pagination does not terminate")
+		page, err := suite.store.TaskHistory.FindTaskHistory(ctx, query)
+		suite.Require().NoError(err)
+		entries = append(entries, page.Entries...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	suite.Require().Len(entries, 4)
+	for i, entry := range entries {
+		suite.Equal(int64(i+1), entry.Version)
+		suite.Equal(taskID, entry.TaskID)
+		suite.Equal("admin", entry.ActorID)
+		suite.True(at.Add(time.Duration(i) * time.Minute).Equal(entry.At))
+	}
+	suite.Equal(domain.TaskActionDeleted, entries[3].Action)
+	// backends decode the values with their own types, so compare the JSON the clients get
+	changes, err := json.Marshal(entries[1].Changes)
+	suite.Require().NoError(err)
+	suite.JSONEq(`[{"field":"status","before":"S1","after":"S2"},{"field":"assignee_ids","before":null,"after":["a","b"]}]`, string(changes))
+
+	page, err := suite.store.TaskHistory.FindTaskHistory(ctx, domain.TaskHistoryQuery{TaskID: domain.NewID().String(), Limit: 10})
+	suite.Require().NoError(err)
+	suite.Empty(page.Entries)
+	suite.Empty(page.NextCursor)
+}
+
+// TestFindAlltasks tests that every task is returned, in the order they were created.
+func (suite *RepositoryContractSuite) TestFindAlltasks() {
+	suite.createTasks(domain.Task{Title: "first"}, domain.Task{Title: "second"}, domain.Task{Title: "third"})
+
+	tasks, err := suite.store.Tasks.FindAlltasks(inDefaultOrganization())
+	suite.Require().NoError(err)
+	titles := []string{}
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	suite.Equal([]string{"first", "second", "third"}, titles)
+}
+
+// TestFindTasks_Sorting tests that paging through the tasks returns each one exactly once,
+// in the order of every sort key and direction, ties being broken by ID.
+func (suite *RepositoryContractSuite) TestFindTasks_Sorting() {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	suite.createTasks(
+		domain.Task{Title: "c", Status: "Open", DueDate: base.Add(2 * time.Hour)},
+		domain.Task{Title: "a", Status: "Done", DueDate: base},
+		domain.Task{Title: "e", Status: "Open", DueDate: base.Add(2 * time.Hour)},
+		domain.Task{Title: "b", Status: "Done", DueDate: base.Add(time.Hour)},
+		domain.Task{Title: "d", Status: "Blocked", DueDate: base.Add(3 * time.Hour)},
+	)
+
+	cases := map[string][]string{
+		"":          {"c", "a", "e", "b", "d"},
+		"-id":       {"d", "b", "e", "a", "c"},
+		"title":     {"a", "b", "c", "d", "e"},
+		"-title":    {"e", "d", "c", "b", "a"},
+		"due_date":  {"a", "b", "c", "e", "d"},
+		"-due_date": {"d", "e", "c", "b", "a"},
+		"status":    {"d", "a", "b", "c", "e"},
+		"-status":   {"e", "c", "b", "a", "d"},
+	}
+	for sort, expected := range cases {
+		for _, limit := range []int{1, 2, 5, 10} {
+			titles := suite.allPages(domain.TaskQuery{Sort: sort, Limit: limit})
+			suite.Equal(expected, titles, "sort %q with limit %d", sort, limit)
+		}
+	}
+}
+
+// TestFindTasks_Filters tests the status, due date range, title prefix and visibility filters.
+func (suite *RepositoryContractSuite) TestFindTasks_Filters() {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	suite.createTasks(
+		domain.Task{Title: "report draft", Status: "Open", DueDate: base, CreatedBy: "alice"},
+		domain.Task{Title: "report review", Status: "Done", DueDate: base.Add(24 * time.Hour), AssigneeIDs: []string{"alice", "bob"}},
+		domain.Task{Title: "budget", Status: "Open", DueDate: base.Add(48 * time.Hour), CreatedBy: "bob"},
+	)
+	from, to := base.Add(24*time.Hour), base.Add(48*time.Hour)
+
+	cases := []struct {
+		query    domain.TaskQuery
+		expected []string
+	}{
+		{domain.TaskQuery{Status: "Open"}, []string{"report draft", "budget"}},
+		{domain.TaskQuery{DueFrom: &from}, []string{"report review", "budget"}},
+		{domain.TaskQuery{DueTo: &from}, []string{"report draft", "report review"}},
+		{domain.TaskQuery{DueFrom: &from, DueTo: &to, Status: "Open"}, []string{"budget"}},
+		{domain.TaskQuery{TitlePrefix: "report"}, []string{"report draft", "report review"}},
+		{domain.TaskQuery{TitlePrefix: "report.*"}, []string{}},
+		{domain.TaskQuery{VisibleTo: "alice"}, []string{"report draft", "report review"}},
+		{domain.TaskQuery{VisibleTo: "bob"}, []string{"report review", "budget"}},
+		{domain.TaskQuery{VisibleTo: "carol"}, []string{}},
+	}
+	for _, tc := range cases {
+		tc.query.Limit = 10
+		suite.Equal(tc.expected, suite.allPages(tc.query), "%+v", tc.query)
+	}
+}
+
+// TestConcurrentTaskWrites tests that tasks created concurrently are all stored.
+func (suite *RepositoryContractSuite) TestConcurrentTaskWrites() {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := suite.store.Tasks.CreateTask(inDefaultOrganization(), domain.Task{Title: fmt.Sprintf("task %d", i)})
+			assert.NoError(suite.T(), err)
+		}(i)
+	}
+	wg.Wait()
+
+	tasks, err := suite.store.Tasks.FindAlltasks(inDefaultOrganization())
+	suite.Require().NoError(err)
+	suite.Len(tasks, 20)
+}
+
+// TestCreateNewUser tests that the first user becomes an admin, later users regular users,
+// and that usernames are unique.
+func (suite *RepositoryContractSuite) TestCreateNewUser() {
+	ctx := inDefaultOrganization()
+	first, err := suite.store.Users.CreateNewUser(ctx, &domain.User{Username: "alice", Password: "hash", Role: domain.RoleUser})
+	suite.Require().NoError(err)
+	suite.Equal(domain.RoleAdmin, first.Role)
+	suite.False(first.ID.IsZero())
+
+	second, err := suite.store.Users.CreateNewUser(ctx, &domain.User{Username: "bob", Password: "hash", Role: domain.RoleAdmin})
+	suite.Require().NoError(err)
+	suite.Equal(domain.RoleUser, second.Role)
+	suite.NotEqual(first.ID, second.ID)
+
+	_, err = suite.store.Users.CreateNewUser(ctx, &domain.User{Username: "alice", Password: "other"})
+	suite.ErrorIs(err, domain.ErrUsernameTaken)
+	suite.ErrorIs(err, domain.ErrConflict)
+}
+
+// TestConcurrentFirstUsers tests that of several users signing up concurrently to a new organization, exactly one becomes its admin.
+func (suite *RepositoryContractSuite) TestConcurrentFirstUsers() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	admins := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			user, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: fmt.Sprintf("user%d", i), Password: "hash"})
+			if !assert.NoError(suite.T(), err) {
+				return
+			}
+			if user.Role == domain.RoleAdmin {
+				mu.Lock()
+				admins++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	suite.Equal(1, admins)
+	count, err := suite.store.Users.CountUsersWithRole(sales, domain.RoleAdmin)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+}
+
+// TestCreateOrganization tests that the first user of an organization becomes its admin, that no user can
+// create an organization that already has users, and that of several concurrent creations exactly one succeeds.
+func (suite *RepositoryContractSuite) TestCreateOrganization() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	alice, err := suite.store.Users.CreateOrganization(sales, &domain.User{Username: "alice", Password: "hash", Role: domain.RoleUser})
+	suite.Require().NoError(err)
+	suite.Equal(domain.RoleAdmin, alice.Role)
+	suite.Equal("sales", alice.OrgID)
+	_, err = suite.store.Users.CreateOrganization(sales, &domain.User{Username: "bob", Password: "hash"})
+	suite.ErrorIs(err, domain.ErrOrganizationExists)
+	_, err = suite.store.Users.CreateOrganization(sales, &domain.User{Username: "alice", Password: "other"})
+	suite.ErrorIs(err, domain.ErrOrganizationExists)
+	bob, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "bob", Password: "hash"})
+	suite.Require().NoError(err, "the users of an existing organization are added to it")
+	suite.Equal(domain.RoleUser, bob.Role)
+
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := suite.store.Users.CreateOrganization(legal, &domain.User{Username: fmt.Sprintf("user%d", i), Password: "hash"})
+			if errors.Is(err, domain.ErrOrganizationExists) {
+				return
+			}
+			if assert.NoError(suite.T(), err) {
+				mu.Lock()
+				created++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	suite.Equal(1, created)
+	page, err := suite.store.Users.FindUsers(legal, domain.UserQuery{Limit: 20})
+	suite.Require().NoError(err)
+	suite.Len(page.Users, 1)
+}
+
+// TestFindUser tests looking users up by username and ID.
+func (suite *RepositoryContractSuite) TestFindUser() {
+	ctx := inDefaultOrganization()
+	created, err := suite.store.Users.CreateNewUser(ctx, &domain.User{Username: "alice", Password: "hash"})
+	suite.Require().NoError(err)
+
+	byName, err := suite.store.Users.FindUser(ctx, "alice")
+	suite.Require().NoError(err)
+	suite.Equal(created.ID, byName.ID)
+	suite.Equal("hash", byName.Password)
+
+	byID, err := suite.store.Users.FindUserById(ctx, created.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("alice", byID.Username)
+
+	_, err = suite.store.Users.FindUser(ctx, "bob")
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+	_, err = suite.store.Users.FindUserById(ctx, domain.NewID().String())
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+	_, err = suite.store.Users.FindUserById(ctx, "not-an-id")
+	suite.ErrorIs(err, domain.ErrInvalidID)
+}
+
+// TestPromoteUser tests that promoting a user makes it an admin.
+func (suite *RepositoryContractSuite) TestPromoteUser() {
+	ctx := inDefaultOrganization()
+	_, err := suite.store.Users.CreateNewUser(ctx, &domain.User{Username: "alice", Password: "hash"})
+	suite.Require().NoError(err)
+	bob, err := suite.store.Users.CreateNewUser(ctx, &domain.User{Username: "bob", Password: "hash"})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.store.Users.PromoteUser(ctx, bob.ID.String()))
+	promoted, err := suite.store.Users.FindUser(ctx, "bob")
+	suite.Require().NoError(err)
+	suite.Equal(domain.RoleAdmin, promoted.Role)
+
+	suite.ErrorIs(suite.store.Users.PromoteUser(ctx, domain.NewID().String()), domain.ErrUserNotFound)
+	suite.ErrorIs(suite.store.Users.PromoteUser(ctx, "not-an-id"), domain.ErrInvalidID)
+}
+
+// TestUserOrganizations tests that every organization has its own first admin and usernames,
+// and that the users of an organization cannot be read or promoted from another one.
+func (suite *RepositoryContractSuite) TestUserOrganizations() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	alice, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "alice", Password: "hash"})
+	suite.Require().NoError(err)
+	suite.Equal("sales", alice.OrgID)
+	suite.Equal(domain.RoleAdmin, alice.Role)
+	bob, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "bob", Password: "hash"})
+	suite.Require().NoError(err)
+	suite.Equal(domain.RoleUser, bob.Role)
+
+	otherAlice, err := suite.store.Users.CreateNewUser(legal, &domain.User{Username: "alice", Password: "other"})
+	suite.Require().NoError(err, "usernames are unique within an organization only")
+	suite.Equal("legal", otherAlice.OrgID)
+	suite.Equal(domain.RoleAdmin, otherAlice.Role, "the first user of every organization is its admin")
+	_, err = suite.store.Users.CreateNewUser(legal, &domain.User{Username: "alice", Password: "hash"})
+	suite.ErrorIs(err, domain.ErrUsernameTaken)
+
+	found, err := suite.store.Users.FindUser(legal, "alice")
+	suite.Require().NoError(err)
+	suite.Equal(otherAlice.ID, found.ID)
+	_, err = suite.store.Users.FindUser(legal, "bob")
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+	_, err = suite.store.Users.FindUser(inDefaultOrganization(), "alice")
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+	_, err = suite.store.Users.FindUser(context.Background(), "alice")
+	suite.ErrorIs(err, domain.ErrOrganizationRequired, "a context without an organization reads none")
+
+	_, err = suite.store.Users.FindUserById(legal, bob.ID.String())
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+	suite.ErrorIs(suite.store.Users.PromoteUser(legal, bob.ID.String()), domain.ErrUserNotFound)
+	found, err = suite.store.Users.FindUserById(sales, bob.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(domain.RoleUser, found.Role)
+	_, err = suite.store.Users.FindUserById(context.Background(), bob.ID.String())
+	suite.ErrorIs(err, domain.ErrOrganizationRequired)
+	found, err = suite.store.Users.FindUserById(domain.ContextWithoutTenant(context.Background()), bob.ID.String())
+	suite.Require().NoError(err, "the service itself reads every organization")
+	suite.Equal("sales", found.OrgID)
+	_, err = suite.store.Users.CreateNewUser(context.Background(), &domain.User{Username: "carol", Password: "hash"})
+	suite.ErrorIs(err, domain.ErrOrganizationRequired)
+}
+
+// TestRoles tests that the roles of an organization are listed by name, that their names are unique
+// within an organization and that they can be neither read nor changed from another one.
+func (suite *RepositoryContractSuite) TestRoles() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for _, name := range []string{"TRIAGE", "AUDITOR"} {
+		role, err := suite.store.Roles.CreateRole(sales, domain.Role{Name: name, Permissions: []string{domain.PermissionTaskReadAll}, CreatedAt: now})
+		suite.Require().NoError(err)
+		suite.Equal("sales", role.OrgID)
+	}
+	_, err := suite.store.Roles.CreateRole(sales, domain.Role{Name: "TRIAGE", Permissions: []string{}, CreatedAt: now})
+	suite.ErrorIs(err, domain.ErrRoleExists)
+	_, err = suite.store.Roles.CreateRole(legal, domain.Role{Name: "TRIAGE", Permissions: []string{}, CreatedAt: now})
+	suite.Require().NoError(err, "role names are unique within an organization only")
+
+	roles, err := suite.store.Roles.FindRoles(sales)
+	suite.Require().NoError(err)
+	suite.Require().Len(roles, 2)
+	suite.Equal("AUDITOR", roles[0].Name)
+	suite.Equal("sales", roles[0].OrgID)
+	suite.Equal([]string{domain.PermissionTaskReadAll}, roles[0].Permissions)
+	suite.True(now.Equal(roles[0].CreatedAt))
+
+	updated, err := suite.store.Roles.UpdateRole(sales, domain.Role{Name: "TRIAGE", Description: "sorts the backlog", Permissions: []string{domain.PermissionTaskReadAll, domain.PermissionTaskUpdate}})
+	suite.Require().NoError(err)
+	suite.Equal("sorts the backlog", updated.Description)
+	suite.Equal([]string{domain.PermissionTaskReadAll, domain.PermissionTaskUpdate}, updated.Permissions)
+	suite.True(now.Equal(updated.CreatedAt), "the creation time does not change")
+	found, err := suite.store.Roles.FindRoleByName(legal, "TRIAGE")
+	suite.Require().NoError(err)
+	suite.Equal([]string{}, found.Permissions, "the role of another organization is left alone")
+	_, err = suite.store.Roles.UpdateRole(legal, domain.Role{Name: "AUDITOR", Permissions: []string{}})
+	suite.ErrorIs(err, domain.ErrRoleNotFound)
+
+	suite.ErrorIs(suite.store.Roles.DeleteRole(legal, "AUDITOR"), domain.ErrRoleNotFound)
+	suite.Require().NoError(suite.store.Roles.DeleteRole(sales, "AUDITOR"))
+	_, err = suite.store.Roles.FindRoleByName(sales, "AUDITOR")
+	suite.ErrorIs(err, domain.ErrRoleNotFound)
+	roles, err = suite.store.Roles.FindRoles(inDefaultOrganization())
+	suite.Require().NoError(err)
+	suite.Empty(roles)
+	_, err = suite.store.Roles.FindRoles(context.Background())
+	suite.ErrorIs(err, domain.ErrOrganizationRequired, "a context without an organization lists no roles")
+}
+
+// TestSetUserRole tests that users are given roles within their organization, that the last admin
+// of an organization cannot be given another role and that the users having a role are counted.
+func (suite *RepositoryContractSuite) TestSetUserRole() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	alice, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "alice", Password: "hash"})
+	suite.Require().NoError(err)
+	bob, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "bob", Password: "hash"})
+	suite.Require().NoError(err)
+	_, err = suite.store.Users.CreateNewUser(legal, &domain.User{Username: "carol", Password: "hash"})
+	suite.Require().NoError(err)
+
+	suite.ErrorIs(suite.store.Users.SetUserRole(sales, alice.ID.String(), domain.RoleUser), domain.ErrLastAdmin)
+	suite.Require().NoError(suite.store.Users.SetUserRole(sales, alice.ID.String(), domain.RoleAdmin), "an admin can stay one")
+	suite.Require().NoError(suite.store.Users.SetUserRole(sales, bob.ID.String(), "TRIAGE"))
+	found, err := suite.store.Users.FindUserById(sales, bob.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal("TRIAGE", found.Role)
+
+	count, err := suite.store.Users.CountUsersWithRole(sales, "TRIAGE")
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+	count, err = suite.store.Users.CountUsersWithRole(legal, domain.RoleAdmin)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), count)
+
+	suite.Require().NoError(suite.store.Users.SetUserRole(sales, bob.ID.String(), domain.RoleAdmin))
+	suite.Require().NoError(suite.store.Users.SetUserRole(sales, alice.ID.String(), domain.RoleUser), "another admin is left")
+	suite.ErrorIs(suite.store.Users.SetUserRole(sales, bob.ID.String(), domain.RoleUser), domain.ErrLastAdmin)
+
+	suite.ErrorIs(suite.store.Users.SetUserRole(legal, bob.ID.String(), domain.RoleUser), domain.ErrUserNotFound)
+	suite.ErrorIs(suite.store.Users.SetUserRole(sales, domain.NewID().String(), domain.RoleUser), domain.ErrUserNotFound)
+	suite.ErrorIs(suite.store.Users.SetUserRole(sales, "not-an-id", domain.RoleUser), domain.ErrInvalidID)
+}
+
+// TestFindUsers tests that the users of an organization are paged through in the order they signed up,
+// filtered by username prefix and role.
+func (suite *RepositoryContractSuite) TestFindUsers() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	for _, username := range []string{"alice", "bob", "alex", "Al", "alan"} {
+		_, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: username, Password: "hash"})
+		suite.Require().NoError(err)
+	}
+	_, err := suite.store.Users.CreateNewUser(legal, &domain.User{Username: "albert", Password: "hash"})
+	suite.Require().NoError(err)
+
+	usernames := []string{}
+	query := domain.UserQuery{Search: "al", Limit: 2}
+	for pages := 0; ; pages++ {
+		suite.Require().Less(pages, 5, "pagination does not terminate")
+		page, err := suite.store.Users.FindUsers(sales, query)
+		suite.Require().NoError(err)
+		for _, user := range page.Users {
+			usernames = append(usernames, user.Username)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	suite.Equal([]string{"alice", "alex", "alan"}, usernames)
+
+	page, err := suite.store.Users.FindUsers(sales, domain.UserQuery{Role: domain.RoleAdmin, Limit: 10})
+	suite.Require().NoError(err)
+	suite.Require().Len(page.Users, 1)
+	suite.Equal("alice", page.Users[0].Username)
+	suite.Empty(page.NextCursor)
+	page, err = suite.store.Users.FindUsers(legal, domain.UserQuery{Limit: 10})
+	suite.Require().NoError(err)
+	suite.Len(page.Users, 1)
+}
+
+// TestUserDeactivation tests that users are deactivated and activated again, and deleted,
+// and that the users of another organization cannot be.
+func (suite *RepositoryContractSuite) TestUserDeactivation() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	_, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "alice", Password: "hash"})
+	suite.Require().NoError(err)
+	bob, err := suite.store.Users.CreateNewUser(sales, &domain.User{Username: "bob", Password: "hash"})
+	suite.Require().NoError(err)
+
+	deactivatedAt := time.Now().UTC().Truncate(time.Millisecond)
+	suite.Require().NoError(suite.store.Users.SetUserDeactivated(sales, bob.ID.String(), &deactivatedAt))
+	found, err := suite.store.Users.FindUser(sales, "bob")
+	suite.Require().NoError(err)
+	suite.Require().NotNil(found.DeactivatedAt)
+	suite.WithinDuration(deactivatedAt, *found.DeactivatedAt, time.Millisecond)
+	suite.Require().NoError(suite.store.Users.SetUserDeactivated(sales, bob.ID.String(), nil))
+	found, err = suite.store.Users.FindUserById(sales, bob.ID.String())
+	suite.Require().NoError(err)
+	suite.Nil(found.DeactivatedAt)
+
+	suite.ErrorIs(suite.store.Users.SetUserDeactivated(legal, bob.ID.String(), &deactivatedAt), domain.ErrUserNotFound)
+	suite.ErrorIs(suite.store.Users.DeleteUser(legal, bob.ID.String()), domain.ErrUserNotFound)
+	suite.Require().NoError(suite.store.Users.DeleteUser(sales, bob.ID.String()))
+	suite.ErrorIs(suite.store.Users.DeleteUser(sales, bob.ID.String()), domain.ErrUserNotFound)
+	_, err = suite.store.Users.FindUser(sales, "bob")
+	suite.ErrorIs(err, domain.ErrUserNotFound)
+	_, err = suite.store.Users.CreateNewUser(sales, &domain.User{Username: "bob", Password: "hash"})
+	suite.NoError(err, "the username of a deleted user is free again")
+}
+
+// TestReassignTasks tests that a user is replaced as the creator and the assignee of the tasks of its organization,
+// including the ones in the trash, keeping the order of the assignees and the new assignee once.
+func (suite *RepositoryContractSuite) TestReassignTasks() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	from, to, other := domain.NewID().String(), domain.NewID().String(), domain.NewID().String()
+	due := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := make([]domain.Task, 0, 5)
+	for _, task := range []domain.Task{
+		{Title: "created", CreatedBy: from, AssigneeIDs: []string{other}},
+		{Title: "assigned", CreatedBy: other, AssigneeIDs: []string{other, from}},
+		{Title: "both assigned", CreatedBy: other, AssigneeIDs: []string{from, to, other}},
+		{Title: "untouched", CreatedBy: other, AssigneeIDs: []string{}},
+		{Title: "deleted", CreatedBy: from, AssigneeIDs: []string{from}},
+	} {
+		task.Description, task.Status, task.DueDate = "d", "Pending", due
+		task, err := suite.store.Tasks.CreateTask(sales, task)
+		suite.Require().NoError(err)
+		created = append(created, task)
+	}
+	_, err := suite.store.Tasks.DeleteTask(sales, created[4].ID.String(), 1, other, time.Now())
+	suite.Require().NoError(err)
+	elsewhere, err := suite.store.Tasks.CreateTask(legal, domain.Task{Title: "elsewhere", Description: "d", Status: "Pending", DueDate: due, CreatedBy: from, AssigneeIDs: []string{from}})
+	suite.Require().NoError(err)
+
+	changed, err := suite.store.Tasks.ReassignTasks(sales, from, to)
+	suite.Require().NoError(err)
+	suite.Equal(int64(4), changed)
+
+	expected := []struct {
+		createdBy string
+		assignees []string
+		version   int64
+	}{
+		{to, []string{other}, 2},
+		{other, []string{other, to}, 2},
+		{other, []string{to, other}, 2},
+		{other, []string{}, 1},
+	}
+	for i, want := range expected {
+		task, err := suite.store.Tasks.FindTaskById(sales, created[i].ID.String())
+		suite.Require().NoError(err)
+		suite.Equal(want.createdBy, task.CreatedBy, task.Title)
+		suite.Equal(want.assignees, task.AssigneeIDs, task.Title)
+		suite.Equal(want.version, task.Version, task.Title)
+	}
+	restored, err := suite.store.Tasks.RestoreTask(sales, created[4].ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(to, restored.CreatedBy)
+	suite.Equal([]string{to}, restored.AssigneeIDs)
+
+	task, err := suite.store.Tasks.FindTaskById(legal, elsewhere.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(from, task.CreatedBy, "the tasks of another organization are left alone")
+}
+
+// TestTaskOrganizations tests that the tasks of an organization can neither be read nor changed from another one.
+func (suite *RepositoryContractSuite) TestTaskOrganizations() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	task, err := suite.store.Tasks.CreateTask(sales, domain.Task{Title: "forecast", Status: "TODO", CreatedBy: "alice", AssigneeIDs: []string{}, Labels: []string{}})
+	suite.Require().NoError(err)
+	suite.Equal("sales", task.OrgID)
+	_, err = suite.store.Tasks.CreateTask(legal, domain.Task{Title: "contract", Status: "TODO", CreatedBy: "alice", AssigneeIDs: []string{}, Labels: []string{}, OrgID: "sales"})
+	suite.Require().NoError(err)
+	id := task.ID.String()
+
+	found, err := suite.store.Tasks.FindTaskById(sales, id)
+	suite.Require().NoError(err)
+	suite.Equal(task.OrgID, found.OrgID)
+	_, err = suite.store.Tasks.FindTaskById(legal, id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	for ctx, titles := range map[context.Context][]string{sales: {"forecast"}, legal: {"contract"}} {
+		page, err := suite.store.Tasks.FindTasks(ctx, domain.TaskQuery{Sort: domain.TaskSortTitle, Limit: 10})
+		suite.Require().NoError(err)
+		suite.Require().Len(page.Tasks, len(titles))
+		suite.Equal(titles[0], page.Tasks[0].Title)
+		all, err := suite.store.Tasks.FindAlltasks(ctx)
+		suite.Require().NoError(err)
+		suite.Len(all, len(titles))
+	}
+	all, err := suite.store.Tasks.FindAlltasks(domain.ContextWithoutTenant(context.Background()))
+	suite.Require().NoError(err)
+	suite.Len(all, 2, "the service itself reads every organization")
+	_, err = suite.store.Tasks.FindAlltasks(context.Background())
+	suite.ErrorIs(err, domain.ErrOrganizationRequired, "a context without an organization reads none")
+	_, err = suite.store.Tasks.FindTaskById(context.Background(), id)
+	suite.ErrorIs(err, domain.ErrOrganizationRequired)
+	_, err = suite.store.Tasks.CreateTask(context.Background(), domain.Task{Title: "orphan", Status: "TODO", AssigneeIDs: []string{}, Labels: []string{}})
+	suite.ErrorIs(err, domain.ErrOrganizationRequired)
+
+	title := "renamed"
+	_, err = suite.store.Tasks.PatchTaskById(legal, domain.TaskPatch{Version: task.Version, Title: &title}, id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	task.Title = title
+	_, err = suite.store.Tasks.UpdateTaskById(legal, task, id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	_, err = suite.store.Tasks.DeleteTask(legal, id, task.Version, "mallory", time.Now())
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	_, err = suite.store.Tasks.DeleteTask(sales, id, task.Version, "alice", time.Now())
+	suite.Require().NoError(err)
+	deleted, err := suite.store.Tasks.FindDeletedTaskById(sales, id)
+	suite.Require().NoError(err)
+	suite.Equal("sales", deleted.OrgID)
+	suite.NotNil(deleted.DeletedAt)
+	_, err = suite.store.Tasks.FindDeletedTaskById(legal, id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	_, err = suite.store.Tasks.RestoreTask(legal, id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	purged, err := suite.store.Tasks.StartPurge(legal, time.Now().Add(time.Hour))
+	suite.Require().NoError(err)
+	suite.Empty(purged)
+	suite.Require().NoError(suite.store.Tasks.PurgeTasks(legal, []string{id}))
+	_, err = suite.store.Tasks.RestoreTask(sales, id)
+	suite.Require().NoError(err)
+}
+
+// TestProjectOrganizations tests that the projects of an organization cannot be found from another one.
+func (suite *RepositoryContractSuite) TestProjectOrganizations() {
+	sales := domain.ContextWithOrganization(context.Background(), "sales")
+	legal := domain.ContextWithOrganization(context.Background(), "legal")
+	project, err := suite.store.Projects.CreateProject(sales, domain.Project{Name: "website", CreatedBy: "alice", CreatedAt: time.Now().UTC().Truncate(time.Millisecond)})
+	suite.Require().NoError(err)
+	suite.Equal("sales", project.OrgID)
+
+	found, err := suite.store.Projects.FindProjectById(sales, project.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(project, found)
+	_, err = suite.store.Projects.FindProjectById(legal, project.ID.String())
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+}
+
+// TestRefreshTokens tests that a refresh token is used once and that revocation applies to its family only.
+func (suite *RepositoryContractSuite) TestRefreshTokens() {
+	ctx := inDefaultOrganization()
+	for _, family := range []string{"revoked", "active"} {
+		_, err := suite.store.RefreshTokens.CreateRefreshToken(ctx, domain.RefreshToken{
+			UserID:    "user-1",
+			FamilyID:  family,
+			TokenHash: "hash-" + family,
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		suite.Require().NoError(err)
+	}
+
+	token, err := suite.store.RefreshTokens.FindRefreshToken(ctx, "hash-active")
+	suite.Require().NoError(err)
+	suite.Equal("active", token.FamilyID)
+	suite.Nil(token.UsedAt)
+	_, err = suite.store.RefreshTokens.FindRefreshToken(ctx, "hash-unknown")
+	suite.ErrorIs(err, domain.ErrRefreshTokenNotFound)
+
+	suite.Require().NoError(suite.store.RefreshTokens.MarkRefreshTokenUsed(ctx, token.ID.String(), time.Now()))
+	suite.ErrorIs(suite.store.RefreshTokens.MarkRefreshTokenUsed(ctx, token.ID.String(), time.Now()), domain.ErrRefreshTokenReused)
+	token, err = suite.store.RefreshTokens.FindRefreshToken(ctx, "hash-active")
+	suite.Require().NoError(err)
+	suite.NotNil(token.UsedAt)
+
+	suite.Require().NoError(suite.store.RefreshTokens.RevokeTokenFamily(ctx, "revoked", time.Now()))
+	revoked, err := suite.store.RefreshTokens.IsTokenFamilyRevoked(ctx, "revoked")
+	suite.Require().NoError(err)
+	suite.True(revoked)
+	revoked, err = suite.store.RefreshTokens.IsTokenFamilyRevoked(ctx, "active")
+	suite.Require().NoError(err)
+	suite.False(revoked)
+
+	_, err = suite.store.RefreshTokens.CreateRefreshToken(ctx, domain.RefreshToken{
+		UserID:    "user-2",
+		FamilyID:  "other-user",
+		TokenHash: "hash-other-user",
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.store.RefreshTokens.RevokeUserTokens(ctx, "user-1", time.Now()))
+	revoked, err = suite.store.RefreshTokens.IsTokenFamilyRevoked(ctx, "active")
+	suite.Require().NoError(err)
+	suite.True(revoked)
+	revoked, err = suite.store.RefreshTokens.IsTokenFamilyRevoked(ctx, "other-user")
+	suite.Require().NoError(err)
+	suite.False(revoked)
+}
+
+// TestRepositoryContract runs the contract suite against every available backend.
+func TestRepositoryContract(t *testing.T) {
+	for name, newStore := range contractStores(t) {
+		t.Run(name, func(t *testing.T) {
+			suite.Run(t, &RepositoryContractSuite{newStore: newStore})
+		})
+	}
+}
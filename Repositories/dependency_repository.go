@@ -0,0 +1,100 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// dependencyRepository stores the dependencies between tasks in a MongoDB collection,
+// with a unique index on the two tasks created by MigrateMongo.
+type dependencyRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.DependencyRepository = &dependencyRepository{}
+
+// NewDependencyRepository creates a new instance of the DependencyRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewDependencyRepository(db mongo.Database, collection string) domain.DependencyRepository {
+	return &dependencyRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// AddDependency stores a new dependency.
+// It returns domain.ErrDependencyExists if the task already depends on the other one.
+func (dr *dependencyRepository) AddDependency(ctx context.Context, dependency domain.TaskDependency) error {
+	collection := dr.database.Collection(dr.collection)
+	filter := bson.M{"task_id": dependency.TaskID, "depends_on_id": dependency.DependsOnID}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": dependency}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrDependencyExists
+	}
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return domain.ErrDependencyExists
+	}
+	return nil
+}
+
+// RemoveDependency deletes the dependency of a task on another one.
+// It returns domain.ErrDependencyNotFound if the task does not depend on the other one.
+func (dr *dependencyRepository) RemoveDependency(ctx context.Context, taskId string, dependsOnId string) error {
+	collection := dr.database.Collection(dr.collection)
+	result, err := collection.DeleteOne(ctx, bson.M{"task_id": taskId, "depends_on_id": dependsOnId})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// FindDependencies returns the dependencies of the given tasks on other tasks.
+func (dr *dependencyRepository) FindDependencies(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+	return dr.find(ctx, bson.M{"task_id": bson.M{"$in": taskIds}})
+}
+
+// FindDependents returns the dependencies of other tasks on the given tasks.
+func (dr *dependencyRepository) FindDependents(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+	return dr.find(ctx, bson.M{"depends_on_id": bson.M{"$in": taskIds}})
+}
+
+// find returns the dependencies matching the filter, ordered by task, then by the task depended on.
+func (dr *dependencyRepository) find(ctx context.Context, filter bson.M) ([]domain.TaskDependency, error) {
+	collection := dr.database.Collection(dr.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "task_id", Value: 1}, {Key: "depends_on_id", Value: 1}})
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	dependencies := []domain.TaskDependency{}
+	if err := cursor.All(ctx, &dependencies); err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// DeleteTaskDependencies deletes every dependency from or to the given tasks.
+func (dr *dependencyRepository) DeleteTaskDependencies(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	collection := dr.database.Collection(dr.collection)
+	_, err := collection.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"task_id": bson.M{"$in": taskIds}},
+		bson.M{"depends_on_id": bson.M{"$in": taskIds}},
+	}})
+	return err
+}
@@ -0,0 +1,101 @@
+package Repositories
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// gridFSBlobStore is a BlobStore keeping the blobs in a GridFS bucket of a MongoDB database.
+// The key of a blob is the ID of its GridFS file.
+type gridFSBlobStore struct {
+	database mongo.Database
+	bucket   string
+}
+
+var _ domain.BlobStore = &gridFSBlobStore{}
+
+// NewGridFSBlobStore creates a BlobStore on the GridFS bucket of the given name.
+func NewGridFSBlobStore(db mongo.Database, bucket string) domain.BlobStore {
+	return &gridFSBlobStore{
+		database: db,
+		bucket:   bucket,
+	}
+}
+
+// open creates a handle on the bucket honouring the deadline of ctx.
+// Buckets keep per-operation buffers and deadlines, so every operation gets its own.
+func (bs *gridFSBlobStore) open(ctx context.Context) (*gridfs.Bucket, error) {
+	bucket, err := gridfs.NewBucket(&bs.database, options.GridFSBucket().SetName(bs.bucket))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetWriteDeadline(deadline)
+	}
+	return bucket, nil
+}
+
+// Put uploads the blob as a GridFS file. A failed upload is aborted, which removes the chunks already written.
+func (bs *gridFSBlobStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	bucket, err := bs.open(ctx)
+	if err != nil {
+		return 0, err
+	}
+	stream, err := bucket.OpenUploadStreamWithID(key, key)
+	if err != nil {
+		return 0, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetWriteDeadline(deadline)
+	}
+	size, err := io.Copy(stream, contextReader{ctx: ctx, r: r})
+	if err != nil {
+		stream.Abort()
+		return 0, err
+	}
+	if err := stream.Close(); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// Get opens a download stream on the GridFS file of the blob.
+// The stream has no deadline: it is read at the pace of the client downloading it.
+// It returns domain.ErrBlobNotFound if there is no such file.
+func (bs *gridFSBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	bucket, err := bs.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetReadDeadline(deadline)
+	}
+	stream, err := bucket.OpenDownloadStream(key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// Delete removes the GridFS file of the blob and its chunks, if there is one.
+func (bs *gridFSBlobStore) Delete(ctx context.Context, key string) error {
+	bucket, err := bs.open(ctx)
+	if err != nil {
+		return err
+	}
+	err = bucket.DeleteContext(ctx, key)
+	if err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// labelRepository stores the label catalogs in a MongoDB collection, keyed by organization and label name.
+type labelRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.LabelRepository = &labelRepository{}
+
+// NewLabelRepository creates a new instance of the LabelRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewLabelRepository(db mongo.Database, collection string) domain.LabelRepository {
+	return &labelRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// labelKey is the _id of the document of a label, so that label names are unique within an organization.
+func labelKey(orgId string, name string) bson.D {
+	return bson.D{{Key: "org_id", Value: orgId}, {Key: "name", Value: name}}
+}
+
+// CreateLabel stores a new label in the organization of ctx, or else in its own OrgID, and returns it.
+// It returns domain.ErrLabelExists if a label of the organization already has this name.
+func (lr *labelRepository) CreateLabel(ctx context.Context, label domain.Label) (domain.Label, error) {
+	collection := lr.database.Collection(lr.collection)
+	org, err := tenantOf(ctx, label.OrgID)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	label.OrgID = org
+	_, err = collection.InsertOne(ctx, bson.M{
+		"_id":         labelKey(label.OrgID, label.Name),
+		"name":        label.Name,
+		"org_id":      label.OrgID,
+		"color":       label.Color,
+		"description": label.Description,
+		"created_at":  label.CreatedAt,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.Label{}, domain.ErrLabelExists
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return label, nil
+}
+
+// FindLabels returns every label of the organization of ctx, ordered by name.
+func (lr *labelRepository) FindLabels(ctx context.Context) ([]domain.Label, error) {
+	collection := lr.database.Collection(lr.collection)
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := collection.Find(ctx, bson.M{"org_id": org}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	labels := []domain.Label{}
+	if err := cursor.All(ctx, &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// FindLabelByName retrieves a label of the organization of ctx by its name.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *labelRepository) FindLabelByName(ctx context.Context, name string) (domain.Label, error) {
+	collection := lr.database.Collection(lr.collection)
+	var label domain.Label
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Label{}, err
+	}
+	err = collection.FindOne(ctx, bson.M{"_id": labelKey(org, name)}).Decode(&label)
+	if err == mongo.ErrNoDocuments {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return label, nil
+}
+
+// UpdateLabel replaces the colour and description of a label of the organization of ctx and returns it.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *labelRepository) UpdateLabel(ctx context.Context, label domain.Label) (domain.Label, error) {
+	collection := lr.database.Collection(lr.collection)
+	update := bson.M{"$set": bson.M{"color": label.Color, "description": label.Description}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated domain.Label
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Label{}, err
+	}
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": labelKey(org, label.Name)}, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return updated, nil
+}
+
+// DeleteLabel deletes a label of the organization of ctx.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *labelRepository) DeleteLabel(ctx context.Context, name string) error {
+	collection := lr.database.Collection(lr.collection)
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": labelKey(org, name)})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrLabelNotFound
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// localBlobStore is a BlobStore keeping every blob in a file of a directory of the local filesystem.
+type localBlobStore struct {
+	dir string
+}
+
+var _ domain.BlobStore = &localBlobStore{}
+
+// NewLocalBlobStore creates a BlobStore writing its blobs to dir, which is created if it does not exist.
+func NewLocalBlobStore(dir string) (domain.BlobStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &localBlobStore{dir: dir}, nil
+}
+
+// path returns the file of a blob. Keys cannot name a file outside of the directory.
+func (bs *localBlobStore) path(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, `/\`) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(bs.dir, key), nil
+}
+
+// Put writes the blob to a temporary file first and renames it once complete,
+// so a failed or cancelled upload never leaves a partial blob behind.
+func (bs *localBlobStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	path, err := bs.path(key)
+	if err != nil {
+		return 0, err
+	}
+	file, err := os.CreateTemp(bs.dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	size, err := io.Copy(file, contextReader{ctx: ctx, r: r})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// Get opens the file of the blob.
+// It returns domain.ErrBlobNotFound if there is none.
+func (bs *localBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := bs.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete removes the file of the blob, if there is one.
+func (bs *localBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := bs.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// contextReader stops reading from r once ctx is done, so a cancelled request stops copying its upload.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (cr contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
@@ -0,0 +1,83 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sync"
+)
+
+// memoryAttachmentRepository is an AttachmentRepository keeping the attachments in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryAttachmentRepository struct {
+	mu          sync.RWMutex
+	attachments map[string][]domain.Attachment
+}
+
+var _ domain.AttachmentRepository = &memoryAttachmentRepository{}
+
+// NewMemoryAttachmentRepository creates an empty in-memory AttachmentRepository.
+func NewMemoryAttachmentRepository() domain.AttachmentRepository {
+	return &memoryAttachmentRepository{attachments: make(map[string][]domain.Attachment)}
+}
+
+// CreateAttachment stores a new attachment with the ID it was given.
+func (ar *memoryAttachmentRepository) CreateAttachment(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error) {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	ar.attachments[attachment.TaskID] = append(ar.attachments[attachment.TaskID], attachment)
+	return attachment, nil
+}
+
+// FindAttachmentById retrieves an attachment by its ID.
+func (ar *memoryAttachmentRepository) FindAttachmentById(ctx context.Context, attachmentId string) (domain.Attachment, error) {
+	id, err := domain.ParseID(attachmentId)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+	for _, attachments := range ar.attachments {
+		for _, attachment := range attachments {
+			if attachment.ID == id {
+				return attachment, nil
+			}
+		}
+	}
+	return domain.Attachment{}, domain.ErrAttachmentNotFound
+}
+
+// FindTaskAttachments returns the attachments of a task, oldest first.
+func (ar *memoryAttachmentRepository) FindTaskAttachments(ctx context.Context, taskId string) ([]domain.Attachment, error) {
+	ar.mu.RLock()
+	defer ar.mu.RUnlock()
+	return append([]domain.Attachment{}, ar.attachments[taskId]...), nil
+}
+
+// DeleteAttachment deletes an attachment by its ID.
+func (ar *memoryAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentId string) error {
+	id, err := domain.ParseID(attachmentId)
+	if err != nil {
+		return err
+	}
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	for taskID, attachments := range ar.attachments {
+		for i, attachment := range attachments {
+			if attachment.ID == id {
+				ar.attachments[taskID] = append(attachments[:i:i], attachments[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return domain.ErrAttachmentNotFound
+}
+
+// DeleteTaskAttachments deletes every attachment of the given tasks.
+func (ar *memoryAttachmentRepository) DeleteTaskAttachments(ctx context.Context, taskIds []string) error {
+	ar.mu.Lock()
+	defer ar.mu.Unlock()
+	for _, taskID := range taskIds {
+		delete(ar.attachments, taskID)
+	}
+	return nil
+}
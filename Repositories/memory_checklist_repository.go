@@ -0,0 +1,167 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sort"
+	"sync"
+)
+
+// memoryChecklistRepository is a ChecklistRepository keeping the checklist items in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryChecklistRepository struct {
+	mu    sync.RWMutex
+	items map[domain.ID]domain.ChecklistItem
+}
+
+var _ domain.ChecklistRepository = &memoryChecklistRepository{}
+
+// NewMemoryChecklistRepository creates an empty in-memory ChecklistRepository.
+func NewMemoryChecklistRepository() domain.ChecklistRepository {
+	return &memoryChecklistRepository{items: make(map[domain.ID]domain.ChecklistItem)}
+}
+
+// copyChecklistItem returns a copy of the item that shares no memory with it.
+func copyChecklistItem(item domain.ChecklistItem) domain.ChecklistItem {
+	if item.DoneAt != nil {
+		doneAt := *item.DoneAt
+		item.DoneAt = &doneAt
+	}
+	return item
+}
+
+// CreateChecklistItem stores a new item and returns it with its ID set.
+func (cr *memoryChecklistRepository) CreateChecklistItem(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	item.ID = domain.NewID()
+
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.items[item.ID] = copyChecklistItem(item)
+	return item, nil
+}
+
+// FindChecklistItemById retrieves a checklist item by its ID.
+func (cr *memoryChecklistRepository) FindChecklistItemById(ctx context.Context, itemId string) (domain.ChecklistItem, error) {
+	id, err := domain.ParseID(itemId)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	item, ok := cr.items[id]
+	if !ok {
+		return domain.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	return copyChecklistItem(item), nil
+}
+
+// FindChecklist returns the items of a task, ordered by position, then by ID.
+func (cr *memoryChecklistRepository) FindChecklist(ctx context.Context, taskId string) ([]domain.ChecklistItem, error) {
+	cr.mu.RLock()
+	items := []domain.ChecklistItem{}
+	for _, item := range cr.items {
+		if item.TaskID == taskId {
+			items = append(items, copyChecklistItem(item))
+		}
+	}
+	cr.mu.RUnlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items, nil
+}
+
+// UpdateChecklistItem replaces the title, required flag and completion of an item and returns the updated item.
+func (cr *memoryChecklistRepository) UpdateChecklistItem(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	stored, ok := cr.items[item.ID]
+	if !ok {
+		return domain.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	stored.Title = item.Title
+	stored.Required = item.Required
+	stored.Done = item.Done
+	stored.DoneBy = item.DoneBy
+	stored.DoneAt = item.DoneAt
+	cr.items[item.ID] = copyChecklistItem(stored)
+	return copyChecklistItem(stored), nil
+}
+
+// ReorderChecklist moves every listed item of the task to its index in itemIds. Items of other tasks are left alone.
+func (cr *memoryChecklistRepository) ReorderChecklist(ctx context.Context, taskId string, itemIds []string) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for position, itemId := range itemIds {
+		item, ok := cr.items[domain.ID(itemId)]
+		if !ok || item.TaskID != taskId {
+			continue
+		}
+		item.Position = position
+		cr.items[item.ID] = item
+	}
+	return nil
+}
+
+// DeleteChecklistItem deletes a checklist item by its ID.
+func (cr *memoryChecklistRepository) DeleteChecklistItem(ctx context.Context, itemId string) error {
+	id, err := domain.ParseID(itemId)
+	if err != nil {
+		return err
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if _, ok := cr.items[id]; !ok {
+		return domain.ErrChecklistItemNotFound
+	}
+	delete(cr.items, id)
+	return nil
+}
+
+// DeleteTaskChecklists deletes every item of the given tasks.
+func (cr *memoryChecklistRepository) DeleteTaskChecklists(ctx context.Context, taskIds []string) error {
+	tasks := make(map[string]bool, len(taskIds))
+	for _, id := range taskIds {
+		tasks[id] = true
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for id, item := range cr.items {
+		if tasks[item.TaskID] {
+			delete(cr.items, id)
+		}
+	}
+	return nil
+}
+
+// CountChecklists counts the items of each of the given tasks that has some.
+func (cr *memoryChecklistRepository) CountChecklists(ctx context.Context, taskIds []string) (map[string]domain.TaskSteps, error) {
+	tasks := make(map[string]bool, len(taskIds))
+	for _, id := range taskIds {
+		tasks[id] = true
+	}
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	counts := make(map[string]domain.TaskSteps)
+	for _, item := range cr.items {
+		if tasks[item.TaskID] {
+			counts[item.TaskID] = counts[item.TaskID].Add(checklistItemSteps(item))
+		}
+	}
+	return counts, nil
+}
+
+// checklistItemSteps counts a single item.
+func checklistItemSteps(item domain.ChecklistItem) domain.TaskSteps {
+	steps := domain.TaskSteps{Total: 1}
+	if item.Done {
+		steps.Done = 1
+	} else if item.Required {
+		steps.OpenRequired = 1
+	}
+	return steps
+}
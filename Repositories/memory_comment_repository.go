@@ -0,0 +1,134 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sync"
+)
+
+// memoryCommentRepository is a CommentRepository keeping the comments in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryCommentRepository struct {
+	mu       sync.RWMutex
+	comments map[string][]domain.Comment
+}
+
+var _ domain.CommentRepository = &memoryCommentRepository{}
+
+// NewMemoryCommentRepository creates an empty in-memory CommentRepository.
+func NewMemoryCommentRepository() domain.CommentRepository {
+	return &memoryCommentRepository{comments: make(map[string][]domain.Comment)}
+}
+
+// CreateComment stores a new comment and returns it with its ID set.
+func (cr *memoryCommentRepository) CreateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	// IDs are generated under the lock so that they follow the order of the comments
+	comment.ID = domain.NewID()
+	cr.comments[comment.TaskID] = append(cr.comments[comment.TaskID], comment)
+	return comment, nil
+}
+
+// FindCommentById retrieves a comment by its ID.
+func (cr *memoryCommentRepository) FindCommentById(ctx context.Context, commentId string) (domain.Comment, error) {
+	id, err := domain.ParseID(commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	for _, comments := range cr.comments {
+		for _, comment := range comments {
+			if comment.ID == id {
+				return comment, nil
+			}
+		}
+	}
+	return domain.Comment{}, domain.ErrCommentNotFound
+}
+
+// FindComments returns one page of the comments of a task, oldest first.
+func (cr *memoryCommentRepository) FindComments(ctx context.Context, query domain.CommentQuery) (domain.CommentPage, error) {
+	var after domain.ID
+	if query.Cursor != "" {
+		id, err := domain.ParseID(query.Cursor)
+		if err != nil {
+			return domain.CommentPage{}, err
+		}
+		after = id
+	}
+
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	page := domain.CommentPage{Comments: []domain.Comment{}}
+	for _, comment := range cr.comments[query.TaskID] {
+		if !after.IsZero() && comment.ID.String() <= after.String() {
+			continue
+		}
+		if len(page.Comments) == query.Limit {
+			page.NextCursor = page.Comments[len(page.Comments)-1].ID.String()
+			break
+		}
+		page.Comments = append(page.Comments, comment)
+	}
+	return page, nil
+}
+
+// UpdateComment replaces the body and the update time of a comment and returns it.
+func (cr *memoryCommentRepository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for taskID, comments := range cr.comments {
+		for i, stored := range comments {
+			if stored.ID == comment.ID {
+				stored.Body, stored.UpdatedAt = comment.Body, comment.UpdatedAt
+				cr.comments[taskID][i] = stored
+				return stored, nil
+			}
+		}
+	}
+	return domain.Comment{}, domain.ErrCommentNotFound
+}
+
+// DeleteComment deletes a comment by its ID.
+func (cr *memoryCommentRepository) DeleteComment(ctx context.Context, commentId string) error {
+	id, err := domain.ParseID(commentId)
+	if err != nil {
+		return err
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for taskID, comments := range cr.comments {
+		for i, comment := range comments {
+			if comment.ID == id {
+				cr.comments[taskID] = append(comments[:i:i], comments[i+1:]...)
+				return nil
+			}
+		}
+	}
+	return domain.ErrCommentNotFound
+}
+
+// DeleteTaskComments deletes every comment of the given tasks.
+func (cr *memoryCommentRepository) DeleteTaskComments(ctx context.Context, taskIds []string) error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	for _, taskID := range taskIds {
+		delete(cr.comments, taskID)
+	}
+	return nil
+}
+
+// CountComments returns the number of comments of each of the given tasks that has some.
+func (cr *memoryCommentRepository) CountComments(ctx context.Context, taskIds []string) (map[string]int, error) {
+	cr.mu.RLock()
+	defer cr.mu.RUnlock()
+	counts := make(map[string]int, len(taskIds))
+	for _, taskID := range taskIds {
+		if n := len(cr.comments[taskID]); n > 0 {
+			counts[taskID] = n
+		}
+	}
+	return counts, nil
+}
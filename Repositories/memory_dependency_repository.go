@@ -0,0 +1,106 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sort"
+	"sync"
+)
+
+// dependencyKey identifies a dependency by its two tasks.
+type dependencyKey struct {
+	taskID      string
+	dependsOnID string
+}
+
+// memoryDependencyRepository is a DependencyRepository keeping the dependencies in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryDependencyRepository struct {
+	mu           sync.RWMutex
+	dependencies map[dependencyKey]domain.TaskDependency
+}
+
+var _ domain.DependencyRepository = &memoryDependencyRepository{}
+
+// NewMemoryDependencyRepository creates an empty in-memory DependencyRepository.
+func NewMemoryDependencyRepository() domain.DependencyRepository {
+	return &memoryDependencyRepository{dependencies: make(map[dependencyKey]domain.TaskDependency)}
+}
+
+// AddDependency stores a new dependency.
+// It returns domain.ErrDependencyExists if the task already depends on the other one.
+func (dr *memoryDependencyRepository) AddDependency(ctx context.Context, dependency domain.TaskDependency) error {
+	key := dependencyKey{dependency.TaskID, dependency.DependsOnID}
+
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	if _, ok := dr.dependencies[key]; ok {
+		return domain.ErrDependencyExists
+	}
+	dr.dependencies[key] = dependency
+	return nil
+}
+
+// RemoveDependency deletes the dependency of a task on another one.
+// It returns domain.ErrDependencyNotFound if the task does not depend on the other one.
+func (dr *memoryDependencyRepository) RemoveDependency(ctx context.Context, taskId string, dependsOnId string) error {
+	key := dependencyKey{taskId, dependsOnId}
+
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	if _, ok := dr.dependencies[key]; !ok {
+		return domain.ErrDependencyNotFound
+	}
+	delete(dr.dependencies, key)
+	return nil
+}
+
+// FindDependencies returns the dependencies of the given tasks on other tasks.
+func (dr *memoryDependencyRepository) FindDependencies(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+	return dr.find(taskIds, func(key dependencyKey) string { return key.taskID }), nil
+}
+
+// FindDependents returns the dependencies of other tasks on the given tasks.
+func (dr *memoryDependencyRepository) FindDependents(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+	return dr.find(taskIds, func(key dependencyKey) string { return key.dependsOnID }), nil
+}
+
+// find returns the dependencies whose end picked by end is one of the given tasks, in order.
+func (dr *memoryDependencyRepository) find(taskIds []string, end func(dependencyKey) string) []domain.TaskDependency {
+	wanted := make(map[string]bool, len(taskIds))
+	for _, id := range taskIds {
+		wanted[id] = true
+	}
+	dr.mu.RLock()
+	dependencies := []domain.TaskDependency{}
+	for key, dependency := range dr.dependencies {
+		if wanted[end(key)] {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	dr.mu.RUnlock()
+
+	sort.Slice(dependencies, func(i, j int) bool {
+		if dependencies[i].TaskID != dependencies[j].TaskID {
+			return dependencies[i].TaskID < dependencies[j].TaskID
+		}
+		return dependencies[i].DependsOnID < dependencies[j].DependsOnID
+	})
+	return dependencies
+}
+
+// DeleteTaskDependencies deletes every dependency from or to the given tasks.
+func (dr *memoryDependencyRepository) DeleteTaskDependencies(ctx context.Context, taskIds []string) error {
+	deleted := make(map[string]bool, len(taskIds))
+	for _, id := range taskIds {
+		deleted[id] = true
+	}
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+	for key := range dr.dependencies {
+		if deleted[key.taskID] || deleted[key.dependsOnID] {
+			delete(dr.dependencies, key)
+		}
+	}
+	return nil
+}
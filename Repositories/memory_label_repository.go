@@ -0,0 +1,116 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sort"
+	"sync"
+)
+
+// memoryLabelRepository is a LabelRepository keeping the label catalogs in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryLabelRepository struct {
+	mu     sync.RWMutex
+	labels map[orgLabel]domain.Label
+}
+
+// orgLabel identifies a label by its organization and name.
+type orgLabel struct {
+	orgID string
+	name  string
+}
+
+var _ domain.LabelRepository = &memoryLabelRepository{}
+
+// NewMemoryLabelRepository creates an empty in-memory LabelRepository.
+func NewMemoryLabelRepository() domain.LabelRepository {
+	return &memoryLabelRepository{labels: make(map[orgLabel]domain.Label)}
+}
+
+// CreateLabel stores a new label in the organization of ctx, or else in its own OrgID, and returns it.
+// It returns domain.ErrLabelExists if a label of the organization already has this name.
+func (lr *memoryLabelRepository) CreateLabel(ctx context.Context, label domain.Label) (domain.Label, error) {
+	org, err := tenantOf(ctx, label.OrgID)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	label.OrgID = org
+	key := orgLabel{label.OrgID, label.Name}
+	if _, ok := lr.labels[key]; ok {
+		return domain.Label{}, domain.ErrLabelExists
+	}
+	lr.labels[key] = label
+	return label, nil
+}
+
+// FindLabels returns every label of the organization of ctx, ordered by name.
+func (lr *memoryLabelRepository) FindLabels(ctx context.Context) ([]domain.Label, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+	labels := []domain.Label{}
+	for key, label := range lr.labels {
+		if key.orgID == org {
+			labels = append(labels, label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels, nil
+}
+
+// FindLabelByName retrieves a label of the organization of ctx by its name.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *memoryLabelRepository) FindLabelByName(ctx context.Context, name string) (domain.Label, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Label{}, err
+	}
+	lr.mu.RLock()
+	defer lr.mu.RUnlock()
+	label, ok := lr.labels[orgLabel{org, name}]
+	if !ok {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+	return label, nil
+}
+
+// UpdateLabel replaces the colour and description of a label of the organization of ctx and returns it.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *memoryLabelRepository) UpdateLabel(ctx context.Context, label domain.Label) (domain.Label, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Label{}, err
+	}
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	key := orgLabel{org, label.Name}
+	stored, ok := lr.labels[key]
+	if !ok {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+	stored.Color, stored.Description = label.Color, label.Description
+	lr.labels[key] = stored
+	return stored, nil
+}
+
+// DeleteLabel deletes a label of the organization of ctx.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *memoryLabelRepository) DeleteLabel(ctx context.Context, name string) error {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return err
+	}
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	key := orgLabel{org, name}
+	if _, ok := lr.labels[key]; !ok {
+		return domain.ErrLabelNotFound
+	}
+	delete(lr.labels, key)
+	return nil
+}
@@ -0,0 +1,179 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sort"
+	"sync"
+)
+
+// memoryProjectRepository is a ProjectRepository keeping the projects and their members in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryProjectRepository struct {
+	mu       sync.RWMutex
+	projects map[domain.ID]domain.Project
+	// members holds the members of each project, by user ID.
+	members map[string]map[string]domain.ProjectMember
+}
+
+var _ domain.ProjectRepository = &memoryProjectRepository{}
+
+// NewMemoryProjectRepository creates an empty in-memory ProjectRepository.
+func NewMemoryProjectRepository() domain.ProjectRepository {
+	return &memoryProjectRepository{
+		projects: make(map[domain.ID]domain.Project),
+		members:  make(map[string]map[string]domain.ProjectMember),
+	}
+}
+
+// CreateProject stores a new project and returns it with its ID and organization set.
+func (pr *memoryProjectRepository) CreateProject(ctx context.Context, project domain.Project) (domain.Project, error) {
+	org, err := tenantOf(ctx, project.OrgID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	project.ID = domain.NewID()
+	project.OrgID = org
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	pr.projects[project.ID] = project
+	return project, nil
+}
+
+// FindProjectById retrieves a project by its ID.
+// It returns domain.ErrProjectNotFound if no project of the organization of ctx has this ID.
+func (pr *memoryProjectRepository) FindProjectById(ctx context.Context, projectId string) (domain.Project, error) {
+	id, err := domain.ParseID(projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	project, ok := pr.projects[id]
+	if !ok || !inTenant(org, project.OrgID) {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	return project, nil
+}
+
+// FindMemberProjects returns the projects the user is a member of, in the order they were created.
+func (pr *memoryProjectRepository) FindMemberProjects(ctx context.Context, userId string) ([]domain.Project, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	projects := []domain.Project{}
+	for _, project := range pr.projects {
+		if _, ok := pr.members[project.ID.String()][userId]; ok {
+			projects = append(projects, project)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if !projects[i].CreatedAt.Equal(projects[j].CreatedAt) {
+			return projects[i].CreatedAt.Before(projects[j].CreatedAt)
+		}
+		return projects[i].ID < projects[j].ID
+	})
+	return projects, nil
+}
+
+// UpdateProject replaces the name and description of a project and returns it.
+// It returns domain.ErrProjectNotFound if no project has this ID.
+func (pr *memoryProjectRepository) UpdateProject(ctx context.Context, project domain.Project) (domain.Project, error) {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	stored, ok := pr.projects[project.ID]
+	if !ok {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	stored.Name, stored.Description = project.Name, project.Description
+	pr.projects[project.ID] = stored
+	return stored, nil
+}
+
+// DeleteProject deletes a project with its members.
+// It returns domain.ErrProjectNotFound if no project has this ID.
+func (pr *memoryProjectRepository) DeleteProject(ctx context.Context, projectId string) error {
+	id, err := domain.ParseID(projectId)
+	if err != nil {
+		return err
+	}
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if _, ok := pr.projects[id]; !ok {
+		return domain.ErrProjectNotFound
+	}
+	delete(pr.projects, id)
+	delete(pr.members, projectId)
+	return nil
+}
+
+// AddMember stores a new member of a project.
+// It returns domain.ErrMemberExists if the user is already a member of the project.
+func (pr *memoryProjectRepository) AddMember(ctx context.Context, member domain.ProjectMember) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	members, ok := pr.members[member.ProjectID]
+	if !ok {
+		members = make(map[string]domain.ProjectMember)
+		pr.members[member.ProjectID] = members
+	}
+	if _, ok := members[member.UserID]; ok {
+		return domain.ErrMemberExists
+	}
+	members[member.UserID] = member
+	return nil
+}
+
+// FindMember returns the membership of a user in a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *memoryProjectRepository) FindMember(ctx context.Context, projectId string, userId string) (domain.ProjectMember, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	member, ok := pr.members[projectId][userId]
+	if !ok {
+		return domain.ProjectMember{}, domain.ErrMemberNotFound
+	}
+	return member, nil
+}
+
+// FindMembers returns the members of a project, ordered by user ID.
+func (pr *memoryProjectRepository) FindMembers(ctx context.Context, projectId string) ([]domain.ProjectMember, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	members := make([]domain.ProjectMember, 0, len(pr.members[projectId]))
+	for _, member := range pr.members[projectId] {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].UserID < members[j].UserID })
+	return members, nil
+}
+
+// UpdateMember changes the role of a member of a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *memoryProjectRepository) UpdateMember(ctx context.Context, member domain.ProjectMember) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	stored, ok := pr.members[member.ProjectID][member.UserID]
+	if !ok {
+		return domain.ErrMemberNotFound
+	}
+	stored.Role = member.Role
+	pr.members[member.ProjectID][member.UserID] = stored
+	return nil
+}
+
+// RemoveMember deletes the membership of a user in a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *memoryProjectRepository) RemoveMember(ctx context.Context, projectId string, userId string) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if _, ok := pr.members[projectId][userId]; !ok {
+		return domain.ErrMemberNotFound
+	}
+	delete(pr.members[projectId], userId)
+	return nil
+}
@@ -0,0 +1,108 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sync"
+	"time"
+)
+
+// memoryRefreshTokenRepository is a RefreshTokenRepository keeping the tokens in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryRefreshTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[domain.ID]domain.RefreshToken
+	byHash map[string]domain.ID
+}
+
+var _ domain.RefreshTokenRepository = &memoryRefreshTokenRepository{}
+
+// NewMemoryRefreshTokenRepository creates an empty in-memory RefreshTokenRepository.
+func NewMemoryRefreshTokenRepository() domain.RefreshTokenRepository {
+	return &memoryRefreshTokenRepository{
+		tokens: make(map[domain.ID]domain.RefreshToken),
+		byHash: make(map[string]domain.ID),
+	}
+}
+
+// CreateRefreshToken stores a newly issued refresh token and returns it with its ID set.
+func (rr *memoryRefreshTokenRepository) CreateRefreshToken(ctx context.Context, token domain.RefreshToken) (domain.RefreshToken, error) {
+	token.ID = domain.NewID()
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.tokens[token.ID] = token
+	rr.byHash[token.TokenHash] = token.ID
+	return token, nil
+}
+
+// FindRefreshToken looks a refresh token up by the hash of its value.
+// It returns domain.ErrRefreshTokenNotFound if no token has this hash.
+func (rr *memoryRefreshTokenRepository) FindRefreshToken(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	id, ok := rr.byHash[tokenHash]
+	if !ok {
+		return domain.RefreshToken{}, domain.ErrRefreshTokenNotFound
+	}
+	return rr.tokens[id], nil
+}
+
+// MarkRefreshTokenUsed records that the token has been exchanged.
+// It returns domain.ErrRefreshTokenReused if the token was already used.
+func (rr *memoryRefreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) error {
+	tokenID, err := domain.ParseID(id)
+	if err != nil {
+		return err
+	}
+
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	token, ok := rr.tokens[tokenID]
+	if !ok || token.UsedAt != nil {
+		return domain.ErrRefreshTokenReused
+	}
+	token.UsedAt = &usedAt
+	rr.tokens[tokenID] = token
+	return nil
+}
+
+// RevokeTokenFamily revokes every token of a session.
+// Tokens that were already revoked keep their original revocation time.
+func (rr *memoryRefreshTokenRepository) RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	for id, token := range rr.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+			rr.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// RevokeUserTokens revokes every session of a user.
+// Tokens that were already revoked keep their original revocation time.
+func (rr *memoryRefreshTokenRepository) RevokeUserTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	for id, token := range rr.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+			rr.tokens[id] = token
+		}
+	}
+	return nil
+}
+
+// IsTokenFamilyRevoked reports whether the session has been revoked.
+func (rr *memoryRefreshTokenRepository) IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	for _, token := range rr.tokens {
+		if token.FamilyID == familyID && token.RevokedAt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,72 @@
+package Repositories
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	domain "example/go-clean-architecture/Domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMemoryUserRepository_ConcurrentSignUp tests that concurrent sign-ups with the same username
+// create a single user, and that exactly one of many concurrent first users becomes an admin.
+func TestMemoryUserRepository_ConcurrentSignUp(t *testing.T) {
+	repo := NewMemoryUserRepository()
+
+	var wg sync.WaitGroup
+	results := make(chan error, 40)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := repo.CreateNewUser(inDefaultOrganization(), &domain.User{Username: fmt.Sprintf("user-%d", i%20), Password: "hash"})
+			results <- err
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	created, taken := 0, 0
+	for err := range results {
+		if err == nil {
+			created++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrUsernameTaken)
+			taken++
+		}
+	}
+	assert.Equal(t, 20, created)
+	assert.Equal(t, 20, taken)
+
+	admins := 0
+	for i := 0; i < 20; i++ {
+		user, err := repo.FindUser(inDefaultOrganization(), fmt.Sprintf("user-%d", i))
+		require.NoError(t, err)
+		if user.Role == domain.RoleAdmin {
+			admins++
+		}
+	}
+	assert.Equal(t, 1, admins)
+}
+
+// TestMemoryTaskRepository_ReturnsCopies tests that callers cannot change stored tasks through the
+// slices of the tasks they were given or passed in.
+func TestMemoryTaskRepository_ReturnsCopies(t *testing.T) {
+	repo := NewMemoryTaskRepository()
+	assignees := []string{"alice"}
+	created, err := repo.CreateTask(inDefaultOrganization(), domain.Task{Title: "task", AssigneeIDs: assignees})
+	require.NoError(t, err)
+	assignees[0] = "mallory"
+
+	found, err := repo.FindTaskById(inDefaultOrganization(), created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, found.AssigneeIDs)
+	found.AssigneeIDs[0] = "mallory"
+
+	found, err = repo.FindTaskById(inDefaultOrganization(), created.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice"}, found.AssigneeIDs)
+}
@@ -0,0 +1,117 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sort"
+	"sync"
+)
+
+// memoryRoleRepository is a RoleRepository keeping the roles in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryRoleRepository struct {
+	mu    sync.RWMutex
+	roles map[orgRole]domain.Role
+}
+
+// orgRole identifies a role by its organization and name.
+type orgRole struct {
+	orgID string
+	name  string
+}
+
+var _ domain.RoleRepository = &memoryRoleRepository{}
+
+// NewMemoryRoleRepository creates an empty in-memory RoleRepository.
+func NewMemoryRoleRepository() domain.RoleRepository {
+	return &memoryRoleRepository{roles: make(map[orgRole]domain.Role)}
+}
+
+// CreateRole stores a new role in the organization of ctx, or else in its own OrgID, and returns it.
+// It returns domain.ErrRoleExists if the organization already has a role with this name.
+func (rr *memoryRoleRepository) CreateRole(ctx context.Context, role domain.Role) (domain.Role, error) {
+	org, err := tenantOf(ctx, role.OrgID)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	role.OrgID = org
+	role.Permissions = append([]string{}, role.Permissions...)
+	key := orgRole{role.OrgID, role.Name}
+	if _, ok := rr.roles[key]; ok {
+		return domain.Role{}, domain.ErrRoleExists
+	}
+	rr.roles[key] = role
+	return role, nil
+}
+
+// FindRoles returns the roles of the organization of ctx ordered by name.
+func (rr *memoryRoleRepository) FindRoles(ctx context.Context) ([]domain.Role, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	roles := []domain.Role{}
+	for key, role := range rr.roles {
+		if key.orgID == org {
+			roles = append(roles, role)
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+	return roles, nil
+}
+
+// FindRoleByName retrieves a role of the organization of ctx by its name.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *memoryRoleRepository) FindRoleByName(ctx context.Context, name string) (domain.Role, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Role{}, err
+	}
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	role, ok := rr.roles[orgRole{org, name}]
+	if !ok {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+	return role, nil
+}
+
+// UpdateRole replaces the description and the permissions of a role of the organization of ctx and returns it.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *memoryRoleRepository) UpdateRole(ctx context.Context, role domain.Role) (domain.Role, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Role{}, err
+	}
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	key := orgRole{org, role.Name}
+	stored, ok := rr.roles[key]
+	if !ok {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+	stored.Description, stored.Permissions = role.Description, append([]string{}, role.Permissions...)
+	rr.roles[key] = stored
+	return stored, nil
+}
+
+// DeleteRole deletes a role of the organization of ctx.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *memoryRoleRepository) DeleteRole(ctx context.Context, name string) error {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return err
+	}
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	key := orgRole{org, name}
+	if _, ok := rr.roles[key]; !ok {
+		return domain.ErrRoleNotFound
+	}
+	delete(rr.roles, key)
+	return nil
+}
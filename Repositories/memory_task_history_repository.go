@@ -0,0 +1,64 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sync"
+)
+
+// memoryTaskHistoryRepository is a TaskHistoryRepository keeping the entries in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryTaskHistoryRepository struct {
+	mu      sync.RWMutex
+	entries map[string][]domain.TaskHistoryEntry
+}
+
+var _ domain.TaskHistoryRepository = &memoryTaskHistoryRepository{}
+
+// NewMemoryTaskHistoryRepository creates an empty in-memory TaskHistoryRepository.
+func NewMemoryTaskHistoryRepository() domain.TaskHistoryRepository {
+	return &memoryTaskHistoryRepository{entries: make(map[string][]domain.TaskHistoryEntry)}
+}
+
+// AppendTaskHistory stores a new entry and returns it with its ID set.
+func (hr *memoryTaskHistoryRepository) AppendTaskHistory(ctx context.Context, entry domain.TaskHistoryEntry) (domain.TaskHistoryEntry, error) {
+	hr.mu.Lock()
+	defer hr.mu.Unlock()
+	// IDs are generated under the lock so that they follow the order of the entries
+	entry.ID = domain.NewID()
+	hr.entries[entry.TaskID] = append(hr.entries[entry.TaskID], copyHistoryEntry(entry))
+	return entry, nil
+}
+
+// FindTaskHistory returns one page of the entries of a task, oldest first.
+func (hr *memoryTaskHistoryRepository) FindTaskHistory(ctx context.Context, query domain.TaskHistoryQuery) (domain.TaskHistoryPage, error) {
+	var after domain.ID
+	if query.Cursor != "" {
+		id, err := domain.ParseID(query.Cursor)
+		if err != nil {
+			return domain.TaskHistoryPage{}, err
+		}
+		after = id
+	}
+
+	hr.mu.RLock()
+	defer hr.mu.RUnlock()
+	page := domain.TaskHistoryPage{Entries: []domain.TaskHistoryEntry{}}
+	for _, entry := range hr.entries[query.TaskID] {
+		if !after.IsZero() && entry.ID.String() <= after.String() {
+			continue
+		}
+		if len(page.Entries) == query.Limit {
+			page.NextCursor = page.Entries[len(page.Entries)-1].ID.String()
+			break
+		}
+		page.Entries = append(page.Entries, copyHistoryEntry(entry))
+	}
+	return page, nil
+}
+
+// copyHistoryEntry returns a copy of the entry that shares no slice with it.
+func copyHistoryEntry(entry domain.TaskHistoryEntry) domain.TaskHistoryEntry {
+	entry.Changes = append([]domain.FieldChange{}, entry.Changes...)
+	return entry
+}
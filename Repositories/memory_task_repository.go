@@ -0,0 +1,499 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryTaskRepository is a TaskRepository keeping the tasks in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryTaskRepository struct {
+	mu    sync.RWMutex
+	tasks map[domain.ID]domain.Task
+	// purging holds the tasks of the trash being purged
+	purging map[domain.ID]bool
+}
+
+var _ domain.TaskRepository = &memoryTaskRepository{}
+
+// NewMemoryTaskRepository creates an empty in-memory TaskRepository.
+// The tasks are lost when the process exits, so it is meant for development and tests.
+func NewMemoryTaskRepository() domain.TaskRepository {
+	return &memoryTaskRepository{tasks: make(map[domain.ID]domain.Task), purging: make(map[domain.ID]bool)}
+}
+
+// copyTask returns a copy of the task that shares no memory with it.
+func copyTask(task domain.Task) domain.Task {
+	if task.AssigneeIDs != nil {
+		task.AssigneeIDs = append([]string{}, task.AssigneeIDs...)
+	}
+	if task.Labels != nil {
+		task.Labels = append([]string{}, task.Labels...)
+	}
+	if task.DeletedAt != nil {
+		deletedAt := *task.DeletedAt
+		task.DeletedAt = &deletedAt
+	}
+	return task
+}
+
+// FindAlltasks retrieves all tasks that are not in the trash, in the order they were created.
+func (tr *memoryTaskRepository) FindAlltasks(ctx context.Context) ([]domain.Task, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+
+	var tasks []domain.Task
+	for _, task := range tr.tasks {
+		if task.DeletedAt == nil && inTenant(org, task.OrgID) {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return compareTasks(tasks[i], tasks[j], domain.TaskSortID) < 0
+	})
+	return tasks, nil
+}
+
+// FindTasks retrieves one page of tasks matching the query, in the same order as the MongoDB repository.
+// It expects a normalized query with a positive limit.
+func (tr *memoryTaskRepository) FindTasks(ctx context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	key, descending, err := domain.ParseTaskSort(query.Sort)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	var last *domain.Task
+	if query.Cursor != "" {
+		if last, err = taskFromCursor(query, key); err != nil {
+			return domain.TaskPage{}, err
+		}
+	}
+	less := func(a, b domain.Task) bool {
+		if descending {
+			return compareTasks(a, b, key) > 0
+		}
+		return compareTasks(a, b, key) < 0
+	}
+
+	tr.mu.RLock()
+	tasks := []domain.Task{}
+	for _, task := range tr.tasks {
+		if matchesTaskQuery(task, query) && !tr.purging[task.ID] && inTenant(org, task.OrgID) && (last == nil || less(*last, task)) {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+	tr.mu.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return less(tasks[i], tasks[j])
+	})
+
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		page.NextCursor = domain.NewTaskCursor(query.Sort, page.Tasks[query.Limit-1]).Encode()
+	}
+	return page, nil
+}
+
+// matchesTaskQuery reports whether the task passes the filters of the query.
+func matchesTaskQuery(task domain.Task, query domain.TaskQuery) bool {
+	if (task.DeletedAt != nil) != query.Deleted {
+		return false
+	}
+	if query.VisibleTo != "" && !task.IsVisibleTo(domain.AuthUser{ID: query.VisibleTo}) {
+		return false
+	}
+	if query.ParentID != "" && task.ParentID != query.ParentID {
+		return false
+	}
+	if query.ProjectID != "" && task.ProjectID != query.ProjectID {
+		return false
+	}
+	if query.Status != "" && task.Status != query.Status {
+		return false
+	}
+	if query.DueFrom != nil && task.DueDate.Before(*query.DueFrom) {
+		return false
+	}
+	if query.DueTo != nil && task.DueDate.After(*query.DueTo) {
+		return false
+	}
+	if len(query.Labels) > 0 && !matchesLabels(task.Labels, query.Labels, query.LabelMatch) {
+		return false
+	}
+	return strings.HasPrefix(task.Title, query.TitlePrefix)
+}
+
+// matchesLabels reports whether the labels of a task include any of the given labels,
+// or all of them if match is domain.LabelMatchAll.
+func matchesLabels(taskLabels []string, labels []string, match string) bool {
+	found := 0
+	for _, label := range labels {
+		for _, taskLabel := range taskLabels {
+			if taskLabel == label {
+				found++
+				break
+			}
+		}
+	}
+	if match == domain.LabelMatchAll {
+		return found == len(labels)
+	}
+	return found > 0
+}
+
+// compareTasks orders two tasks by the sort key, then by ID, returning -1, 0 or +1.
+func compareTasks(a domain.Task, b domain.Task, key string) int {
+	var c int
+	switch key {
+	case domain.TaskSortDueDate:
+		c = a.DueDate.Compare(b.DueDate)
+	case domain.TaskSortTitle:
+		c = strings.Compare(a.Title, b.Title)
+	case domain.TaskSortStatus:
+		c = strings.Compare(a.Status, b.Status)
+	}
+	if c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// taskFromCursor returns a task holding the sort value and ID of the last task of the previous page.
+func taskFromCursor(query domain.TaskQuery, key string) (*domain.Task, error) {
+	tc, err := domain.DecodeTaskCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	lastID, err := domain.ParseID(tc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTaskQuery)
+	}
+
+	last := &domain.Task{ID: lastID}
+	switch key {
+	case domain.TaskSortDueDate:
+		if last.DueDate, err = time.Parse(time.RFC3339Nano, tc.Value); err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTaskQuery)
+		}
+	case domain.TaskSortTitle:
+		last.Title = tc.Value
+	case domain.TaskSortStatus:
+		last.Status = tc.Value
+	}
+	return last, nil
+}
+
+// FindTaskById retrieves a task by its ID.
+// It returns domain.ErrTaskNotFound if no task has this ID or the task is in the trash.
+func (tr *memoryTaskRepository) FindTaskById(ctx context.Context, taskId string) (domain.Task, error) {
+	taskID, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	task, ok := tr.tasks[taskID]
+	if !ok || task.DeletedAt != nil || !inTenant(org, task.OrgID) {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	return copyTask(task), nil
+}
+
+// FindDeletedTaskById retrieves a task of the trash by its ID.
+// It returns domain.ErrTaskNotFound if no task of the trash has this ID.
+func (tr *memoryTaskRepository) FindDeletedTaskById(ctx context.Context, taskId string) (domain.Task, error) {
+	taskID, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	task, ok := tr.tasks[taskID]
+	if !ok || task.DeletedAt == nil || !inTenant(org, task.OrgID) {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	return copyTask(task), nil
+}
+
+// CreateTask stores a new task and returns it with its ID and organization set, at version 1.
+func (tr *memoryTaskRepository) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
+	task.ID = domain.NewID()
+	task.Version = 1
+	org, err := tenantOf(ctx, task.OrgID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.OrgID = org
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.tasks[task.ID] = copyTask(task)
+	return task, nil
+}
+
+// UpdateTaskById replaces the editable fields of the task with the given ID and returns the updated task.
+// It returns domain.ErrVersionMismatch if the task is not at updatedTask.Version.
+func (tr *memoryTaskRepository) UpdateTaskById(ctx context.Context, updatedTask domain.Task, id string) (domain.Task, error) {
+	taskID, err := domain.ParseID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	task, err := tr.taskAtVersion(ctx, taskID, updatedTask.Version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.Version++
+	task.Title = updatedTask.Title
+	task.Description = updatedTask.Description
+	task.DueDate = updatedTask.DueDate
+	task.Status = updatedTask.Status
+	task.AssigneeIDs = updatedTask.AssigneeIDs
+	task.Labels = updatedTask.Labels
+	tr.tasks[taskID] = copyTask(task)
+	return copyTask(task), nil
+}
+
+// PatchTaskById changes only the fields set in the patch and returns the updated task.
+// It returns domain.ErrTaskNotFound if no task has this ID and domain.ErrVersionMismatch
+// if the task is not at patch.Version.
+func (tr *memoryTaskRepository) PatchTaskById(ctx context.Context, patch domain.TaskPatch, id string) (domain.Task, error) {
+	taskID, err := domain.ParseID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	task, err := tr.taskAtVersion(ctx, taskID, patch.Version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task = patch.Apply(task)
+	task.Version++
+	tr.tasks[taskID] = task
+	return copyTask(task), nil
+}
+
+// DeleteTask moves the task with the given ID to the trash and returns it.
+// It returns domain.ErrTaskNotFound if no task has this ID and domain.ErrVersionMismatch
+// if the task is not at the given version.
+func (tr *memoryTaskRepository) DeleteTask(ctx context.Context, taskId string, version int64, deletedBy string, deletedAt time.Time) (domain.Task, error) {
+	taskID, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	task, err := tr.taskAtVersion(ctx, taskID, version)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.Version++
+	task.DeletedAt = &deletedAt
+	task.DeletedBy = deletedBy
+	tr.tasks[taskID] = copyTask(task)
+	return copyTask(task), nil
+}
+
+// RestoreTask takes the task with the given ID out of the trash and returns it.
+// It returns domain.ErrTaskNotFound if no task with this ID is in the trash.
+func (tr *memoryTaskRepository) RestoreTask(ctx context.Context, taskId string) (domain.Task, error) {
+	taskID, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	task, ok := tr.tasks[taskID]
+	if !ok || task.DeletedAt == nil || tr.purging[taskID] || !inTenant(org, task.OrgID) {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	task.Version++
+	task.DeletedAt = nil
+	task.DeletedBy = ""
+	tr.tasks[taskID] = task
+	return copyTask(task), nil
+}
+
+// StartPurge marks the tasks moved to the trash before deletedBefore as being purged and returns the IDs
+// of every task being purged.
+func (tr *memoryTaskRepository) StartPurge(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	purging := []string{}
+	for id, task := range tr.tasks {
+		if !inTenant(org, task.OrgID) {
+			continue
+		}
+		if task.DeletedAt != nil && task.DeletedAt.Before(deletedBefore) {
+			tr.purging[id] = true
+		}
+		if tr.purging[id] {
+			purging = append(purging, id.String())
+		}
+	}
+	sort.Strings(purging)
+	return purging, nil
+}
+
+// PurgeTasks permanently deletes the given tasks being purged and detaches their subtasks.
+func (tr *memoryTaskRepository) PurgeTasks(ctx context.Context, taskIds []string) error {
+	org, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+	purged := make(map[string]bool, len(taskIds))
+	for _, id := range taskIds {
+		purged[id] = true
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	for id, task := range tr.tasks {
+		if !inTenant(org, task.OrgID) {
+			continue
+		}
+		if purged[id.String()] && tr.purging[id] {
+			delete(tr.tasks, id)
+			delete(tr.purging, id)
+		} else if purged[task.ParentID] {
+			task.ParentID = ""
+			task.Version++
+			tr.tasks[id] = task
+		}
+	}
+	return nil
+}
+
+// CountSubtasks returns the number of live subtasks of each of the given tasks in each status.
+func (tr *memoryTaskRepository) CountSubtasks(ctx context.Context, parentIds []string) (map[string]map[string]int, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parents := make(map[string]bool, len(parentIds))
+	for _, id := range parentIds {
+		parents[id] = true
+	}
+
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
+	counts := make(map[string]map[string]int)
+	for _, task := range tr.tasks {
+		if task.DeletedAt != nil || !parents[task.ParentID] || !inTenant(org, task.OrgID) {
+			continue
+		}
+		if counts[task.ParentID] == nil {
+			counts[task.ParentID] = make(map[string]int)
+		}
+		counts[task.ParentID][task.Status]++
+	}
+	return counts, nil
+}
+
+// ReassignTasks replaces the user fromUserId by toUserId as the creator and as an assignee of every task,
+// including the ones in the trash, and returns the number of tasks changed.
+func (tr *memoryTaskRepository) ReassignTasks(ctx context.Context, fromUserId string, toUserId string) (int64, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	var changed int64
+	for id, task := range tr.tasks {
+		if !inTenant(org, task.OrgID) {
+			continue
+		}
+		assignees, reassigned := replaceAssignee(task.AssigneeIDs, fromUserId, toUserId)
+		if task.CreatedBy == fromUserId {
+			task.CreatedBy = toUserId
+			reassigned = true
+		}
+		if !reassigned {
+			continue
+		}
+		task.AssigneeIDs = assignees
+		task.Version++
+		tr.tasks[id] = task
+		changed++
+	}
+	return changed, nil
+}
+
+// replaceAssignee returns the assignees with from replaced by to in place, or left out if to is already one of them,
+// and whether from was an assignee.
+func replaceAssignee(assigneeIDs []string, from string, to string) ([]string, bool) {
+	found, present := false, false
+	for _, id := range assigneeIDs {
+		found = found || id == from
+		present = present || id == to
+	}
+	if !found {
+		return assigneeIDs, false
+	}
+	replaced := make([]string, 0, len(assigneeIDs))
+	for _, id := range assigneeIDs {
+		switch {
+		case id != from:
+			replaced = append(replaced, id)
+		case !present:
+			replaced = append(replaced, to)
+		}
+	}
+	return replaced, true
+}
+
+// taskAtVersion returns the stored task if it is at the given version, not in the trash and can be read with ctx.
+// The caller must hold the write lock.
+func (tr *memoryTaskRepository) taskAtVersion(ctx context.Context, taskID domain.ID, version int64) (domain.Task, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task, ok := tr.tasks[taskID]
+	if !ok || task.DeletedAt != nil || !inTenant(org, task.OrgID) {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	if task.Version != version {
+		return domain.Task{}, domain.ErrVersionMismatch
+	}
+	return task, nil
+}
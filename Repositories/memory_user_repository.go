@@ -0,0 +1,287 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryUserRepository is a UserRepository keeping the users in memory.
+// It has the same semantics as the MongoDB repository and is safe for concurrent use.
+type memoryUserRepository struct {
+	mu         sync.RWMutex
+	users      map[domain.ID]domain.User
+	byUsername map[orgUsername]domain.ID
+}
+
+// orgUsername identifies a user by its organization and username.
+type orgUsername struct {
+	orgID    string
+	username string
+}
+
+var _ domain.UserRepository = &memoryUserRepository{}
+
+// NewMemoryUserRepository creates an empty in-memory UserRepository.
+// The users are lost when the process exits, so it is meant for development and tests.
+func NewMemoryUserRepository() domain.UserRepository {
+	return &memoryUserRepository{
+		users:      make(map[domain.ID]domain.User),
+		byUsername: make(map[orgUsername]domain.ID),
+	}
+}
+
+// FindUser retrieves a user of the organization of ctx by its username.
+// It returns domain.ErrUserNotFound if no user has this username.
+func (ur *memoryUserRepository) FindUser(ctx context.Context, username string) (domain.User, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.User{}, err
+	}
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+	id, ok := ur.byUsername[orgUsername{org, username}]
+	if !ok {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	return ur.users[id], nil
+}
+
+// FindUserById retrieves a user by its ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *memoryUserRepository) FindUserById(ctx context.Context, userId string) (domain.User, error) {
+	userID, err := domain.ParseID(userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+	user, ok := ur.users[userID]
+	if !ok || !inTenant(org, user.OrgID) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// CreateNewUser stores a new user and returns it with its ID, organization and role set.
+// The user joins the organization of ctx, or else its own OrgID. The first user of an organization
+// becomes an admin and every later user a regular user.
+// It returns domain.ErrUsernameTaken if the username is already used in the organization.
+func (ur *memoryUserRepository) CreateNewUser(ctx context.Context, user *domain.User) (domain.User, error) {
+	return ur.insertUser(ctx, user, false)
+}
+
+// CreateOrganization stores the first user of the organization of ctx, or else of its own OrgID, as its admin
+// and returns it with its ID, organization and role set.
+// It returns domain.ErrOrganizationExists if the organization already has users.
+func (ur *memoryUserRepository) CreateOrganization(ctx context.Context, admin *domain.User) (domain.User, error) {
+	return ur.insertUser(ctx, admin, true)
+}
+
+// insertUser stores a new user like CreateNewUser does, or, if newOrganization is set, like CreateOrganization does.
+func (ur *memoryUserRepository) insertUser(ctx context.Context, user *domain.User, newOrganization bool) (domain.User, error) {
+	org, err := tenantOf(ctx, user.OrgID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+	user.OrgID = org
+	members := 0
+	for _, existing := range ur.users {
+		if existing.OrgID == user.OrgID {
+			members++
+		}
+	}
+	if newOrganization && members > 0 {
+		return domain.User{}, domain.ErrOrganizationExists
+	}
+	key := orgUsername{user.OrgID, user.Username}
+	if _, ok := ur.byUsername[key]; ok {
+		return domain.User{}, domain.ErrUsernameTaken
+	}
+
+	if members == 0 {
+		user.Role = domain.RoleAdmin
+	} else {
+		user.Role = domain.RoleUser
+	}
+	user.ID = domain.NewID()
+
+	ur.users[user.ID] = *user
+	ur.byUsername[key] = user.ID
+	return *user, nil
+}
+
+// PromoteUser gives the admin role to the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *memoryUserRepository) PromoteUser(ctx context.Context, userId string) error {
+	userID, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+	user, ok := ur.users[userID]
+	if !ok || !inTenant(org, user.OrgID) {
+		return domain.ErrUserNotFound
+	}
+	user.Role = domain.RoleAdmin
+	ur.users[userID] = user
+	return nil
+}
+
+// SetUserRole gives a role to the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID,
+// and domain.ErrLastAdmin if the user is the last admin of its organization and the role is another one.
+func (ur *memoryUserRepository) SetUserRole(ctx context.Context, userId string, role string) error {
+	userID, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+	user, ok := ur.users[userID]
+	if !ok || !inTenant(org, user.OrgID) {
+		return domain.ErrUserNotFound
+	}
+	if user.Role == domain.RoleAdmin && role != domain.RoleAdmin && ur.countRole(user.OrgID, domain.RoleAdmin) == 1 {
+		return domain.ErrLastAdmin
+	}
+	user.Role = role
+	ur.users[userID] = user
+	return nil
+}
+
+// CountUsersWithRole counts the users of the organization of ctx having the given role.
+func (ur *memoryUserRepository) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+	return ur.countRole(org, role), nil
+}
+
+// countRole counts the users of an organization having the given role. The caller must hold the lock.
+func (ur *memoryUserRepository) countRole(orgId string, role string) int64 {
+	var count int64
+	for _, user := range ur.users {
+		if user.OrgID == orgId && user.Role == role {
+			count++
+		}
+	}
+	return count
+}
+
+// FindUsers returns one page of the users of the organization of ctx in the order they signed up.
+// It expects a normalized query with a positive limit.
+func (ur *memoryUserRepository) FindUsers(ctx context.Context, query domain.UserQuery) (domain.UserPage, error) {
+	var after domain.ID
+	if query.Cursor != "" {
+		id, err := domain.ParseID(query.Cursor)
+		if err != nil {
+			return domain.UserPage{}, err
+		}
+		after = id
+	}
+
+	orgId, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+	ur.mu.RLock()
+	defer ur.mu.RUnlock()
+	users := []domain.User{}
+	for _, user := range ur.users {
+		if user.OrgID != orgId || !strings.HasPrefix(user.Username, query.Search) {
+			continue
+		}
+		if query.Role != "" && user.Role != query.Role {
+			continue
+		}
+		if !after.IsZero() && user.ID.String() <= after.String() {
+			continue
+		}
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID.String() < users[j].ID.String()
+	})
+
+	page := domain.UserPage{Users: users}
+	if len(users) > query.Limit {
+		page.Users = users[:query.Limit]
+		page.NextCursor = page.Users[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
+
+// SetUserDeactivated deactivates the user with the given ID at the given time, or activates it again if nil.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *memoryUserRepository) SetUserDeactivated(ctx context.Context, userId string, deactivatedAt *time.Time) error {
+	userID, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+	user, ok := ur.users[userID]
+	if !ok || !inTenant(org, user.OrgID) {
+		return domain.ErrUserNotFound
+	}
+	user.DeactivatedAt = nil
+	if deactivatedAt != nil {
+		at := *deactivatedAt
+		user.DeactivatedAt = &at
+	}
+	ur.users[userID] = user
+	return nil
+}
+
+// DeleteUser deletes the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *memoryUserRepository) DeleteUser(ctx context.Context, userId string) error {
+	userID, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	org, err := tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	ur.mu.Lock()
+	defer ur.mu.Unlock()
+	user, ok := ur.users[userID]
+	if !ok || !inTenant(org, user.OrgID) {
+		return domain.ErrUserNotFound
+	}
+	delete(ur.users, userID)
+	delete(ur.byUsername, orgUsername{user.OrgID, user.Username})
+	return nil
+}
@@ -0,0 +1,210 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MigrateMongo brings the collections used by NewMongoStore up to date: it fills in the fields added
+// since documents were written and creates the indexes the queries rely on. It is safe to run on every start.
+func MigrateMongo(ctx context.Context, db mongo.Database) error {
+	// tasks written before optimistic concurrency control have no version yet
+	_, err := db.Collection("tasks").UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return err
+	}
+
+	// tasks written before labels have none
+	_, err = db.Collection("tasks").UpdateMany(ctx,
+		bson.M{"labels": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"labels": bson.A{}}},
+	)
+	if err != nil {
+		return err
+	}
+
+	// users, tasks and projects written before organizations belong to the default one
+	for _, collection := range []string{"users", "tasks", "projects"} {
+		_, err = db.Collection(collection).UpdateMany(ctx,
+			bson.M{"org_id": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"org_id": domain.DefaultOrganization}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// organizations with users written before the organization documents already have their first user
+	orgIds, err := db.Collection("users").Distinct(ctx, "org_id", bson.M{})
+	if err != nil {
+		return err
+	}
+	for _, orgId := range orgIds {
+		_, err = db.Collection("organizations").UpdateOne(ctx,
+			bson.M{"_id": orgId},
+			bson.M{"$setOnInsert": bson.M{"created_at": time.Now().UTC()}},
+			options.Update().SetUpsert(true),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	// labels written before every organization had its own catalog, keyed by name alone, are copied into
+	// the default organization and every organization with users, whose tasks may already have them
+	cursor, err := db.Collection("labels").Find(ctx, bson.M{"_id": bson.M{"$type": "string"}})
+	if err != nil {
+		return err
+	}
+	var sharedLabels []bson.M
+	if err := cursor.All(ctx, &sharedLabels); err != nil {
+		return err
+	}
+	for _, label := range sharedLabels {
+		name, _ := label["_id"].(string)
+		for _, orgId := range append([]interface{}{domain.DefaultOrganization}, orgIds...) {
+			org, _ := orgId.(string)
+			_, err = db.Collection("labels").UpdateOne(ctx,
+				bson.M{"_id": labelKey(org, name)},
+				bson.M{"$setOnInsert": bson.M{
+					"name":        name,
+					"org_id":      org,
+					"color":       label["color"],
+					"description": label["description"],
+					"created_at":  label["created_at"],
+				}},
+				options.Update().SetUpsert(true),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		if _, err = db.Collection("labels").DeleteOne(ctx, bson.M{"_id": name}); err != nil {
+			return err
+		}
+	}
+
+	// usernames are unique within an organization, and users log in by organization and username
+	_, err = db.Collection("users").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "username", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// the deletion of a role counts the users having it, and demotions count the admins of the organization
+	_, err = db.Collection("users").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "org_id", Value: 1}, {Key: "role", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the sessions of a user are revoked together when it is deactivated or deleted
+	_, err = db.Collection("refresh_tokens").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "user_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// every query on the tasks is restricted to the organization of the caller
+	_, err = db.Collection("tasks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "org_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the trash purge selects the tasks by deletion time
+	_, err = db.Collection("tasks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "deleted_at", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the trash purge finds the tasks it did not finish purging
+	_, err = db.Collection("tasks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "purge_started_at", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the subtasks of a task are listed and counted by parent
+	_, err = db.Collection("tasks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "parent_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the tasks of a project are listed by project
+	_, err = db.Collection("tasks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "project_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the tasks are filtered by label; the index is multikey since labels is an array
+	_, err = db.Collection("tasks").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "labels", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the checklist of a task is read in order
+	_, err = db.Collection("task_checklist_items").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "position", Value: 1}, {Key: "_id", Value: 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// a task depends at most once on another one, and its dependents are found by the task they depend on
+	_, err = db.Collection("task_dependencies").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "task_id", Value: 1}, {Key: "depends_on_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "depends_on_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// a user is a member of a project at most once, and the projects of a user are found by member
+	_, err = db.Collection("project_members").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "project_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the history, the comments and the attachments of a task are read in insertion order
+	for _, collection := range []string{"task_history", "task_comments", "task_attachments"} {
+		_, err = db.Collection(collection).Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "task_id", Value: 1}, {Key: "_id", Value: 1}},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package Repositories
+
+import (
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// parseObjectID converts a hex id from the outside world into an ObjectID,
+// reporting malformed ids as domain.ErrInvalidID.
+func parseObjectID(id string) (primitive.ObjectID, error) {
+	if _, err := domain.ParseID(id); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return primitive.ObjectIDFromHex(id)
+}
@@ -0,0 +1,227 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// projectRepository stores the projects in a MongoDB collection and their members in another one,
+// with a unique index on the project and the user created by MigrateMongo.
+type projectRepository struct {
+	database          mongo.Database
+	collection        string
+	membersCollection string
+}
+
+var _ domain.ProjectRepository = &projectRepository{}
+
+// NewProjectRepository creates a new instance of the ProjectRepository interface.
+// It takes a mongo.Database and the names of the collections of the projects and of their members as parameters.
+func NewProjectRepository(db mongo.Database, collection string, membersCollection string) domain.ProjectRepository {
+	return &projectRepository{
+		database:          db,
+		collection:        collection,
+		membersCollection: membersCollection,
+	}
+}
+
+// CreateProject stores a new project and returns it with its ID and organization set.
+func (pr *projectRepository) CreateProject(ctx context.Context, project domain.Project) (domain.Project, error) {
+	collection := pr.database.Collection(pr.collection)
+	project.ID = domain.NewID()
+	org, err := tenantOf(ctx, project.OrgID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	project.OrgID = org
+	if _, err := collection.InsertOne(ctx, project); err != nil {
+		return domain.Project{}, err
+	}
+	return project, nil
+}
+
+// FindProjectById retrieves a project by its ID.
+// It returns domain.ErrProjectNotFound if no project of the organization of ctx has this ID.
+func (pr *projectRepository) FindProjectById(ctx context.Context, projectId string) (domain.Project, error) {
+	collection := pr.database.Collection(pr.collection)
+	objID, err := parseObjectID(projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	var project domain.Project
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return domain.Project{}, err
+	}
+	err = collection.FindOne(ctx, filter).Decode(&project)
+	if err == mongo.ErrNoDocuments {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return project, nil
+}
+
+// FindMemberProjects returns the projects the user is a member of, in the order they were created.
+func (pr *projectRepository) FindMemberProjects(ctx context.Context, userId string) ([]domain.Project, error) {
+	members := pr.database.Collection(pr.membersCollection)
+	memberships, err := members.Find(ctx, bson.M{"user_id": userId})
+	if err != nil {
+		return nil, err
+	}
+	defer memberships.Close(ctx)
+
+	found := []domain.ProjectMember{}
+	if err := memberships.All(ctx, &found); err != nil {
+		return nil, err
+	}
+	projects := []domain.Project{}
+	if len(found) == 0 {
+		return projects, nil
+	}
+	ids := make([]primitive.ObjectID, 0, len(found))
+	for _, member := range found {
+		objID, err := parseObjectID(member.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, objID)
+	}
+	collection := pr.database.Collection(pr.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err := cursor.All(ctx, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// UpdateProject replaces the name and description of a project and returns it.
+// It returns domain.ErrProjectNotFound if no project has this ID.
+func (pr *projectRepository) UpdateProject(ctx context.Context, project domain.Project) (domain.Project, error) {
+	collection := pr.database.Collection(pr.collection)
+	objID, err := parseObjectID(project.ID.String())
+	if err != nil {
+		return domain.Project{}, err
+	}
+	update := bson.M{"$set": bson.M{"name": project.Name, "description": project.Description}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated domain.Project
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": objID}, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return updated, nil
+}
+
+// DeleteProject deletes a project with its members.
+// It returns domain.ErrProjectNotFound if no project has this ID.
+func (pr *projectRepository) DeleteProject(ctx context.Context, projectId string) error {
+	collection := pr.database.Collection(pr.collection)
+	objID, err := parseObjectID(projectId)
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrProjectNotFound
+	}
+	_, err = pr.database.Collection(pr.membersCollection).DeleteMany(ctx, bson.M{"project_id": projectId})
+	return err
+}
+
+// AddMember stores a new member of a project.
+// It returns domain.ErrMemberExists if the user is already a member of the project.
+func (pr *projectRepository) AddMember(ctx context.Context, member domain.ProjectMember) error {
+	collection := pr.database.Collection(pr.membersCollection)
+	filter := bson.M{"project_id": member.ProjectID, "user_id": member.UserID}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": member}, options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrMemberExists
+	}
+	if err != nil {
+		return err
+	}
+	if result.UpsertedCount == 0 {
+		return domain.ErrMemberExists
+	}
+	return nil
+}
+
+// FindMember returns the membership of a user in a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *projectRepository) FindMember(ctx context.Context, projectId string, userId string) (domain.ProjectMember, error) {
+	collection := pr.database.Collection(pr.membersCollection)
+	var member domain.ProjectMember
+	err := collection.FindOne(ctx, bson.M{"project_id": projectId, "user_id": userId}).Decode(&member)
+	if err == mongo.ErrNoDocuments {
+		return domain.ProjectMember{}, domain.ErrMemberNotFound
+	}
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	return member, nil
+}
+
+// FindMembers returns the members of a project, ordered by user ID.
+func (pr *projectRepository) FindMembers(ctx context.Context, projectId string) ([]domain.ProjectMember, error) {
+	collection := pr.database.Collection(pr.membersCollection)
+	opts := options.Find().SetSort(bson.D{{Key: "user_id", Value: 1}})
+	cursor, err := collection.Find(ctx, bson.M{"project_id": projectId}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	members := []domain.ProjectMember{}
+	if err := cursor.All(ctx, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// UpdateMember changes the role of a member of a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *projectRepository) UpdateMember(ctx context.Context, member domain.ProjectMember) error {
+	collection := pr.database.Collection(pr.membersCollection)
+	filter := bson.M{"project_id": member.ProjectID, "user_id": member.UserID}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"role": member.Role}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+// RemoveMember deletes the membership of a user in a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *projectRepository) RemoveMember(ctx context.Context, projectId string, userId string) error {
+	collection := pr.database.Collection(pr.membersCollection)
+	result, err := collection.DeleteOne(ctx, bson.M{"project_id": projectId, "user_id": userId})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
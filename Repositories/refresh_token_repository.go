@@ -0,0 +1,106 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// refreshTokenRepository stores the refresh tokens of the user sessions.
+type refreshTokenRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.RefreshTokenRepository = &refreshTokenRepository{}
+
+// NewRefreshTokenRepository creates a new instance of the RefreshTokenRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewRefreshTokenRepository(db mongo.Database, collection string) domain.RefreshTokenRepository {
+	return &refreshTokenRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// CreateRefreshToken stores a newly issued refresh token and returns it with its ID set.
+func (rr *refreshTokenRepository) CreateRefreshToken(ctx context.Context, token domain.RefreshToken) (domain.RefreshToken, error) {
+	collection := rr.database.Collection(rr.collection)
+	token.ID = domain.NewID()
+	if _, err := collection.InsertOne(ctx, token); err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// FindRefreshToken looks a refresh token up by the hash of its value.
+// It returns domain.ErrRefreshTokenNotFound if no token has this hash.
+func (rr *refreshTokenRepository) FindRefreshToken(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+	collection := rr.database.Collection(rr.collection)
+	var token domain.RefreshToken
+	err := collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&token)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return domain.RefreshToken{}, domain.ErrRefreshTokenNotFound
+		}
+		return domain.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// MarkRefreshTokenUsed records that the token has been exchanged.
+// The update only matches unused tokens, so when two requests race to use the same
+// token exactly one of them succeeds and the other gets domain.ErrRefreshTokenReused.
+func (rr *refreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) error {
+	collection := rr.database.Collection(rr.collection)
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return err
+	}
+	result, err := collection.UpdateOne(ctx,
+		bson.M{"_id": objID, "used_at": nil},
+		bson.M{"$set": bson.M{"used_at": usedAt}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeTokenFamily revokes every token of a session.
+// Tokens that were already revoked keep their original revocation time.
+func (rr *refreshTokenRepository) RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	collection := rr.database.Collection(rr.collection)
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"family_id": familyID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt}},
+	)
+	return err
+}
+
+// RevokeUserTokens revokes every session of a user.
+// Tokens that were already revoked keep their original revocation time.
+func (rr *refreshTokenRepository) RevokeUserTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	collection := rr.database.Collection(rr.collection)
+	_, err := collection.UpdateMany(ctx,
+		bson.M{"user_id": userID, "revoked_at": nil},
+		bson.M{"$set": bson.M{"revoked_at": revokedAt}},
+	)
+	return err
+}
+
+// IsTokenFamilyRevoked reports whether the session has been revoked.
+func (rr *refreshTokenRepository) IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	collection := rr.database.Collection(rr.collection)
+	count, err := collection.CountDocuments(ctx, bson.M{"family_id": familyID, "revoked_at": bson.M{"$ne": nil}})
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,94 @@
+package Repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RefreshTokenRepositoryTestSuite struct {
+	suite.Suite
+	client *mongo.Client
+	db     *mongo.Database
+	repo   domain.RefreshTokenRepository
+}
+
+// SetupSuite connects to the MongoDB test instance and initializes the repository under test.
+func (suite *RefreshTokenRepositoryTestSuite) SetupSuite() {
+	// Connect to the MongoDB test instance, skipping the suite when it is not running
+	client := connectTestMongo(suite.T())
+
+	suite.client = client
+	suite.db = client.Database("testRefreshTokens")
+	suite.repo = NewRefreshTokenRepository(*suite.db, "refresh_tokens")
+}
+
+// TearDownSuite drops the test database and disconnects from MongoDB.
+func (suite *RefreshTokenRepositoryTestSuite) TearDownSuite() {
+	err := suite.db.Drop(context.Background())
+	suite.Require().NoError(err)
+
+	err = suite.client.Disconnect(context.Background())
+	suite.Require().NoError(err)
+}
+
+// TestRotation tests that a refresh token can be found by its hash and marked used exactly once.
+func (suite *RefreshTokenRepositoryTestSuite) TestRotation() {
+	created, err := suite.repo.CreateRefreshToken(context.Background(), domain.RefreshToken{
+		UserID:    "user-1",
+		FamilyID:  "family-rotation",
+		TokenHash: "hash-rotation",
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	suite.Require().NoError(err)
+
+	found, err := suite.repo.FindRefreshToken(context.Background(), "hash-rotation")
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), created.ID, found.ID)
+	assert.Nil(suite.T(), found.UsedAt)
+
+	err = suite.repo.MarkRefreshTokenUsed(context.Background(), created.ID.String(), time.Now())
+	suite.Require().NoError(err)
+
+	err = suite.repo.MarkRefreshTokenUsed(context.Background(), created.ID.String(), time.Now())
+	assert.ErrorIs(suite.T(), err, domain.ErrRefreshTokenReused)
+
+	_, err = suite.repo.FindRefreshToken(context.Background(), "unknown-hash")
+	assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+}
+
+// TestRevokeTokenFamily tests that revoking a family is reported for that family only.
+func (suite *RefreshTokenRepositoryTestSuite) TestRevokeTokenFamily() {
+	for _, family := range []string{"family-revoked", "family-active"} {
+		_, err := suite.repo.CreateRefreshToken(context.Background(), domain.RefreshToken{
+			UserID:    "user-1",
+			FamilyID:  family,
+			TokenHash: "hash-" + family,
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		suite.Require().NoError(err)
+	}
+
+	err := suite.repo.RevokeTokenFamily(context.Background(), "family-revoked", time.Now())
+	suite.Require().NoError(err)
+
+	revoked, err := suite.repo.IsTokenFamilyRevoked(context.Background(), "family-revoked")
+	suite.Require().NoError(err)
+	assert.True(suite.T(), revoked)
+
+	revoked, err = suite.repo.IsTokenFamilyRevoked(context.Background(), "family-active")
+	suite.Require().NoError(err)
+	assert.False(suite.T(), revoked)
+}
+
+func TestRefreshTokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenRepositoryTestSuite))
+}
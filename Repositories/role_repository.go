@@ -0,0 +1,137 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// roleRepository stores the roles in a MongoDB collection, keyed by organization and role name.
+type roleRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.RoleRepository = &roleRepository{}
+
+// NewRoleRepository creates a new instance of the RoleRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewRoleRepository(db mongo.Database, collection string) domain.RoleRepository {
+	return &roleRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// roleKey is the _id of the document of a role, so that role names are unique within an organization.
+func roleKey(orgId string, name string) bson.D {
+	return bson.D{{Key: "org_id", Value: orgId}, {Key: "name", Value: name}}
+}
+
+// CreateRole stores a new role in the organization of ctx, or else in its own OrgID, and returns it.
+// It returns domain.ErrRoleExists if the organization already has a role with this name.
+func (rr *roleRepository) CreateRole(ctx context.Context, role domain.Role) (domain.Role, error) {
+	collection := rr.database.Collection(rr.collection)
+	org, err := tenantOf(ctx, role.OrgID)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	role.OrgID = org
+	_, err = collection.InsertOne(ctx, bson.M{
+		"_id":         roleKey(role.OrgID, role.Name),
+		"name":        role.Name,
+		"org_id":      role.OrgID,
+		"description": role.Description,
+		"permissions": role.Permissions,
+		"created_at":  role.CreatedAt,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.Role{}, domain.ErrRoleExists
+	}
+	if err != nil {
+		return domain.Role{}, err
+	}
+	return role, nil
+}
+
+// FindRoles returns the roles of the organization of ctx ordered by name.
+func (rr *roleRepository) FindRoles(ctx context.Context) ([]domain.Role, error) {
+	collection := rr.database.Collection(rr.collection)
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := collection.Find(ctx, bson.M{"org_id": org}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	roles := []domain.Role{}
+	if err := cursor.All(ctx, &roles); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// FindRoleByName retrieves a role of the organization of ctx by its name.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *roleRepository) FindRoleByName(ctx context.Context, name string) (domain.Role, error) {
+	collection := rr.database.Collection(rr.collection)
+	var role domain.Role
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Role{}, err
+	}
+	err = collection.FindOne(ctx, bson.M{"_id": roleKey(org, name)}).Decode(&role)
+	if err == mongo.ErrNoDocuments {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return domain.Role{}, err
+	}
+	return role, nil
+}
+
+// UpdateRole replaces the description and the permissions of a role of the organization of ctx and returns it.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *roleRepository) UpdateRole(ctx context.Context, role domain.Role) (domain.Role, error) {
+	collection := rr.database.Collection(rr.collection)
+	update := bson.M{"$set": bson.M{"description": role.Description, "permissions": role.Permissions}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var updated domain.Role
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Role{}, err
+	}
+	err = collection.FindOneAndUpdate(ctx, bson.M{"_id": roleKey(org, role.Name)}, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+	if err != nil {
+		return domain.Role{}, err
+	}
+	return updated, nil
+}
+
+// DeleteRole deletes a role of the organization of ctx.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *roleRepository) DeleteRole(ctx context.Context, name string) error {
+	collection := rr.database.Collection(rr.collection)
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, bson.M{"_id": roleKey(org, name)})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrRoleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlAttachmentRepository is an AttachmentRepository backed by the task_attachments table of a SQL database.
+type sqlAttachmentRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.AttachmentRepository = &sqlAttachmentRepository{}
+
+// NewSQLAttachmentRepository creates an AttachmentRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLAttachmentRepository(conn *sql.DB, dialect db.Dialect) domain.AttachmentRepository {
+	return &sqlAttachmentRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// attachmentColumns are the columns of the task_attachments table, in the order scanAttachments reads them.
+const attachmentColumns = "id, task_id, filename, content_type, size, uploaded_by, created_at"
+
+// CreateAttachment stores a new attachment with the ID it was given.
+func (ar *sqlAttachmentRepository) CreateAttachment(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error) {
+	_, err := ar.database.session().exec(ctx,
+		"INSERT INTO task_attachments ("+attachmentColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		attachment.ID.String(), attachment.TaskID, attachment.Filename, attachment.ContentType, attachment.Size,
+		attachment.UploadedBy, attachment.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// FindAttachmentById retrieves an attachment by its ID.
+// It returns domain.ErrAttachmentNotFound if no attachment has this ID.
+func (ar *sqlAttachmentRepository) FindAttachmentById(ctx context.Context, attachmentId string) (domain.Attachment, error) {
+	id, err := domain.ParseID(attachmentId)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	rows, err := ar.database.session().query(ctx, "SELECT "+attachmentColumns+" FROM task_attachments WHERE id = ?", id.String())
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	defer rows.Close()
+	attachments, err := scanAttachments(rows)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if len(attachments) == 0 {
+		return domain.Attachment{}, domain.ErrAttachmentNotFound
+	}
+	return attachments[0], nil
+}
+
+// FindTaskAttachments returns the attachments of a task, oldest first.
+func (ar *sqlAttachmentRepository) FindTaskAttachments(ctx context.Context, taskId string) ([]domain.Attachment, error) {
+	rows, err := ar.database.session().query(ctx,
+		"SELECT "+attachmentColumns+" FROM task_attachments WHERE task_id = ? ORDER BY id", taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanAttachments(rows)
+}
+
+// DeleteAttachment deletes an attachment by its ID.
+// It returns domain.ErrAttachmentNotFound if no attachment has this ID.
+func (ar *sqlAttachmentRepository) DeleteAttachment(ctx context.Context, attachmentId string) error {
+	id, err := domain.ParseID(attachmentId)
+	if err != nil {
+		return err
+	}
+	result, err := ar.database.session().exec(ctx, "DELETE FROM task_attachments WHERE id = ?", id.String())
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// DeleteTaskAttachments deletes every attachment of the given tasks.
+func (ar *sqlAttachmentRepository) DeleteTaskAttachments(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	list, args := sqlInList(taskIds)
+	_, err := ar.database.session().exec(ctx, "DELETE FROM task_attachments WHERE task_id IN ("+list+")", args...)
+	return err
+}
+
+// scanAttachments reads the attachments selected with attachmentColumns.
+func scanAttachments(rows *sql.Rows) ([]domain.Attachment, error) {
+	attachments := []domain.Attachment{}
+	for rows.Next() {
+		var attachment domain.Attachment
+		var id, taskID string
+		err := rows.Scan(&id, &taskID, &attachment.Filename, &attachment.ContentType, &attachment.Size,
+			&attachment.UploadedBy, &attachment.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		attachment.ID = domain.ID(strings.TrimSpace(id))
+		attachment.TaskID = strings.TrimSpace(taskID)
+		attachment.CreatedAt = attachment.CreatedAt.UTC()
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
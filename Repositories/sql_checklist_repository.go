@@ -0,0 +1,201 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlChecklistRepository is a ChecklistRepository backed by the task_checklist_items table of a SQL database.
+type sqlChecklistRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.ChecklistRepository = &sqlChecklistRepository{}
+
+// NewSQLChecklistRepository creates a ChecklistRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLChecklistRepository(conn *sql.DB, dialect db.Dialect) domain.ChecklistRepository {
+	return &sqlChecklistRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// checklistColumns are the columns of the task_checklist_items table, in the order scanChecklistItems reads them.
+const checklistColumns = "id, task_id, title, required, position, done, done_by, done_at, created_at"
+
+// CreateChecklistItem stores a new item and returns it with its ID set.
+func (cr *sqlChecklistRepository) CreateChecklistItem(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	item.ID = domain.NewID()
+	_, err := cr.database.session().exec(ctx,
+		"INSERT INTO task_checklist_items ("+checklistColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		item.ID.String(), item.TaskID, item.Title, item.Required, item.Position, item.Done,
+		sql.NullString{String: item.DoneBy, Valid: item.Done}, nullTime(item.DoneAt), item.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+// FindChecklistItemById retrieves a checklist item by its ID.
+// It returns domain.ErrChecklistItemNotFound if no item has this ID.
+func (cr *sqlChecklistRepository) FindChecklistItemById(ctx context.Context, itemId string) (domain.ChecklistItem, error) {
+	id, err := domain.ParseID(itemId)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return findChecklistItem(ctx, cr.database.session(), id)
+}
+
+// findChecklistItem loads the checklist item with the given ID.
+func findChecklistItem(ctx context.Context, s sqlSession, id domain.ID) (domain.ChecklistItem, error) {
+	rows, err := s.query(ctx, "SELECT "+checklistColumns+" FROM task_checklist_items WHERE id = ?", id.String())
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	defer rows.Close()
+	items, err := scanChecklistItems(rows)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if len(items) == 0 {
+		return domain.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	return items[0], nil
+}
+
+// FindChecklist returns the items of a task, ordered by position, then by ID.
+func (cr *sqlChecklistRepository) FindChecklist(ctx context.Context, taskId string) ([]domain.ChecklistItem, error) {
+	rows, err := cr.database.session().query(ctx,
+		"SELECT "+checklistColumns+" FROM task_checklist_items WHERE task_id = ? ORDER BY position, id", taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanChecklistItems(rows)
+}
+
+// UpdateChecklistItem replaces the title, required flag and completion of an item and returns the updated item.
+// It returns domain.ErrChecklistItemNotFound if no item has this ID.
+func (cr *sqlChecklistRepository) UpdateChecklistItem(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	var updated domain.ChecklistItem
+	err := cr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE task_checklist_items SET title = ?, required = ?, done = ?, done_by = ?, done_at = ? WHERE id = ?",
+			item.Title, item.Required, item.Done, sql.NullString{String: item.DoneBy, Valid: item.Done},
+			nullTime(item.DoneAt), item.ID.String(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrChecklistItemNotFound
+		}
+		updated, err = findChecklistItem(ctx, tx, item.ID)
+		return err
+	})
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return updated, nil
+}
+
+// ReorderChecklist moves every listed item of the task to its index in itemIds, in a single transaction.
+func (cr *sqlChecklistRepository) ReorderChecklist(ctx context.Context, taskId string, itemIds []string) error {
+	return cr.database.inTx(ctx, func(tx sqlSession) error {
+		for position, itemId := range itemIds {
+			_, err := tx.exec(ctx,
+				"UPDATE task_checklist_items SET position = ? WHERE id = ? AND task_id = ?", position, itemId, taskId,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteChecklistItem deletes a checklist item by its ID.
+// It returns domain.ErrChecklistItemNotFound if no item has this ID.
+func (cr *sqlChecklistRepository) DeleteChecklistItem(ctx context.Context, itemId string) error {
+	id, err := domain.ParseID(itemId)
+	if err != nil {
+		return err
+	}
+	result, err := cr.database.session().exec(ctx, "DELETE FROM task_checklist_items WHERE id = ?", id.String())
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrChecklistItemNotFound
+	}
+	return nil
+}
+
+// DeleteTaskChecklists deletes every item of the given tasks.
+func (cr *sqlChecklistRepository) DeleteTaskChecklists(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	list, args := sqlInList(taskIds)
+	_, err := cr.database.session().exec(ctx, "DELETE FROM task_checklist_items WHERE task_id IN ("+list+")", args...)
+	return err
+}
+
+// CountChecklists counts the items of each of the given tasks that has some.
+func (cr *sqlChecklistRepository) CountChecklists(ctx context.Context, taskIds []string) (map[string]domain.TaskSteps, error) {
+	counts := make(map[string]domain.TaskSteps, len(taskIds))
+	if len(taskIds) == 0 {
+		return counts, nil
+	}
+	list, args := sqlInList(taskIds)
+	rows, err := cr.database.session().query(ctx,
+		"SELECT task_id, COUNT(*),"+
+			" SUM(CASE WHEN done THEN 1 ELSE 0 END),"+
+			" SUM(CASE WHEN required AND NOT done THEN 1 ELSE 0 END)"+
+			" FROM task_checklist_items WHERE task_id IN ("+list+") GROUP BY task_id",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID string
+		var steps domain.TaskSteps
+		if err := rows.Scan(&taskID, &steps.Total, &steps.Done, &steps.OpenRequired); err != nil {
+			return nil, err
+		}
+		counts[strings.TrimSpace(taskID)] = steps
+	}
+	return counts, rows.Err()
+}
+
+// scanChecklistItems reads the items selected with checklistColumns.
+func scanChecklistItems(rows *sql.Rows) ([]domain.ChecklistItem, error) {
+	items := []domain.ChecklistItem{}
+	for rows.Next() {
+		var item domain.ChecklistItem
+		var id, taskID string
+		var doneBy sql.NullString
+		var doneAt sql.NullTime
+		err := rows.Scan(&id, &taskID, &item.Title, &item.Required, &item.Position, &item.Done, &doneBy, &doneAt, &item.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		item.ID = domain.ID(strings.TrimSpace(id))
+		item.TaskID = strings.TrimSpace(taskID)
+		item.DoneBy = doneBy.String
+		item.DoneAt = timePointer(doneAt)
+		item.CreatedAt = item.CreatedAt.UTC()
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
@@ -0,0 +1,209 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlCommentRepository is a CommentRepository backed by the task_comments table of a SQL database.
+type sqlCommentRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.CommentRepository = &sqlCommentRepository{}
+
+// NewSQLCommentRepository creates a CommentRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLCommentRepository(conn *sql.DB, dialect db.Dialect) domain.CommentRepository {
+	return &sqlCommentRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// commentColumns are the columns of the task_comments table, in the order scanComments reads them.
+const commentColumns = "id, task_id, author_id, body, created_at, updated_at"
+
+// CreateComment stores a new comment and returns it with its ID set.
+func (cr *sqlCommentRepository) CreateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	comment.ID = domain.NewID()
+	_, err := cr.database.session().exec(ctx,
+		"INSERT INTO task_comments ("+commentColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		comment.ID.String(), comment.TaskID, comment.AuthorID, comment.Body, comment.CreatedAt.UTC(), comment.UpdatedAt.UTC(),
+	)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return comment, nil
+}
+
+// FindCommentById retrieves a comment by its ID.
+// It returns domain.ErrCommentNotFound if no comment has this ID.
+func (cr *sqlCommentRepository) FindCommentById(ctx context.Context, commentId string) (domain.Comment, error) {
+	id, err := domain.ParseID(commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return findComment(ctx, cr.database.session(), id)
+}
+
+// findComment loads the comment with the given ID.
+func findComment(ctx context.Context, s sqlSession, id domain.ID) (domain.Comment, error) {
+	rows, err := s.query(ctx, "SELECT "+commentColumns+" FROM task_comments WHERE id = ?", id.String())
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	defer rows.Close()
+	comments, err := scanComments(rows)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if len(comments) == 0 {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+	return comments[0], nil
+}
+
+// FindComments returns one page of the comments of a task, oldest first, with keyset pagination on id.
+func (cr *sqlCommentRepository) FindComments(ctx context.Context, query domain.CommentQuery) (domain.CommentPage, error) {
+	statement := "SELECT " + commentColumns + " FROM task_comments WHERE task_id = ?"
+	args := []interface{}{query.TaskID}
+	if query.Cursor != "" {
+		lastID, err := domain.ParseID(query.Cursor)
+		if err != nil {
+			return domain.CommentPage{}, err
+		}
+		statement += " AND id > ?"
+		args = append(args, lastID.String())
+	}
+	// fetch one extra comment to know whether another page follows
+	statement += " ORDER BY id LIMIT ?"
+	args = append(args, query.Limit+1)
+
+	rows, err := cr.database.session().query(ctx, statement, args...)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+	defer rows.Close()
+	comments, err := scanComments(rows)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+
+	page := domain.CommentPage{Comments: comments}
+	if len(comments) > query.Limit {
+		page.Comments = comments[:query.Limit]
+		page.NextCursor = page.Comments[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
+
+// UpdateComment replaces the body and the update time of a comment and returns it.
+func (cr *sqlCommentRepository) UpdateComment(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+	var updated domain.Comment
+	err := cr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE task_comments SET body = ?, updated_at = ? WHERE id = ?",
+			comment.Body, comment.UpdatedAt.UTC(), comment.ID.String(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrCommentNotFound
+		}
+		updated, err = findComment(ctx, tx, comment.ID)
+		return err
+	})
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return updated, nil
+}
+
+// DeleteComment deletes a comment by its ID.
+// It returns domain.ErrCommentNotFound if no comment has this ID.
+func (cr *sqlCommentRepository) DeleteComment(ctx context.Context, commentId string) error {
+	id, err := domain.ParseID(commentId)
+	if err != nil {
+		return err
+	}
+	result, err := cr.database.session().exec(ctx, "DELETE FROM task_comments WHERE id = ?", id.String())
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrCommentNotFound
+	}
+	return nil
+}
+
+// DeleteTaskComments deletes every comment of the given tasks.
+func (cr *sqlCommentRepository) DeleteTaskComments(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	list, args := sqlInList(taskIds)
+	_, err := cr.database.session().exec(ctx, "DELETE FROM task_comments WHERE task_id IN ("+list+")", args...)
+	return err
+}
+
+// CountComments returns the number of comments of each of the given tasks that has some.
+func (cr *sqlCommentRepository) CountComments(ctx context.Context, taskIds []string) (map[string]int, error) {
+	counts := make(map[string]int, len(taskIds))
+	if len(taskIds) == 0 {
+		return counts, nil
+	}
+	list, args := sqlInList(taskIds)
+	rows, err := cr.database.session().query(ctx,
+		"SELECT task_id, COUNT(*) FROM task_comments WHERE task_id IN ("+list+") GROUP BY task_id",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID string
+		var n int
+		if err := rows.Scan(&taskID, &n); err != nil {
+			return nil, err
+		}
+		counts[strings.TrimSpace(taskID)] = n
+	}
+	return counts, rows.Err()
+}
+
+// sqlInList returns the placeholders and arguments of an IN list of the given values.
+func sqlInList(values []string) (string, []interface{}) {
+	placeholders := make([]string, 0, len(values))
+	args := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, "?")
+		args = append(args, value)
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+// scanComments reads the comments selected with commentColumns.
+func scanComments(rows *sql.Rows) ([]domain.Comment, error) {
+	comments := []domain.Comment{}
+	for rows.Next() {
+		var comment domain.Comment
+		var id, taskID string
+		if err := rows.Scan(&id, &taskID, &comment.AuthorID, &comment.Body, &comment.CreatedAt, &comment.UpdatedAt); err != nil {
+			return nil, err
+		}
+		comment.ID = domain.ID(strings.TrimSpace(id))
+		comment.TaskID = strings.TrimSpace(taskID)
+		comment.CreatedAt = comment.CreatedAt.UTC()
+		comment.UpdatedAt = comment.UpdatedAt.UTC()
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
@@ -0,0 +1,59 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+
+	"example/go-clean-architecture/db"
+)
+
+// sqlQueryer is implemented by *sql.DB and *sql.Tx.
+type sqlQueryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// sqlDatabase runs the queries of the SQL repositories, which are written with ? placeholders,
+// on a database of the given dialect.
+type sqlDatabase struct {
+	conn    *sql.DB
+	dialect db.Dialect
+}
+
+// sqlSession is a sqlDatabase bound to a connection or a transaction.
+type sqlSession struct {
+	q       sqlQueryer
+	dialect db.Dialect
+}
+
+func (s sqlSession) exec(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return s.q.ExecContext(ctx, s.dialect.Rebind(query), args...)
+}
+
+func (s sqlSession) query(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return s.q.QueryContext(ctx, s.dialect.Rebind(query), args...)
+}
+
+func (s sqlSession) queryRow(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return s.q.QueryRowContext(ctx, s.dialect.Rebind(query), args...)
+}
+
+// session returns a session running its queries outside of any transaction.
+func (d sqlDatabase) session() sqlSession {
+	return sqlSession{q: d.conn, dialect: d.dialect}
+}
+
+// inTx runs fn in a transaction, committed if fn succeeds and rolled back otherwise.
+func (d sqlDatabase) inTx(ctx context.Context, fn func(tx sqlSession) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(sqlSession{q: tx, dialect: d.dialect}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,119 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlDependencyRepository is a DependencyRepository backed by the task_dependencies table of a SQL database.
+type sqlDependencyRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.DependencyRepository = &sqlDependencyRepository{}
+
+// NewSQLDependencyRepository creates a DependencyRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLDependencyRepository(conn *sql.DB, dialect db.Dialect) domain.DependencyRepository {
+	return &sqlDependencyRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// dependencyColumns are the columns of the task_dependencies table, in the order scanDependencies reads them.
+const dependencyColumns = "task_id, depends_on_id, created_by, created_at"
+
+// AddDependency stores a new dependency.
+// It returns domain.ErrDependencyExists if the task already depends on the other one.
+func (dr *sqlDependencyRepository) AddDependency(ctx context.Context, dependency domain.TaskDependency) error {
+	result, err := dr.database.session().exec(ctx,
+		"INSERT INTO task_dependencies ("+dependencyColumns+") VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING",
+		dependency.TaskID, dependency.DependsOnID, dependency.CreatedBy, dependency.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrDependencyExists
+	}
+	return nil
+}
+
+// RemoveDependency deletes the dependency of a task on another one.
+// It returns domain.ErrDependencyNotFound if the task does not depend on the other one.
+func (dr *sqlDependencyRepository) RemoveDependency(ctx context.Context, taskId string, dependsOnId string) error {
+	result, err := dr.database.session().exec(ctx,
+		"DELETE FROM task_dependencies WHERE task_id = ? AND depends_on_id = ?", taskId, dependsOnId,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// FindDependencies returns the dependencies of the given tasks on other tasks.
+func (dr *sqlDependencyRepository) FindDependencies(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+	return dr.find(ctx, "task_id", taskIds)
+}
+
+// FindDependents returns the dependencies of other tasks on the given tasks.
+func (dr *sqlDependencyRepository) FindDependents(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+	return dr.find(ctx, "depends_on_id", taskIds)
+}
+
+// find returns the dependencies whose column is one of the given tasks,
+// ordered by task, then by the task depended on.
+func (dr *sqlDependencyRepository) find(ctx context.Context, column string, taskIds []string) ([]domain.TaskDependency, error) {
+	if len(taskIds) == 0 {
+		return []domain.TaskDependency{}, nil
+	}
+	list, args := sqlInList(taskIds)
+	rows, err := dr.database.session().query(ctx,
+		"SELECT "+dependencyColumns+" FROM task_dependencies WHERE "+column+" IN ("+list+") ORDER BY task_id, depends_on_id",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanDependencies(rows)
+}
+
+// DeleteTaskDependencies deletes every dependency from or to the given tasks.
+func (dr *sqlDependencyRepository) DeleteTaskDependencies(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	list, args := sqlInList(taskIds)
+	_, err := dr.database.session().exec(ctx,
+		"DELETE FROM task_dependencies WHERE task_id IN ("+list+") OR depends_on_id IN ("+list+")",
+		append(args, args...)...,
+	)
+	return err
+}
+
+// scanDependencies reads the dependencies selected with dependencyColumns.
+func scanDependencies(rows *sql.Rows) ([]domain.TaskDependency, error) {
+	dependencies := []domain.TaskDependency{}
+	for rows.Next() {
+		var dependency domain.TaskDependency
+		var taskID, dependsOnID string
+		if err := rows.Scan(&taskID, &dependsOnID, &dependency.CreatedBy, &dependency.CreatedAt); err != nil {
+			return nil, err
+		}
+		dependency.TaskID = strings.TrimSpace(taskID)
+		dependency.DependsOnID = strings.TrimSpace(dependsOnID)
+		dependency.CreatedAt = dependency.CreatedAt.UTC()
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, rows.Err()
+}
@@ -0,0 +1,153 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlLabelRepository is a LabelRepository backed by the labels table of a SQL database, keyed by organization and name.
+type sqlLabelRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.LabelRepository = &sqlLabelRepository{}
+
+// NewSQLLabelRepository creates a LabelRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLLabelRepository(conn *sql.DB, dialect db.Dialect) domain.LabelRepository {
+	return &sqlLabelRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// labelColumns are the columns of the labels table, in the order scanLabels reads them.
+const labelColumns = "org_id, name, color, description, created_at"
+
+// CreateLabel stores a new label in the organization of ctx, or else in its own OrgID, and returns it.
+// It returns domain.ErrLabelExists if a label of the organization already has this name.
+func (lr *sqlLabelRepository) CreateLabel(ctx context.Context, label domain.Label) (domain.Label, error) {
+	org, err := tenantOf(ctx, label.OrgID)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	label.OrgID = org
+	result, err := lr.database.session().exec(ctx,
+		"INSERT INTO labels ("+labelColumns+") VALUES (?, ?, ?, ?, ?) ON CONFLICT DO NOTHING",
+		label.OrgID, label.Name, label.Color, label.Description, label.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return domain.Label{}, err
+	} else if n == 0 {
+		return domain.Label{}, domain.ErrLabelExists
+	}
+	return label, nil
+}
+
+// FindLabels returns every label of the organization of ctx, ordered by name.
+func (lr *sqlLabelRepository) FindLabels(ctx context.Context) ([]domain.Label, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := lr.database.session().query(ctx, "SELECT "+labelColumns+" FROM labels WHERE org_id = ? ORDER BY name", org)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanLabels(rows)
+}
+
+// FindLabelByName retrieves a label of the organization of ctx by its name.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *sqlLabelRepository) FindLabelByName(ctx context.Context, name string) (domain.Label, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return findLabel(ctx, lr.database.session(), org, name)
+}
+
+// UpdateLabel replaces the colour and description of a label of the organization of ctx and returns it.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *sqlLabelRepository) UpdateLabel(ctx context.Context, label domain.Label) (domain.Label, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Label{}, err
+	}
+	var updated domain.Label
+	err = lr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE labels SET color = ?, description = ? WHERE org_id = ? AND name = ?", label.Color, label.Description, org, label.Name,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrLabelNotFound
+		}
+		updated, err = findLabel(ctx, tx, org, label.Name)
+		return err
+	})
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return updated, nil
+}
+
+// DeleteLabel deletes a label of the organization of ctx.
+// It returns domain.ErrLabelNotFound if no label of the organization has this name.
+func (lr *sqlLabelRepository) DeleteLabel(ctx context.Context, name string) error {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return err
+	}
+	result, err := lr.database.session().exec(ctx, "DELETE FROM labels WHERE org_id = ? AND name = ?", org, name)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrLabelNotFound
+	}
+	return nil
+}
+
+// findLabel loads the label of the organization with the given name.
+func findLabel(ctx context.Context, s sqlSession, orgId string, name string) (domain.Label, error) {
+	rows, err := s.query(ctx, "SELECT "+labelColumns+" FROM labels WHERE org_id = ? AND name = ?", orgId, name)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	defer rows.Close()
+	labels, err := scanLabels(rows)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if len(labels) == 0 {
+		return domain.Label{}, domain.ErrLabelNotFound
+	}
+	return labels[0], nil
+}
+
+// scanLabels reads the labels selected with labelColumns.
+func scanLabels(rows *sql.Rows) ([]domain.Label, error) {
+	labels := []domain.Label{}
+	for rows.Next() {
+		var label domain.Label
+		if err := rows.Scan(&label.OrgID, &label.Name, &label.Color, &label.Description, &label.CreatedAt); err != nil {
+			return nil, err
+		}
+		label.Color = strings.TrimSpace(label.Color)
+		label.CreatedAt = label.CreatedAt.UTC()
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
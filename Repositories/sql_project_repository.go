@@ -0,0 +1,255 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlProjectRepository is a ProjectRepository backed by the projects and project_members tables of a SQL database.
+type sqlProjectRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.ProjectRepository = &sqlProjectRepository{}
+
+// NewSQLProjectRepository creates a ProjectRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLProjectRepository(conn *sql.DB, dialect db.Dialect) domain.ProjectRepository {
+	return &sqlProjectRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// projectColumns are the columns of the projects table, in the order scanProjects reads them.
+const projectColumns = "id, name, description, created_by, created_at, org_id"
+
+// projectMemberColumns are the columns of the project_members table, in the order scanProjectMembers reads them.
+const projectMemberColumns = "project_id, user_id, role, added_at"
+
+// CreateProject stores a new project and returns it with its ID and organization set.
+func (pr *sqlProjectRepository) CreateProject(ctx context.Context, project domain.Project) (domain.Project, error) {
+	project.ID = domain.NewID()
+	org, err := tenantOf(ctx, project.OrgID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	project.OrgID = org
+	_, err = pr.database.session().exec(ctx,
+		"INSERT INTO projects ("+projectColumns+") VALUES (?, ?, ?, ?, ?, ?)",
+		project.ID.String(), project.Name, project.Description, project.CreatedBy, project.CreatedAt.UTC(), project.OrgID,
+	)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return project, nil
+}
+
+// FindProjectById retrieves a project by its ID.
+// It returns domain.ErrProjectNotFound if no project of the organization of ctx has this ID.
+func (pr *sqlProjectRepository) FindProjectById(ctx context.Context, projectId string) (domain.Project, error) {
+	id, err := domain.ParseID(projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return findProject(ctx, pr.database.session(), id)
+}
+
+// FindMemberProjects returns the projects the user is a member of, in the order they were created.
+func (pr *sqlProjectRepository) FindMemberProjects(ctx context.Context, userId string) ([]domain.Project, error) {
+	rows, err := pr.database.session().query(ctx,
+		"SELECT "+projectColumns+" FROM projects WHERE id IN (SELECT project_id FROM project_members WHERE user_id = ?) ORDER BY created_at, id",
+		userId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanProjects(rows)
+}
+
+// UpdateProject replaces the name and description of a project and returns it.
+// It returns domain.ErrProjectNotFound if no project has this ID.
+func (pr *sqlProjectRepository) UpdateProject(ctx context.Context, project domain.Project) (domain.Project, error) {
+	var updated domain.Project
+	err := pr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE projects SET name = ?, description = ? WHERE id = ?", project.Name, project.Description, project.ID.String(),
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrProjectNotFound
+		}
+		updated, err = findProject(ctx, tx, project.ID)
+		return err
+	})
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return updated, nil
+}
+
+// DeleteProject deletes a project with its members.
+// It returns domain.ErrProjectNotFound if no project has this ID.
+func (pr *sqlProjectRepository) DeleteProject(ctx context.Context, projectId string) error {
+	id, err := domain.ParseID(projectId)
+	if err != nil {
+		return err
+	}
+	return pr.database.inTx(ctx, func(tx sqlSession) error {
+		if _, err := tx.exec(ctx, "DELETE FROM project_members WHERE project_id = ?", id.String()); err != nil {
+			return err
+		}
+		result, err := tx.exec(ctx, "DELETE FROM projects WHERE id = ?", id.String())
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrProjectNotFound
+		}
+		return nil
+	})
+}
+
+// AddMember stores a new member of a project.
+// It returns domain.ErrMemberExists if the user is already a member of the project.
+func (pr *sqlProjectRepository) AddMember(ctx context.Context, member domain.ProjectMember) error {
+	result, err := pr.database.session().exec(ctx,
+		"INSERT INTO project_members ("+projectMemberColumns+") VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING",
+		member.ProjectID, member.UserID, member.Role, member.AddedAt.UTC(),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrMemberExists
+	}
+	return nil
+}
+
+// FindMember returns the membership of a user in a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *sqlProjectRepository) FindMember(ctx context.Context, projectId string, userId string) (domain.ProjectMember, error) {
+	rows, err := pr.database.session().query(ctx,
+		"SELECT "+projectMemberColumns+" FROM project_members WHERE project_id = ? AND user_id = ?", projectId, userId,
+	)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	defer rows.Close()
+	members, err := scanProjectMembers(rows)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if len(members) == 0 {
+		return domain.ProjectMember{}, domain.ErrMemberNotFound
+	}
+	return members[0], nil
+}
+
+// FindMembers returns the members of a project, ordered by user ID.
+func (pr *sqlProjectRepository) FindMembers(ctx context.Context, projectId string) ([]domain.ProjectMember, error) {
+	rows, err := pr.database.session().query(ctx,
+		"SELECT "+projectMemberColumns+" FROM project_members WHERE project_id = ? ORDER BY user_id", projectId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanProjectMembers(rows)
+}
+
+// UpdateMember changes the role of a member of a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *sqlProjectRepository) UpdateMember(ctx context.Context, member domain.ProjectMember) error {
+	result, err := pr.database.session().exec(ctx,
+		"UPDATE project_members SET role = ? WHERE project_id = ? AND user_id = ?", member.Role, member.ProjectID, member.UserID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+// RemoveMember deletes the membership of a user in a project.
+// It returns domain.ErrMemberNotFound if the user is not a member of the project.
+func (pr *sqlProjectRepository) RemoveMember(ctx context.Context, projectId string, userId string) error {
+	result, err := pr.database.session().exec(ctx,
+		"DELETE FROM project_members WHERE project_id = ? AND user_id = ?", projectId, userId,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+// findProject loads the project with the given ID, if it is of the organization of ctx.
+func findProject(ctx context.Context, s sqlSession, id domain.ID) (domain.Project, error) {
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	rows, err := s.query(ctx, "SELECT "+projectColumns+" FROM projects WHERE id = ?"+tenant, append([]interface{}{id.String()}, tenantArgs...)...)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	defer rows.Close()
+	projects, err := scanProjects(rows)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if len(projects) == 0 {
+		return domain.Project{}, domain.ErrProjectNotFound
+	}
+	return projects[0], nil
+}
+
+// scanProjects reads the projects selected with projectColumns.
+func scanProjects(rows *sql.Rows) ([]domain.Project, error) {
+	projects := []domain.Project{}
+	for rows.Next() {
+		var project domain.Project
+		var id string
+		if err := rows.Scan(&id, &project.Name, &project.Description, &project.CreatedBy, &project.CreatedAt, &project.OrgID); err != nil {
+			return nil, err
+		}
+		project.ID = domain.ID(strings.TrimSpace(id))
+		project.CreatedAt = project.CreatedAt.UTC()
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+// scanProjectMembers reads the members selected with projectMemberColumns.
+func scanProjectMembers(rows *sql.Rows) ([]domain.ProjectMember, error) {
+	members := []domain.ProjectMember{}
+	for rows.Next() {
+		var member domain.ProjectMember
+		if err := rows.Scan(&member.ProjectID, &member.UserID, &member.Role, &member.AddedAt); err != nil {
+			return nil, err
+		}
+		member.ProjectID = strings.TrimSpace(member.ProjectID)
+		member.AddedAt = member.AddedAt.UTC()
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
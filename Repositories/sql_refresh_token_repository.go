@@ -0,0 +1,138 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlRefreshTokenRepository is a RefreshTokenRepository backed by the refresh_tokens table of a SQL database.
+type sqlRefreshTokenRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.RefreshTokenRepository = &sqlRefreshTokenRepository{}
+
+// NewSQLRefreshTokenRepository creates a RefreshTokenRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLRefreshTokenRepository(conn *sql.DB, dialect db.Dialect) domain.RefreshTokenRepository {
+	return &sqlRefreshTokenRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// refreshTokenColumns are the columns of the refresh_tokens table, in the order FindRefreshToken reads them.
+const refreshTokenColumns = "id, user_id, family_id, token_hash, created_at, expires_at, used_at, revoked_at"
+
+// CreateRefreshToken stores a newly issued refresh token and returns it with its ID set.
+func (rr *sqlRefreshTokenRepository) CreateRefreshToken(ctx context.Context, token domain.RefreshToken) (domain.RefreshToken, error) {
+	token.ID = domain.NewID()
+	_, err := rr.database.session().exec(ctx,
+		"INSERT INTO refresh_tokens ("+refreshTokenColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		token.ID.String(), token.UserID, token.FamilyID, token.TokenHash,
+		token.CreatedAt.UTC(), token.ExpiresAt.UTC(), nullTime(token.UsedAt), nullTime(token.RevokedAt),
+	)
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// FindRefreshToken looks a refresh token up by the hash of its value.
+// It returns domain.ErrRefreshTokenNotFound if no token has this hash.
+func (rr *sqlRefreshTokenRepository) FindRefreshToken(ctx context.Context, tokenHash string) (domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	var id string
+	var usedAt, revokedAt sql.NullTime
+	err := rr.database.session().queryRow(ctx,
+		"SELECT "+refreshTokenColumns+" FROM refresh_tokens WHERE token_hash = ?", tokenHash,
+	).Scan(&id, &token.UserID, &token.FamilyID, &token.TokenHash, &token.CreatedAt, &token.ExpiresAt, &usedAt, &revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.RefreshToken{}, domain.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		return domain.RefreshToken{}, err
+	}
+
+	token.ID = domain.ID(strings.TrimSpace(id))
+	token.CreatedAt = token.CreatedAt.UTC()
+	token.ExpiresAt = token.ExpiresAt.UTC()
+	token.UsedAt = timePointer(usedAt)
+	token.RevokedAt = timePointer(revokedAt)
+	return token, nil
+}
+
+// MarkRefreshTokenUsed records that the token has been exchanged.
+// The update only matches an unused token, so it returns domain.ErrRefreshTokenReused
+// to every caller but the first.
+func (rr *sqlRefreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) error {
+	tokenID, err := domain.ParseID(id)
+	if err != nil {
+		return err
+	}
+	result, err := rr.database.session().exec(ctx,
+		"UPDATE refresh_tokens SET used_at = ? WHERE id = ? AND used_at IS NULL",
+		usedAt.UTC(), tokenID.String(),
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RevokeTokenFamily revokes every token of a session.
+// Tokens that were already revoked keep their original revocation time.
+func (rr *sqlRefreshTokenRepository) RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	_, err := rr.database.session().exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL",
+		revokedAt.UTC(), familyID,
+	)
+	return err
+}
+
+// RevokeUserTokens revokes every session of a user.
+// Tokens that were already revoked keep their original revocation time.
+func (rr *sqlRefreshTokenRepository) RevokeUserTokens(ctx context.Context, userID string, revokedAt time.Time) error {
+	_, err := rr.database.session().exec(ctx,
+		"UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL",
+		revokedAt.UTC(), userID,
+	)
+	return err
+}
+
+// IsTokenFamilyRevoked reports whether the session has been revoked.
+func (rr *sqlRefreshTokenRepository) IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	var count int
+	err := rr.database.session().queryRow(ctx,
+		"SELECT COUNT(*) FROM refresh_tokens WHERE family_id = ? AND revoked_at IS NOT NULL", familyID,
+	).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// nullTime converts an optional time to a nullable column value.
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+// timePointer converts a nullable column value to an optional time in UTC.
+func timePointer(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	utc := t.Time.UTC()
+	return &utc
+}
@@ -0,0 +1,90 @@
+package Repositories
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSQLiteUserRepository_ConcurrentSignUp tests that two processes sharing a SQLite file keep usernames
+// unique and make exactly one of many concurrent first users an admin.
+func TestSQLiteUserRepository_ConcurrentSignUp(t *testing.T) {
+	dsn := db.SQLiteDSN(filepath.Join(t.TempDir(), "tasks.db"))
+	var repos []domain.UserRepository
+	for i := 0; i < 2; i++ {
+		conn, err := db.OpenSQL(context.Background(), db.SQLite, dsn)
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+		_, err = db.Migrate(context.Background(), conn, db.SQLite)
+		require.NoError(t, err)
+		repos = append(repos, NewSQLUserRepository(conn, db.SQLite))
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan error, 40)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := repos[i%2].CreateNewUser(inDefaultOrganization(), &domain.User{Username: fmt.Sprintf("user-%d", i%20), Password: "hash"})
+			results <- err
+		}(i)
+	}
+	wg.Wait()
+	close(results)
+
+	created := 0
+	for err := range results {
+		if err == nil {
+			created++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrUsernameTaken)
+		}
+	}
+	assert.Equal(t, 20, created)
+
+	admins := 0
+	for i := 0; i < 20; i++ {
+		user, err := repos[0].FindUser(inDefaultOrganization(), fmt.Sprintf("user-%d", i))
+		require.NoError(t, err)
+		if user.Role == domain.RoleAdmin {
+			admins++
+		}
+	}
+	assert.Equal(t, 1, admins)
+}
+
+// TestSQLite_PersistsAcrossRestarts tests that the data of a SQLite file survives reopening it
+// and that migrating an up to date schema applies nothing.
+func TestSQLite_PersistsAcrossRestarts(t *testing.T) {
+	dsn := db.SQLiteDSN(filepath.Join(t.TempDir(), "tasks.db"))
+
+	conn, err := db.OpenSQL(context.Background(), db.SQLite, dsn)
+	require.NoError(t, err)
+	applied, err := db.Migrate(context.Background(), conn, db.SQLite)
+	require.NoError(t, err)
+	assert.Positive(t, applied)
+	task, err := NewSQLTaskRepository(conn, db.SQLite).CreateTask(inDefaultOrganization(), domain.Task{Title: "Persisted", Status: "Pending", AssigneeIDs: []string{"u1"}})
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	conn, err = db.OpenSQL(context.Background(), db.SQLite, dsn)
+	require.NoError(t, err)
+	defer conn.Close()
+	applied, err = db.Migrate(context.Background(), conn, db.SQLite)
+	require.NoError(t, err)
+	assert.Zero(t, applied)
+
+	found, err := NewSQLTaskRepository(conn, db.SQLite).FindTaskById(inDefaultOrganization(), task.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "Persisted", found.Title)
+	assert.Equal(t, []string{"u1"}, found.AssigneeIDs)
+}
@@ -0,0 +1,155 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlRoleRepository is a RoleRepository backed by the roles table of a SQL database.
+type sqlRoleRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.RoleRepository = &sqlRoleRepository{}
+
+// NewSQLRoleRepository creates a RoleRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLRoleRepository(conn *sql.DB, dialect db.Dialect) domain.RoleRepository {
+	return &sqlRoleRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// roleColumns are the columns of the roles table, in the order scanRoles reads them.
+const roleColumns = "org_id, name, description, permissions, created_at"
+
+// CreateRole stores a new role in the organization of ctx, or else in its own OrgID, and returns it.
+// It returns domain.ErrRoleExists if the organization already has a role with this name.
+func (rr *sqlRoleRepository) CreateRole(ctx context.Context, role domain.Role) (domain.Role, error) {
+	org, err := tenantOf(ctx, role.OrgID)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	role.OrgID = org
+	result, err := rr.database.session().exec(ctx,
+		"INSERT INTO roles ("+roleColumns+") VALUES (?, ?, ?, ?, ?) ON CONFLICT DO NOTHING",
+		role.OrgID, role.Name, role.Description, strings.Join(role.Permissions, " "), role.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return domain.Role{}, err
+	} else if n == 0 {
+		return domain.Role{}, domain.ErrRoleExists
+	}
+	return role, nil
+}
+
+// FindRoles returns the roles of the organization of ctx ordered by name.
+func (rr *sqlRoleRepository) FindRoles(ctx context.Context) ([]domain.Role, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	rows, err := rr.database.session().query(ctx, "SELECT "+roleColumns+" FROM roles WHERE org_id = ? ORDER BY name", org)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanRoles(rows)
+}
+
+// FindRoleByName retrieves a role of the organization of ctx by its name.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *sqlRoleRepository) FindRoleByName(ctx context.Context, name string) (domain.Role, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Role{}, err
+	}
+	return findRole(ctx, rr.database.session(), org, name)
+}
+
+// UpdateRole replaces the description and the permissions of a role of the organization of ctx and returns it.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *sqlRoleRepository) UpdateRole(ctx context.Context, role domain.Role) (domain.Role, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.Role{}, err
+	}
+	var updated domain.Role
+	err = rr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE roles SET description = ?, permissions = ? WHERE org_id = ? AND name = ?",
+			role.Description, strings.Join(role.Permissions, " "), org, role.Name,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrRoleNotFound
+		}
+		updated, err = findRole(ctx, tx, org, role.Name)
+		return err
+	})
+	if err != nil {
+		return domain.Role{}, err
+	}
+	return updated, nil
+}
+
+// DeleteRole deletes a role of the organization of ctx.
+// It returns domain.ErrRoleNotFound if the organization has no role with this name.
+func (rr *sqlRoleRepository) DeleteRole(ctx context.Context, name string) error {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return err
+	}
+	result, err := rr.database.session().exec(ctx, "DELETE FROM roles WHERE org_id = ? AND name = ?", org, name)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrRoleNotFound
+	}
+	return nil
+}
+
+// findRole loads the role of an organization with the given name.
+func findRole(ctx context.Context, s sqlSession, orgId string, name string) (domain.Role, error) {
+	rows, err := s.query(ctx, "SELECT "+roleColumns+" FROM roles WHERE org_id = ? AND name = ?", orgId, name)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	defer rows.Close()
+	roles, err := scanRoles(rows)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	if len(roles) == 0 {
+		return domain.Role{}, domain.ErrRoleNotFound
+	}
+	return roles[0], nil
+}
+
+// scanRoles reads the roles selected with roleColumns.
+func scanRoles(rows *sql.Rows) ([]domain.Role, error) {
+	roles := []domain.Role{}
+	for rows.Next() {
+		var role domain.Role
+		var permissions string
+		if err := rows.Scan(&role.OrgID, &role.Name, &role.Description, &permissions, &role.CreatedAt); err != nil {
+			return nil, err
+		}
+		role.Permissions = strings.Fields(permissions)
+		role.CreatedAt = role.CreatedAt.UTC()
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
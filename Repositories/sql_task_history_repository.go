@@ -0,0 +1,96 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlTaskHistoryRepository is a TaskHistoryRepository backed by the task_history table of a SQL database.
+type sqlTaskHistoryRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.TaskHistoryRepository = &sqlTaskHistoryRepository{}
+
+// NewSQLTaskHistoryRepository creates a TaskHistoryRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLTaskHistoryRepository(conn *sql.DB, dialect db.Dialect) domain.TaskHistoryRepository {
+	return &sqlTaskHistoryRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// taskHistoryColumns are the columns of the task_history table, in the order FindTaskHistory reads them.
+const taskHistoryColumns = "id, task_id, action, actor_id, at, version, changes"
+
+// AppendTaskHistory stores a new entry and returns it with its ID set.
+// The changes are stored as a JSON array.
+func (hr *sqlTaskHistoryRepository) AppendTaskHistory(ctx context.Context, entry domain.TaskHistoryEntry) (domain.TaskHistoryEntry, error) {
+	if entry.Changes == nil {
+		entry.Changes = []domain.FieldChange{}
+	}
+	changes, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return domain.TaskHistoryEntry{}, err
+	}
+	entry.ID = domain.NewID()
+	_, err = hr.database.session().exec(ctx,
+		"INSERT INTO task_history ("+taskHistoryColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		entry.ID.String(), entry.TaskID, entry.Action, entry.ActorID, entry.At.UTC(), entry.Version, string(changes),
+	)
+	if err != nil {
+		return domain.TaskHistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+// FindTaskHistory returns one page of the entries of a task, oldest first, with keyset pagination on id.
+func (hr *sqlTaskHistoryRepository) FindTaskHistory(ctx context.Context, query domain.TaskHistoryQuery) (domain.TaskHistoryPage, error) {
+	statement := "SELECT " + taskHistoryColumns + " FROM task_history WHERE task_id = ?"
+	args := []interface{}{query.TaskID}
+	if query.Cursor != "" {
+		lastID, err := domain.ParseID(query.Cursor)
+		if err != nil {
+			return domain.TaskHistoryPage{}, err
+		}
+		statement += " AND id > ?"
+		args = append(args, lastID.String())
+	}
+	// fetch one extra entry to know whether another page follows
+	statement += " ORDER BY id LIMIT ?"
+	args = append(args, query.Limit+1)
+
+	rows, err := hr.database.session().query(ctx, statement, args...)
+	if err != nil {
+		return domain.TaskHistoryPage{}, err
+	}
+	defer rows.Close()
+	entries := []domain.TaskHistoryEntry{}
+	for rows.Next() {
+		var entry domain.TaskHistoryEntry
+		var id, taskID, changes string
+		if err := rows.Scan(&id, &taskID, &entry.Action, &entry.ActorID, &entry.At, &entry.Version, &changes); err != nil {
+			return domain.TaskHistoryPage{}, err
+		}
+		if err := json.Unmarshal([]byte(changes), &entry.Changes); err != nil {
+			return domain.TaskHistoryPage{}, err
+		}
+		entry.ID = domain.ID(strings.TrimSpace(id))
+		entry.TaskID = strings.TrimSpace(taskID)
+		entry.At = entry.At.UTC()
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.TaskHistoryPage{}, err
+	}
+
+	page := domain.TaskHistoryPage{Entries: entries}
+	if len(entries) > query.Limit {
+		page.Entries = entries[:query.Limit]
+		page.NextCursor = page.Entries[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
@@ -0,0 +1,758 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlTaskRepository is a TaskRepository backed by the tasks, task_assignees and task_labels tables of a SQL database.
+type sqlTaskRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.TaskRepository = &sqlTaskRepository{}
+
+// NewSQLTaskRepository creates a TaskRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLTaskRepository(conn *sql.DB, dialect db.Dialect) domain.TaskRepository {
+	return &sqlTaskRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// taskColumns are the columns of the tasks table, in the order scanTasks reads them.
+const taskColumns = "id, title, description, due_date, status, created_by, version, deleted_at, deleted_by, parent_id, project_id, org_id"
+
+// taskSortColumns maps the domain sort keys to the columns they order by.
+var taskSortColumns = map[string]string{
+	domain.TaskSortID:      "id",
+	domain.TaskSortDueDate: "due_date",
+	domain.TaskSortTitle:   "title",
+	domain.TaskSortStatus:  "status",
+}
+
+// FindAlltasks retrieves all tasks that are not in the trash, in the order they were created.
+func (tr *sqlTaskRepository) FindAlltasks(ctx context.Context) ([]domain.Task, error) {
+	s := tr.database.session()
+	tenant, args, err := sqlTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.query(ctx, "SELECT "+taskColumns+" FROM tasks WHERE deleted_at IS NULL"+tenant+" ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	return tasks, loadTaskLists(ctx, s, tasks)
+}
+
+// FindTasks retrieves one page of tasks matching the query, with keyset pagination on the sort column and id.
+// It expects a normalized query with a positive limit.
+func (tr *sqlTaskRepository) FindTasks(ctx context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	key, descending, err := domain.ParseTaskSort(query.Sort)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	column := taskSortColumns[key]
+	direction, after := "ASC", ">"
+	if descending {
+		direction, after = "DESC", "<"
+	}
+
+	conditions := []string{"deleted_at IS NULL"}
+	if query.Deleted {
+		conditions[0] = "deleted_at IS NOT NULL AND purge_started_at IS NULL"
+	}
+	var args []interface{}
+	org, err := tenant(ctx)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	if org != "" {
+		conditions = append(conditions, "org_id = ?")
+		args = append(args, org)
+	}
+	if query.VisibleTo != "" {
+		conditions = append(conditions, "(created_by = ? OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?))")
+		args = append(args, query.VisibleTo, query.VisibleTo)
+	}
+	if query.ParentID != "" {
+		conditions = append(conditions, "parent_id = ?")
+		args = append(args, query.ParentID)
+	}
+	if query.ProjectID != "" {
+		conditions = append(conditions, "project_id = ?")
+		args = append(args, query.ProjectID)
+	}
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, query.Status)
+	}
+	if query.DueFrom != nil {
+		conditions = append(conditions, "due_date >= ?")
+		args = append(args, query.DueFrom.UTC())
+	}
+	if query.DueTo != nil {
+		conditions = append(conditions, "due_date <= ?")
+		args = append(args, query.DueTo.UTC())
+	}
+	if query.TitlePrefix != "" {
+		// substr rather than LIKE, whose case sensitivity and wildcards differ between databases
+		conditions = append(conditions, "substr(title, 1, ?) = ?")
+		args = append(args, utf8.RuneCountInString(query.TitlePrefix), query.TitlePrefix)
+	}
+	if len(query.Labels) > 0 {
+		labels := uniqueStrings(query.Labels)
+		list, labelArgs := sqlInList(labels)
+		if query.LabelMatch == domain.LabelMatchAll {
+			// a task has each label once, so it has all of them when it has as many of them as there are
+			conditions = append(conditions, "(SELECT COUNT(*) FROM task_labels l WHERE l.org_id = tasks.org_id AND l.task_id = tasks.id AND l.label IN ("+list+")) = ?")
+			args = append(append(args, labelArgs...), len(labels))
+		} else {
+			conditions = append(conditions, "EXISTS (SELECT 1 FROM task_labels l WHERE l.org_id = tasks.org_id AND l.task_id = tasks.id AND l.label IN ("+list+"))")
+			args = append(args, labelArgs...)
+		}
+	}
+	if query.Cursor != "" {
+		last, err := taskFromCursor(query, key)
+		if err != nil {
+			return domain.TaskPage{}, err
+		}
+		if column == "id" {
+			conditions = append(conditions, "id "+after+" ?")
+			args = append(args, last.ID.String())
+		} else {
+			lastValue := taskColumnValue(*last, key)
+			conditions = append(conditions, fmt.Sprintf("(%s %s ? OR (%s = ? AND id %s ?))", column, after, column, after))
+			args = append(args, lastValue, lastValue, last.ID.String())
+		}
+	}
+
+	statement := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(conditions, " AND ") + " ORDER BY "
+	if column != "id" {
+		statement += column + " " + direction + ", "
+	}
+	// fetch one extra task to know whether another page follows
+	statement += "id " + direction + " LIMIT ?"
+	args = append(args, query.Limit+1)
+
+	s := tr.database.session()
+	rows, err := s.query(ctx, statement, args...)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		page.NextCursor = domain.NewTaskCursor(query.Sort, page.Tasks[query.Limit-1]).Encode()
+	}
+	return page, loadTaskLists(ctx, s, page.Tasks)
+}
+
+// taskColumnValue returns the value of the sort column of the task.
+func taskColumnValue(task domain.Task, key string) interface{} {
+	switch key {
+	case domain.TaskSortDueDate:
+		return task.DueDate.UTC()
+	case domain.TaskSortTitle:
+		return task.Title
+	case domain.TaskSortStatus:
+		return task.Status
+	}
+	return task.ID.String()
+}
+
+// FindTaskById retrieves a task by its ID.
+// It returns domain.ErrTaskNotFound if no task has this ID or the task is in the trash.
+func (tr *sqlTaskRepository) FindTaskById(ctx context.Context, taskId string) (domain.Task, error) {
+	id, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return findTask(ctx, tr.database.session(), id, false)
+}
+
+// FindDeletedTaskById retrieves a task of the trash by its ID.
+// It returns domain.ErrTaskNotFound if no task of the trash has this ID.
+func (tr *sqlTaskRepository) FindDeletedTaskById(ctx context.Context, taskId string) (domain.Task, error) {
+	id, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return findTask(ctx, tr.database.session(), id, true)
+}
+
+// findTask loads the task with the given ID with its assignees and labels, from the trash if deleted is true.
+func findTask(ctx context.Context, s sqlSession, id domain.ID, deleted bool) (domain.Task, error) {
+	statement := "SELECT " + taskColumns + " FROM tasks WHERE id = ? AND deleted_at IS NULL"
+	if deleted {
+		statement = "SELECT " + taskColumns + " FROM tasks WHERE id = ? AND deleted_at IS NOT NULL"
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	rows, err := s.query(ctx, statement+tenant, append([]interface{}{id.String()}, tenantArgs...)...)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if len(tasks) == 0 {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	if err := loadTaskLists(ctx, s, tasks); err != nil {
+		return domain.Task{}, err
+	}
+	return tasks[0], nil
+}
+
+// CreateTask stores a new task and returns it with its ID and organization set.
+func (tr *sqlTaskRepository) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
+	task.ID = domain.NewID()
+	task.Version = 1
+	org, err := tenantOf(ctx, task.OrgID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.OrgID = org
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		_, err := tx.exec(ctx,
+			"INSERT INTO tasks ("+taskColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, NULL, NULL, ?, ?, ?)",
+			task.ID.String(), task.Title, task.Description, task.DueDate.UTC(), task.Status, task.CreatedBy, task.Version,
+			sql.NullString{String: task.ParentID, Valid: task.ParentID != ""},
+			sql.NullString{String: task.ProjectID, Valid: task.ProjectID != ""},
+			task.OrgID,
+		)
+		if err != nil {
+			return err
+		}
+		if err := insertAssignees(ctx, tx, task.ID, task.AssigneeIDs); err != nil {
+			return err
+		}
+		return insertLabels(ctx, tx, task.ID, task.Labels)
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+// UpdateTaskById replaces the editable fields of the task with the given ID and returns the updated task.
+// It returns domain.ErrTaskNotFound if no task has this ID and domain.ErrVersionMismatch
+// if the task is not at updatedTask.Version.
+func (tr *sqlTaskRepository) UpdateTaskById(ctx context.Context, updatedTask domain.Task, id string) (domain.Task, error) {
+	taskID, err := domain.ParseID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	var updated domain.Task
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE tasks SET title = ?, description = ?, due_date = ?, status = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL"+tenant,
+			append([]interface{}{updatedTask.Title, updatedTask.Description, updatedTask.DueDate.UTC(), updatedTask.Status, taskID.String(), updatedTask.Version}, tenantArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if err := checkTaskWritten(ctx, tx, result, taskID); err != nil {
+			return err
+		}
+
+		if _, err := tx.exec(ctx, "DELETE FROM task_assignees WHERE task_id = ?", taskID.String()); err != nil {
+			return err
+		}
+		if err := insertAssignees(ctx, tx, taskID, updatedTask.AssigneeIDs); err != nil {
+			return err
+		}
+		if _, err := tx.exec(ctx, "DELETE FROM task_labels WHERE task_id = ?", taskID.String()); err != nil {
+			return err
+		}
+		if err := insertLabels(ctx, tx, taskID, updatedTask.Labels); err != nil {
+			return err
+		}
+		updated, err = findTask(ctx, tx, taskID, false)
+		return err
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return updated, nil
+}
+
+// PatchTaskById changes only the fields set in the patch and returns the updated task.
+// It returns domain.ErrTaskNotFound if no task has this ID and domain.ErrVersionMismatch
+// if the task is not at patch.Version. The version is incremented even if only the assignees or labels change.
+func (tr *sqlTaskRepository) PatchTaskById(ctx context.Context, patch domain.TaskPatch, id string) (domain.Task, error) {
+	taskID, err := domain.ParseID(id)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	columns := []string{"version = version + 1"}
+	var args []interface{}
+	if patch.Title != nil {
+		columns = append(columns, "title = ?")
+		args = append(args, *patch.Title)
+	}
+	if patch.Description != nil {
+		columns = append(columns, "description = ?")
+		args = append(args, *patch.Description)
+	}
+	if patch.DueDate != nil {
+		columns = append(columns, "due_date = ?")
+		args = append(args, patch.DueDate.UTC())
+	}
+	if patch.Status != nil {
+		columns = append(columns, "status = ?")
+		args = append(args, *patch.Status)
+	}
+
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	var updated domain.Task
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE tasks SET "+strings.Join(columns, ", ")+" WHERE id = ? AND version = ? AND deleted_at IS NULL"+tenant,
+			append(append(args, taskID.String(), patch.Version), tenantArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if err := checkTaskWritten(ctx, tx, result, taskID); err != nil {
+			return err
+		}
+		if patch.AssigneeIDs != nil {
+			if _, err := tx.exec(ctx, "DELETE FROM task_assignees WHERE task_id = ?", taskID.String()); err != nil {
+				return err
+			}
+			if err := insertAssignees(ctx, tx, taskID, *patch.AssigneeIDs); err != nil {
+				return err
+			}
+		}
+		if patch.Labels != nil {
+			if _, err := tx.exec(ctx, "DELETE FROM task_labels WHERE task_id = ?", taskID.String()); err != nil {
+				return err
+			}
+			if err := insertLabels(ctx, tx, taskID, *patch.Labels); err != nil {
+				return err
+			}
+		}
+		updated, err = findTask(ctx, tx, taskID, false)
+		return err
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return updated, nil
+}
+
+// DeleteTask moves the task with the given ID to the trash if it is at the given version and returns it.
+// It returns domain.ErrTaskNotFound if no task has this ID and domain.ErrVersionMismatch if the task is at another version.
+func (tr *sqlTaskRepository) DeleteTask(ctx context.Context, taskId string, version int64, deletedBy string, deletedAt time.Time) (domain.Task, error) {
+	id, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	var deleted domain.Task
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE tasks SET deleted_at = ?, deleted_by = ?, version = version + 1 WHERE id = ? AND version = ? AND deleted_at IS NULL"+tenant,
+			append([]interface{}{deletedAt.UTC(), deletedBy, id.String(), version}, tenantArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if err := checkTaskWritten(ctx, tx, result, id); err != nil {
+			return err
+		}
+		deleted, err = findTask(ctx, tx, id, true)
+		return err
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return deleted, nil
+}
+
+// RestoreTask takes the task with the given ID out of the trash and returns it.
+// It returns domain.ErrTaskNotFound if no task with this ID is in the trash.
+func (tr *sqlTaskRepository) RestoreTask(ctx context.Context, taskId string) (domain.Task, error) {
+	id, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	var restored domain.Task
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		result, err := tx.exec(ctx,
+			"UPDATE tasks SET deleted_at = NULL, deleted_by = NULL, version = version + 1 WHERE id = ? AND deleted_at IS NOT NULL AND purge_started_at IS NULL"+tenant,
+			append([]interface{}{id.String()}, tenantArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return domain.ErrTaskNotFound
+		}
+		restored, err = findTask(ctx, tx, id, false)
+		return err
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return restored, nil
+}
+
+// StartPurge marks the tasks moved to the trash before deletedBefore as being purged and returns the IDs
+// of every task being purged.
+func (tr *sqlTaskRepository) StartPurge(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	purging := []string{}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		_, err := tx.exec(ctx,
+			"UPDATE tasks SET purge_started_at = ? WHERE deleted_at < ? AND purge_started_at IS NULL"+tenant,
+			append([]interface{}{time.Now().UTC(), deletedBefore.UTC()}, tenantArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		rows, err := tx.query(ctx, "SELECT id FROM tasks WHERE purge_started_at IS NOT NULL"+tenant+" ORDER BY id", tenantArgs...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			purging = append(purging, strings.TrimSpace(id))
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return purging, nil
+}
+
+// PurgeTasks permanently deletes the given tasks being purged, with their assignees and labels,
+// and detaches their subtasks.
+func (tr *sqlTaskRepository) PurgeTasks(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	list, listArgs := sqlInList(taskIds)
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return err
+	}
+	args := append(listArgs, tenantArgs...)
+	return tr.database.inTx(ctx, func(tx sqlSession) error {
+		_, err := tx.exec(ctx, "UPDATE tasks SET parent_id = NULL, version = version + 1 WHERE parent_id IN ("+list+")"+tenant, args...)
+		if err != nil {
+			return err
+		}
+		purged := "SELECT id FROM tasks WHERE id IN (" + list + ") AND purge_started_at IS NOT NULL" + tenant
+		for _, table := range []string{"task_assignees", "task_labels"} {
+			if _, err := tx.exec(ctx, "DELETE FROM "+table+" WHERE task_id IN ("+purged+")", args...); err != nil {
+				return err
+			}
+		}
+		_, err = tx.exec(ctx, "DELETE FROM tasks WHERE id IN ("+list+") AND purge_started_at IS NOT NULL"+tenant, args...)
+		return err
+	})
+}
+
+// CountSubtasks returns the number of live subtasks of each of the given tasks in each status.
+func (tr *sqlTaskRepository) CountSubtasks(ctx context.Context, parentIds []string) (map[string]map[string]int, error) {
+	counts := make(map[string]map[string]int)
+	if len(parentIds) == 0 {
+		return counts, nil
+	}
+	list, args := sqlInList(parentIds)
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tr.database.session().query(ctx,
+		"SELECT parent_id, status, COUNT(*) FROM tasks WHERE deleted_at IS NULL AND parent_id IN ("+list+")"+tenant+" GROUP BY parent_id, status",
+		append(args, tenantArgs...)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var parentID, status string
+		var count int
+		if err := rows.Scan(&parentID, &status, &count); err != nil {
+			return nil, err
+		}
+		parentID = strings.TrimSpace(parentID)
+		if counts[parentID] == nil {
+			counts[parentID] = make(map[string]int)
+		}
+		counts[parentID][status] = count
+	}
+	return counts, rows.Err()
+}
+
+// ReassignTasks replaces the user fromUserId by toUserId as the creator and as an assignee of every task,
+// including the ones in the trash, and returns the number of tasks changed. An assignee is replaced in place,
+// or removed if toUserId is already assigned to the task.
+func (tr *sqlTaskRepository) ReassignTasks(ctx context.Context, fromUserId string, toUserId string) (int64, error) {
+	var ids []string
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return 0, err
+	}
+	err = tr.database.inTx(ctx, func(tx sqlSession) error {
+		rows, err := tx.query(ctx,
+			"SELECT id FROM tasks WHERE (created_by = ? OR EXISTS (SELECT 1 FROM task_assignees a WHERE a.task_id = tasks.id AND a.user_id = ?))"+tenant,
+			append([]interface{}{fromUserId, fromUserId}, tenantArgs...)...,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var id string
+			if err := rows.Scan(&id); err != nil {
+				return err
+			}
+			ids = append(ids, strings.TrimSpace(id))
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		rows.Close()
+		if len(ids) == 0 {
+			return nil
+		}
+
+		list, args := sqlInList(ids)
+		statements := []struct {
+			query string
+			args  []interface{}
+		}{
+			{"UPDATE tasks SET version = version + 1 WHERE id IN (" + list + ")", args},
+			{"UPDATE tasks SET created_by = ? WHERE created_by = ? AND id IN (" + list + ")", append([]interface{}{toUserId, fromUserId}, args...)},
+			{
+				"DELETE FROM task_assignees WHERE user_id = ? AND task_id IN (SELECT task_id FROM task_assignees WHERE user_id = ?) AND task_id IN (" + list + ")",
+				append([]interface{}{fromUserId, toUserId}, args...),
+			},
+			{"UPDATE task_assignees SET user_id = ? WHERE user_id = ? AND task_id IN (" + list + ")", append([]interface{}{toUserId, fromUserId}, args...)},
+		}
+		for _, statement := range statements {
+			if _, err := tx.exec(ctx, statement.query, statement.args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// checkTaskWritten checks that a conditional write on the task with the given ID changed a row.
+// Otherwise it returns domain.ErrTaskNotFound if the task does not exist or is in the trash,
+// and domain.ErrVersionMismatch if it is at another version.
+func checkTaskWritten(ctx context.Context, s sqlSession, result sql.Result, id domain.ID) error {
+	n, err := result.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+	var exists int
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return err
+	}
+	err = s.queryRow(ctx, "SELECT 1 FROM tasks WHERE id = ? AND deleted_at IS NULL"+tenant, append([]interface{}{id.String()}, tenantArgs...)...).Scan(&exists)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return domain.ErrVersionMismatch
+}
+
+// scanTasks reads the tasks selected with taskColumns and closes rows.
+func scanTasks(rows *sql.Rows) ([]domain.Task, error) {
+	defer rows.Close()
+	tasks := []domain.Task{}
+	for rows.Next() {
+		var task domain.Task
+		var id string
+		var deletedAt sql.NullTime
+		var deletedBy, parentID, projectID sql.NullString
+		if err := rows.Scan(&id, &task.Title, &task.Description, &task.DueDate, &task.Status, &task.CreatedBy, &task.Version, &deletedAt, &deletedBy, &parentID, &projectID, &task.OrgID); err != nil {
+			return nil, err
+		}
+		task.ID = domain.ID(strings.TrimSpace(id))
+		task.DeletedAt = timePointer(deletedAt)
+		task.DeletedBy = deletedBy.String
+		task.ParentID = strings.TrimSpace(parentID.String)
+		task.ProjectID = strings.TrimSpace(projectID.String)
+		task.DueDate = task.DueDate.UTC()
+		task.AssigneeIDs = []string{}
+		task.Labels = []string{}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// loadTaskLists sets the assignees and the labels of the tasks.
+func loadTaskLists(ctx context.Context, s sqlSession, tasks []domain.Task) error {
+	if err := loadAssignees(ctx, s, tasks); err != nil {
+		return err
+	}
+	return loadLabels(ctx, s, tasks)
+}
+
+// loadAssignees sets the assignees of the tasks, in the order they were stored.
+func loadAssignees(ctx context.Context, s sqlSession, tasks []domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	index := make(map[string]int, len(tasks))
+	placeholders := make([]string, 0, len(tasks))
+	args := make([]interface{}, 0, len(tasks))
+	for i, task := range tasks {
+		index[task.ID.String()] = i
+		placeholders = append(placeholders, "?")
+		args = append(args, task.ID.String())
+	}
+
+	rows, err := s.query(ctx,
+		"SELECT task_id, user_id FROM task_assignees WHERE task_id IN ("+strings.Join(placeholders, ", ")+") ORDER BY task_id, position",
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID, userID string
+		if err := rows.Scan(&taskID, &userID); err != nil {
+			return err
+		}
+		i, ok := index[strings.TrimSpace(taskID)]
+		if !ok {
+			return errors.New("assignee of an unexpected task")
+		}
+		tasks[i].AssigneeIDs = append(tasks[i].AssigneeIDs, userID)
+	}
+	return rows.Err()
+}
+
+// insertAssignees stores the assignees of a task in order.
+func insertAssignees(ctx context.Context, tx sqlSession, taskID domain.ID, assigneeIDs []string) error {
+	for position, userID := range assigneeIDs {
+		_, err := tx.exec(ctx,
+			"INSERT INTO task_assignees (task_id, position, user_id) VALUES (?, ?, ?)",
+			taskID.String(), position, userID,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadLabels sets the labels of the tasks, in name order.
+func loadLabels(ctx context.Context, s sqlSession, tasks []domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	index := make(map[string]int, len(tasks))
+	ids := make([]string, 0, len(tasks))
+	for i, task := range tasks {
+		index[task.ID.String()] = i
+		ids = append(ids, task.ID.String())
+	}
+	list, args := sqlInList(ids)
+	rows, err := s.query(ctx, "SELECT task_id, label FROM task_labels WHERE task_id IN ("+list+") ORDER BY task_id, label", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var taskID, label string
+		if err := rows.Scan(&taskID, &label); err != nil {
+			return err
+		}
+		i, ok := index[strings.TrimSpace(taskID)]
+		if !ok {
+			return errors.New("label of an unexpected task")
+		}
+		tasks[i].Labels = append(tasks[i].Labels, label)
+	}
+	return rows.Err()
+}
+
+// insertLabels stores the labels of a task in the organization of the task. A label given twice is stored once.
+func insertLabels(ctx context.Context, tx sqlSession, taskID domain.ID, labels []string) error {
+	for _, label := range labels {
+		_, err := tx.exec(ctx,
+			"INSERT INTO task_labels (task_id, org_id, label) SELECT id, org_id, ? FROM tasks WHERE id = ? ON CONFLICT DO NOTHING",
+			label, taskID.String(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uniqueStrings returns the values without duplicates, in the order they first appear.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
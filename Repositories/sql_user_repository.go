@@ -0,0 +1,328 @@
+package Repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+)
+
+// sqlUserRepository is a UserRepository backed by the users table of a SQL database.
+type sqlUserRepository struct {
+	database sqlDatabase
+}
+
+var _ domain.UserRepository = &sqlUserRepository{}
+
+// NewSQLUserRepository creates a UserRepository on a SQL database of the given dialect.
+// The schema is created by db.Migrate.
+func NewSQLUserRepository(conn *sql.DB, dialect db.Dialect) domain.UserRepository {
+	return &sqlUserRepository{database: sqlDatabase{conn: conn, dialect: dialect}}
+}
+
+// userColumns are the columns of the users table, in the order scanUser reads them.
+const userColumns = "id, username, password, role, created_at, updated_at, org_id, deactivated_at"
+
+// userTableLocks holds the statement each dialect uses to serialize sign-ups, so that exactly one
+// of several concurrent first sign-ups to an organization becomes its admin.
+var userTableLocks = map[db.Dialect]string{
+	db.Postgres: "LOCK TABLE users IN SHARE ROW EXCLUSIVE MODE",
+}
+
+// FindUser retrieves a user of the organization of ctx by its username.
+// It returns domain.ErrUserNotFound if no user has this username.
+func (ur *sqlUserRepository) FindUser(ctx context.Context, username string) (domain.User, error) {
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.User{}, err
+	}
+	row := ur.database.session().queryRow(ctx, "SELECT "+userColumns+" FROM users WHERE username = ? AND org_id = ?", username, org)
+	return scanUser(row)
+}
+
+// FindUserById retrieves a user by its ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *sqlUserRepository) FindUserById(ctx context.Context, userId string) (domain.User, error) {
+	id, err := domain.ParseID(userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return domain.User{}, err
+	}
+	row := ur.database.session().queryRow(ctx, "SELECT "+userColumns+" FROM users WHERE id = ?"+tenant, append([]interface{}{id.String()}, tenantArgs...)...)
+	return scanUser(row)
+}
+
+// CreateNewUser stores a new user and returns it with its ID, organization and role set.
+// The user joins the organization of ctx, or else its own OrgID. The first user of an organization
+// becomes an admin and every later user a regular user.
+// It returns domain.ErrUsernameTaken if the username is already used in the organization.
+func (ur *sqlUserRepository) CreateNewUser(ctx context.Context, user *domain.User) (domain.User, error) {
+	return ur.insertUser(ctx, user, false)
+}
+
+// CreateOrganization stores the first user of the organization of ctx, or else of its own OrgID, as its admin
+// and returns it with its ID, organization and role set.
+// It returns domain.ErrOrganizationExists if the organization already has users.
+func (ur *sqlUserRepository) CreateOrganization(ctx context.Context, admin *domain.User) (domain.User, error) {
+	return ur.insertUser(ctx, admin, true)
+}
+
+// insertUser stores a new user like CreateNewUser does, or, if newOrganization is set, like CreateOrganization does.
+func (ur *sqlUserRepository) insertUser(ctx context.Context, user *domain.User, newOrganization bool) (domain.User, error) {
+	org, err := tenantOf(ctx, user.OrgID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	user.OrgID = org
+	err = ur.database.inTx(ctx, func(tx sqlSession) error {
+		if lock, ok := userTableLocks[tx.dialect]; ok {
+			if _, err := tx.exec(ctx, lock); err != nil {
+				return err
+			}
+		}
+
+		var taken, users int
+		err := tx.queryRow(ctx,
+			"SELECT (SELECT COUNT(*) FROM users WHERE username = ? AND org_id = ?), (SELECT COUNT(*) FROM users WHERE org_id = ?)",
+			user.Username, user.OrgID, user.OrgID,
+		).Scan(&taken, &users)
+		if err != nil {
+			return err
+		}
+		if newOrganization && users > 0 {
+			return domain.ErrOrganizationExists
+		}
+		if taken > 0 {
+			return domain.ErrUsernameTaken
+		}
+
+		if users == 0 {
+			user.Role = domain.RoleAdmin
+		} else {
+			user.Role = domain.RoleUser
+		}
+		user.ID = domain.NewID()
+
+		_, err = tx.exec(ctx,
+			"INSERT INTO users ("+userColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			user.ID.String(), user.Username, user.Password, user.Role, user.CreatedAt.UTC(), user.UpdatedAt.UTC(), user.OrgID, nullTime(user.DeactivatedAt),
+		)
+		return err
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+	return *user, nil
+}
+
+// PromoteUser gives the admin role to the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *sqlUserRepository) PromoteUser(ctx context.Context, userId string) error {
+	id, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := ur.database.session().exec(ctx, "UPDATE users SET role = ? WHERE id = ?"+tenant, append([]interface{}{domain.RoleAdmin, id.String()}, tenantArgs...)...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// SetUserRole gives a role to the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID,
+// and domain.ErrLastAdmin if the user is the last admin of its organization and the role is another one.
+// The table is locked like for sign-ups, so that concurrent demotions cannot remove every admin.
+func (ur *sqlUserRepository) SetUserRole(ctx context.Context, userId string, role string) error {
+	id, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return err
+	}
+	return ur.database.inTx(ctx, func(tx sqlSession) error {
+		if lock, ok := userTableLocks[tx.dialect]; ok {
+			if _, err := tx.exec(ctx, lock); err != nil {
+				return err
+			}
+		}
+
+		user, err := scanUser(tx.queryRow(ctx, "SELECT "+userColumns+" FROM users WHERE id = ?"+tenant, append([]interface{}{id.String()}, tenantArgs...)...))
+		if err != nil {
+			return err
+		}
+		if user.Role == domain.RoleAdmin && role != domain.RoleAdmin {
+			var admins int
+			err := tx.queryRow(ctx, "SELECT COUNT(*) FROM users WHERE role = ? AND org_id = ?", domain.RoleAdmin, user.OrgID).Scan(&admins)
+			if err != nil {
+				return err
+			}
+			if admins == 1 {
+				return domain.ErrLastAdmin
+			}
+		}
+		_, err = tx.exec(ctx, "UPDATE users SET role = ? WHERE id = ?", role, id.String())
+		return err
+	})
+}
+
+// CountUsersWithRole counts the users of the organization of ctx having the given role.
+func (ur *sqlUserRepository) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	var count int64
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	err = ur.database.session().queryRow(ctx, "SELECT COUNT(*) FROM users WHERE role = ? AND org_id = ?", role, org).Scan(&count)
+	return count, err
+}
+
+// FindUsers returns one page of the users of the organization of ctx in the order they signed up,
+// with keyset pagination on id. It expects a normalized query with a positive limit.
+func (ur *sqlUserRepository) FindUsers(ctx context.Context, query domain.UserQuery) (domain.UserPage, error) {
+	statement := "SELECT " + userColumns + " FROM users WHERE org_id = ?"
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+	args := []interface{}{org}
+	if query.Search != "" {
+		// substr rather than LIKE, like the title prefix of the tasks
+		statement += " AND substr(username, 1, ?) = ?"
+		args = append(args, utf8.RuneCountInString(query.Search), query.Search)
+	}
+	if query.Role != "" {
+		statement += " AND role = ?"
+		args = append(args, query.Role)
+	}
+	if query.Cursor != "" {
+		lastID, err := domain.ParseID(query.Cursor)
+		if err != nil {
+			return domain.UserPage{}, err
+		}
+		statement += " AND id > ?"
+		args = append(args, lastID.String())
+	}
+	// fetch one extra user to know whether another page follows
+	statement += " ORDER BY id LIMIT ?"
+	args = append(args, query.Limit+1)
+
+	rows, err := ur.database.session().query(ctx, statement, args...)
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+	defer rows.Close()
+	users, err := scanUsers(rows)
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+
+	page := domain.UserPage{Users: users}
+	if len(users) > query.Limit {
+		page.Users = users[:query.Limit]
+		page.NextCursor = page.Users[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
+
+// SetUserDeactivated deactivates the user with the given ID at the given time, or activates it again if nil.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *sqlUserRepository) SetUserDeactivated(ctx context.Context, userId string, deactivatedAt *time.Time) error {
+	id, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := ur.database.session().exec(ctx, "UPDATE users SET deactivated_at = ? WHERE id = ?"+tenant, append([]interface{}{nullTime(deactivatedAt), id.String()}, tenantArgs...)...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// DeleteUser deletes the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *sqlUserRepository) DeleteUser(ctx context.Context, userId string) error {
+	id, err := domain.ParseID(userId)
+	if err != nil {
+		return err
+	}
+	tenant, tenantArgs, err := sqlTenant(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := ur.database.session().exec(ctx, "DELETE FROM users WHERE id = ?"+tenant, append([]interface{}{id.String()}, tenantArgs...)...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// rowScanner is a *sql.Row or the current row of a *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser reads a user selected with userColumns.
+func scanUser(row rowScanner) (domain.User, error) {
+	var user domain.User
+	var id string
+	var deactivatedAt sql.NullTime
+	err := row.Scan(&id, &user.Username, &user.Password, &user.Role, &user.CreatedAt, &user.UpdatedAt, &user.OrgID, &deactivatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.User{}, domain.ErrUserNotFound
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+	user.ID = domain.ID(strings.TrimSpace(id))
+	user.CreatedAt = user.CreatedAt.UTC()
+	user.UpdatedAt = user.UpdatedAt.UTC()
+	user.DeactivatedAt = timePointer(deactivatedAt)
+	return user, nil
+}
+
+// scanUsers reads the users selected with userColumns.
+func scanUsers(rows *sql.Rows) ([]domain.User, error) {
+	users := []domain.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
@@ -0,0 +1,77 @@
+package Repositories
+
+import (
+	"database/sql"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/db"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Store groups the repositories of one storage backend.
+type Store struct {
+	Tasks         domain.TaskRepository
+	Users         domain.UserRepository
+	RefreshTokens domain.RefreshTokenRepository
+	TaskHistory   domain.TaskHistoryRepository
+	Comments      domain.CommentRepository
+	Attachments   domain.AttachmentRepository
+	Checklists    domain.ChecklistRepository
+	Dependencies  domain.DependencyRepository
+	Labels        domain.LabelRepository
+	Projects      domain.ProjectRepository
+	Roles         domain.RoleRepository
+}
+
+// NewMongoStore creates the repositories backed by the collections of a MongoDB database.
+func NewMongoStore(db mongo.Database) Store {
+	return Store{
+		Tasks:         NewTaskRepository(db, "tasks"),
+		Users:         NewUserRepository(db, "users", "organizations"),
+		RefreshTokens: NewRefreshTokenRepository(db, "refresh_tokens"),
+		TaskHistory:   NewTaskHistoryRepository(db, "task_history"),
+		Comments:      NewCommentRepository(db, "task_comments"),
+		Attachments:   NewAttachmentRepository(db, "task_attachments"),
+		Checklists:    NewChecklistRepository(db, "task_checklist_items"),
+		Dependencies:  NewDependencyRepository(db, "task_dependencies"),
+		Labels:        NewLabelRepository(db, "labels"),
+		Projects:      NewProjectRepository(db, "projects", "project_members"),
+		Roles:         NewRoleRepository(db, "roles"),
+	}
+}
+
+// NewMemoryStore creates empty in-memory repositories.
+func NewMemoryStore() Store {
+	return Store{
+		Tasks:         NewMemoryTaskRepository(),
+		Users:         NewMemoryUserRepository(),
+		RefreshTokens: NewMemoryRefreshTokenRepository(),
+		TaskHistory:   NewMemoryTaskHistoryRepository(),
+		Comments:      NewMemoryCommentRepository(),
+		Attachments:   NewMemoryAttachmentRepository(),
+		Checklists:    NewMemoryChecklistRepository(),
+		Dependencies:  NewMemoryDependencyRepository(),
+		Labels:        NewMemoryLabelRepository(),
+		Projects:      NewMemoryProjectRepository(),
+		Roles:         NewMemoryRoleRepository(),
+	}
+}
+
+// NewSQLStore creates the repositories backed by the tables of a SQL database of the given dialect.
+// The schema must have been brought up to date with db.Migrate.
+func NewSQLStore(conn *sql.DB, dialect db.Dialect) Store {
+	return Store{
+		Tasks:         NewSQLTaskRepository(conn, dialect),
+		Users:         NewSQLUserRepository(conn, dialect),
+		RefreshTokens: NewSQLRefreshTokenRepository(conn, dialect),
+		TaskHistory:   NewSQLTaskHistoryRepository(conn, dialect),
+		Comments:      NewSQLCommentRepository(conn, dialect),
+		Attachments:   NewSQLAttachmentRepository(conn, dialect),
+		Checklists:    NewSQLChecklistRepository(conn, dialect),
+		Dependencies:  NewSQLDependencyRepository(conn, dialect),
+		Labels:        NewSQLLabelRepository(conn, dialect),
+		Projects:      NewSQLProjectRepository(conn, dialect),
+		Roles:         NewSQLRoleRepository(conn, dialect),
+	}
+}
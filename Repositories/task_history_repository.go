@@ -0,0 +1,71 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// taskHistoryRepository stores the history of the tasks in a MongoDB collection.
+type taskHistoryRepository struct {
+	database   mongo.Database
+	collection string
+}
+
+var _ domain.TaskHistoryRepository = &taskHistoryRepository{}
+
+// NewTaskHistoryRepository creates a new instance of the TaskHistoryRepository interface.
+// It takes a mongo.Database and a collection name as parameters.
+func NewTaskHistoryRepository(db mongo.Database, collection string) domain.TaskHistoryRepository {
+	return &taskHistoryRepository{
+		database:   db,
+		collection: collection,
+	}
+}
+
+// AppendTaskHistory stores a new entry and returns it with its ID set.
+func (hr *taskHistoryRepository) AppendTaskHistory(ctx context.Context, entry domain.TaskHistoryEntry) (domain.TaskHistoryEntry, error) {
+	collection := hr.database.Collection(hr.collection)
+	entry.ID = domain.NewID()
+	if _, err := collection.InsertOne(ctx, entry); err != nil {
+		return domain.TaskHistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+// FindTaskHistory returns one page of the entries of a task, oldest first.
+// Pages are read with keyset pagination on _id, like the task list.
+func (hr *taskHistoryRepository) FindTaskHistory(ctx context.Context, query domain.TaskHistoryQuery) (domain.TaskHistoryPage, error) {
+	collection := hr.database.Collection(hr.collection)
+	filter := bson.M{"task_id": query.TaskID}
+	if query.Cursor != "" {
+		lastID, err := parseObjectID(query.Cursor)
+		if err != nil {
+			return domain.TaskHistoryPage{}, err
+		}
+		filter["_id"] = bson.M{"$gt": lastID}
+	}
+
+	// fetch one extra entry to know whether another page follows
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(query.Limit + 1))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.TaskHistoryPage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	entries := []domain.TaskHistoryEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return domain.TaskHistoryPage{}, err
+	}
+
+	page := domain.TaskHistoryPage{Entries: entries}
+	if len(entries) > query.Limit {
+		page.Entries = entries[:query.Limit]
+		page.NextCursor = page.Entries[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
@@ -3,6 +3,9 @@ package Repositories
 import (
 	"context"
 	domain "example/go-clean-architecture/Domain"
+	"fmt"
+	"regexp"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -26,12 +29,19 @@ func NewTaskRepository(db mongo.Database, collection string) domain.TaskReposito
 	}
 }
 
-// FindAlltasks retrieves all tasks from the task repository.
+// notDeleted matches the tasks that are not in the trash, including those stored before tasks could be deleted softly.
+var notDeleted = bson.M{"deleted_at": nil}
+
+// FindAlltasks retrieves all tasks from the task repository, except those in the trash.
 // It returns a slice of domain.Task and an error if any.
 func (tr *taskRepository) FindAlltasks(ctx context.Context) ([]domain.Task, error) {
 	collection := tr.database.Collection(tr.collection)
 	var tasks []domain.Task
-	cursor, err := collection.Find(ctx, bson.D{})
+	filter, err := tenantFilter(ctx, notDeleted)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -50,38 +60,201 @@ func (tr *taskRepository) FindAlltasks(ctx context.Context) ([]domain.Task, erro
 	return tasks, nil
 }
 
+// taskSortFields maps the domain sort keys to the document fields they order by.
+var taskSortFields = map[string]string{
+	domain.TaskSortID:      "_id",
+	domain.TaskSortDueDate: "due_date",
+	domain.TaskSortTitle:   "title",
+	domain.TaskSortStatus:  "status",
+}
+
+// FindTasks retrieves one page of tasks matching the query.
+// Pages are read with keyset pagination on the sort field and _id, so the cost
+// of a page does not grow with the number of pages before it.
+// It expects a normalized query with a positive limit.
+func (tr *taskRepository) FindTasks(ctx context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	collection := tr.database.Collection(tr.collection)
+
+	key, descending, err := domain.ParseTaskSort(query.Sort)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	field := taskSortFields[key]
+	direction, after := 1, "$gt"
+	if descending {
+		direction, after = -1, "$lt"
+	}
+
+	filter, err := tenantFilter(ctx, bson.M{"deleted_at": nil})
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	if query.Deleted {
+		filter["deleted_at"] = bson.M{"$ne": nil}
+		filter["purge_started_at"] = nil
+	}
+	if query.VisibleTo != "" {
+		filter["$or"] = bson.A{
+			bson.M{"created_by": query.VisibleTo},
+			bson.M{"assignee_ids": query.VisibleTo},
+		}
+	}
+	if query.ParentID != "" {
+		filter["parent_id"] = query.ParentID
+	}
+	if query.ProjectID != "" {
+		filter["project_id"] = query.ProjectID
+	}
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+	if query.DueFrom != nil || query.DueTo != nil {
+		dueDate := bson.M{}
+		if query.DueFrom != nil {
+			dueDate["$gte"] = *query.DueFrom
+		}
+		if query.DueTo != nil {
+			dueDate["$lte"] = *query.DueTo
+		}
+		filter["due_date"] = dueDate
+	}
+	if query.TitlePrefix != "" {
+		filter["title"] = bson.M{"$regex": "^" + regexp.QuoteMeta(query.TitlePrefix)}
+	}
+	if len(query.Labels) > 0 {
+		// served by the multikey index on labels
+		operator := "$in"
+		if query.LabelMatch == domain.LabelMatchAll {
+			operator = "$all"
+		}
+		filter["labels"] = bson.M{operator: query.Labels}
+	}
+	if query.Cursor != "" {
+		cursorFilter, err := taskCursorFilter(query, key, field, after)
+		if err != nil {
+			return domain.TaskPage{}, err
+		}
+		filter = bson.M{"$and": bson.A{filter, cursorFilter}}
+	}
+
+	sort := bson.D{{Key: field, Value: direction}}
+	if field != "_id" {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+	// fetch one extra task to know whether another page follows
+	opts := options.Find().SetSort(sort).SetLimit(int64(query.Limit + 1))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	tasks := []domain.Task{}
+	if err := cursor.All(ctx, &tasks); err != nil {
+		return domain.TaskPage{}, err
+	}
+
+	page := domain.TaskPage{Tasks: tasks}
+	if len(tasks) > query.Limit {
+		page.Tasks = tasks[:query.Limit]
+		page.NextCursor = domain.NewTaskCursor(query.Sort, page.Tasks[query.Limit-1]).Encode()
+	}
+	return page, nil
+}
+
+// taskCursorFilter builds the filter selecting the tasks that come after the query cursor.
+func taskCursorFilter(query domain.TaskQuery, key string, field string, after string) (bson.M, error) {
+	tc, err := domain.DecodeTaskCursor(query.Cursor, query.Sort)
+	if err != nil {
+		return nil, err
+	}
+	lastID, err := primitive.ObjectIDFromHex(tc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTaskQuery)
+	}
+	if field == "_id" {
+		return bson.M{"_id": bson.M{after: lastID}}, nil
+	}
+
+	var lastValue interface{} = tc.Value
+	if key == domain.TaskSortDueDate {
+		dueDate, err := time.Parse(time.RFC3339Nano, tc.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTaskQuery)
+		}
+		lastValue = dueDate
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{after: lastValue}},
+		bson.M{field: lastValue, "_id": bson.M{after: lastID}},
+	}}, nil
+}
+
 // FindTaskById retrieves a task from the database by its ID.
 // It takes a context.Context and a taskId string as parameters.
 // It returns a domain.Task and an error.
 // The domain.Task represents the retrieved task from the database.
-// The error is returned if there was an issue retrieving the task.
+// The error is returned if there was an issue retrieving the task, domain.ErrTaskNotFound if it is in the trash.
 func (tr *taskRepository) FindTaskById(ctx context.Context, taskId string) (domain.Task, error) {
 	collection := tr.database.Collection(tr.collection)
-	objID, err := primitive.ObjectIDFromHex(taskId)
+	objID, err := parseObjectID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID, "deleted_at": nil})
 	if err != nil {
 		return domain.Task{}, err
 	}
-	filter := bson.M{"_id": objID}
 
 	var task domain.Task
 	err = collection.FindOne(ctx, filter).Decode(&task)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return domain.Task{}, err // No document found
+			return domain.Task{}, domain.ErrTaskNotFound // No document found
 		}
 		return domain.Task{}, err
 	}
 	return task, nil
 }
 
+// FindDeletedTaskById retrieves a task of the trash by its ID.
+// It returns domain.ErrTaskNotFound if no task of the trash has this ID.
+func (tr *taskRepository) FindDeletedTaskById(ctx context.Context, taskId string) (domain.Task, error) {
+	collection := tr.database.Collection(tr.collection)
+	objID, err := parseObjectID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}})
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	var task domain.Task
+	err = collection.FindOne(ctx, filter).Decode(&task)
+	if err == mongo.ErrNoDocuments {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
 // CreateTask creates a new task in the task repository.
 // It takes a context and a task object as parameters.
-// It returns the created task and an error, if any.
+// It returns the created task, with its ID and organization set, and an error, if any.
 func (tr *taskRepository) CreateTask(ctx context.Context, task domain.Task) (domain.Task, error) {
 	collection := tr.database.Collection(tr.collection)
-	task.ID = primitive.NewObjectID()
+	task.ID = domain.NewID()
+	task.Version = 1
+	org, err := tenantOf(ctx, task.OrgID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.OrgID = org
 	// Insert the task into the collection
-	_, err := collection.InsertOne(ctx, task)
+	_, err = collection.InsertOne(ctx, task)
 	if err != nil {
 		return domain.Task{}, err
 	}
@@ -92,41 +265,258 @@ func (tr *taskRepository) CreateTask(ctx context.Context, task domain.Task) (dom
 // It takes the updatedTask object containing the new values for the task fields,
 // the id string representing the ID of the task to be updated.
 // It returns the updated task object and an error if any occurred.
+// The update only matches the task at updatedTask.Version, so concurrent writers cannot overwrite each other.
 func (tr *taskRepository) UpdateTaskById(ctx context.Context, updatedTask domain.Task, id string) (domain.Task, error) {
+	set := bson.M{
+		"title":        updatedTask.Title,
+		"description":  updatedTask.Description,
+		"due_date":     updatedTask.DueDate,
+		"status":       updatedTask.Status,
+		"assignee_ids": updatedTask.AssigneeIDs,
+		"labels":       updatedTask.Labels,
+	}
+	return tr.updateVersion(ctx, id, updatedTask.Version, set)
+}
+
+// updateVersion sets the fields of the task with the given ID if it is at the given version,
+// increments its version and returns the updated task.
+// It returns domain.ErrTaskNotFound if no task has this ID or the task is in the trash,
+// and domain.ErrVersionMismatch if the task is at another version.
+func (tr *taskRepository) updateVersion(ctx context.Context, id string, version int64, set bson.M) (domain.Task, error) {
 	collection := tr.database.Collection(tr.collection)
-	update := bson.M{
-		"$set": bson.M{
-			"title":       updatedTask.Title,
-			"description": updatedTask.Description,
-			"due_date":    updatedTask.DueDate,
-			"status":      updatedTask.Status,
-		},
+	objID, err := parseObjectID(id)
+	if err != nil {
+		return domain.Task{}, err
 	}
-	objID, err := primitive.ObjectIDFromHex(id)
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID, "version": version, "deleted_at": nil})
 	if err != nil {
 		return domain.Task{}, err
 	}
-	filter := bson.M{"_id": objID}
+	update := bson.M{"$inc": bson.M{"version": 1}}
+	if len(set) > 0 {
+		update["$set"] = set
+	}
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 	var updated domain.Task
 	err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return domain.Task{}, tr.missingOrModified(ctx, objID)
+	}
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return domain.Task{}, err
-		}
 		return domain.Task{}, err
 	}
 	return updated, nil
 }
 
-// DeleteTask deletes a task from the database.
-// It takes a context.Context and a taskId string as parameters.
-// It returns an error if there was a problem deleting the task.
-func (tr *taskRepository) DeleteTask(ctx context.Context, taskId string) error {
+// missingOrModified tells why a conditional write matched no task: it no longer exists or it is at another version.
+func (tr *taskRepository) missingOrModified(ctx context.Context, objID primitive.ObjectID) error {
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID, "deleted_at": nil})
+	if err != nil {
+		return err
+	}
+	n, err := tr.database.Collection(tr.collection).CountDocuments(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrTaskNotFound
+	}
+	return domain.ErrVersionMismatch
+}
+
+// PatchTaskById updates only the fields set in the patch and returns the updated task.
+// It returns domain.ErrTaskNotFound if no task has this ID and domain.ErrVersionMismatch
+// if the task is not at patch.Version.
+func (tr *taskRepository) PatchTaskById(ctx context.Context, patch domain.TaskPatch, id string) (domain.Task, error) {
+	set := bson.M{}
+	if patch.Title != nil {
+		set["title"] = *patch.Title
+	}
+	if patch.Description != nil {
+		set["description"] = *patch.Description
+	}
+	if patch.DueDate != nil {
+		set["due_date"] = *patch.DueDate
+	}
+	if patch.Status != nil {
+		set["status"] = *patch.Status
+	}
+	if patch.AssigneeIDs != nil {
+		set["assignee_ids"] = *patch.AssigneeIDs
+	}
+	if patch.Labels != nil {
+		set["labels"] = *patch.Labels
+	}
+	return tr.updateVersion(ctx, id, patch.Version, set)
+}
+
+// DeleteTask moves a task to the trash by recording who deleted it and when; the document is kept until it is purged.
+// It takes a context.Context, a taskId string, the version of the task and the deleter as parameters.
+// It returns the deleted task, or an error if there was a problem deleting the task, domain.ErrVersionMismatch
+// if the task is at another version.
+func (tr *taskRepository) DeleteTask(ctx context.Context, taskId string, version int64, deletedBy string, deletedAt time.Time) (domain.Task, error) {
+	return tr.updateVersion(ctx, taskId, version, bson.M{"deleted_at": deletedAt, "deleted_by": deletedBy})
+}
+
+// RestoreTask takes a task out of the trash and returns it.
+// It returns domain.ErrTaskNotFound if no task with this ID is in the trash.
+func (tr *taskRepository) RestoreTask(ctx context.Context, taskId string) (domain.Task, error) {
 	collection := tr.database.Collection(tr.collection)
-	_, err := collection.DeleteOne(context.TODO(), bson.M{"_id": taskId})
+	objID, err := parseObjectID(taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}, "purge_started_at": nil})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	update := bson.M{
+		"$unset": bson.M{"deleted_at": "", "deleted_by": ""},
+		"$inc":   bson.M{"version": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var restored domain.Task
+	err = collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&restored)
+	if err == mongo.ErrNoDocuments {
+		return domain.Task{}, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return restored, nil
+}
+
+// StartPurge marks the tasks moved to the trash before deletedBefore as being purged and returns the IDs
+// of every task being purged. The mark is set by the same atomic update that checks the task is in the trash,
+// so a task is either restored or purged, never both.
+func (tr *taskRepository) StartPurge(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	collection := tr.database.Collection(tr.collection)
+	expired, err := tenantFilter(ctx, bson.M{"deleted_at": bson.M{"$lt": deletedBefore}, "purge_started_at": nil})
+	if err != nil {
+		return nil, err
+	}
+	_, err = collection.UpdateMany(ctx, expired, bson.M{"$set": bson.M{"purge_started_at": time.Now().UTC()}})
+	if err != nil {
+		return nil, err
+	}
+	opts := options.Find().SetProjection(bson.M{"_id": 1}).SetSort(bson.M{"_id": 1})
+	purgingFilter, err := tenantFilter(ctx, bson.M{"purge_started_at": bson.M{"$ne": nil}})
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := collection.Find(ctx, purgingFilter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var purging []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &purging); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(purging))
+	for _, task := range purging {
+		ids = append(ids, task.ID.Hex())
+	}
+	return ids, nil
+}
+
+// PurgeTasks permanently deletes the given tasks being purged and detaches their subtasks.
+// The subtasks are detached first, so that a purge failing in between leaves no subtask of a missing task
+// once it is finished.
+func (tr *taskRepository) PurgeTasks(ctx context.Context, taskIds []string) error {
+	if len(taskIds) == 0 {
+		return nil
+	}
+	collection := tr.database.Collection(tr.collection)
+	objIDs := make([]primitive.ObjectID, 0, len(taskIds))
+	for _, id := range taskIds {
+		objID, err := parseObjectID(id)
+		if err != nil {
+			return err
+		}
+		objIDs = append(objIDs, objID)
+	}
+	subtasks, err := tenantFilter(ctx, bson.M{"parent_id": bson.M{"$in": taskIds}})
+	if err != nil {
+		return err
+	}
+	_, err = collection.UpdateMany(ctx, subtasks, bson.M{"$unset": bson.M{"parent_id": ""}, "$inc": bson.M{"version": 1}})
+	if err != nil {
+		return err
+	}
+	purged, err := tenantFilter(ctx, bson.M{"_id": bson.M{"$in": objIDs}, "purge_started_at": bson.M{"$ne": nil}})
 	if err != nil {
 		return err
 	}
-	return nil
+	_, err = collection.DeleteMany(ctx, purged)
+	return err
+}
+
+// ReassignTasks replaces the user fromUserId by toUserId as the creator and as an assignee of every task,
+// including the ones in the trash, and returns the number of tasks changed. An assignee is replaced in place,
+// or removed if toUserId is already assigned to the task. Every task is changed atomically by an update pipeline.
+func (tr *taskRepository) ReassignTasks(ctx context.Context, fromUserId string, toUserId string) (int64, error) {
+	collection := tr.database.Collection(tr.collection)
+	filter, err := tenantFilter(ctx, bson.M{"$or": bson.A{bson.M{"created_by": fromUserId}, bson.M{"assignee_ids": fromUserId}}})
+	if err != nil {
+		return 0, err
+	}
+	assignees := bson.M{"$ifNull": bson.A{"$assignee_ids", bson.A{}}}
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"created_by": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$created_by", fromUserId}}, toUserId, "$created_by"}},
+		"assignee_ids": bson.M{"$cond": bson.A{
+			bson.M{"$in": bson.A{toUserId, assignees}},
+			bson.M{"$filter": bson.M{"input": assignees, "cond": bson.M{"$ne": bson.A{"$$this", fromUserId}}}},
+			bson.M{"$map": bson.M{"input": assignees, "in": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$$this", fromUserId}}, toUserId, "$$this"}}}},
+		}},
+		"version": bson.M{"$add": bson.A{"$version", 1}},
+	}}}}
+	result, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// CountSubtasks returns the number of live subtasks of each of the given tasks in each status.
+func (tr *taskRepository) CountSubtasks(ctx context.Context, parentIds []string) (map[string]map[string]int, error) {
+	counts := make(map[string]map[string]int)
+	if len(parentIds) == 0 {
+		return counts, nil
+	}
+	collection := tr.database.Collection(tr.collection)
+	filter, err := tenantFilter(ctx, bson.M{"parent_id": bson.M{"$in": parentIds}, "deleted_at": nil})
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   bson.M{"parent_id": "$parent_id", "status": "$status"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Key struct {
+			ParentID string `bson:"parent_id"`
+			Status   string `bson:"status"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		if counts[group.Key.ParentID] == nil {
+			counts[group.Key.ParentID] = make(map[string]int)
+		}
+		counts[group.Key.ParentID][group.Key.Status] = group.Count
+	}
+	return counts, nil
 }
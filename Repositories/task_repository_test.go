@@ -11,7 +11,6 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var title = "New Task"
@@ -31,14 +30,8 @@ type TaskRepositoryTestSuite struct {
 // It connects to the MongoDB test instance, pings the MongoDB instance to ensure connection is established,
 // and initializes the necessary variables for the test suite.
 func (suite *TaskRepositoryTestSuite) SetupSuite() {
-	// Connect to the MongoDB test instance
-	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
-	client, err := mongo.Connect(context.Background(), clientOptions)
-	suite.Require().NoError(err)
-
-	// Ping the MongoDB instance to ensure connection is established
-	err = client.Ping(context.Background(), nil)
-	suite.Require().NoError(err)
+	// Connect to the MongoDB test instance, skipping the suite when it is not running
+	client := connectTestMongo(suite.T())
 
 	suite.client = client
 	suite.db = client.Database("testTaskManager")
@@ -73,7 +66,7 @@ func (suite *TaskRepositoryTestSuite) TestCreateTask() {
 		DueDate:     due_date,
 	}
 
-	newtask, err := suite.repo.CreateTask(context.Background(), task)
+	newtask, err := suite.repo.CreateTask(inDefaultOrganization(), task)
 	suite.Require().NoError(err)
 
 	// Verify task wask inserted
@@ -89,7 +82,7 @@ func (suite *TaskRepositoryTestSuite) TestCreateTask() {
 // TestFindAlltasks is a unit test function that tests the FindAlltasks method of the TaskRepository.
 // It verifies that the correct number of tasks were fetched and that the fetched tasks have the same title, description, status, and due date as the previously created task.
 func (suite *TaskRepositoryTestSuite) TestFindAlltasks() {
-	fetchedTasks, err := suite.repo.FindAlltasks(context.Background())
+	fetchedTasks, err := suite.repo.FindAlltasks(inDefaultOrganization())
 	suite.Require().NoError(err)
 
 	// Verify the correct number of tasks were fetched
@@ -103,6 +96,65 @@ func (suite *TaskRepositoryTestSuite) TestFindAlltasks() {
 	assert.Equal(suite.T(), due_date, tasks.DueDate, "Same task due_date with the previously created task")
 }
 
+// TestFindTasks tests the keyset pagination of the FindTasks method of the TaskRepository.
+//
+// It creates tasks sharing a title prefix with increasing due dates, then pages through them
+// two at a time sorted by due date and verifies every task is returned exactly once, in order,
+// and that the last page has no next cursor.
+func (suite *TaskRepositoryTestSuite) TestFindTasks() {
+	prefix := "paged task "
+	for i := 0; i < 5; i++ {
+		_, err := suite.repo.CreateTask(inDefaultOrganization(), domain.Task{
+			Title:       prefix + string(rune('a'+i)),
+			Description: description,
+			Status:      "Paged",
+			DueDate:     due_date.Add(time.Duration(i) * time.Hour),
+		})
+		suite.Require().NoError(err)
+	}
+
+	query := domain.TaskQuery{Status: "Paged", TitlePrefix: prefix, Sort: "due_date", Limit: 2}
+	var titles []string
+	for pages := 0; ; pages++ {
+		suite.Require().Less(pages, 5, "pagination does not terminate")
+		page, err := suite.repo.FindTasks(inDefaultOrganization(), query)
+		suite.Require().NoError(err)
+		for _, task := range page.Tasks {
+			titles = append(titles, task.Title)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	assert.Equal(suite.T(), []string{prefix + "a", prefix + "b", prefix + "c", prefix + "d", prefix + "e"}, titles)
+}
+
+// TestFindTasks_VisibleTo tests that FindTasks only returns the tasks a user created or is assigned to
+// when the query is restricted to that user.
+func (suite *TaskRepositoryTestSuite) TestFindTasks_VisibleTo() {
+	owner := domain.NewID().String()
+	assignee := domain.NewID().String()
+	stranger := domain.NewID().String()
+
+	_, err := suite.repo.CreateTask(inDefaultOrganization(), domain.Task{
+		Title:       "owned task",
+		Description: description,
+		Status:      "Visible",
+		DueDate:     due_date,
+		CreatedBy:   owner,
+		AssigneeIDs: []string{assignee},
+	})
+	suite.Require().NoError(err)
+
+	for user, expected := range map[string]int{owner: 1, assignee: 1, stranger: 0} {
+		page, err := suite.repo.FindTasks(inDefaultOrganization(), domain.TaskQuery{Status: "Visible", VisibleTo: user, Limit: 10})
+		suite.Require().NoError(err)
+		assert.Len(suite.T(), page.Tasks, expected)
+	}
+}
+
 // TestFindTaskById tests the functionality of finding a task by its ID.
 //
 // It creates a new task, retrieves it by its ID, and then verifies if the retrieved task matches the original task.
@@ -120,10 +172,10 @@ func (suite *TaskRepositoryTestSuite) TestFindTaskById() {
 	}
 	
 
-	newTask, err := suite.repo.CreateTask(context.Background(), task)
+	newTask, err := suite.repo.CreateTask(inDefaultOrganization(), task)
 	suite.Require().NoError(err)
 
-	foundTask, err := suite.repo.FindTaskById(context.Background(), newTask.ID.Hex())
+	foundTask, err := suite.repo.FindTaskById(inDefaultOrganization(), newTask.ID.String())
 	suite.Require().NoError(err)
 
 	assert.Equal(suite.T(), task.Title, foundTask.Title, "Same task title with the previously created task")
@@ -133,6 +185,18 @@ func (suite *TaskRepositoryTestSuite) TestFindTaskById() {
 }
 
 
+// TestFindTaskById_Errors tests that missing tasks and malformed ids are reported with the domain error kinds.
+func (suite *TaskRepositoryTestSuite) TestFindTaskById_Errors() {
+	_, err := suite.repo.FindTaskById(inDefaultOrganization(), domain.NewID().String())
+	assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+
+	_, err = suite.repo.FindTaskById(inDefaultOrganization(), "not-an-id")
+	assert.ErrorIs(suite.T(), err, domain.ErrInvalidID)
+
+	_, err = suite.repo.DeleteTask(inDefaultOrganization(), domain.NewID().String(), 1, "admin", time.Now())
+	assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+}
+
 // TestUpdateTaskById tests the functionality of updating a task by its ID.
 //
 // It creates a new task, updates its properties, and then verifies if the task is updated correctly.
@@ -152,7 +216,7 @@ func (suite *TaskRepositoryTestSuite) TestUpdateTaskById() {
 	}
 	
 
-	newTask, err := suite.repo.CreateTask(context.Background(), task)
+	newTask, err := suite.repo.CreateTask(inDefaultOrganization(), task)
 	suite.Require().NoError(err)
 
 	newTitle := "Updated Task"
@@ -165,9 +229,10 @@ func (suite *TaskRepositoryTestSuite) TestUpdateTaskById() {
 		Description: newDescription,
 		Status:      newStatus,
 		DueDate:    newDueDate,
+		Version:     newTask.Version,
 	}
 
-	task, err = suite.repo.UpdateTaskById(context.Background(), taskUpdate, newTask.ID.Hex())
+	task, err = suite.repo.UpdateTaskById(inDefaultOrganization(), taskUpdate, newTask.ID.String())
 	suite.Require().NoError(err)
 
 	// find out if the task is updated
@@ -178,7 +243,7 @@ func (suite *TaskRepositoryTestSuite) TestUpdateTaskById() {
 	suite.Require().NoError(err)
 
 	// Check if its the same task
-	taskFound, err := suite.repo.FindTaskById(context.Background(), task.ID.Hex())
+	taskFound, err := suite.repo.FindTaskById(inDefaultOrganization(), task.ID.String())
 	suite.Require().NoError(err)
 
 	// check if the fields are updated
@@ -203,7 +268,7 @@ func (suite *TaskRepositoryTestSuite) TestDeleteTask() {
 		DueDate:    time.Now().UTC().Truncate(24 * time.Hour),
 	}
 
-	newTask, err := suite.repo.CreateTask(context.Background(), task)
+	newTask, err := suite.repo.CreateTask(inDefaultOrganization(), task)
 	suite.Require().NoError(err)
 
 	// Verify the task is inserted
@@ -213,11 +278,28 @@ func (suite *TaskRepositoryTestSuite) TestDeleteTask() {
 	err = collection.FindOne(context.Background(), bson.M{"_id": newTask.ID}).Decode(&result)
 	suite.Require().NoError(err)
 
-	err = suite.repo.DeleteTask(context.Background(), newTask.ID.Hex())
+	_, err = suite.repo.DeleteTask(inDefaultOrganization(), newTask.ID.String(), newTask.Version, "admin", time.Now())
 	suite.Require().NoError(err)
 
 }
 
+// TestMigrateMongo_TaskVersions tests that tasks stored before versions existed get version 1,
+// so that they can be updated with conditional writes.
+func (suite *TaskRepositoryTestSuite) TestMigrateMongo_TaskVersions() {
+	id := domain.NewID()
+	collection := suite.db.Collection(suite.collection)
+	_, err := collection.InsertOne(context.Background(), bson.M{"_id": id, "title": "legacy task"})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(MigrateMongo(context.Background(), *suite.db))
+	task, err := suite.repo.FindTaskById(inDefaultOrganization(), id.String())
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), task.Version)
+
+	_, err = suite.repo.DeleteTask(inDefaultOrganization(), id.String(), task.Version, "admin", time.Now())
+	suite.Require().NoError(err)
+}
+
 func TestTaskRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(TaskRepositoryTestSuite))
 }
\ No newline at end of file
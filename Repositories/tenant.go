@@ -0,0 +1,72 @@
+package Repositories
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// The tenant helpers restrict the queries of the task, user, project, role and label repositories to the
+// organization of the caller, as returned by domain.OrganizationFromContext. Queries made with a context made by
+// domain.ContextWithoutTenant, like the ones of the trash purge, see every organization; those made with any
+// other context without an organization fail with domain.ErrOrganizationRequired.
+
+// tenant returns the organization the queries made with ctx are restricted to,
+// or "" for a context made by domain.ContextWithoutTenant.
+func tenant(ctx context.Context) (string, error) {
+	if org, ok := domain.OrganizationFromContext(ctx); ok {
+		return org, nil
+	}
+	if domain.IsWithoutTenant(ctx) {
+		return "", nil
+	}
+	return "", domain.ErrOrganizationRequired
+}
+
+// tenantOf returns the organization a record created or looked up with ctx belongs to: the one of ctx,
+// or else, for a context made by domain.ContextWithoutTenant, the given one.
+func tenantOf(ctx context.Context, orgId string) (string, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return "", err
+	}
+	if org == "" {
+		org = orgId
+	}
+	if org == "" {
+		return "", domain.ErrOrganizationRequired
+	}
+	return org, nil
+}
+
+// inTenant reports whether a record of the given organization can be read in the tenant returned by tenant.
+func inTenant(tenant string, orgId string) bool {
+	return tenant == "" || tenant == orgId
+}
+
+// tenantFilter returns a copy of the MongoDB filter also matching the organization of ctx.
+func tenantFilter(ctx context.Context, filter bson.M) (bson.M, error) {
+	org, err := tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scoped := make(bson.M, len(filter)+1)
+	for key, value := range filter {
+		scoped[key] = value
+	}
+	if org != "" {
+		scoped["org_id"] = org
+	}
+	return scoped, nil
+}
+
+// sqlTenant returns the condition restricting a SQL query to the organization of ctx, to append to its WHERE
+// clause, with its argument. Both are empty for a context made by domain.ContextWithoutTenant.
+func sqlTenant(ctx context.Context) (string, []interface{}, error) {
+	org, err := tenant(ctx)
+	if err != nil || org == "" {
+		return "", nil, err
+	}
+	return " AND org_id = ?", []interface{}{org}, nil
+}
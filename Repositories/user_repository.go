@@ -3,78 +3,167 @@ package Repositories
 import (
 	"context"
 	"errors"
+	"fmt"
+	"regexp"
+	"time"
 	domain "example/go-clean-architecture/Domain"
 
 	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type userRepository struct {
 	database   mongo.Database
 	collection string
+	// organizations holds one document per organization with users, keyed by its ID,
+	// so that exactly one of several concurrent first sign-ups to an organization becomes its admin.
+	organizations string
 }
 var _ domain.UserRepository = &userRepository{}
-func NewUserRepository(db mongo.Database, collection string) *userRepository {
+func NewUserRepository(db mongo.Database, collection string, organizations string) *userRepository {
 	return &userRepository{
-		database:   db,
-		collection: collection,
+		database:      db,
+		collection:    collection,
+		organizations: organizations,
 	}
 }
-// FindUser(ctx context.Context, username string) User
-// 	CreateNewUser(ctx context.Context, user User) error
-// 	PromoteUser(ctx context.Context, userId string) error
+// FindUser retrieves a user of the organization of ctx by its username.
+// It returns domain.ErrUserNotFound if no user has this username.
 func (ur *userRepository) FindUser(ctx context.Context, username string) (domain.User, error){
 	collection := ur.database.Collection(ur.collection)
 	var existingUser domain.User
-	err := collection.FindOne(context.Background(), bson.M{"username": username}).Decode(&existingUser)
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.User{}, err
+	}
+	err = collection.FindOne(ctx, bson.M{"username": username, "org_id": org}).Decode(&existingUser)
 	if err != nil {
 		if errors.Is(err, mongo.ErrNoDocuments) {
-			return domain.User{}, errors.New("user not found")
+			return domain.User{}, domain.ErrUserNotFound
 		}
 		return domain.User{}, err
 	}
 	return existingUser, nil
 }
 
+// FindUserById retrieves a user by its ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *userRepository) FindUserById(ctx context.Context, userId string) (domain.User, error) {
+	collection := ur.database.Collection(ur.collection)
+	objID, err := parseObjectID(userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	var user domain.User
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return domain.User{}, err
+	}
+	err = collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.User{}, domain.ErrUserNotFound
+		}
+		return domain.User{}, err
+	}
+	return user, nil
+}
+
 // CreateNewUser creates a new user in the database.
 // It takes a context and a user object as input parameters.
+// The user joins the organization of ctx, or else its own OrgID, and usernames are unique within an organization.
+// The user claiming the organization document of its organization is its first user and becomes an admin,
+// every later user a regular user.
 // It returns the created user object and an error if any.
-func (ur *userRepository) CreateNewUser(ctx context.Context, user *domain.User) (domain.User, error){
+func (ur *userRepository) CreateNewUser(ctx context.Context, user *domain.User) (domain.User, error) {
+	return ur.insertUser(ctx, user, false)
+}
+
+// CreateOrganization stores the first user of the organization of ctx, or else of its own OrgID, as its admin
+// and returns it with its ID, organization and role set.
+// It returns domain.ErrOrganizationExists if the organization document of the organization was already claimed.
+func (ur *userRepository) CreateOrganization(ctx context.Context, admin *domain.User) (domain.User, error) {
+	return ur.insertUser(ctx, admin, true)
+}
+
+// insertUser stores a new user like CreateNewUser does, or, if newOrganization is set, like CreateOrganization does.
+func (ur *userRepository) insertUser(ctx context.Context, user *domain.User, newOrganization bool) (domain.User, error) {
 	var existingUser domain.User
 	collection := ur.database.Collection(ur.collection)
-	err := collection.FindOne(ctx, bson.M{"username": user.Username}).Decode(&existingUser)
+	org, err := tenantOf(ctx, user.OrgID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	user.OrgID = org
+	err = collection.FindOne(ctx, bson.M{"username": user.Username, "org_id": user.OrgID}).Decode(&existingUser)
+	if err == nil && newOrganization {
+		return domain.User{}, domain.ErrOrganizationExists
+	}
 	if err == nil {
-		return domain.User{}, errors.New("username already exists")
+		return domain.User{}, domain.ErrUsernameTaken
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return domain.User{}, err
 	}
 
-	// count users in the database. 
-	count, _ := collection.CountDocuments(context.TODO(), bson.M{})
-
-	// Promote the first user to admin
-	if count == 0 {
-		user.Role = "ADMIN"
+	// Promote the first user of the organization to admin
+	first, err := ur.claimOrganization(ctx, user.OrgID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if !first && newOrganization {
+		return domain.User{}, domain.ErrOrganizationExists
+	}
+	if first {
+		user.Role = domain.RoleAdmin
 	}else {
-		user.Role = "USER"
+		user.Role = domain.RoleUser
 	}
 
-	user.ID = primitive.NewObjectID()	
+	user.ID = domain.NewID()	
 
-	_, err = collection.InsertOne(context.Background(), user)
+	_, err = collection.InsertOne(ctx, user)
+	if err != nil && first {
+		// give the organization back, so that the next sign-up becomes its admin
+		if _, releaseErr := ur.database.Collection(ur.organizations).DeleteOne(ctx, bson.M{"_id": user.OrgID}); releaseErr != nil {
+			return domain.User{}, fmt.Errorf("failed to release organization %s after %v: %w", user.OrgID, err, releaseErr)
+		}
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		// a concurrent sign-up took the username first
+		return domain.User{}, domain.ErrUsernameTaken
+	}
 	if err != nil{
-		return domain.User{}, errors.New("failed to insert data")
+		return domain.User{}, fmt.Errorf("failed to insert data: %w", err)
 	}
 	return *user, nil 
 }
 
+// claimOrganization inserts the document of the organization and reports whether it did not exist yet.
+// The documents are keyed by the ID of the organization, so that only one of several concurrent claims succeeds.
+func (ur *userRepository) claimOrganization(ctx context.Context, orgId string) (bool, error) {
+	_, err := ur.database.Collection(ur.organizations).InsertOne(ctx, bson.M{"_id": orgId, "created_at": time.Now().UTC()})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 
 func (ur *userRepository) PromoteUser(ctx context.Context, userId string) error{
 	collection := ur.database.Collection(ur.collection)
-	objID, err := primitive.ObjectIDFromHex(userId)
+	objID, err := parseObjectID(userId)
 	if err != nil {
 		return err // Return an error if userId is not a valid ObjectID
 	}
-	filter := bson.M{"_id": objID}
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
 	update := bson.M{
 		"$set": bson.M{
 			"role": "ADMIN",
@@ -86,9 +175,168 @@ func (ur *userRepository) PromoteUser(ctx context.Context, userId string) error{
 	}
 
 	if result.MatchedCount == 0 {
-		return mongo.ErrNoDocuments // No user found with the given ID
+		return domain.ErrUserNotFound // No user found with the given ID
+	}
+	return nil
+}
+
+// SetUserRole gives a role to the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID,
+// and domain.ErrLastAdmin if the user is the last admin of its organization and the role is another one.
+// MongoDB cannot lock the collection, so a demotion that turns out to have removed the last admin
+// because of a concurrent one is reverted.
+func (ur *userRepository) SetUserRole(ctx context.Context, userId string, role string) error {
+	collection := ur.database.Collection(ur.collection)
+	objID, err := parseObjectID(userId)
+	if err != nil {
+		return err
+	}
+	var user domain.User
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	err = collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return domain.ErrUserNotFound
+		}
+		return err
+	}
+	demoted := user.Role == domain.RoleAdmin && role != domain.RoleAdmin
+	if demoted {
+		admins, err := collection.CountDocuments(ctx, bson.M{"org_id": user.OrgID, "role": domain.RoleAdmin})
+		if err != nil {
+			return err
+		}
+		if admins == 1 {
+			return domain.ErrLastAdmin
+		}
+	}
+
+	result, err := collection.UpdateOne(ctx, bson.M{"_id": objID, "role": user.Role}, bson.M{"$set": bson.M{"role": role}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		// the role changed meanwhile, so the check above no longer holds
+		return domain.NewError(domain.ErrPreconditionFailed, "the role of the user changed, try again")
+	}
+	if demoted {
+		admins, err := collection.CountDocuments(ctx, bson.M{"org_id": user.OrgID, "role": domain.RoleAdmin})
+		if err != nil {
+			return err
+		}
+		if admins == 0 {
+			_, err = collection.UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"role": domain.RoleAdmin}})
+			if err != nil {
+				return err
+			}
+			return domain.ErrLastAdmin
+		}
 	}
 	return nil
 }
 
+// CountUsersWithRole counts the users of the organization of ctx having the given role.
+func (ur *userRepository) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	collection := ur.database.Collection(ur.collection)
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+	return collection.CountDocuments(ctx, bson.M{"org_id": org, "role": role})
+}
+
+// FindUsers returns one page of the users of the organization of ctx in the order they signed up,
+// with keyset pagination on _id. It expects a normalized query with a positive limit.
+func (ur *userRepository) FindUsers(ctx context.Context, query domain.UserQuery) (domain.UserPage, error) {
+	collection := ur.database.Collection(ur.collection)
+	org, err := tenantOf(ctx, "")
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+	filter := bson.M{"org_id": org}
+	if query.Search != "" {
+		// served by the unique index on org_id and username
+		filter["username"] = bson.M{"$regex": "^" + regexp.QuoteMeta(query.Search)}
+	}
+	if query.Role != "" {
+		filter["role"] = query.Role
+	}
+	if query.Cursor != "" {
+		lastID, err := parseObjectID(query.Cursor)
+		if err != nil {
+			return domain.UserPage{}, err
+		}
+		filter["_id"] = bson.M{"$gt": lastID}
+	}
+
+	// fetch one extra user to know whether another page follows
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(int64(query.Limit + 1))
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return domain.UserPage{}, err
+	}
+	defer cursor.Close(ctx)
+
+	users := []domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return domain.UserPage{}, err
+	}
+
+	page := domain.UserPage{Users: users}
+	if len(users) > query.Limit {
+		page.Users = users[:query.Limit]
+		page.NextCursor = page.Users[query.Limit-1].ID.String()
+	}
+	return page, nil
+}
+
+// SetUserDeactivated deactivates the user with the given ID at the given time, or activates it again if nil.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *userRepository) SetUserDeactivated(ctx context.Context, userId string, deactivatedAt *time.Time) error {
+	collection := ur.database.Collection(ur.collection)
+	objID, err := parseObjectID(userId)
+	if err != nil {
+		return err
+	}
+	update := bson.M{"$unset": bson.M{"deactivated_at": ""}}
+	if deactivatedAt != nil {
+		update = bson.M{"$set": bson.M{"deactivated_at": *deactivatedAt}}
+	}
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
 
+// DeleteUser deletes the user with the given ID.
+// It returns domain.ErrUserNotFound if no user of the organization of ctx has this ID.
+func (ur *userRepository) DeleteUser(ctx context.Context, userId string) error {
+	collection := ur.database.Collection(ur.collection)
+	objID, err := parseObjectID(userId)
+	if err != nil {
+		return err
+	}
+	filter, err := tenantFilter(ctx, bson.M{"_id": objID})
+	if err != nil {
+		return err
+	}
+	result, err := collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var userName = "johndoe"
@@ -30,19 +29,13 @@ type UserRepositoryTestSuite struct {
 // It connects to the MongoDB test instance, pings the MongoDB instance to ensure connection is established,
 // and initializes the necessary variables for the test suite.
 func (suite *UserRepositoryTestSuite) SetupSuite() {
-	// Connect to the MongoDB test instance
-	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
-	client, err := mongo.Connect(context.Background(), clientOptions)
-	suite.Require().NoError(err)
-
-	// Ping the MongoDB instance to ensure connection is established
-	err = client.Ping(context.Background(), nil)
-	suite.Require().NoError(err)
+	// Connect to the MongoDB test instance, skipping the suite when it is not running
+	client := connectTestMongo(suite.T())
 
 	suite.client = client
 	suite.db = client.Database("testdb")
 	suite.collection = "users"
-	suite.repo = NewUserRepository(*suite.db, suite.collection)
+	suite.repo = NewUserRepository(*suite.db, suite.collection, "organizations")
 }
 
 // TearDownSuite is a function that is called after all tests have run to clean up resources.
@@ -71,7 +64,7 @@ func (suite *UserRepositoryTestSuite) TestCreateUser() {
 		Role:  		userRole,
 	}
 
-	user, err := suite.repo.CreateNewUser(context.Background(), &newUser)
+	user, err := suite.repo.CreateNewUser(inDefaultOrganization(), &newUser)
 	suite.Require().NoError(err)
 
 	// Verify user was inserted
@@ -92,7 +85,7 @@ func (suite *UserRepositoryTestSuite) TestCreateUser() {
 // TestFindUser tests the FindUser method of the UserRepository.
 // It fetches a user from the repository and verifies that the fetched user's username and role match the previously inserted values.
 func (suite *UserRepositoryTestSuite) TestFindUser() {
-	fetchedUser, err := suite.repo.FindUser(context.Background(), userName)
+	fetchedUser, err := suite.repo.FindUser(inDefaultOrganization(), userName)
 	suite.Require().NoError(err)
 
 	// Verify that the correct number of users were fetched
@@ -101,6 +94,20 @@ func (suite *UserRepositoryTestSuite) TestFindUser() {
 }
 
 
+// TestFindUserById tests the FindUserById method of the UserRepository.
+// It looks up the previously inserted user by its ID and verifies that unknown ids are reported as not found.
+func (suite *UserRepositoryTestSuite) TestFindUserById() {
+	inserted, err := suite.repo.FindUser(inDefaultOrganization(), userName)
+	suite.Require().NoError(err)
+
+	fetchedUser, err := suite.repo.FindUserById(inDefaultOrganization(), inserted.ID.String())
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), userName, fetchedUser.Username)
+
+	_, err = suite.repo.FindUserById(inDefaultOrganization(), domain.NewID().String())
+	assert.ErrorIs(suite.T(), err, domain.ErrNotFound)
+}
+
 // TestPromoteUser tests the functionality of promoting a user.
 //
 // It creates a new user with the given username, password, and role.
@@ -114,6 +121,7 @@ func (suite *UserRepositoryTestSuite) TestPromoteUser() {
 		Username: "sister",
 		Password: "password123",
 		Role : "USER",
+		OrgID: domain.DefaultOrganization,
 	}
 
 	// Insert the user
@@ -124,10 +132,10 @@ func (suite *UserRepositoryTestSuite) TestPromoteUser() {
 	var user domain.User
 	err = collection.FindOne(context.Background(), bson.M{"username": newUser.Username}).Decode(&user)
     suite.Require().NoError(err)
-	fmt.Println(user.ID.Hex())
+	fmt.Println(user.ID.String())
 
 
-	err = suite.repo.PromoteUser(context.Background(), user.ID.Hex())
+	err = suite.repo.PromoteUser(inDefaultOrganization(), user.ID.String())
 	suite.Require().NoError(err)
 
 
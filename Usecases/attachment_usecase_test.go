@@ -0,0 +1,179 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// pngHeader is the signature content is detected as image/png from.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+type AttachmentUseCaseSuite struct {
+	suite.Suite
+	mockAttachmentRepo *mocks.AttachmentRepository
+	mockTaskRepo       *mocks.TaskRepository
+	mockBlobs          *mocks.BlobStore
+	attachmentUseCase  domain.AttachmentUseCase
+	// task is visible to adminUser, who created it, and to regularUser, who is assigned to it
+	task domain.Task
+}
+
+func (suite *AttachmentUseCaseSuite) SetupTest() {
+	suite.mockAttachmentRepo = new(mocks.AttachmentRepository)
+	suite.mockTaskRepo = new(mocks.TaskRepository)
+	suite.mockBlobs = new(mocks.BlobStore)
+	suite.task = domain.Task{ID: taskID, CreatedBy: adminUser.ID, AssigneeIDs: []string{regularUser.ID}}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(suite.task, nil)
+	limits := domain.AttachmentLimits{MaxSize: 64, AllowedTypes: []string{"image/png", "text/plain"}}
+	suite.attachmentUseCase = NewAttachmentUsecase(suite.mockAttachmentRepo, suite.mockTaskRepo, new(mocks.ProjectRepository), suite.mockBlobs, limits, time.Second*2)
+}
+
+// drainBlob stands for a BlobStore.Put storing the whole content.
+func drainBlob(ctx context.Context, key string, r io.Reader) (int64, error) {
+	return io.Copy(io.Discard, r)
+}
+
+// TestAddAttachment tests that a file is stored under the ID of its attachment, with the type detected from its content.
+func (suite *AttachmentUseCaseSuite) TestAddAttachment() {
+	var key string
+	suite.mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(drainBlob).Run(func(args mock.Arguments) {
+		key = args.String(1)
+	})
+	suite.mockAttachmentRepo.On("CreateAttachment", mock.Anything, mock.Anything).Return(func(ctx context.Context, attachment domain.Attachment) (domain.Attachment, error) {
+		return attachment, nil
+	})
+
+	content := append(append([]byte{}, pngHeader...), "pixels"...)
+	attachment, err := suite.attachmentUseCase.AddAttachment(asUser(regularUser), taskID.String(), `C:\shots\screen.png`, bytes.NewReader(content))
+	suite.Require().NoError(err)
+	suite.Equal(key, attachment.ID.String())
+	suite.Equal(taskID.String(), attachment.TaskID)
+	suite.Equal("screen.png", attachment.Filename)
+	suite.Equal("image/png", attachment.ContentType)
+	suite.Equal(int64(len(content)), attachment.Size)
+	suite.Equal(regularUser.ID, attachment.UploadedBy)
+	suite.False(attachment.CreatedAt.IsZero())
+
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	_, err = suite.attachmentUseCase.AddAttachment(asUser(stranger), taskID.String(), "screen.png", bytes.NewReader(content))
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	_, err = suite.attachmentUseCase.AddAttachment(context.Background(), taskID.String(), "screen.png", bytes.NewReader(content))
+	suite.ErrorIs(err, domain.ErrUnauthenticated)
+	_, err = suite.attachmentUseCase.AddAttachment(asUser(regularUser), taskID.String(), "", bytes.NewReader(content))
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockBlobs.AssertNumberOfCalls(suite.T(), "Put", 1)
+}
+
+// TestAddAttachment_ProjectViewer tests that the viewers of a project cannot attach files to its tasks.
+func (suite *AttachmentUseCaseSuite) TestAddAttachment_ProjectViewer() {
+	pid := domain.NewID().String()
+	viewer := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	projects := new(mocks.ProjectRepository)
+	projects.On("FindMember", mock.Anything, pid, viewer.ID).Return(domain.ProjectMember{ProjectID: pid, UserID: viewer.ID, Role: domain.ProjectRoleViewer}, nil)
+	tasks := new(mocks.TaskRepository)
+	tasks.On("FindTaskById", mock.Anything, taskID.String()).Return(domain.Task{ID: taskID, CreatedBy: adminUser.ID, ProjectID: pid}, nil)
+	limits := domain.AttachmentLimits{MaxSize: 64, AllowedTypes: []string{"text/plain"}}
+	attachmentUseCase := NewAttachmentUsecase(suite.mockAttachmentRepo, tasks, projects, suite.mockBlobs, limits, time.Second)
+
+	_, err := attachmentUseCase.AddAttachment(asUser(viewer), taskID.String(), "notes.txt", strings.NewReader("notes"))
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	suite.mockBlobs.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestAddAttachment_Rejected tests that files of a type that is not allowed are refused before anything is stored
+// and that nothing is kept of a file over the maximum size.
+func (suite *AttachmentUseCaseSuite) TestAddAttachment_Rejected() {
+	_, err := suite.attachmentUseCase.AddAttachment(asUser(regularUser), taskID.String(), "page.html", strings.NewReader("<html><body>hi</body></html>"))
+	suite.ErrorIs(err, domain.ErrAttachmentType)
+	suite.ErrorIs(err, domain.ErrUnsupportedMediaType)
+	suite.mockBlobs.AssertNotCalled(suite.T(), "Put", mock.Anything, mock.Anything, mock.Anything)
+
+	var key string
+	suite.mockBlobs.On("Put", mock.Anything, mock.Anything, mock.Anything).Return(drainBlob).Run(func(args mock.Arguments) {
+		key = args.String(1)
+	})
+	suite.mockBlobs.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	_, err = suite.attachmentUseCase.AddAttachment(asUser(regularUser), taskID.String(), "notes.txt", strings.NewReader(strings.Repeat("a", 65)))
+	suite.ErrorIs(err, domain.ErrAttachmentTooLarge)
+	suite.ErrorIs(err, domain.ErrTooLarge)
+	suite.mockBlobs.AssertCalled(suite.T(), "Delete", mock.Anything, key)
+	suite.mockAttachmentRepo.AssertNotCalled(suite.T(), "CreateAttachment", mock.Anything, mock.Anything)
+
+	suite.mockAttachmentRepo.On("CreateAttachment", mock.Anything, mock.Anything).Return(domain.Attachment{}, errors.New("database down"))
+	_, err = suite.attachmentUseCase.AddAttachment(asUser(regularUser), taskID.String(), "notes.txt", strings.NewReader(strings.Repeat("a", 64)))
+	suite.EqualError(err, "database down")
+	suite.mockBlobs.AssertCalled(suite.T(), "Delete", mock.Anything, key)
+	suite.mockBlobs.AssertNumberOfCalls(suite.T(), "Delete", 2)
+}
+
+// TestOpenAttachment tests that an attachment is only reachable through its own task and that a missing blob
+// is reported as a missing attachment.
+func (suite *AttachmentUseCaseSuite) TestOpenAttachment() {
+	attachment := domain.Attachment{ID: domain.NewID(), TaskID: taskID.String(), Filename: "notes.txt", UploadedBy: adminUser.ID}
+	suite.mockAttachmentRepo.On("FindAttachmentById", mock.Anything, attachment.ID.String()).Return(attachment, nil)
+	suite.mockBlobs.On("Get", mock.Anything, attachment.ID.String()).Return(io.NopCloser(strings.NewReader("notes")), nil).Once()
+
+	found, content, err := suite.attachmentUseCase.OpenAttachment(asUser(regularUser), taskID.String(), attachment.ID.String())
+	suite.Require().NoError(err)
+	suite.Equal(attachment, found)
+	read, _ := io.ReadAll(content)
+	suite.Equal("notes", string(read))
+
+	other := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	otherTask := domain.NewID()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, otherTask.String()).Return(domain.Task{ID: otherTask, CreatedBy: other.ID}, nil)
+	_, _, err = suite.attachmentUseCase.OpenAttachment(asUser(other), otherTask.String(), attachment.ID.String())
+	suite.ErrorIs(err, domain.ErrAttachmentNotFound, "an attachment is only reachable through its own task")
+
+	suite.mockBlobs.On("Get", mock.Anything, attachment.ID.String()).Return(nil, domain.ErrBlobNotFound)
+	_, _, err = suite.attachmentUseCase.OpenAttachment(asUser(regularUser), taskID.String(), attachment.ID.String())
+	suite.ErrorIs(err, domain.ErrAttachmentNotFound)
+}
+
+// TestDeleteAttachment tests that only the uploader and admins can delete an attachment, with its content.
+func (suite *AttachmentUseCaseSuite) TestDeleteAttachment() {
+	attachment := domain.Attachment{ID: domain.NewID(), TaskID: taskID.String(), UploadedBy: adminUser.ID}
+	suite.mockAttachmentRepo.On("FindAttachmentById", mock.Anything, attachment.ID.String()).Return(attachment, nil)
+	suite.mockAttachmentRepo.On("DeleteAttachment", mock.Anything, attachment.ID.String()).Return(nil)
+	suite.mockBlobs.On("Delete", mock.Anything, attachment.ID.String()).Return(nil)
+
+	err := suite.attachmentUseCase.DeleteAttachment(asUser(regularUser), taskID.String(), attachment.ID.String())
+	suite.ErrorIs(err, domain.ErrNotUploader)
+	suite.ErrorIs(err, domain.ErrForbidden)
+	suite.mockAttachmentRepo.AssertNotCalled(suite.T(), "DeleteAttachment", mock.Anything, mock.Anything)
+
+	suite.NoError(suite.attachmentUseCase.DeleteAttachment(asUser(adminUser), taskID.String(), attachment.ID.String()))
+	suite.mockAttachmentRepo.AssertExpectations(suite.T())
+	suite.mockBlobs.AssertExpectations(suite.T())
+}
+
+// TestDeleteTaskAttachments tests that the content of every attachment of the purged tasks is deleted with them.
+func (suite *AttachmentUseCaseSuite) TestDeleteTaskAttachments() {
+	taskA, taskB := domain.NewID().String(), domain.NewID().String()
+	first, second := domain.NewID(), domain.NewID()
+	suite.mockAttachmentRepo.On("FindTaskAttachments", mock.Anything, taskA).Return([]domain.Attachment{{ID: first}, {ID: second}}, nil)
+	suite.mockAttachmentRepo.On("FindTaskAttachments", mock.Anything, taskB).Return([]domain.Attachment{}, nil)
+	suite.mockBlobs.On("Delete", mock.Anything, first.String()).Return(nil)
+	suite.mockBlobs.On("Delete", mock.Anything, second.String()).Return(nil)
+	suite.mockAttachmentRepo.On("DeleteTaskAttachments", mock.Anything, []string{taskA, taskB}).Return(nil)
+
+	suite.NoError(suite.attachmentUseCase.DeleteTaskAttachments(context.Background(), []string{taskA, taskB}))
+	suite.mockAttachmentRepo.AssertExpectations(suite.T())
+	suite.mockBlobs.AssertExpectations(suite.T())
+}
+
+func TestAttachmentUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUseCaseSuite))
+}
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// sniffLength is the number of bytes the type of an attachment is detected from.
+const sniffLength = 512
+
+// attachmentUseCase represents the use case for attaching files to tasks.
+// The metadata of the attachments is kept in the attachmentRepository and their content in the blob store.
+// Attachments follow the visibility of their task, like the comments.
+type attachmentUseCase struct {
+	attachmentRepository domain.AttachmentRepository
+	taskRepository       domain.TaskRepository
+	projectRepository    domain.ProjectRepository
+	blobs                domain.BlobStore
+	limits               domain.AttachmentLimits
+	contextTimeout       time.Duration
+}
+
+var _ domain.AttachmentUseCase = &attachmentUseCase{}
+
+// NewAttachmentUsecase creates a new instance of the AttachmentUseCase interface.
+// It takes the attachmentRepository storing the metadata, the taskRepository and projectRepository the visibility
+// of the tasks is read from, the BlobStore keeping the content, the limits of the accepted files
+// and a timeout of type time.Duration as parameters.
+// The timeout bounds the database operations, not the transfer of the content, which goes at the pace of the client.
+func NewAttachmentUsecase(attachmentRepository domain.AttachmentRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, blobs domain.BlobStore, limits domain.AttachmentLimits, timeout time.Duration) domain.AttachmentUseCase {
+	return &attachmentUseCase{
+		attachmentRepository: attachmentRepository,
+		taskRepository:       taskRepository,
+		projectRepository:    projectRepository,
+		blobs:                blobs,
+		limits:               limits,
+		contextTimeout:       timeout,
+	}
+}
+
+// GetAttachments returns the attachments of a task, oldest first.
+// Tasks the caller may not see are reported as domain.ErrTaskNotFound.
+func (au *attachmentUseCase) GetAttachments(c context.Context, taskId string) ([]domain.Attachment, error) {
+	ctx, close := context.WithTimeout(c, au.contextTimeout)
+	defer close()
+
+	task, _, err := readableTask(ctx, au.taskRepository, au.projectRepository, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return au.attachmentRepository.FindTaskAttachments(ctx, task.ID.String())
+}
+
+// AddAttachment stores the content read from content as a new attachment of a task, uploaded by the authenticated caller.
+// The type of the content is detected from its first bytes and must be one of the allowed types,
+// otherwise it returns domain.ErrAttachmentType; content over the maximum size gives domain.ErrAttachmentTooLarge.
+// Nothing is kept of a rejected upload. The caller must be able to change the task: domain.ErrProjectRole is
+// returned to those who may only read it.
+func (au *attachmentUseCase) AddAttachment(c context.Context, taskId string, filename string, content io.Reader) (domain.Attachment, error) {
+	filename, err := domain.CleanFilename(filename)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	ctx, close := context.WithTimeout(c, au.contextTimeout)
+	defer close()
+	task, user, err := editableTask(ctx, au.taskRepository, au.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	reader := bufio.NewReaderSize(content, sniffLength)
+	head, err := reader.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		return domain.Attachment{}, err
+	}
+	contentType := http.DetectContentType(head)
+	if !au.limits.Allows(contentType) {
+		return domain.Attachment{}, fmt.Errorf("%w: %s", domain.ErrAttachmentType, contentType)
+	}
+
+	attachment := domain.Attachment{
+		ID:          domain.NewID(),
+		TaskID:      task.ID.String(),
+		Filename:    filename,
+		ContentType: contentType,
+		UploadedBy:  user.ID,
+	}
+	// one byte more than allowed is read to tell a file of exactly the maximum size from a larger one
+	attachment.Size, err = au.blobs.Put(c, attachment.ID.String(), io.LimitReader(reader, au.limits.MaxSize+1))
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if attachment.Size > au.limits.MaxSize {
+		au.deleteBlob(attachment.ID.String())
+		return domain.Attachment{}, fmt.Errorf("%w: the maximum is %d bytes", domain.ErrAttachmentTooLarge, au.limits.MaxSize)
+	}
+
+	ctx, close = context.WithTimeout(c, au.contextTimeout)
+	defer close()
+	attachment.CreatedAt = time.Now().UTC()
+	created, err := au.attachmentRepository.CreateAttachment(ctx, attachment)
+	if err != nil {
+		au.deleteBlob(attachment.ID.String())
+		return domain.Attachment{}, err
+	}
+	return created, nil
+}
+
+// OpenAttachment returns an attachment of a task with its content, which the caller must close.
+func (au *attachmentUseCase) OpenAttachment(c context.Context, taskId string, attachmentId string) (domain.Attachment, io.ReadCloser, error) {
+	ctx, close := context.WithTimeout(c, au.contextTimeout)
+	defer close()
+
+	attachment, _, err := au.taskAttachment(ctx, taskId, attachmentId)
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	// the content is read after this returns, so it is not bound to the timeout
+	content, err := au.blobs.Get(c, attachment.ID.String())
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return domain.Attachment{}, nil, domain.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment deletes an attachment of a task with its content.
+// Only the uploader of the attachment and the users with domain.PermissionAttachmentModerate can delete it;
+// others get domain.ErrNotUploader.
+func (au *attachmentUseCase) DeleteAttachment(c context.Context, taskId string, attachmentId string) error {
+	ctx, close := context.WithTimeout(c, au.contextTimeout)
+	defer close()
+
+	attachment, user, err := au.taskAttachment(ctx, taskId, attachmentId)
+	if err != nil {
+		return err
+	}
+	if !attachment.CanDelete(user) {
+		return domain.ErrNotUploader
+	}
+	if err := au.attachmentRepository.DeleteAttachment(ctx, attachment.ID.String()); err != nil {
+		return err
+	}
+	// the metadata goes first: a blob left behind is unreachable, an attachment without its blob is broken
+	return au.blobs.Delete(ctx, attachment.ID.String())
+}
+
+// DeleteTaskAttachments deletes the attachments of the given tasks with their content.
+// It is meant for the tasks purged from the trash and does not check the caller.
+func (au *attachmentUseCase) DeleteTaskAttachments(c context.Context, taskIds []string) error {
+	ctx, close := context.WithTimeout(c, au.contextTimeout)
+	defer close()
+
+	for _, taskId := range taskIds {
+		attachments, err := au.attachmentRepository.FindTaskAttachments(ctx, taskId)
+		if err != nil {
+			return err
+		}
+		for _, attachment := range attachments {
+			if err := au.blobs.Delete(ctx, attachment.ID.String()); err != nil {
+				return err
+			}
+		}
+	}
+	return au.attachmentRepository.DeleteTaskAttachments(ctx, taskIds)
+}
+
+// taskAttachment returns the attachment with the given ID and the authenticated caller
+// if the attachment belongs to the task and the caller can see the task.
+func (au *attachmentUseCase) taskAttachment(ctx context.Context, taskId string, attachmentId string) (domain.Attachment, domain.AuthUser, error) {
+	task, user, err := readableTask(ctx, au.taskRepository, au.projectRepository, taskId)
+	if err != nil {
+		return domain.Attachment{}, domain.AuthUser{}, err
+	}
+	attachment, err := au.attachmentRepository.FindAttachmentById(ctx, attachmentId)
+	if err != nil {
+		return domain.Attachment{}, domain.AuthUser{}, err
+	}
+	if attachment.TaskID != task.ID.String() {
+		return domain.Attachment{}, domain.AuthUser{}, domain.ErrAttachmentNotFound
+	}
+	return attachment, user, nil
+}
+
+// deleteBlob removes the blob of a rejected upload. The request may have been cancelled,
+// so it gets its own deadline, and a failure only leaves an unreachable blob behind.
+func (au *attachmentUseCase) deleteBlob(key string) {
+	ctx, close := context.WithTimeout(context.Background(), au.contextTimeout)
+	defer close()
+	if err := au.blobs.Delete(ctx, key); err != nil {
+		log.Printf("deleting the blob of a rejected attachment: %v", err)
+	}
+}
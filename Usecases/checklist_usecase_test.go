@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChecklistUseCaseSuite struct {
+	suite.Suite
+	mockChecklistRepo *mocks.ChecklistRepository
+	mockTaskRepo      *mocks.TaskRepository
+	checklistUseCase  domain.ChecklistUseCase
+	// items is the checklist of the task, which is visible to adminUser and regularUser
+	items []domain.ChecklistItem
+}
+
+func (suite *ChecklistUseCaseSuite) SetupTest() {
+	suite.mockChecklistRepo = new(mocks.ChecklistRepository)
+	suite.mockTaskRepo = new(mocks.TaskRepository)
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(
+		domain.Task{ID: taskID, CreatedBy: adminUser.ID, AssigneeIDs: []string{regularUser.ID}}, nil,
+	)
+	suite.items = []domain.ChecklistItem{
+		{ID: domain.NewID(), TaskID: taskID.String(), Title: "write", Required: true, Position: 0},
+		{ID: domain.NewID(), TaskID: taskID.String(), Title: "review", Position: 2},
+	}
+	suite.mockChecklistRepo.On("FindChecklist", mock.Anything, taskID.String()).Maybe().Return(
+		func(ctx context.Context, taskId string) []domain.ChecklistItem { return suite.items }, nil,
+	)
+	suite.checklistUseCase = NewChecklistUsecase(suite.mockChecklistRepo, suite.mockTaskRepo, new(mocks.ProjectRepository), time.Second*2)
+}
+
+// TestAddChecklistItem tests that items are appended after the last one of the checklist of a visible task,
+// with a valid title and up to the size limit.
+func (suite *ChecklistUseCaseSuite) TestAddChecklistItem() {
+	suite.mockChecklistRepo.On("CreateChecklistItem", mock.Anything, mock.MatchedBy(func(item domain.ChecklistItem) bool {
+		return item.TaskID == taskID.String() && item.Title == "ship" && item.Required && item.Position == 3 &&
+			!item.Done && !item.CreatedAt.IsZero()
+	})).Return(func(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) {
+		item.ID = domain.NewID()
+		return item, nil
+	})
+
+	item, err := suite.checklistUseCase.AddChecklistItem(asUser(regularUser), taskID.String(), "ship", true)
+	suite.Require().NoError(err)
+	suite.False(item.ID.IsZero())
+
+	_, err = suite.checklistUseCase.AddChecklistItem(asUser(regularUser), taskID.String(), " ", false)
+	suite.ErrorIs(err, domain.ErrValidation)
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	_, err = suite.checklistUseCase.AddChecklistItem(asUser(stranger), taskID.String(), "ship", false)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	suite.items = make([]domain.ChecklistItem, domain.MaxChecklistItems)
+	_, err = suite.checklistUseCase.AddChecklistItem(asUser(regularUser), taskID.String(), "ship", false)
+	suite.ErrorIs(err, domain.ErrChecklistFull)
+	suite.mockChecklistRepo.AssertNumberOfCalls(suite.T(), "CreateChecklistItem", 1)
+}
+
+// TestCompleteChecklistItem tests that completing an item records who did it and when,
+// that reopening clears both and that items of other tasks are not found.
+func (suite *ChecklistUseCaseSuite) TestCompleteChecklistItem() {
+	item := suite.items[0]
+	suite.mockChecklistRepo.On("FindChecklistItemById", mock.Anything, item.ID.String()).Return(item, nil)
+	suite.mockChecklistRepo.On("UpdateChecklistItem", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) { return item, nil },
+	)
+
+	done, err := suite.checklistUseCase.CompleteChecklistItem(asUser(regularUser), taskID.String(), item.ID.String(), true)
+	suite.Require().NoError(err)
+	suite.True(done.Done)
+	suite.Equal(regularUser.ID, done.DoneBy)
+	suite.NotNil(done.DoneAt)
+
+	reopened := new(mocks.ChecklistRepository)
+	reopened.On("FindChecklistItemById", mock.Anything, item.ID.String()).Return(done, nil)
+	reopened.On("UpdateChecklistItem", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, item domain.ChecklistItem) (domain.ChecklistItem, error) { return item, nil },
+	)
+	item, err = NewChecklistUsecase(reopened, suite.mockTaskRepo, new(mocks.ProjectRepository), time.Second).CompleteChecklistItem(asUser(adminUser), taskID.String(), item.ID.String(), false)
+	suite.Require().NoError(err)
+	suite.False(item.Done)
+	suite.Empty(item.DoneBy)
+	suite.Nil(item.DoneAt)
+
+	other := domain.ChecklistItem{ID: domain.NewID(), TaskID: domain.NewID().String()}
+	suite.mockChecklistRepo.On("FindChecklistItemById", mock.Anything, other.ID.String()).Return(other, nil)
+	_, err = suite.checklistUseCase.CompleteChecklistItem(asUser(adminUser), taskID.String(), other.ID.String(), true)
+	suite.ErrorIs(err, domain.ErrChecklistItemNotFound)
+	suite.mockChecklistRepo.AssertNumberOfCalls(suite.T(), "UpdateChecklistItem", 1)
+}
+
+// TestReorderChecklist tests that the new order must list every item of the checklist exactly once.
+func (suite *ChecklistUseCaseSuite) TestReorderChecklist() {
+	first, second := suite.items[0].ID.String(), suite.items[1].ID.String()
+	suite.mockChecklistRepo.On("ReorderChecklist", mock.Anything, taskID.String(), []string{second, first}).Return(nil)
+
+	_, err := suite.checklistUseCase.ReorderChecklist(asUser(regularUser), taskID.String(), []string{second, first})
+	suite.Require().NoError(err)
+
+	for _, order := range [][]string{{second}, {second, second}, {second, first, first}, {second, domain.NewID().String()}} {
+		_, err = suite.checklistUseCase.ReorderChecklist(asUser(regularUser), taskID.String(), order)
+		suite.ErrorIs(err, domain.ErrValidation, order)
+	}
+	suite.mockChecklistRepo.AssertNumberOfCalls(suite.T(), "ReorderChecklist", 1)
+}
+
+// TestDeleteChecklistItem tests that an item is deleted through its own task only.
+func (suite *ChecklistUseCaseSuite) TestDeleteChecklistItem() {
+	item := suite.items[1]
+	suite.mockChecklistRepo.On("FindChecklistItemById", mock.Anything, item.ID.String()).Return(item, nil)
+	suite.mockChecklistRepo.On("DeleteChecklistItem", mock.Anything, item.ID.String()).Return(nil)
+
+	suite.Require().NoError(suite.checklistUseCase.DeleteChecklistItem(asUser(regularUser), taskID.String(), item.ID.String()))
+
+	otherTask := domain.NewID()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, otherTask.String()).Return(domain.Task{ID: otherTask, CreatedBy: adminUser.ID}, nil)
+	err := suite.checklistUseCase.DeleteChecklistItem(asUser(adminUser), otherTask.String(), item.ID.String())
+	suite.ErrorIs(err, domain.ErrChecklistItemNotFound)
+	suite.mockChecklistRepo.AssertNumberOfCalls(suite.T(), "DeleteChecklistItem", 1)
+}
+
+// TestChecklist_ProjectViewer tests that the viewers of a project read the checklists of its tasks but cannot change them.
+func (suite *ChecklistUseCaseSuite) TestChecklist_ProjectViewer() {
+	pid := domain.NewID().String()
+	viewer := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	projects := new(mocks.ProjectRepository)
+	projects.On("FindMember", mock.Anything, pid, viewer.ID).Return(domain.ProjectMember{ProjectID: pid, UserID: viewer.ID, Role: domain.ProjectRoleViewer}, nil)
+	tasks := new(mocks.TaskRepository)
+	tasks.On("FindTaskById", mock.Anything, taskID.String()).Return(domain.Task{ID: taskID, CreatedBy: adminUser.ID, ProjectID: pid}, nil)
+	checklistUseCase := NewChecklistUsecase(suite.mockChecklistRepo, tasks, projects, time.Second)
+	ctx, id, item := asUser(viewer), taskID.String(), suite.items[0].ID.String()
+
+	items, err := checklistUseCase.GetChecklist(ctx, id)
+	suite.Require().NoError(err)
+	suite.Len(items, 2)
+	_, err = checklistUseCase.AddChecklistItem(ctx, id, "ship", false)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	_, err = checklistUseCase.CompleteChecklistItem(ctx, id, item, true)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	_, err = checklistUseCase.ReorderChecklist(ctx, id, []string{suite.items[1].ID.String(), item})
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	suite.ErrorIs(checklistUseCase.DeleteChecklistItem(ctx, id, item), domain.ErrProjectRole)
+	suite.mockChecklistRepo.AssertNotCalled(suite.T(), "FindChecklistItemById", mock.Anything, mock.Anything)
+}
+
+func TestChecklistUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistUseCaseSuite))
+}
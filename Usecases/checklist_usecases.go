@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// checklistUseCase represents the use case for the checklists of the tasks.
+// Checklists follow the access to their task: users read the checklists of the tasks they can see
+// and change those of the tasks they can change.
+type checklistUseCase struct {
+	checklistRepository domain.ChecklistRepository
+	taskRepository      domain.TaskRepository
+	projectRepository   domain.ProjectRepository
+	contextTimeout      time.Duration
+}
+
+var _ domain.ChecklistUseCase = &checklistUseCase{}
+
+// NewChecklistUsecase creates a new instance of the ChecklistUseCase interface.
+// It takes the checklistRepository storing the items, the taskRepository and projectRepository the visibility
+// of their task is read from and a timeout of type time.Duration as parameters.
+func NewChecklistUsecase(checklistRepository domain.ChecklistRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, timeout time.Duration) domain.ChecklistUseCase {
+	return &checklistUseCase{
+		checklistRepository: checklistRepository,
+		taskRepository:      taskRepository,
+		projectRepository:   projectRepository,
+		contextTimeout:      timeout,
+	}
+}
+
+// GetChecklist returns the items of the checklist of a task in order.
+// Tasks the caller may not see are reported as domain.ErrTaskNotFound.
+func (cu *checklistUseCase) GetChecklist(c context.Context, taskId string) ([]domain.ChecklistItem, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	task, _, err := readableTask(ctx, cu.taskRepository, cu.projectRepository, taskId)
+	if err != nil {
+		return nil, err
+	}
+	return cu.checklistRepository.FindChecklist(ctx, task.ID.String())
+}
+
+// AddChecklistItem appends an item to the checklist of a task and returns it.
+// The title must not be blank nor longer than domain.MaxChecklistTitleLength, and a checklist holds
+// at most domain.MaxChecklistItems items; beyond that domain.ErrChecklistFull is returned.
+// Like the other changes to a checklist, it returns domain.ErrProjectRole to the callers who may only read the task.
+func (cu *checklistUseCase) AddChecklistItem(c context.Context, taskId string, title string, required bool) (domain.ChecklistItem, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	if err := domain.ValidateChecklistTitle(title); err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	task, _, err := editableTask(ctx, cu.taskRepository, cu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	items, err := cu.checklistRepository.FindChecklist(ctx, task.ID.String())
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if len(items) >= domain.MaxChecklistItems {
+		return domain.ChecklistItem{}, domain.ErrChecklistFull
+	}
+	position := 0
+	if len(items) > 0 {
+		position = items[len(items)-1].Position + 1
+	}
+	return cu.checklistRepository.CreateChecklistItem(ctx, domain.ChecklistItem{
+		TaskID:    task.ID.String(),
+		Title:     title,
+		Required:  required,
+		Position:  position,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// CompleteChecklistItem marks an item of the checklist of a task as done by the caller,
+// or as open again when done is false, and returns the updated item.
+// Completing a done item, or reopening an open one, leaves it unchanged.
+func (cu *checklistUseCase) CompleteChecklistItem(c context.Context, taskId string, itemId string, done bool) (domain.ChecklistItem, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	task, user, err := editableTask(ctx, cu.taskRepository, cu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	item, err := cu.taskItem(ctx, task, itemId)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if item.Done == done {
+		return item, nil
+	}
+	item.Done = done
+	if done {
+		now := time.Now().UTC()
+		item.DoneBy, item.DoneAt = user.ID, &now
+	} else {
+		item.DoneBy, item.DoneAt = "", nil
+	}
+	return cu.checklistRepository.UpdateChecklistItem(ctx, item)
+}
+
+// ReorderChecklist puts the items of the checklist of a task in the order of itemIds and returns the checklist.
+// itemIds must list every item of the checklist exactly once.
+func (cu *checklistUseCase) ReorderChecklist(c context.Context, taskId string, itemIds []string) ([]domain.ChecklistItem, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	task, _, err := editableTask(ctx, cu.taskRepository, cu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return nil, err
+	}
+	items, err := cu.checklistRepository.FindChecklist(ctx, task.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(itemIds) != len(items) {
+		return nil, fmt.Errorf("%w: the order must list the %d items of the checklist", domain.ErrValidation, len(items))
+	}
+	remaining := make(map[string]bool, len(items))
+	for _, item := range items {
+		remaining[item.ID.String()] = true
+	}
+	for _, id := range itemIds {
+		if !remaining[id] {
+			return nil, fmt.Errorf("%w: %q is not an item of the checklist or is listed twice", domain.ErrValidation, id)
+		}
+		delete(remaining, id)
+	}
+	if err := cu.checklistRepository.ReorderChecklist(ctx, task.ID.String(), itemIds); err != nil {
+		return nil, err
+	}
+	return cu.checklistRepository.FindChecklist(ctx, task.ID.String())
+}
+
+// DeleteChecklistItem deletes an item of the checklist of a task.
+func (cu *checklistUseCase) DeleteChecklistItem(c context.Context, taskId string, itemId string) error {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	task, _, err := editableTask(ctx, cu.taskRepository, cu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return err
+	}
+	item, err := cu.taskItem(ctx, task, itemId)
+	if err != nil {
+		return err
+	}
+	return cu.checklistRepository.DeleteChecklistItem(ctx, item.ID.String())
+}
+
+// taskItem returns the checklist item with the given ID if it belongs to the task.
+func (cu *checklistUseCase) taskItem(ctx context.Context, task domain.Task, itemId string) (domain.ChecklistItem, error) {
+	item, err := cu.checklistRepository.FindChecklistItemById(ctx, itemId)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if item.TaskID != task.ID.String() {
+		return domain.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	return item, nil
+}
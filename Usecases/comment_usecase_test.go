@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CommentUseCaseSuite struct {
+	suite.Suite
+	mockCommentRepo *mocks.CommentRepository
+	mockTaskRepo    *mocks.TaskRepository
+	commentUseCase  domain.CommentUseCase
+	// task is visible to adminUser, who created it, and to regularUser, who is assigned to it
+	task domain.Task
+}
+
+func (suite *CommentUseCaseSuite) SetupTest() {
+	suite.mockCommentRepo = new(mocks.CommentRepository)
+	suite.mockTaskRepo = new(mocks.TaskRepository)
+	suite.task = domain.Task{ID: taskID, CreatedBy: adminUser.ID, AssigneeIDs: []string{regularUser.ID}}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(suite.task, nil)
+	suite.commentUseCase = NewCommentUsecase(suite.mockCommentRepo, suite.mockTaskRepo, new(mocks.ProjectRepository), time.Second*2)
+}
+
+// TestAddComment tests that a comment is posted as the caller on a task they can see, with a valid body.
+func (suite *CommentUseCaseSuite) TestAddComment() {
+	suite.mockCommentRepo.On("CreateComment", mock.Anything, mock.MatchedBy(func(comment domain.Comment) bool {
+		return comment.TaskID == taskID.String() && comment.AuthorID == regularUser.ID && comment.Body == "on it" &&
+			!comment.CreatedAt.IsZero() && comment.CreatedAt.Equal(comment.UpdatedAt)
+	})).Return(func(ctx context.Context, comment domain.Comment) (domain.Comment, error) {
+		comment.ID = domain.NewID()
+		return comment, nil
+	})
+
+	comment, err := suite.commentUseCase.AddComment(asUser(regularUser), taskID.String(), "on it")
+	suite.Require().NoError(err)
+	suite.False(comment.ID.IsZero())
+
+	_, err = suite.commentUseCase.AddComment(asUser(regularUser), taskID.String(), "  ")
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.commentUseCase.AddComment(asUser(regularUser), taskID.String(), strings.Repeat("a", domain.MaxCommentLength+1))
+	suite.ErrorIs(err, domain.ErrValidation)
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	_, err = suite.commentUseCase.AddComment(asUser(stranger), taskID.String(), "hello")
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	_, err = suite.commentUseCase.AddComment(context.Background(), taskID.String(), "hello")
+	suite.ErrorIs(err, domain.ErrUnauthenticated)
+	suite.mockCommentRepo.AssertNumberOfCalls(suite.T(), "CreateComment", 1)
+}
+
+// TestGetComments tests that the comments of a visible task are read with a validated page window.
+func (suite *CommentUseCaseSuite) TestGetComments() {
+	page := domain.CommentPage{Comments: []domain.Comment{{ID: domain.NewID(), TaskID: taskID.String(), Body: "on it"}}}
+	expected := domain.CommentQuery{TaskID: taskID.String(), Limit: domain.DefaultTaskPageSize}
+	suite.mockCommentRepo.On("FindComments", mock.Anything, expected).Return(page, nil)
+
+	result, err := suite.commentUseCase.GetComments(asUser(regularUser), taskID.String(), domain.CommentQuery{TaskID: "ignored"})
+	suite.Require().NoError(err)
+	suite.Equal(page, result)
+
+	_, err = suite.commentUseCase.GetComments(asUser(regularUser), taskID.String(), domain.CommentQuery{Limit: domain.MaxTaskPageSize + 1})
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.commentUseCase.GetComments(asUser(regularUser), taskID.String(), domain.CommentQuery{Cursor: "garbage"})
+	suite.ErrorIs(err, domain.ErrValidation)
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	_, err = suite.commentUseCase.GetComments(asUser(stranger), taskID.String(), domain.CommentQuery{})
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	suite.mockCommentRepo.AssertExpectations(suite.T())
+}
+
+// TestEditComment tests that only the author and admins can edit a comment, addressed through its own task.
+func (suite *CommentUseCaseSuite) TestEditComment() {
+	created := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	comment := domain.Comment{ID: domain.NewID(), TaskID: taskID.String(), AuthorID: regularUser.ID, Body: "on it", CreatedAt: created, UpdatedAt: created}
+	suite.mockCommentRepo.On("FindCommentById", mock.Anything, comment.ID.String()).Return(comment, nil)
+	suite.mockCommentRepo.On("UpdateComment", mock.Anything, mock.MatchedBy(func(updated domain.Comment) bool {
+		return updated.ID == comment.ID && updated.Body == "done" && updated.UpdatedAt.After(created)
+	})).Return(func(ctx context.Context, updated domain.Comment) (domain.Comment, error) {
+		return updated, nil
+	})
+
+	updated, err := suite.commentUseCase.EditComment(asUser(regularUser), taskID.String(), comment.ID.String(), "done")
+	suite.Require().NoError(err)
+	suite.Equal("done", updated.Body)
+	suite.Equal(created, updated.CreatedAt)
+	_, err = suite.commentUseCase.EditComment(asUser(adminUser), taskID.String(), comment.ID.String(), "done")
+	suite.NoError(err, "admins can edit any comment")
+
+	other := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	otherTask := domain.NewID()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, otherTask.String()).Return(domain.Task{ID: otherTask, CreatedBy: other.ID}, nil)
+	_, err = suite.commentUseCase.EditComment(asUser(other), otherTask.String(), comment.ID.String(), "done")
+	suite.ErrorIs(err, domain.ErrCommentNotFound, "a comment is only reachable through its own task")
+	_, err = suite.commentUseCase.EditComment(asUser(regularUser), taskID.String(), comment.ID.String(), "")
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockCommentRepo.AssertNumberOfCalls(suite.T(), "UpdateComment", 2)
+}
+
+// TestDeleteComment tests that users other than the author and admins cannot delete a comment.
+func (suite *CommentUseCaseSuite) TestDeleteComment() {
+	comment := domain.Comment{ID: domain.NewID(), TaskID: taskID.String(), AuthorID: adminUser.ID}
+	suite.mockCommentRepo.On("FindCommentById", mock.Anything, comment.ID.String()).Return(comment, nil)
+	suite.mockCommentRepo.On("DeleteComment", mock.Anything, comment.ID.String()).Return(nil)
+
+	err := suite.commentUseCase.DeleteComment(asUser(regularUser), taskID.String(), comment.ID.String())
+	suite.ErrorIs(err, domain.ErrNotCommentAuthor)
+	suite.ErrorIs(err, domain.ErrForbidden)
+	suite.mockCommentRepo.AssertNotCalled(suite.T(), "DeleteComment", mock.Anything, mock.Anything)
+
+	suite.NoError(suite.commentUseCase.DeleteComment(asUser(adminUser), taskID.String(), comment.ID.String()))
+	suite.mockCommentRepo.AssertExpectations(suite.T())
+}
+
+func TestCommentUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(CommentUseCaseSuite))
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// commentUseCase represents the use case for discussing tasks.
+// Comments follow the visibility of their task: users only read and post comments on the tasks they can see.
+type commentUseCase struct {
+	commentRepository domain.CommentRepository
+	taskRepository    domain.TaskRepository
+	projectRepository domain.ProjectRepository
+	contextTimeout    time.Duration
+}
+
+var _ domain.CommentUseCase = &commentUseCase{}
+
+// NewCommentUsecase creates a new instance of the CommentUseCase interface.
+// It takes the commentRepository storing the comments, the taskRepository and projectRepository the visibility
+// of their task is read from and a timeout of type time.Duration as parameters.
+func NewCommentUsecase(commentRepository domain.CommentRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, timeout time.Duration) domain.CommentUseCase {
+	return &commentUseCase{
+		commentRepository: commentRepository,
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		contextTimeout:    timeout,
+	}
+}
+
+// GetComments returns one page of the comments of a task, oldest first.
+// A zero limit falls back to domain.DefaultTaskPageSize.
+// Tasks the caller may not see are reported as domain.ErrTaskNotFound.
+func (cu *commentUseCase) GetComments(c context.Context, taskId string, query domain.CommentQuery) (domain.CommentPage, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	task, _, err := readableTask(ctx, cu.taskRepository, cu.projectRepository, taskId)
+	if err != nil {
+		return domain.CommentPage{}, err
+	}
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultTaskPageSize
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxTaskPageSize {
+		return domain.CommentPage{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidTaskQuery, domain.MaxTaskPageSize)
+	}
+	if query.Cursor != "" {
+		if _, err := domain.ParseID(query.Cursor); err != nil {
+			return domain.CommentPage{}, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTaskQuery)
+		}
+	}
+	query.TaskID = task.ID.String()
+	return cu.commentRepository.FindComments(ctx, query)
+}
+
+// AddComment posts a comment on a task as the authenticated caller and returns it.
+// The body must not be blank nor longer than domain.MaxCommentLength.
+func (cu *commentUseCase) AddComment(c context.Context, taskId string, body string) (domain.Comment, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	if err := domain.ValidateCommentBody(body); err != nil {
+		return domain.Comment{}, err
+	}
+	task, user, err := readableTask(ctx, cu.taskRepository, cu.projectRepository, taskId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	now := time.Now().UTC()
+	return cu.commentRepository.CreateComment(ctx, domain.Comment{
+		TaskID:    task.ID.String(),
+		AuthorID:  user.ID,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// EditComment replaces the body of a comment of a task and returns the updated comment.
+// Only the author of the comment and the users with domain.PermissionCommentModerate can edit it;
+// others get domain.ErrNotCommentAuthor.
+func (cu *commentUseCase) EditComment(c context.Context, taskId string, commentId string, body string) (domain.Comment, error) {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	if err := domain.ValidateCommentBody(body); err != nil {
+		return domain.Comment{}, err
+	}
+	comment, err := cu.changeableComment(ctx, taskId, commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	comment.Body = body
+	comment.UpdatedAt = time.Now().UTC()
+	return cu.commentRepository.UpdateComment(ctx, comment)
+}
+
+// DeleteComment deletes a comment of a task.
+// Only the author of the comment and the users with domain.PermissionCommentModerate can delete it;
+// others get domain.ErrNotCommentAuthor.
+func (cu *commentUseCase) DeleteComment(c context.Context, taskId string, commentId string) error {
+	ctx, close := context.WithTimeout(c, cu.contextTimeout)
+	defer close()
+
+	comment, err := cu.changeableComment(ctx, taskId, commentId)
+	if err != nil {
+		return err
+	}
+	return cu.commentRepository.DeleteComment(ctx, comment.ID.String())
+}
+
+// changeableComment returns the comment with the given ID if it belongs to the task, the caller can see the task
+// and the caller may change the comment.
+func (cu *commentUseCase) changeableComment(ctx context.Context, taskId string, commentId string) (domain.Comment, error) {
+	task, user, err := readableTask(ctx, cu.taskRepository, cu.projectRepository, taskId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	comment, err := cu.commentRepository.FindCommentById(ctx, commentId)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if comment.TaskID != task.ID.String() {
+		return domain.Comment{}, domain.ErrCommentNotFound
+	}
+	if !comment.CanChange(user) {
+		return domain.Comment{}, domain.ErrNotCommentAuthor
+	}
+	return comment, nil
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LabelUseCaseSuite struct {
+	suite.Suite
+	mockLabelRepo *mocks.LabelRepository
+	mockTaskRepo  *mocks.TaskRepository
+	labelUseCase  domain.LabelUseCase
+}
+
+func (suite *LabelUseCaseSuite) SetupTest() {
+	suite.mockLabelRepo = new(mocks.LabelRepository)
+	suite.mockTaskRepo = new(mocks.TaskRepository)
+	suite.labelUseCase = NewLabelUsecase(suite.mockLabelRepo, suite.mockTaskRepo, time.Second*2)
+}
+
+// TestCreateLabel tests that a label is stored with its name and colour normalized and its creation time set,
+// and that invalid labels are rejected.
+func (suite *LabelUseCaseSuite) TestCreateLabel() {
+	suite.mockLabelRepo.On("CreateLabel", mock.Anything, mock.MatchedBy(func(label domain.Label) bool {
+		return label.Name == "customer-x" && label.Color == "#1f77b4" && !label.CreatedAt.IsZero()
+	})).Return(func(ctx context.Context, label domain.Label) (domain.Label, error) { return label, nil })
+
+	label, err := suite.labelUseCase.CreateLabel(asUser(adminUser), domain.Label{Name: " Customer-X", Color: "#1F77B4"})
+	suite.Require().NoError(err)
+	suite.Equal("customer-x", label.Name)
+
+	_, err = suite.labelUseCase.CreateLabel(asUser(adminUser), domain.Label{Name: "bug", Color: "red"})
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.labelUseCase.CreateLabel(asUser(adminUser), domain.Label{Name: "a,b", Color: "#d62728"})
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockLabelRepo.AssertNumberOfCalls(suite.T(), "CreateLabel", 1)
+}
+
+// TestUpdateLabel tests that a label is updated by its normalized name, whatever name the body holds.
+func (suite *LabelUseCaseSuite) TestUpdateLabel() {
+	expected := domain.Label{Name: "bug", Color: "#d62728", Description: "something broken"}
+	suite.mockLabelRepo.On("UpdateLabel", mock.Anything, expected).Return(expected, nil)
+
+	label, err := suite.labelUseCase.UpdateLabel(asUser(adminUser), "BUG", domain.Label{Name: "other", Color: "#D62728", Description: "something broken"})
+	suite.Require().NoError(err)
+	suite.Equal(expected, label)
+	suite.mockLabelRepo.AssertExpectations(suite.T())
+}
+
+// TestDeleteLabel tests that a label used by live tasks cannot be deleted and that unknown labels are not found.
+func (suite *LabelUseCaseSuite) TestDeleteLabel() {
+	suite.mockLabelRepo.On("FindLabelByName", mock.Anything, "bug").Return(domain.Label{Name: "bug"}, nil)
+	suite.mockLabelRepo.On("FindLabelByName", mock.Anything, "frontend").Return(domain.Label{Name: "frontend"}, nil)
+	suite.mockLabelRepo.On("FindLabelByName", mock.Anything, "unknown").Return(domain.Label{}, domain.ErrLabelNotFound)
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, domain.TaskQuery{Labels: []string{"bug"}, Limit: 1}).Return(
+		domain.TaskPage{Tasks: []domain.Task{{ID: taskID, Labels: []string{"bug"}}}}, nil,
+	)
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, domain.TaskQuery{Labels: []string{"frontend"}, Limit: 1}).Return(domain.TaskPage{}, nil)
+	suite.mockLabelRepo.On("DeleteLabel", mock.Anything, "frontend").Return(nil)
+
+	suite.ErrorIs(suite.labelUseCase.DeleteLabel(asUser(adminUser), "bug"), domain.ErrLabelInUse)
+	suite.Require().NoError(suite.labelUseCase.DeleteLabel(asUser(adminUser), "Frontend"))
+	suite.ErrorIs(suite.labelUseCase.DeleteLabel(asUser(adminUser), "unknown"), domain.ErrLabelNotFound)
+	suite.mockLabelRepo.AssertNumberOfCalls(suite.T(), "DeleteLabel", 1)
+}
+
+func TestLabelUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(LabelUseCaseSuite))
+}
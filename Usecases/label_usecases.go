@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// labelUseCase represents the use case for the label catalog of the organization of the caller.
+// The routes changing the catalog are restricted to the users with domain.PermissionLabelManage.
+type labelUseCase struct {
+	labelRepository domain.LabelRepository
+	taskRepository  domain.TaskRepository
+	contextTimeout  time.Duration
+}
+
+var _ domain.LabelUseCase = &labelUseCase{}
+
+// NewLabelUsecase creates a new instance of the LabelUseCase interface.
+// It takes the labelRepository storing the catalog, the taskRepository the use of the labels is read from
+// and a timeout of type time.Duration as parameters.
+func NewLabelUsecase(labelRepository domain.LabelRepository, taskRepository domain.TaskRepository, timeout time.Duration) domain.LabelUseCase {
+	return &labelUseCase{
+		labelRepository: labelRepository,
+		taskRepository:  taskRepository,
+		contextTimeout:  timeout,
+	}
+}
+
+// GetLabels returns every label of the catalog, ordered by name.
+func (lu *labelUseCase) GetLabels(c context.Context) ([]domain.Label, error) {
+	ctx, close := context.WithTimeout(c, lu.contextTimeout)
+	defer close()
+
+	return lu.labelRepository.FindLabels(ctx)
+}
+
+// CreateLabel adds a label to the catalog and returns it, with its name and colour normalized.
+// It returns domain.ErrLabelExists if a label already has the same normalized name.
+func (lu *labelUseCase) CreateLabel(c context.Context, label domain.Label) (domain.Label, error) {
+	ctx, close := context.WithTimeout(c, lu.contextTimeout)
+	defer close()
+
+	if err := label.Validate(); err != nil {
+		return domain.Label{}, err
+	}
+	label.CreatedAt = time.Now().UTC()
+	return lu.labelRepository.CreateLabel(ctx, label)
+}
+
+// UpdateLabel replaces the colour and description of the label with the given name and returns it.
+// The name of a label never changes, so the one of the given label is ignored.
+func (lu *labelUseCase) UpdateLabel(c context.Context, name string, label domain.Label) (domain.Label, error) {
+	ctx, close := context.WithTimeout(c, lu.contextTimeout)
+	defer close()
+
+	label.Name = name
+	if err := label.Validate(); err != nil {
+		return domain.Label{}, err
+	}
+	return lu.labelRepository.UpdateLabel(ctx, label)
+}
+
+// DeleteLabel deletes a label from the catalog.
+// It returns domain.ErrLabelInUse while tasks of the organization that are not in the trash have the label.
+func (lu *labelUseCase) DeleteLabel(c context.Context, name string) error {
+	ctx, close := context.WithTimeout(c, lu.contextTimeout)
+	defer close()
+
+	name, err := domain.NormalizeLabelName(name)
+	if err != nil {
+		return err
+	}
+	if _, err := lu.labelRepository.FindLabelByName(ctx, name); err != nil {
+		return err
+	}
+	page, err := lu.taskRepository.FindTasks(ctx, domain.TaskQuery{Labels: []string{name}, Limit: 1})
+	if err != nil {
+		return err
+	}
+	if len(page.Tasks) > 0 {
+		return fmt.Errorf("%w: remove %q from the tasks first", domain.ErrLabelInUse, name)
+	}
+	return lu.labelRepository.DeleteLabel(ctx, name)
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// projectMember returns the membership of the user in a project.
+// Users with domain.PermissionProjectManage act as owners of every project.
+// It returns domain.ErrProjectNotFound if the project does not exist or the user is not one of its members,
+// so the projects of other users are not leaked.
+func projectMember(ctx context.Context, projects domain.ProjectRepository, projectId string, user domain.AuthUser) (domain.ProjectMember, error) {
+	id, err := domain.ParseID(projectId)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if user.Can(domain.PermissionProjectManage) {
+		if _, err := projects.FindProjectById(ctx, id.String()); err != nil {
+			return domain.ProjectMember{}, err
+		}
+		return domain.ProjectMember{ProjectID: id.String(), UserID: user.ID, Role: domain.ProjectRoleOwner}, nil
+	}
+	member, err := projects.FindMember(ctx, id.String(), user.ID)
+	if errors.Is(err, domain.ErrMemberNotFound) {
+		return domain.ProjectMember{}, domain.ErrProjectNotFound
+	}
+	return member, err
+}
+
+// keepOwner returns domain.ErrLastOwner if the member is the last owner of its project.
+func keepOwner(ctx context.Context, projects domain.ProjectRepository, member domain.ProjectMember) error {
+	if member.Role != domain.ProjectRoleOwner {
+		return nil
+	}
+	members, err := projects.FindMembers(ctx, member.ProjectID)
+	if err != nil {
+		return err
+	}
+	for _, other := range members {
+		if other.UserID != member.UserID && other.Role == domain.ProjectRoleOwner {
+			return nil
+		}
+	}
+	return domain.ErrLastOwner
+}
+
+// canReadTask reports whether the user may read the task, on its own account (see domain.Task.IsVisibleTo)
+// or as a member of the project of the task.
+func canReadTask(ctx context.Context, projects domain.ProjectRepository, task domain.Task, user domain.AuthUser) (bool, error) {
+	if task.IsVisibleTo(user) {
+		return true, nil
+	}
+	if task.ProjectID == "" {
+		return false, nil
+	}
+	_, err := projects.FindMember(ctx, task.ProjectID, user.ID)
+	if errors.Is(err, domain.ErrMemberNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// readableTask returns the task with the given ID and the authenticated caller, if the caller may read the task,
+// see canReadTask. It returns domain.ErrTaskNotFound if not, so the existence of the task is not leaked.
+func readableTask(ctx context.Context, tasks domain.TaskRepository, projects domain.ProjectRepository, taskId string) (domain.Task, domain.AuthUser, error) {
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.Task{}, domain.AuthUser{}, domain.ErrUnauthenticated
+	}
+	task, err := tasks.FindTaskById(ctx, taskId)
+	if err != nil {
+		return domain.Task{}, domain.AuthUser{}, err
+	}
+	if readable, err := canReadTask(ctx, projects, task, user); err != nil {
+		return domain.Task{}, domain.AuthUser{}, err
+	} else if !readable {
+		return domain.Task{}, domain.AuthUser{}, domain.ErrTaskNotFound
+	}
+	return task, user, nil
+}
+
+// editableTask returns the task with the given ID and the authenticated caller, if the caller may change the task:
+// its creator and assignees change it on their own account, and the other users if they may make a change
+// needing the given permission, see canEditTask. Otherwise it returns domain.ErrProjectRole if the caller can
+// read the task, and domain.ErrTaskNotFound if not.
+func editableTask(ctx context.Context, tasks domain.TaskRepository, projects domain.ProjectRepository, taskId string, permission string) (domain.Task, domain.AuthUser, error) {
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.Task{}, domain.AuthUser{}, domain.ErrUnauthenticated
+	}
+	task, err := tasks.FindTaskById(ctx, taskId)
+	if err != nil {
+		return domain.Task{}, domain.AuthUser{}, err
+	}
+	if !task.IsInvolved(user) {
+		if err := checkEditable(ctx, projects, task, user, permission); err != nil {
+			return domain.Task{}, domain.AuthUser{}, err
+		}
+	}
+	return task, user, nil
+}
+
+// canEditTask reports whether the user may make a change to the task needing the given permission
+// outside of projects: the users with the permission make it to every task, the other users
+// to the tasks of the projects they are an editor or owner of.
+func canEditTask(ctx context.Context, projects domain.ProjectRepository, task domain.Task, user domain.AuthUser, permission string) (bool, error) {
+	if user.Can(permission) {
+		return true, nil
+	}
+	if task.ProjectID == "" {
+		return false, nil
+	}
+	member, err := projects.FindMember(ctx, task.ProjectID, user.ID)
+	if errors.Is(err, domain.ErrMemberNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return member.CanEdit(), nil
+}
+
+// checkEditable returns nil if the user may make a change to the task needing the given permission, see canEditTask.
+// Otherwise it returns domain.ErrProjectRole if the user can read the task, and domain.ErrTaskNotFound if not,
+// so its existence is not leaked.
+func checkEditable(ctx context.Context, projects domain.ProjectRepository, task domain.Task, user domain.AuthUser, permission string) error {
+	editable, err := canEditTask(ctx, projects, task, user, permission)
+	if err != nil || editable {
+		return err
+	}
+	readable, err := canReadTask(ctx, projects, task, user)
+	if err != nil {
+		return err
+	}
+	if readable {
+		return domain.ErrProjectRole
+	}
+	return domain.ErrTaskNotFound
+}
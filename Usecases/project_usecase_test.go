@@ -0,0 +1,179 @@
+package usecases
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// ProjectUseCaseSuite tests the projects against a mocked project repository
+// that keeps the members of the test in memory.
+type ProjectUseCaseSuite struct {
+	suite.Suite
+	mockProjectRepo *mocks.ProjectRepository
+	mockTaskRepo    *mocks.TaskRepository
+	projectUseCase  domain.ProjectUseCase
+	project         domain.Project
+	// members holds the members of the project of the test, by user ID
+	members map[string]domain.ProjectMember
+}
+
+var editorUser = domain.AuthUser{ID: domain.NewID().String(), Username: "editor", Role: domain.RoleUser}
+
+func (suite *ProjectUseCaseSuite) SetupTest() {
+	suite.project = domain.Project{ID: domain.NewID(), Name: "website", CreatedBy: regularUser.ID}
+	pid := suite.project.ID.String()
+	suite.members = map[string]domain.ProjectMember{
+		regularUser.ID: {ProjectID: pid, UserID: regularUser.ID, Role: domain.ProjectRoleOwner},
+		editorUser.ID:  {ProjectID: pid, UserID: editorUser.ID, Role: domain.ProjectRoleEditor},
+	}
+
+	suite.mockProjectRepo = new(mocks.ProjectRepository)
+	suite.mockProjectRepo.On("FindProjectById", mock.Anything, pid).Maybe().Return(suite.project, nil)
+	suite.mockProjectRepo.On("FindMember", mock.Anything, pid, mock.Anything).Maybe().Return(
+		func(ctx context.Context, projectId string, userId string) (domain.ProjectMember, error) {
+			member, ok := suite.members[userId]
+			if !ok {
+				return domain.ProjectMember{}, domain.ErrMemberNotFound
+			}
+			return member, nil
+		},
+	)
+	suite.mockProjectRepo.On("FindMember", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(domain.ProjectMember{}, domain.ErrMemberNotFound)
+	suite.mockProjectRepo.On("FindMembers", mock.Anything, pid).Maybe().Return(
+		func(ctx context.Context, projectId string) ([]domain.ProjectMember, error) {
+			members := []domain.ProjectMember{}
+			for _, member := range suite.members {
+				members = append(members, member)
+			}
+			return members, nil
+		},
+	)
+	suite.mockProjectRepo.On("UpdateMember", mock.Anything, mock.Anything).Maybe().Return(func(ctx context.Context, member domain.ProjectMember) error {
+		suite.members[member.UserID] = member
+		return nil
+	})
+	suite.mockProjectRepo.On("RemoveMember", mock.Anything, pid, mock.Anything).Maybe().Return(func(ctx context.Context, projectId string, userId string) error {
+		delete(suite.members, userId)
+		return nil
+	})
+	suite.mockTaskRepo = new(mocks.TaskRepository)
+	userRepo := new(mocks.UserRepository)
+	userRepo.On("FindUserById", mock.Anything, adminUser.ID).Maybe().Return(domain.User{ID: domain.ID(adminUser.ID)}, nil)
+	userRepo.On("FindUserById", mock.Anything, mock.Anything).Maybe().Return(domain.User{}, domain.ErrUserNotFound)
+	suite.projectUseCase = NewProjectUsecase(suite.mockProjectRepo, suite.mockTaskRepo, userRepo, time.Second*2)
+}
+
+// TestCreateProject tests that the creator of a project becomes its owner.
+func (suite *ProjectUseCaseSuite) TestCreateProject() {
+	suite.mockProjectRepo.On("CreateProject", mock.Anything, mock.MatchedBy(func(project domain.Project) bool {
+		return project.Name == "mobile" && project.CreatedBy == editorUser.ID && !project.CreatedAt.IsZero()
+	})).Return(func(ctx context.Context, project domain.Project) (domain.Project, error) {
+		project.ID = suite.project.ID
+		return project, nil
+	})
+	suite.mockProjectRepo.On("AddMember", mock.Anything, mock.MatchedBy(func(member domain.ProjectMember) bool {
+		return member.ProjectID == suite.project.ID.String() && member.UserID == editorUser.ID && member.Role == domain.ProjectRoleOwner
+	})).Return(nil).Once()
+
+	project, err := suite.projectUseCase.CreateProject(asUser(editorUser), domain.Project{Name: " mobile"})
+	suite.Require().NoError(err)
+	suite.Equal("mobile", project.Name)
+	_, err = suite.projectUseCase.CreateProject(asUser(editorUser), domain.Project{Name: " "})
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.projectUseCase.CreateProject(context.Background(), domain.Project{Name: "mobile"})
+	suite.ErrorIs(err, domain.ErrUnauthenticated)
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+}
+
+// TestProjectAccess tests that only the members of a project see it, that only its owners and the admins
+// change it, and that projects are not found by the other users.
+func (suite *ProjectUseCaseSuite) TestProjectAccess() {
+	pid := suite.project.ID.String()
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+
+	project, err := suite.projectUseCase.GetProject(asUser(editorUser), pid)
+	suite.Require().NoError(err)
+	suite.Equal(suite.project, project)
+	_, err = suite.projectUseCase.GetProject(asUser(adminUser), pid)
+	suite.NoError(err, "admins see every project")
+	_, err = suite.projectUseCase.GetProject(asUser(stranger), pid)
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+	_, err = suite.projectUseCase.GetMembers(asUser(stranger), pid)
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+
+	_, err = suite.projectUseCase.UpdateProject(asUser(editorUser), pid, domain.Project{Name: "web"})
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	_, err = suite.projectUseCase.AddMember(asUser(editorUser), pid, adminUser.ID, domain.ProjectRoleViewer)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+
+	suite.mockProjectRepo.On("UpdateProject", mock.Anything, domain.Project{ID: suite.project.ID, Name: "web"}).Return(domain.Project{ID: suite.project.ID, Name: "web"}, nil).Twice()
+	_, err = suite.projectUseCase.UpdateProject(asUser(regularUser), pid, domain.Project{Name: " web "})
+	suite.NoError(err)
+	_, err = suite.projectUseCase.UpdateProject(asUser(adminUser), pid, domain.Project{Name: "web"})
+	suite.NoError(err, "admins act as owners")
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+}
+
+// TestAddMember tests that members are added with a valid role and only if the user exists.
+func (suite *ProjectUseCaseSuite) TestAddMember() {
+	pid := suite.project.ID.String()
+	suite.mockProjectRepo.On("AddMember", mock.Anything, mock.MatchedBy(func(member domain.ProjectMember) bool {
+		return member.ProjectID == pid && member.UserID == adminUser.ID && member.Role == domain.ProjectRoleViewer
+	})).Return(nil).Once()
+
+	member, err := suite.projectUseCase.AddMember(asUser(regularUser), pid, adminUser.ID, "Viewer")
+	suite.Require().NoError(err)
+	suite.Equal(domain.ProjectRoleViewer, member.Role)
+	_, err = suite.projectUseCase.AddMember(asUser(regularUser), pid, adminUser.ID, "guest")
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.projectUseCase.AddMember(asUser(regularUser), pid, domain.NewID().String(), domain.ProjectRoleViewer)
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+}
+
+// TestLastOwner tests that a project always keeps an owner, and that members can leave a project.
+func (suite *ProjectUseCaseSuite) TestLastOwner() {
+	pid := suite.project.ID.String()
+
+	_, err := suite.projectUseCase.UpdateMember(asUser(regularUser), pid, regularUser.ID, domain.ProjectRoleEditor)
+	suite.ErrorIs(err, domain.ErrLastOwner)
+	suite.ErrorIs(suite.projectUseCase.RemoveMember(asUser(regularUser), pid, regularUser.ID), domain.ErrLastOwner)
+	suite.ErrorIs(suite.projectUseCase.RemoveMember(asUser(editorUser), pid, regularUser.ID), domain.ErrProjectRole)
+
+	member, err := suite.projectUseCase.UpdateMember(asUser(regularUser), pid, editorUser.ID, domain.ProjectRoleOwner)
+	suite.Require().NoError(err)
+	suite.Equal(domain.ProjectRoleOwner, member.Role)
+	suite.Require().NoError(suite.projectUseCase.RemoveMember(asUser(regularUser), pid, regularUser.ID), "the other owner keeps the project")
+	suite.NotContains(suite.members, regularUser.ID)
+
+	suite.members[regularUser.ID] = domain.ProjectMember{ProjectID: pid, UserID: regularUser.ID, Role: domain.ProjectRoleViewer}
+	suite.Require().NoError(suite.projectUseCase.RemoveMember(asUser(regularUser), pid, regularUser.ID), "members can leave")
+	_, err = suite.projectUseCase.UpdateMember(asUser(editorUser), pid, regularUser.ID, domain.ProjectRoleEditor)
+	suite.ErrorIs(err, domain.ErrMemberNotFound)
+}
+
+// TestDeleteProject tests that a project with tasks, including tasks in the trash, cannot be deleted.
+func (suite *ProjectUseCaseSuite) TestDeleteProject() {
+	pid := suite.project.ID.String()
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, domain.TaskQuery{ProjectID: pid, Limit: 1}).Return(domain.TaskPage{}, nil)
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, domain.TaskQuery{ProjectID: pid, Deleted: true, Limit: 1}).Return(
+		domain.TaskPage{Tasks: []domain.Task{{ID: taskID, ProjectID: pid}}}, nil,
+	).Once()
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, domain.TaskQuery{ProjectID: pid, Deleted: true, Limit: 1}).Return(domain.TaskPage{}, nil)
+	suite.mockProjectRepo.On("DeleteProject", mock.Anything, pid).Return(nil).Once()
+
+	suite.ErrorIs(suite.projectUseCase.DeleteProject(asUser(regularUser), pid), domain.ErrProjectNotEmpty)
+	suite.ErrorIs(suite.projectUseCase.DeleteProject(asUser(editorUser), pid), domain.ErrProjectRole)
+	suite.Require().NoError(suite.projectUseCase.DeleteProject(asUser(regularUser), pid))
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+}
+
+func TestProjectUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(ProjectUseCaseSuite))
+}
@@ -0,0 +1,240 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// projectUseCase represents the use case for managing the projects and their members.
+// Users only see the projects they are members of, and only owners change a project and its members;
+// the users with domain.PermissionProjectManage act as owners of every project.
+type projectUseCase struct {
+	projectRepository domain.ProjectRepository
+	taskRepository    domain.TaskRepository
+	userRepository    domain.UserRepository
+	contextTimeout    time.Duration
+}
+
+var _ domain.ProjectUseCase = &projectUseCase{}
+
+// NewProjectUsecase creates a new instance of the ProjectUseCase interface.
+// It takes the projectRepository storing the projects and their members, the taskRepository the tasks
+// of the projects are read from, the userRepository the new members are looked up in
+// and a timeout of type time.Duration as parameters.
+func NewProjectUsecase(projectRepository domain.ProjectRepository, taskRepository domain.TaskRepository, userRepository domain.UserRepository, timeout time.Duration) domain.ProjectUseCase {
+	return &projectUseCase{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		userRepository:    userRepository,
+		contextTimeout:    timeout,
+	}
+}
+
+// CreateProject stores a new project and makes the caller its owner.
+func (pu *projectUseCase) CreateProject(c context.Context, project domain.Project) (domain.Project, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.Project{}, domain.ErrUnauthenticated
+	}
+	if err := project.Validate(); err != nil {
+		return domain.Project{}, err
+	}
+	project.CreatedBy = user.ID
+	project.CreatedAt = time.Now().UTC()
+	created, err := pu.projectRepository.CreateProject(ctx, project)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	err = pu.projectRepository.AddMember(ctx, domain.ProjectMember{
+		ProjectID: created.ID.String(),
+		UserID:    user.ID,
+		Role:      domain.ProjectRoleOwner,
+		AddedAt:   created.CreatedAt,
+	})
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return created, nil
+}
+
+// GetProjects returns the projects the caller is a member of, in the order they were created.
+func (pu *projectUseCase) GetProjects(c context.Context) ([]domain.Project, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthenticated
+	}
+	return pu.projectRepository.FindMemberProjects(ctx, user.ID)
+}
+
+// GetProject returns a project the caller is a member of.
+// Other projects are reported as domain.ErrProjectNotFound.
+func (pu *projectUseCase) GetProject(c context.Context, projectId string) (domain.Project, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	member, err := pu.member(ctx, projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	return pu.projectRepository.FindProjectById(ctx, member.ProjectID)
+}
+
+// UpdateProject replaces the name and description of a project the caller owns and returns it.
+func (pu *projectUseCase) UpdateProject(c context.Context, projectId string, project domain.Project) (domain.Project, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	member, err := pu.owner(ctx, projectId)
+	if err != nil {
+		return domain.Project{}, err
+	}
+	if err := project.Validate(); err != nil {
+		return domain.Project{}, err
+	}
+	project.ID = domain.ID(member.ProjectID)
+	return pu.projectRepository.UpdateProject(ctx, project)
+}
+
+// DeleteProject deletes a project the caller owns, with its members.
+// It returns domain.ErrProjectNotEmpty while the project has tasks, including tasks in the trash.
+func (pu *projectUseCase) DeleteProject(c context.Context, projectId string) error {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	member, err := pu.owner(ctx, projectId)
+	if err != nil {
+		return err
+	}
+	for _, deleted := range []bool{false, true} {
+		page, err := pu.taskRepository.FindTasks(ctx, domain.TaskQuery{ProjectID: member.ProjectID, Deleted: deleted, Limit: 1})
+		if err != nil {
+			return err
+		}
+		if len(page.Tasks) > 0 {
+			return domain.ErrProjectNotEmpty
+		}
+	}
+	return pu.projectRepository.DeleteProject(ctx, member.ProjectID)
+}
+
+// GetMembers returns the members of a project the caller is a member of, ordered by user ID.
+func (pu *projectUseCase) GetMembers(c context.Context, projectId string) ([]domain.ProjectMember, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	member, err := pu.member(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+	return pu.projectRepository.FindMembers(ctx, member.ProjectID)
+}
+
+// AddMember gives a user a role in a project the caller owns and returns the new member.
+// It returns an ErrValidation error if the user does not exist and domain.ErrMemberExists
+// if the user is already a member of the project.
+func (pu *projectUseCase) AddMember(c context.Context, projectId string, userId string, role string) (domain.ProjectMember, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	owner, err := pu.owner(ctx, projectId)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if role, err = domain.ParseProjectRole(role); err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if _, err := pu.userRepository.FindUserById(ctx, userId); errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ProjectMember{}, fmt.Errorf("%w: the user %s does not exist", domain.ErrValidation, userId)
+	} else if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	member := domain.ProjectMember{ProjectID: owner.ProjectID, UserID: userId, Role: role, AddedAt: time.Now().UTC()}
+	if err := pu.projectRepository.AddMember(ctx, member); err != nil {
+		return domain.ProjectMember{}, err
+	}
+	return member, nil
+}
+
+// UpdateMember changes the role of a member of a project the caller owns and returns the member.
+// It returns domain.ErrLastOwner if the last owner of the project would lose the owner role.
+func (pu *projectUseCase) UpdateMember(c context.Context, projectId string, userId string, role string) (domain.ProjectMember, error) {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	owner, err := pu.owner(ctx, projectId)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if role, err = domain.ParseProjectRole(role); err != nil {
+		return domain.ProjectMember{}, err
+	}
+	member, err := pu.projectRepository.FindMember(ctx, owner.ProjectID, userId)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if role != domain.ProjectRoleOwner {
+		if err := keepOwner(ctx, pu.projectRepository, member); err != nil {
+			return domain.ProjectMember{}, err
+		}
+	}
+	member.Role = role
+	if err := pu.projectRepository.UpdateMember(ctx, member); err != nil {
+		return domain.ProjectMember{}, err
+	}
+	return member, nil
+}
+
+// RemoveMember removes a member from a project. Owners remove any member, and every member can leave the project.
+// It returns domain.ErrLastOwner if the last owner of the project would leave it.
+func (pu *projectUseCase) RemoveMember(c context.Context, projectId string, userId string) error {
+	ctx, close := context.WithTimeout(c, pu.contextTimeout)
+	defer close()
+
+	caller, err := pu.member(ctx, projectId)
+	if err != nil {
+		return err
+	}
+	if caller.UserID != userId && !caller.CanManage() {
+		return domain.ErrProjectRole
+	}
+	member, err := pu.projectRepository.FindMember(ctx, caller.ProjectID, userId)
+	if err != nil {
+		return err
+	}
+	if err := keepOwner(ctx, pu.projectRepository, member); err != nil {
+		return err
+	}
+	return pu.projectRepository.RemoveMember(ctx, caller.ProjectID, userId)
+}
+
+// member returns the membership of the authenticated caller in a project.
+func (pu *projectUseCase) member(ctx context.Context, projectId string) (domain.ProjectMember, error) {
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.ProjectMember{}, domain.ErrUnauthenticated
+	}
+	return projectMember(ctx, pu.projectRepository, projectId, user)
+}
+
+// owner returns the membership of the authenticated caller in a project,
+// or domain.ErrProjectRole if the caller is a member but not an owner.
+func (pu *projectUseCase) owner(ctx context.Context, projectId string) (domain.ProjectMember, error) {
+	member, err := pu.member(ctx, projectId)
+	if err != nil {
+		return domain.ProjectMember{}, err
+	}
+	if !member.CanManage() {
+		return domain.ProjectMember{}, domain.ErrProjectRole
+	}
+	return member, nil
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoleUseCaseSuite struct {
+	suite.Suite
+	mockRoleRepo *mocks.RoleRepository
+	mockUserRepo *mocks.UserRepository
+	roleUseCase  domain.RoleUseCase
+}
+
+func (suite *RoleUseCaseSuite) SetupTest() {
+	suite.mockRoleRepo = new(mocks.RoleRepository)
+	suite.mockUserRepo = new(mocks.UserRepository)
+	suite.roleUseCase = NewRoleUsecase(suite.mockRoleRepo, suite.mockUserRepo, time.Second*2)
+}
+
+// TestGetRoles tests that the built-in roles are listed before the roles of the organization,
+// and that a role is found by its normalized name.
+func (suite *RoleUseCaseSuite) TestGetRoles() {
+	triage := domain.Role{Name: "TRIAGE", OrgID: "sales", Permissions: []string{domain.PermissionTaskReadAll}}
+	suite.mockRoleRepo.On("FindRoles", mock.Anything).Return([]domain.Role{triage}, nil)
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "TRIAGE").Return(triage, nil)
+
+	roles, err := suite.roleUseCase.GetRoles(asUser(adminUser))
+	suite.Require().NoError(err)
+	suite.Require().Len(roles, 3)
+	suite.Equal([]string{domain.RoleAdmin, domain.RoleUser, "TRIAGE"}, []string{roles[0].Name, roles[1].Name, roles[2].Name})
+
+	role, err := suite.roleUseCase.GetRole(asUser(adminUser), "triage")
+	suite.Require().NoError(err)
+	suite.Equal(triage, role)
+	role, err = suite.roleUseCase.GetRole(asUser(adminUser), "user")
+	suite.Require().NoError(err)
+	suite.True(role.Builtin)
+	suite.mockRoleRepo.AssertNumberOfCalls(suite.T(), "FindRoleByName", 1)
+}
+
+// TestCreateRole tests that a role is stored with its name and permissions normalized and its creation time set,
+// and that the names of the built-in roles, invalid roles and permissions the caller does not have are rejected.
+func (suite *RoleUseCaseSuite) TestCreateRole() {
+	suite.mockRoleRepo.On("CreateRole", mock.Anything, mock.MatchedBy(func(role domain.Role) bool {
+		return role.Name == "TRIAGE" && len(role.Permissions) == 2 && !role.CreatedAt.IsZero()
+	})).Return(func(ctx context.Context, role domain.Role) (domain.Role, error) { return role, nil })
+
+	role, err := suite.roleUseCase.CreateRole(asUser(adminUser), domain.Role{Name: " triage", Permissions: []string{"task:update", "task:read_all"}})
+	suite.Require().NoError(err)
+	suite.Equal([]string{domain.PermissionTaskReadAll, domain.PermissionTaskUpdate}, role.Permissions)
+
+	_, err = suite.roleUseCase.CreateRole(asUser(adminUser), domain.Role{Name: "admin", Permissions: []string{}})
+	suite.ErrorIs(err, domain.ErrRoleExists)
+	_, err = suite.roleUseCase.CreateRole(asUser(adminUser), domain.Role{Name: "root", Permissions: []string{"*"}})
+	suite.ErrorIs(err, domain.ErrValidation)
+
+	manager := domain.AuthUser{ID: domain.NewID().String(), Role: "MANAGER", Permissions: []string{domain.PermissionRoleManage, domain.PermissionTaskReadAll}}
+	_, err = suite.roleUseCase.CreateRole(asUser(manager), domain.Role{Name: "triage", Permissions: []string{"task:update"}})
+	suite.ErrorIs(err, domain.ErrPermissionNotHeld)
+	suite.ErrorIs(err, domain.ErrForbidden)
+	suite.mockRoleRepo.AssertNumberOfCalls(suite.T(), "CreateRole", 1)
+}
+
+// TestUpdateRole tests that a role is updated by its normalized name and that the built-in roles cannot change.
+func (suite *RoleUseCaseSuite) TestUpdateRole() {
+	expected := domain.Role{Name: "TRIAGE", Description: "sorts the backlog", Permissions: []string{domain.PermissionTaskUpdate}}
+	suite.mockRoleRepo.On("UpdateRole", mock.Anything, expected).Return(expected, nil)
+
+	role, err := suite.roleUseCase.UpdateRole(asUser(adminUser), "Triage", domain.Role{Name: "other", Description: "sorts the backlog", Permissions: []string{"task:update"}})
+	suite.Require().NoError(err)
+	suite.Equal(expected, role)
+
+	_, err = suite.roleUseCase.UpdateRole(asUser(adminUser), "user", domain.Role{Permissions: []string{"task:update"}})
+	suite.ErrorIs(err, domain.ErrBuiltinRole)
+	suite.mockRoleRepo.AssertExpectations(suite.T())
+}
+
+// TestDeleteRole tests that a role given to users and the built-in roles cannot be deleted,
+// and that unknown roles are not found.
+func (suite *RoleUseCaseSuite) TestDeleteRole() {
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "TRIAGE").Return(domain.Role{Name: "TRIAGE"}, nil)
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "AUDITOR").Return(domain.Role{Name: "AUDITOR"}, nil)
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "UNKNOWN").Return(domain.Role{}, domain.ErrRoleNotFound)
+	suite.mockUserRepo.On("CountUsersWithRole", mock.Anything, "TRIAGE").Return(int64(2), nil)
+	suite.mockUserRepo.On("CountUsersWithRole", mock.Anything, "AUDITOR").Return(int64(0), nil)
+	suite.mockRoleRepo.On("DeleteRole", mock.Anything, "AUDITOR").Return(nil)
+
+	suite.ErrorIs(suite.roleUseCase.DeleteRole(asUser(adminUser), "triage"), domain.ErrRoleInUse)
+	suite.ErrorIs(suite.roleUseCase.DeleteRole(asUser(adminUser), "ADMIN"), domain.ErrBuiltinRole)
+	suite.ErrorIs(suite.roleUseCase.DeleteRole(asUser(adminUser), "unknown"), domain.ErrRoleNotFound)
+	suite.NoError(suite.roleUseCase.DeleteRole(asUser(adminUser), "auditor"))
+	suite.mockRoleRepo.AssertNumberOfCalls(suite.T(), "DeleteRole", 1)
+}
+
+func TestRoleUseCaseSuite(t *testing.T) {
+	suite.Run(t, new(RoleUseCaseSuite))
+}
@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// roleUseCase represents the use case for the roles of the organizations.
+// The routes are restricted to the users with domain.PermissionRoleManage, who can only grant
+// the permissions they have themselves.
+type roleUseCase struct {
+	roleRepository domain.RoleRepository
+	userRepository domain.UserRepository
+	contextTimeout time.Duration
+}
+
+var _ domain.RoleUseCase = &roleUseCase{}
+
+// NewRoleUsecase creates a new instance of the RoleUseCase interface.
+// It takes the roleRepository storing the roles, the userRepository the use of the roles is read from
+// and a timeout of type time.Duration as parameters.
+func NewRoleUsecase(roleRepository domain.RoleRepository, userRepository domain.UserRepository, timeout time.Duration) domain.RoleUseCase {
+	return &roleUseCase{
+		roleRepository: roleRepository,
+		userRepository: userRepository,
+		contextTimeout: timeout,
+	}
+}
+
+// GetRoles returns the built-in roles followed by the roles of the organization of the caller, ordered by name.
+func (ru *roleUseCase) GetRoles(c context.Context) ([]domain.Role, error) {
+	ctx, close := context.WithTimeout(c, ru.contextTimeout)
+	defer close()
+
+	roles, err := ru.roleRepository.FindRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(domain.BuiltinRoles(), roles...), nil
+}
+
+// GetRole returns the built-in role or the role of the organization of the caller with the given name.
+func (ru *roleUseCase) GetRole(c context.Context, name string) (domain.Role, error) {
+	ctx, close := context.WithTimeout(c, ru.contextTimeout)
+	defer close()
+
+	name, err := domain.NormalizeRoleName(name)
+	if err != nil {
+		return domain.Role{}, err
+	}
+	if role, ok := domain.BuiltinRole(name); ok {
+		return role, nil
+	}
+	return ru.roleRepository.FindRoleByName(ctx, name)
+}
+
+// CreateRole adds a role to the organization of the caller and returns it, with its name and permissions normalized.
+// It returns domain.ErrRoleExists if the name is the one of a built-in role or of another role of the organization,
+// and domain.ErrPermissionNotHeld if the role grants a permission the caller does not have.
+func (ru *roleUseCase) CreateRole(c context.Context, role domain.Role) (domain.Role, error) {
+	ctx, close := context.WithTimeout(c, ru.contextTimeout)
+	defer close()
+
+	if err := role.Validate(); err != nil {
+		return domain.Role{}, err
+	}
+	if _, ok := domain.BuiltinRole(role.Name); ok {
+		return domain.Role{}, domain.ErrRoleExists
+	}
+	if err := checkGrantable(ctx, role.Permissions); err != nil {
+		return domain.Role{}, err
+	}
+	role.OrgID = ""
+	role.CreatedAt = time.Now().UTC()
+	return ru.roleRepository.CreateRole(ctx, role)
+}
+
+// UpdateRole replaces the description and the permissions of the role with the given name and returns it.
+// The name of a role never changes, so the one of the given role is ignored.
+// It returns domain.ErrBuiltinRole for the built-in roles and domain.ErrPermissionNotHeld if the role
+// grants a permission the caller does not have. The users of the role get its new permissions
+// when their access token is next refreshed.
+func (ru *roleUseCase) UpdateRole(c context.Context, name string, role domain.Role) (domain.Role, error) {
+	ctx, close := context.WithTimeout(c, ru.contextTimeout)
+	defer close()
+
+	role.Name = name
+	if err := role.Validate(); err != nil {
+		return domain.Role{}, err
+	}
+	if _, ok := domain.BuiltinRole(role.Name); ok {
+		return domain.Role{}, domain.ErrBuiltinRole
+	}
+	if err := checkGrantable(ctx, role.Permissions); err != nil {
+		return domain.Role{}, err
+	}
+	return ru.roleRepository.UpdateRole(ctx, role)
+}
+
+// DeleteRole deletes a role of the organization of the caller.
+// It returns domain.ErrBuiltinRole for the built-in roles and domain.ErrRoleInUse while users have the role.
+// A user given the role while it is deleted has no permission until given another one.
+func (ru *roleUseCase) DeleteRole(c context.Context, name string) error {
+	ctx, close := context.WithTimeout(c, ru.contextTimeout)
+	defer close()
+
+	name, err := domain.NormalizeRoleName(name)
+	if err != nil {
+		return err
+	}
+	if _, ok := domain.BuiltinRole(name); ok {
+		return domain.ErrBuiltinRole
+	}
+	if _, err := ru.roleRepository.FindRoleByName(ctx, name); err != nil {
+		return err
+	}
+	users, err := ru.userRepository.CountUsersWithRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if users > 0 {
+		return fmt.Errorf("%w: %d users have the role %s", domain.ErrRoleInUse, users, name)
+	}
+	return ru.roleRepository.DeleteRole(ctx, name)
+}
+
+// checkGrantable checks that the authenticated caller has every one of the permissions it grants.
+// It returns domain.ErrPermissionNotHeld naming the first one it does not have.
+func checkGrantable(ctx context.Context, permissions []string) error {
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthenticated
+	}
+	for _, permission := range permissions {
+		if !user.Can(permission) {
+			return fmt.Errorf("%w: %s", domain.ErrPermissionNotHeld, permission)
+		}
+	}
+	return nil
+}
+
+// rolePermissions returns the permissions of the role with the given name in an organization.
+// A role that no longer exists grants no permission.
+func rolePermissions(ctx context.Context, roles domain.RoleRepository, orgId string, name string) ([]string, error) {
+	if role, ok := domain.BuiltinRole(name); ok {
+		return role.Permissions, nil
+	}
+	role, err := roles.FindRoleByName(domain.ContextWithOrganization(ctx, orgId), name)
+	if errors.Is(err, domain.ErrRoleNotFound) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role.Permissions, nil
+}
@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"context"
+	domain "example/go-clean-architecture/Domain"
+	"example/go-clean-architecture/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// TaskDependencySuite tests the dependencies between tasks against mocked repositories
+// that keep the tasks and the dependencies of the test in memory.
+type TaskDependencySuite struct {
+	suite.Suite
+	taskUseCase  *taskUseCase
+	tasks        map[string]domain.Task
+	dependencies []domain.TaskDependency
+	history      []domain.TaskHistoryEntry
+}
+
+func (suite *TaskDependencySuite) SetupTest() {
+	suite.tasks = map[string]domain.Task{}
+	suite.dependencies = nil
+	suite.history = nil
+
+	taskRepo := new(mocks.TaskRepository)
+	taskRepo.On("FindTaskById", mock.Anything, mock.Anything).Return(func(ctx context.Context, id string) (domain.Task, error) {
+		task, ok := suite.tasks[id]
+		if !ok {
+			return domain.Task{}, domain.ErrTaskNotFound
+		}
+		return task, nil
+	})
+	taskRepo.On("UpdateTaskById", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, task domain.Task, id string) (domain.Task, error) {
+		if suite.tasks[id].Version != task.Version {
+			return domain.Task{}, domain.ErrVersionMismatch
+		}
+		task.Version++
+		suite.tasks[id] = task
+		return task, nil
+	})
+	taskRepo.On("DeleteTask", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, id string, version int64, deletedBy string, at time.Time) (domain.Task, error) {
+			task := suite.tasks[id]
+			delete(suite.tasks, id)
+			task.Version++
+			return task, nil
+		},
+	)
+	taskRepo.On("CountSubtasks", mock.Anything, mock.Anything).Return(map[string]map[string]int{}, nil)
+	historyRepo := new(mocks.TaskHistoryRepository)
+	historyRepo.On("AppendTaskHistory", mock.Anything, mock.Anything).Return(func(ctx context.Context, entry domain.TaskHistoryEntry) (domain.TaskHistoryEntry, error) {
+		suite.history = append(suite.history, entry)
+		return entry, nil
+	})
+	commentRepo := new(mocks.CommentRepository)
+	commentRepo.On("CountComments", mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+	checklistRepo := new(mocks.ChecklistRepository)
+	checklistRepo.On("CountChecklists", mock.Anything, mock.Anything).Return(func(ctx context.Context, taskIds []string) (map[string]domain.TaskSteps, error) {
+		return map[string]domain.TaskSteps{}, nil
+	})
+
+	dependencyRepo := new(mocks.DependencyRepository)
+	dependencyRepo.On("AddDependency", mock.Anything, mock.Anything).Return(func(ctx context.Context, dependency domain.TaskDependency) error {
+		for _, existing := range suite.dependencies {
+			if existing.TaskID == dependency.TaskID && existing.DependsOnID == dependency.DependsOnID {
+				return domain.ErrDependencyExists
+			}
+		}
+		suite.dependencies = append(suite.dependencies, dependency)
+		return nil
+	})
+	dependencyRepo.On("RemoveDependency", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, taskId string, dependsOnId string) error {
+		for i, existing := range suite.dependencies {
+			if existing.TaskID == taskId && existing.DependsOnID == dependsOnId {
+				suite.dependencies = append(suite.dependencies[:i], suite.dependencies[i+1:]...)
+				return nil
+			}
+		}
+		return domain.ErrDependencyNotFound
+	})
+	dependencyRepo.On("FindDependencies", mock.Anything, mock.Anything).Return(func(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+		return suite.findDependencies(taskIds, func(edge domain.TaskDependency) string { return edge.TaskID }), nil
+	})
+	dependencyRepo.On("FindDependents", mock.Anything, mock.Anything).Return(func(ctx context.Context, taskIds []string) ([]domain.TaskDependency, error) {
+		return suite.findDependencies(taskIds, func(edge domain.TaskDependency) string { return edge.DependsOnID }), nil
+	})
+
+	suite.taskUseCase = NewTaskUsecase(taskRepo, historyRepo, commentRepo, checklistRepo, dependencyRepo, new(mocks.LabelRepository), new(mocks.ProjectRepository), domain.DefaultTaskWorkflow(), time.Second*2)
+}
+
+// findDependencies returns the dependencies whose end picked by end is one of the given tasks.
+func (suite *TaskDependencySuite) findDependencies(taskIds []string, end func(domain.TaskDependency) string) []domain.TaskDependency {
+	found := []domain.TaskDependency{}
+	for _, edge := range suite.dependencies {
+		for _, id := range taskIds {
+			if end(edge) == id {
+				found = append(found, edge)
+			}
+		}
+	}
+	return found
+}
+
+// addTask stores a task created by the given user in the given status and returns its ID.
+func (suite *TaskDependencySuite) addTask(title string, status string, createdBy domain.AuthUser) string {
+	task := domain.Task{ID: domain.NewID(), Title: title, Status: status, CreatedBy: createdBy.ID, Version: 1}
+	suite.tasks[task.ID.String()] = task
+	return task.ID.String()
+}
+
+// status returns the current status of a task.
+func (suite *TaskDependencySuite) status(id string) string {
+	return suite.tasks[id].Status
+}
+
+// TestBlockedStatus tests that a task is blocked while it depends on an unresolved task,
+// and is unblocked when that task is resolved or deleted, or the dependency is removed.
+func (suite *TaskDependencySuite) TestBlockedStatus() {
+	ctx := asUser(adminUser)
+	a := suite.addTask("design", domain.TaskStatusReview, adminUser)
+	b := suite.addTask("build", domain.TaskStatusInProgress, adminUser)
+
+	task, err := suite.taskUseCase.AddDependency(ctx, b, a)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusBlocked, task.Status)
+	suite.Require().Len(suite.history, 1)
+	suite.Equal(domain.TaskActionTransitioned, suite.history[0].Action)
+
+	_, err = suite.taskUseCase.TransitionTask(ctx, b, domain.TaskStatusInProgress)
+	suite.ErrorIs(err, domain.ErrTaskBlocked)
+	_, err = suite.taskUseCase.AddDependency(ctx, b, a)
+	suite.ErrorIs(err, domain.ErrDependencyExists)
+
+	_, err = suite.taskUseCase.TransitionTask(ctx, a, domain.TaskStatusDone)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusTodo, suite.status(b), "resolving the dependency unblocks the task")
+
+	_, err = suite.taskUseCase.TransitionTask(ctx, a, domain.TaskStatusInProgress)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusBlocked, suite.status(b), "reopening the dependency blocks the task again")
+
+	task, err = suite.taskUseCase.RemoveDependency(ctx, b, a)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusTodo, task.Status)
+	_, err = suite.taskUseCase.RemoveDependency(ctx, b, a)
+	suite.ErrorIs(err, domain.ErrDependencyNotFound)
+
+	done := suite.addTask("research", domain.TaskStatusDone, adminUser)
+	task, err = suite.taskUseCase.AddDependency(ctx, b, done)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusTodo, task.Status, "a resolved dependency does not block")
+
+	_, err = suite.taskUseCase.AddDependency(ctx, b, a)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusBlocked, suite.status(b))
+	suite.Require().NoError(suite.taskUseCase.DeleteTaskById(ctx, a, 0))
+	suite.Equal(domain.TaskStatusTodo, suite.status(b), "deleting the dependency unblocks the task")
+}
+
+// TestCycles tests that a dependency closing a cycle is rejected.
+func (suite *TaskDependencySuite) TestCycles() {
+	ctx := asUser(adminUser)
+	a := suite.addTask("a", domain.TaskStatusDone, adminUser)
+	b := suite.addTask("b", domain.TaskStatusDone, adminUser)
+	c := suite.addTask("c", domain.TaskStatusDone, adminUser)
+	_, err := suite.taskUseCase.AddDependency(ctx, b, a)
+	suite.Require().NoError(err)
+	_, err = suite.taskUseCase.AddDependency(ctx, c, b)
+	suite.Require().NoError(err)
+
+	_, err = suite.taskUseCase.AddDependency(ctx, a, c)
+	suite.ErrorIs(err, domain.ErrDependencyCycle)
+	_, err = suite.taskUseCase.AddDependency(ctx, a, a)
+	suite.ErrorIs(err, domain.ErrDependencyCycle)
+	_, err = suite.taskUseCase.AddDependency(ctx, c, a)
+	suite.NoError(err, "a shortcut is not a cycle")
+	_, err = suite.taskUseCase.AddDependency(ctx, a, domain.NewID().String())
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.Len(suite.dependencies, 3)
+}
+
+// TestGetTaskGraph tests that the graph lists the tasks upstream and downstream of a task closest first,
+// hiding the tasks the caller may not see and leaving out the tasks in the trash.
+func (suite *TaskDependencySuite) TestGetTaskGraph() {
+	ctx := asUser(adminUser)
+	a := suite.addTask("a", domain.TaskStatusDone, regularUser)
+	b := suite.addTask("b", domain.TaskStatusDone, adminUser)
+	c := suite.addTask("c", domain.TaskStatusDone, regularUser)
+	d := suite.addTask("d", domain.TaskStatusDone, regularUser)
+	trashed := suite.addTask("trashed", domain.TaskStatusDone, regularUser)
+	for _, edge := range [][2]string{{b, a}, {c, b}, {d, c}, {c, trashed}} {
+		_, err := suite.taskUseCase.AddDependency(ctx, edge[0], edge[1])
+		suite.Require().NoError(err)
+	}
+	delete(suite.tasks, trashed)
+
+	graph, err := suite.taskUseCase.GetTaskGraph(asUser(regularUser), c)
+	suite.Require().NoError(err)
+	suite.Equal(c, graph.TaskID)
+	suite.Equal([]domain.TaskGraphNode{{ID: b, Depth: 1}, {ID: a, Title: "a", Status: domain.TaskStatusDone, Depth: 2}}, graph.Upstream)
+	suite.Equal([]domain.TaskGraphNode{{ID: d, Title: "d", Status: domain.TaskStatusDone, Depth: 1}}, graph.Downstream)
+	suite.Len(graph.Edges, 3)
+	suite.False(graph.Truncated)
+
+	_, err = suite.taskUseCase.GetTaskGraph(asUser(regularUser), b)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+}
+
+// TestDependencies_ProjectViewer tests that the viewers of a project cannot add or remove the dependencies of its tasks.
+func (suite *TaskDependencySuite) TestDependencies_ProjectViewer() {
+	pid := domain.NewID().String()
+	viewer := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	projects := new(mocks.ProjectRepository)
+	projects.On("FindMember", mock.Anything, pid, viewer.ID).Return(domain.ProjectMember{ProjectID: pid, UserID: viewer.ID, Role: domain.ProjectRoleViewer}, nil)
+	suite.taskUseCase.projectRepository = projects
+	a := suite.addTask("a", domain.TaskStatusTodo, adminUser)
+	b := suite.addTask("b", domain.TaskStatusTodo, viewer)
+	task := suite.tasks[a]
+	task.ProjectID = pid
+	suite.tasks[a] = task
+
+	_, err := suite.taskUseCase.AddDependency(asUser(viewer), a, b)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	_, err = suite.taskUseCase.AddDependency(asUser(adminUser), a, b)
+	suite.Require().NoError(err)
+	_, err = suite.taskUseCase.RemoveDependency(asUser(viewer), a, b)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	suite.Len(suite.dependencies, 1)
+}
+
+func TestTaskDependencySuite(t *testing.T) {
+	suite.Run(t, new(TaskDependencySuite))
+}
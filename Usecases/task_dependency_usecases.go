@@ -0,0 +1,275 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+)
+
+// AddDependency makes a task depend on another one and returns the task.
+// The caller must be able to change the task and see the other one. A task with an unresolved dependency is moved to BLOCKED,
+// if the workflow allows it. It returns an ErrDependencyCycle error if the other task already depends
+// on the task, directly or not.
+func (tu *taskUseCase) AddDependency(c context.Context, taskId string, dependsOnId string) (domain.Task, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	task, user, err := editableTask(ctx, tu.taskRepository, tu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	blocker, err := tu.taskRepository.FindTaskById(ctx, dependsOnId)
+	readable := false
+	if err == nil {
+		readable, err = canReadTask(ctx, tu.projectRepository, blocker, user)
+	}
+	if errors.Is(err, domain.ErrTaskNotFound) || (err == nil && !readable) {
+		return domain.Task{}, fmt.Errorf("%w: the task %s does not exist", domain.ErrValidation, dependsOnId)
+	} else if err != nil {
+		return domain.Task{}, err
+	}
+	if blocker.ID == task.ID {
+		return domain.Task{}, fmt.Errorf("%w: a task cannot depend on itself", domain.ErrDependencyCycle)
+	}
+	cycle, err := tu.dependsOn(ctx, blocker.ID.String(), task.ID.String())
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if cycle {
+		return domain.Task{}, fmt.Errorf("%w: %s already depends on %s", domain.ErrDependencyCycle, blocker.ID, task.ID)
+	}
+	err = tu.dependencyRepository.AddDependency(ctx, domain.TaskDependency{
+		TaskID:      task.ID.String(),
+		DependsOnID: blocker.ID.String(),
+		CreatedBy:   user.ID,
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if task, err = tu.updateBlocked(ctx, task, false); err != nil {
+		return domain.Task{}, err
+	}
+	return task, tu.computeFields(ctx, &task)
+}
+
+// RemoveDependency removes the dependency of a task on another one and returns the task.
+// The caller must be able to change the task.
+// A BLOCKED task whose last unresolved dependency is removed goes back to the initial status of the workflow.
+func (tu *taskUseCase) RemoveDependency(c context.Context, taskId string, dependsOnId string) (domain.Task, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	task, _, err := editableTask(ctx, tu.taskRepository, tu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.dependencyRepository.RemoveDependency(ctx, task.ID.String(), dependsOnId); err != nil {
+		return domain.Task{}, err
+	}
+	blocking := false
+	if blocker, err := tu.taskRepository.FindTaskById(ctx, dependsOnId); err == nil {
+		blocking = !domain.IsTaskResolved(blocker.Status)
+	} else if !errors.Is(err, domain.ErrTaskNotFound) {
+		return domain.Task{}, err
+	}
+	if task, err = tu.updateBlocked(ctx, task, blocking); err != nil {
+		return domain.Task{}, err
+	}
+	return task, tu.computeFields(ctx, &task)
+}
+
+// GetTaskGraph returns the tasks a task depends on and the tasks depending on it, directly or not,
+// with the dependencies between them. Tasks in the trash are left out of the graph.
+func (tu *taskUseCase) GetTaskGraph(c context.Context, taskId string) (domain.TaskGraph, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	task, user, err := readableTask(ctx, tu.taskRepository, tu.projectRepository, taskId)
+	if err != nil {
+		return domain.TaskGraph{}, err
+	}
+	id := task.ID.String()
+	upstream, upstreamEdges, upstreamTruncated, err := tu.walkGraph(ctx, user, id,
+		tu.dependencyRepository.FindDependencies, func(edge domain.TaskDependency) string { return edge.DependsOnID })
+	if err != nil {
+		return domain.TaskGraph{}, err
+	}
+	downstream, downstreamEdges, downstreamTruncated, err := tu.walkGraph(ctx, user, id,
+		tu.dependencyRepository.FindDependents, func(edge domain.TaskDependency) string { return edge.TaskID })
+	if err != nil {
+		return domain.TaskGraph{}, err
+	}
+	return domain.TaskGraph{
+		TaskID:     id,
+		Upstream:   upstream,
+		Downstream: downstream,
+		Edges:      append(upstreamEdges, downstreamEdges...),
+		Truncated:  upstreamTruncated || downstreamTruncated,
+	}, nil
+}
+
+// walkGraph goes through the dependency graph from a task breadth first, following the dependencies
+// returned by find to the task picked by next, and returns the tasks it reached with the dependencies it followed.
+// It stops adding tasks after domain.MaxTaskGraphSize and then reports the graph as truncated.
+func (tu *taskUseCase) walkGraph(ctx context.Context, user domain.AuthUser, from string,
+	find func(context.Context, []string) ([]domain.TaskDependency, error),
+	next func(domain.TaskDependency) string,
+) ([]domain.TaskGraphNode, []domain.TaskDependency, bool, error) {
+	nodes, edges, truncated := []domain.TaskGraphNode{}, []domain.TaskDependency{}, false
+	seen, inGraph := map[string]bool{from: true}, map[string]bool{from: true}
+	frontier := []string{from}
+	for depth := 1; len(frontier) > 0; depth++ {
+		found, err := find(ctx, frontier)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		frontier = nil
+		for _, edge := range found {
+			id := next(edge)
+			if !seen[id] {
+				seen[id] = true
+				task, err := tu.taskRepository.FindTaskById(ctx, id)
+				if errors.Is(err, domain.ErrTaskNotFound) {
+					continue
+				}
+				if err != nil {
+					return nil, nil, false, err
+				}
+				if len(nodes) == domain.MaxTaskGraphSize {
+					truncated = true
+					continue
+				}
+				node := domain.TaskGraphNode{ID: id, Depth: depth}
+				readable, err := canReadTask(ctx, tu.projectRepository, task, user)
+				if err != nil {
+					return nil, nil, false, err
+				}
+				if readable {
+					node.Title, node.Status = task.Title, task.Status
+				}
+				nodes = append(nodes, node)
+				inGraph[id] = true
+				frontier = append(frontier, id)
+			}
+			if inGraph[id] {
+				edges = append(edges, edge)
+			}
+		}
+	}
+	return nodes, edges, truncated, nil
+}
+
+// dependsOn reports whether the task from depends on the task to, directly or not.
+// Tasks in the trash keep their dependencies, so they are followed too.
+func (tu *taskUseCase) dependsOn(ctx context.Context, from string, to string) (bool, error) {
+	seen := map[string]bool{from: true}
+	frontier := []string{from}
+	for len(frontier) > 0 {
+		edges, err := tu.dependencyRepository.FindDependencies(ctx, frontier)
+		if err != nil {
+			return false, err
+		}
+		frontier = nil
+		for _, edge := range edges {
+			if edge.DependsOnID == to {
+				return true, nil
+			}
+			if !seen[edge.DependsOnID] {
+				seen[edge.DependsOnID] = true
+				frontier = append(frontier, edge.DependsOnID)
+			}
+		}
+	}
+	return false, nil
+}
+
+// openDependencies counts the dependencies of a task on tasks that are neither resolved nor in the trash.
+func (tu *taskUseCase) openDependencies(ctx context.Context, taskId string) (int, error) {
+	edges, err := tu.dependencyRepository.FindDependencies(ctx, []string{taskId})
+	if err != nil {
+		return 0, err
+	}
+	open := 0
+	for _, edge := range edges {
+		blocker, err := tu.taskRepository.FindTaskById(ctx, edge.DependsOnID)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if !domain.IsTaskResolved(blocker.Status) {
+			open++
+		}
+	}
+	return open, nil
+}
+
+// updateBlocked derives the status of a task from its dependencies and returns the task.
+// An unresolved task with open dependencies is moved to BLOCKED and, when unblock is set, a BLOCKED task
+// without open dependencies is moved back to the initial status of the workflow.
+// Moves the workflow does not allow are left out, and the moves made are recorded in the history of the task.
+func (tu *taskUseCase) updateBlocked(ctx context.Context, task domain.Task, unblock bool) (domain.Task, error) {
+	open, err := tu.openDependencies(ctx, task.ID.String())
+	if err != nil {
+		return domain.Task{}, err
+	}
+	var status string
+	switch {
+	case open > 0 && task.Status != domain.TaskStatusBlocked && !domain.IsTaskResolved(task.Status):
+		status = domain.TaskStatusBlocked
+	case open == 0 && unblock && task.Status == domain.TaskStatusBlocked:
+		status = tu.workflow.Initial
+	default:
+		return task, nil
+	}
+	if _, err := tu.workflow.ParseStatus(status); err != nil {
+		return task, nil
+	}
+	if err := tu.workflow.CheckTransition(task.Status, status); err != nil {
+		return task, nil
+	}
+	current := task
+	task.Status = status
+	updated, err := tu.taskRepository.UpdateTaskById(ctx, task, task.ID.String())
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return updated, tu.recordHistory(ctx, domain.TaskActionTransitioned, &current, &updated)
+}
+
+// updateDependents derives the status of the tasks depending on a task whose status changed,
+// unblocking them if the task has been resolved.
+func (tu *taskUseCase) updateDependents(ctx context.Context, task domain.Task, resolved bool) error {
+	edges, err := tu.dependencyRepository.FindDependents(ctx, []string{task.ID.String()})
+	if err != nil {
+		return err
+	}
+	for _, edge := range edges {
+		dependent, err := tu.taskRepository.FindTaskById(ctx, edge.TaskID)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			continue
+		}
+		if err == nil {
+			_, err = tu.updateBlocked(ctx, dependent, resolved)
+		}
+		if err != nil {
+			return fmt.Errorf("updating the tasks depending on %s: %w", task.ID, err)
+		}
+	}
+	return nil
+}
+
+// statusChanged updates the tasks depending on a task whose status went from the one of before
+// to the one of after, if it became resolved or unresolved.
+func (tu *taskUseCase) statusChanged(ctx context.Context, before domain.Task, after domain.Task) error {
+	resolved := domain.IsTaskResolved(after.Status)
+	if domain.IsTaskResolved(before.Status) == resolved {
+		return nil
+	}
+	return tu.updateDependents(ctx, after, resolved)
+}
@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	domain "example/go-clean-architecture/Domain"
 	"example/go-clean-architecture/mocks"
 	"testing"
@@ -10,63 +11,114 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type TaskUseCaseSuite struct {
 	suite.Suite
-	mockTaskRepo *mocks.TaskRepository
-	taskUseCase  *taskUseCase
+	mockTaskRepo    *mocks.TaskRepository
+	mockHistoryRepo *mocks.TaskHistoryRepository
+	mockCommentRepo *mocks.CommentRepository
+	mockChecklists  *mocks.ChecklistRepository
+	mockDeps        *mocks.DependencyRepository
+	mockLabels      *mocks.LabelRepository
+	mockProjects    *mocks.ProjectRepository
+	taskUseCase     *taskUseCase
+	// history holds the entries appended to the history repository by the test
+	history []domain.TaskHistoryEntry
 }
 
-var taskID = primitive.NewObjectID()
+var taskID = domain.NewID()
 var taskTitle = "Task Title"
 var taskDescription = "Task Description"
-var taskStatus = "Started"
+var taskStatus = domain.TaskStatusInProgress
 var taskDueDate = time.Now()
 
+var adminUser = domain.AuthUser{ID: domain.NewID().String(), Username: "admin", Role: domain.RoleAdmin, Permissions: domain.BuiltinRoles()[0].Permissions}
+var regularUser = domain.AuthUser{ID: domain.NewID().String(), Username: "user", Role: domain.RoleUser}
+
+// asUser returns a context carrying the given authenticated caller.
+func asUser(user domain.AuthUser) context.Context {
+	return domain.ContextWithAuthUser(context.Background(), user)
+}
+
 func (suite *TaskUseCaseSuite) SetupTest() {
 	suite.mockTaskRepo = new(mocks.TaskRepository)
-	suite.taskUseCase = NewTaskUsecase(suite.mockTaskRepo, time.Second*2)
+	suite.mockHistoryRepo = new(mocks.TaskHistoryRepository)
+	suite.history = nil
+	suite.mockHistoryRepo.On("AppendTaskHistory", mock.Anything, mock.Anything).Maybe().Return(
+		func(ctx context.Context, entry domain.TaskHistoryEntry) (domain.TaskHistoryEntry, error) {
+			entry.ID = domain.NewID()
+			suite.history = append(suite.history, entry)
+			return entry, nil
+		},
+	)
+	suite.mockCommentRepo = new(mocks.CommentRepository)
+	suite.mockCommentRepo.On("CountComments", mock.Anything, mock.Anything).Maybe().Return(map[string]int{}, nil)
+	suite.mockTaskRepo.On("CountSubtasks", mock.Anything, mock.Anything).Maybe().Return(map[string]map[string]int{}, nil)
+	suite.mockChecklists = new(mocks.ChecklistRepository)
+	suite.mockChecklists.On("CountChecklists", mock.Anything, mock.Anything).Maybe().Return(
+		func(ctx context.Context, taskIds []string) map[string]domain.TaskSteps { return map[string]domain.TaskSteps{} }, nil,
+	)
+	suite.mockDeps = new(mocks.DependencyRepository)
+	suite.mockDeps.On("FindDependencies", mock.Anything, mock.Anything).Maybe().Return([]domain.TaskDependency{}, nil)
+	suite.mockDeps.On("FindDependents", mock.Anything, mock.Anything).Maybe().Return([]domain.TaskDependency{}, nil)
+	suite.mockLabels = new(mocks.LabelRepository)
+	suite.mockProjects = new(mocks.ProjectRepository)
+	suite.taskUseCase = NewTaskUsecase(suite.mockTaskRepo, suite.mockHistoryRepo, suite.mockCommentRepo, suite.mockChecklists, suite.mockDeps, suite.mockLabels, suite.mockProjects, domain.DefaultTaskWorkflow(), time.Second*2)
 }
 
-// TestGetAllTasks is a unit test function that tests the GetAllTasks method of the TaskUseCase struct.
-// It creates mock tasks and asserts that the returned tasks match the expected tasks.
-// The mockTaskRepo is expected to return the mock tasks without any error.
-// This test ensures that GetAllTasks method retrieves all tasks successfully.
-func (suite *TaskUseCaseSuite) TestGetAllTasks() {
-	mockTasks := []domain.Task{
-		{
-			ID:          taskID,
-			Title:       taskTitle,
-			Description: taskDescription,
-			Status:      taskStatus,
-			DueDate:    taskDueDate,
-		},
-		{
-			ID:           primitive.NewObjectID(),
-			Title:       "second task",
-			Description: "description for second task",
-			Status:      "ongoing",
-			DueDate:    taskDueDate,
-		},
+// TestGetTasks tests that GetTasks applies the default page size before querying the repository.
+func (suite *TaskUseCaseSuite) TestGetTasks() {
+	page := domain.TaskPage{
+		Tasks: []domain.Task{{ID: taskID, Title: taskTitle, Status: taskStatus, DueDate: taskDueDate}},
 	}
+	expected := domain.TaskQuery{Status: taskStatus, Sort: "-due_date", Limit: domain.DefaultTaskPageSize, LabelMatch: domain.LabelMatchAny}
 
-	suite.mockTaskRepo.On("FindAlltasks", mock.Anything).Return(mockTasks, nil)
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, expected).Return(page, nil)
+
+	result, err := suite.taskUseCase.GetTasks(asUser(adminUser), domain.TaskQuery{Status: taskStatus, Sort: "-due_date"})
 
-	tasks, err := suite.taskUseCase.GetAllTasks(context.Background())
 	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), page, result)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
 
-	for idx, mockTask:= range mockTasks{
-		assert.Equal(suite.T(), mockTask, tasks[idx])
+// TestGetTasks_RegularUser tests that callers without the ADMIN role only query the tasks visible to them,
+// even when they try to set the visibility filter themselves.
+func (suite *TaskUseCaseSuite) TestGetTasks_RegularUser() {
+	expected := domain.TaskQuery{VisibleTo: regularUser.ID, Limit: domain.DefaultTaskPageSize, LabelMatch: domain.LabelMatchAny}
 
-	}
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, expected).Return(domain.TaskPage{}, nil)
+
+	_, err := suite.taskUseCase.GetTasks(asUser(regularUser), domain.TaskQuery{VisibleTo: adminUser.ID})
+
+	assert.NoError(suite.T(), err)
 	suite.mockTaskRepo.AssertExpectations(suite.T())
 }
 
+// TestGetTasks_InvalidQuery tests that unknown sort keys, oversized limits and foreign cursors
+// are rejected without reaching the repository.
+func (suite *TaskUseCaseSuite) TestGetTasks_InvalidQuery() {
+	cursor := domain.NewTaskCursor("title", domain.Task{ID: taskID, Title: taskTitle}).Encode()
+	queries := []domain.TaskQuery{
+		{Sort: "priority"},
+		{Limit: domain.MaxTaskPageSize + 1},
+		{Cursor: "not-a-cursor"},
+		{Cursor: cursor, Sort: "-title"},
+		{Labels: []string{"a,b"}},
+		{Labels: []string{"bug"}, LabelMatch: "some"},
+	}
+
+	for _, query := range queries {
+		_, err := suite.taskUseCase.GetTasks(asUser(adminUser), query)
+		assert.ErrorIs(suite.T(), err, domain.ErrInvalidTaskQuery)
+	}
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "FindTasks", mock.Anything, mock.Anything)
+}
+
 // TestAddNewTask is a unit test function that tests the AddNewTask method of the TaskUseCase struct.
 // It creates a new task with the given taskID, taskTitle, taskDescription, taskStatus, and taskDueDate.
-// The mockTaskRepo's CreateTask method is expected to be called with the newTask parameter and return the createdTask and nil error.
+// The mockTaskRepo's CreateTask method is expected to be called with the newTask stamped with its creator and return the createdTask and nil error.
 // The AddNewTask method is then called with the newTask parameter, and the returned task and error are asserted.
 // Finally, the expectations of the mockTaskRepo are asserted.
 func (suite *TaskUseCaseSuite) TestAddNewTask() {
@@ -76,6 +128,7 @@ func (suite *TaskUseCaseSuite) TestAddNewTask() {
 			Description: taskDescription,
 			Status:      taskStatus,
 			DueDate:    taskDueDate,
+			AssigneeIDs: []string{regularUser.ID},
 	}
 	createdTask := domain.Task{
 		ID:          taskID,
@@ -83,11 +136,14 @@ func (suite *TaskUseCaseSuite) TestAddNewTask() {
 		Description: taskDescription,
 		Status:      taskStatus,
 		DueDate:     taskDueDate,
+		CreatedBy:   adminUser.ID,
+		AssigneeIDs: []string{regularUser.ID},
+		Labels:      []string{},
 	}
 
-	suite.mockTaskRepo.On("CreateTask", mock.Anything, newTask).Return(createdTask, nil)
+	suite.mockTaskRepo.On("CreateTask", mock.Anything, createdTask).Return(createdTask, nil)
 
-	task, err := suite.taskUseCase.AddNewTask(context.Background(), newTask)
+	task, err := suite.taskUseCase.AddNewTask(asUser(adminUser), newTask)
 
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), createdTask, task)
@@ -107,9 +163,9 @@ func (suite *TaskUseCaseSuite) TestGetTaskByID() {
         DueDate:     time.Now(),
     }
 
-    suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.Hex()).Return(task, nil)
+    suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(task, nil)
 
-	result, err := suite.taskUseCase.GetTaskByID(context.Background(), taskID.Hex())
+	result, err := suite.taskUseCase.GetTaskByID(asUser(adminUser), taskID.String())
 
     assert.NoError(suite.T(), err)
     assert.Equal(suite.T(), task, result)
@@ -117,6 +173,30 @@ func (suite *TaskUseCaseSuite) TestGetTaskByID() {
     suite.mockTaskRepo.AssertExpectations(suite.T())
 }
 
+// TestGetTaskByID_Visibility tests that a regular user can read the tasks they created or are assigned to
+// and gets domain.ErrTaskNotFound for any other task.
+func (suite *TaskUseCaseSuite) TestGetTaskByID_Visibility() {
+	created := domain.Task{ID: domain.NewID(), CreatedBy: regularUser.ID}
+	assigned := domain.Task{ID: domain.NewID(), CreatedBy: adminUser.ID, AssigneeIDs: []string{regularUser.ID}}
+	hidden := domain.Task{ID: domain.NewID(), CreatedBy: adminUser.ID}
+
+	for _, task := range []domain.Task{created, assigned, hidden} {
+		suite.mockTaskRepo.On("FindTaskById", mock.Anything, task.ID.String()).Return(task, nil)
+	}
+
+	_, err := suite.taskUseCase.GetTaskByID(asUser(regularUser), created.ID.String())
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.taskUseCase.GetTaskByID(asUser(regularUser), assigned.ID.String())
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.taskUseCase.GetTaskByID(asUser(regularUser), hidden.ID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrTaskNotFound)
+
+	_, err = suite.taskUseCase.GetTaskByID(context.Background(), hidden.ID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrUnauthenticated)
+}
+
 // TestModifyTaskById is a unit test function that tests the ModifyTaskById method of the TaskUseCase struct.
 // It verifies that the method correctly modifies a task by its ID and returns the updated task.
 // The test creates an updated task with a new title, description, status, and due date.
@@ -128,33 +208,540 @@ func (suite *TaskUseCaseSuite) TestModifyTaskById() {
         ID:          taskID,
         Title:       "Updated Task",
         Description: "Updated Description",
-        Status:      "completed",
+        Status:      domain.TaskStatusReview,
         DueDate:     time.Now(),
+        AssigneeIDs: []string{},
+        Labels:      []string{},
     }
 
-    suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, updatedTask, taskID.Hex()).Return(updatedTask, nil)
+    suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(domain.Task{ID: taskID, Status: domain.TaskStatusInProgress}, nil)
+    suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, updatedTask, taskID.String()).Return(updatedTask, nil)
 
-    result, err := suite.taskUseCase.ModifyTaskById(context.Background(), updatedTask, taskID.Hex())
+    result, err := suite.taskUseCase.ModifyTaskById(context.Background(), updatedTask, taskID.String())
 
     assert.NoError(suite.T(), err)
     assert.Equal(suite.T(), updatedTask, result)
     suite.mockTaskRepo.AssertExpectations(suite.T())
 }
 
+// TestModifyTaskById_Workflow tests that an update keeps the current status when none is given,
+// normalizes the spelling of a new status and rejects unknown statuses and illegal transitions.
+func (suite *TaskUseCaseSuite) TestModifyTaskById_Workflow() {
+	current := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusTodo}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(current, nil)
+
+	kept := domain.Task{Title: "Renamed", Status: domain.TaskStatusTodo, AssigneeIDs: []string{}, Labels: []string{}}
+	suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, kept, taskID.String()).Return(kept, nil).Once()
+	_, err := suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Title: "Renamed"}, taskID.String())
+	assert.NoError(suite.T(), err)
+
+	started := domain.Task{Title: "Renamed", Status: domain.TaskStatusInProgress, AssigneeIDs: []string{}, Labels: []string{}}
+	suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, started, taskID.String()).Return(started, nil).Once()
+	_, err = suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Title: "Renamed", Status: "in progress"}, taskID.String())
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Status: "Started"}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrUnknownTaskStatus)
+
+	_, err = suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Status: "done"}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrIllegalTransition)
+	assert.ErrorIs(suite.T(), err, domain.ErrConflict)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
+// TestPatchTaskById tests that a patch is validated, that a new status follows the workflow
+// and that only the patched fields reach the repository, applied to the current version of the task.
+func (suite *TaskUseCaseSuite) TestPatchTaskById() {
+	title, blank, status := "Renamed", " ", "Review"
+	current := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusInProgress, Version: 3}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(current, nil)
+
+	renamed := domain.TaskPatch{Title: &title, Version: 3}
+	suite.mockTaskRepo.On("PatchTaskById", mock.Anything, renamed, taskID.String()).Return(renamed.Apply(current), nil).Once()
+	result, err := suite.taskUseCase.PatchTaskById(context.Background(), domain.TaskPatch{Title: &title}, taskID.String())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Renamed", result.Title)
+
+	review := domain.TaskStatusReview
+	suite.mockTaskRepo.On("PatchTaskById", mock.Anything, domain.TaskPatch{Status: &review, Version: 3}, taskID.String()).Return(current, nil).Once()
+	_, err = suite.taskUseCase.PatchTaskById(context.Background(), domain.TaskPatch{Status: &status}, taskID.String())
+	assert.NoError(suite.T(), err)
+
+	done := domain.TaskStatusDone
+	_, err = suite.taskUseCase.PatchTaskById(context.Background(), domain.TaskPatch{Status: &done}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrIllegalTransition)
+
+	_, err = suite.taskUseCase.PatchTaskById(context.Background(), domain.TaskPatch{Title: &blank}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrValidation)
+	_, err = suite.taskUseCase.PatchTaskById(context.Background(), domain.TaskPatch{}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrValidation)
+
+	_, err = suite.taskUseCase.PatchTaskById(context.Background(), domain.TaskPatch{Title: &title, Version: 2}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrVersionMismatch)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
+// TestModifyTaskById_Version tests that an update applies to the version the caller read,
+// or to the current version if the caller gave none, and that a stale version is rejected
+// before the workflow is checked.
+func (suite *TaskUseCaseSuite) TestModifyTaskById_Version() {
+	current := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusTodo, Version: 4}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(current, nil)
+
+	expected := domain.Task{Title: "Renamed", Status: domain.TaskStatusTodo, AssigneeIDs: []string{}, Labels: []string{}, Version: 4}
+	suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, expected, taskID.String()).Return(expected, nil).Twice()
+	_, err := suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Title: "Renamed"}, taskID.String())
+	assert.NoError(suite.T(), err)
+	_, err = suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Title: "Renamed", Version: 4}, taskID.String())
+	assert.NoError(suite.T(), err)
+
+	_, err = suite.taskUseCase.ModifyTaskById(context.Background(), domain.Task{Status: "done", Version: 3}, taskID.String())
+	assert.ErrorIs(suite.T(), err, domain.ErrVersionMismatch)
+	assert.ErrorIs(suite.T(), err, domain.ErrPreconditionFailed)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
+// TestAddNewTask_Workflow tests that a task without a status starts in the initial status of the workflow
+// and that a task cannot be created in a status outside the workflow.
+func (suite *TaskUseCaseSuite) TestAddNewTask_Workflow() {
+	expected := domain.Task{Title: taskTitle, Status: domain.TaskStatusTodo, CreatedBy: adminUser.ID, AssigneeIDs: []string{}, Labels: []string{}}
+	suite.mockTaskRepo.On("CreateTask", mock.Anything, expected).Return(expected, nil)
+
+	task, err := suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), domain.TaskStatusTodo, task.Status)
+
+	_, err = suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle, Status: "Started"})
+	assert.ErrorIs(suite.T(), err, domain.ErrUnknownTaskStatus)
+	suite.mockTaskRepo.AssertNumberOfCalls(suite.T(), "CreateTask", 1)
+}
+
+// TestTaskLabels tests that the labels of a task are normalized, that only labels of the catalog can be added
+// and that a label the task already has is kept even if it left the catalog.
+func (suite *TaskUseCaseSuite) TestTaskLabels() {
+	suite.mockLabels.On("FindLabelByName", mock.Anything, "bug").Return(domain.Label{Name: "bug", Color: "#d62728"}, nil)
+	suite.mockLabels.On("FindLabelByName", mock.Anything, mock.Anything).Return(domain.Label{}, domain.ErrLabelNotFound)
+
+	expected := domain.Task{Title: taskTitle, Status: domain.TaskStatusTodo, CreatedBy: adminUser.ID, AssigneeIDs: []string{}, Labels: []string{"bug"}}
+	suite.mockTaskRepo.On("CreateTask", mock.Anything, expected).Return(expected, nil).Once()
+	_, err := suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle, Labels: []string{"Bug ", "bug"}})
+	suite.Require().NoError(err)
+	_, err = suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle, Labels: []string{"bug", "frontend"}})
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.Contains(err.Error(), `"frontend"`)
+
+	current := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusTodo, Labels: []string{"legacy"}, Version: 2}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(current, nil)
+	labels := []string{"bug", "legacy"}
+	suite.mockTaskRepo.On("PatchTaskById", mock.Anything, domain.TaskPatch{Labels: &labels, Version: 2}, taskID.String()).Return(current, nil).Once()
+	_, err = suite.taskUseCase.PatchTaskById(asUser(adminUser), domain.TaskPatch{Labels: &[]string{"legacy", "BUG"}}, taskID.String())
+	suite.Require().NoError(err)
+	_, err = suite.taskUseCase.PatchTaskById(asUser(adminUser), domain.TaskPatch{Labels: &[]string{"frontend"}}, taskID.String())
+	suite.ErrorIs(err, domain.ErrValidation)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
+// TestTransitionTask tests that the creator, an assignee or an admin can move a task along the workflow,
+// that illegal moves are rejected and that other users cannot tell the task exists.
+func (suite *TaskUseCaseSuite) TestTransitionTask() {
+	task := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusInProgress, CreatedBy: adminUser.ID, AssigneeIDs: []string{regularUser.ID}}
+	moved := task
+	moved.Status = domain.TaskStatusReview
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(task, nil)
+	suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, moved, taskID.String()).Return(moved, nil).Once()
+
+	result, err := suite.taskUseCase.TransitionTask(asUser(regularUser), taskID.String(), "review")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), moved, result)
+
+	_, err = suite.taskUseCase.TransitionTask(asUser(adminUser), taskID.String(), domain.TaskStatusDone)
+	assert.ErrorIs(suite.T(), err, domain.ErrIllegalTransition)
+	assert.Contains(suite.T(), err.Error(), "cannot move a task from IN_PROGRESS to DONE")
+
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	_, err = suite.taskUseCase.TransitionTask(asUser(stranger), taskID.String(), domain.TaskStatusReview)
+	assert.ErrorIs(suite.T(), err, domain.ErrTaskNotFound)
+
+	_, err = suite.taskUseCase.TransitionTask(context.Background(), taskID.String(), domain.TaskStatusReview)
+	assert.ErrorIs(suite.T(), err, domain.ErrUnauthenticated)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
 // TestDeleteTask is a unit test function that tests the DeleteTaskById method of the TaskUseCase struct.
-// It creates a new task ID using primitive.NewObjectID().Hex() and mocks the DeleteTask method of the task repository.
+// It creates a new task ID using domain.NewID().String() and mocks the DeleteTask method of the task repository.
 // The test asserts that no error is returned when calling the DeleteTaskById method and that the expectations of the mocked repository are met.
 func (suite *TaskUseCaseSuite) TestDeleteTask() {
-    taskID := primitive.NewObjectID().Hex()
+    taskID := domain.NewID().String()
 
-    suite.mockTaskRepo.On("DeleteTask", mock.Anything, taskID).Return(nil)
+    suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID).Return(domain.Task{Version: 2}, nil)
+    suite.mockTaskRepo.On("DeleteTask", mock.Anything, taskID, int64(2), "", mock.AnythingOfType("time.Time")).Return(domain.Task{Version: 3}, nil)
 
-    err := suite.taskUseCase.DeleteTaskById(context.Background(), taskID)
+    err := suite.taskUseCase.DeleteTaskById(context.Background(), taskID, 2)
 
     assert.NoError(suite.T(), err)
+    assert.ErrorIs(suite.T(), suite.taskUseCase.DeleteTaskById(context.Background(), taskID, 1), domain.ErrVersionMismatch)
+    suite.mockTaskRepo.AssertNumberOfCalls(suite.T(), "DeleteTask", 1)
     suite.mockTaskRepo.AssertExpectations(suite.T())
 }
 
+// TestDeleteTask_CurrentVersion tests that a delete without a version applies to the current version of the task.
+func (suite *TaskUseCaseSuite) TestDeleteTask_CurrentVersion() {
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(domain.Task{ID: taskID, Version: 5}, nil)
+	suite.mockTaskRepo.On("DeleteTask", mock.Anything, taskID.String(), int64(5), "", mock.AnythingOfType("time.Time")).Return(domain.Task{ID: taskID, Version: 6}, nil)
+
+	assert.NoError(suite.T(), suite.taskUseCase.DeleteTaskById(context.Background(), taskID.String(), 0))
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
+// TestTaskHistory tests that creating, updating, moving and deleting a task each record who made the change
+// and the fields it changed, and that failed changes record nothing.
+func (suite *TaskUseCaseSuite) TestTaskHistory() {
+	due := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	created := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusTodo, DueDate: due, CreatedBy: adminUser.ID, AssigneeIDs: []string{}, Labels: []string{}, Version: 1}
+	suite.mockTaskRepo.On("CreateTask", mock.Anything, mock.Anything).Return(created, nil)
+	_, err := suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle, DueDate: due})
+	suite.Require().NoError(err)
+
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(created, nil)
+	later := due.Add(24 * time.Hour)
+	moved := created
+	moved.DueDate, moved.Version = later, 2
+	suite.mockTaskRepo.On("PatchTaskById", mock.Anything, mock.Anything, taskID.String()).Return(moved, nil)
+	_, err = suite.taskUseCase.PatchTaskById(asUser(adminUser), domain.TaskPatch{DueDate: &later}, taskID.String())
+	suite.Require().NoError(err)
+
+	_, err = suite.taskUseCase.TransitionTask(asUser(regularUser), taskID.String(), domain.TaskStatusInProgress)
+	suite.Require().ErrorIs(err, domain.ErrTaskNotFound)
+
+	deleted := created
+	deleted.Version = 2
+	suite.mockTaskRepo.On("DeleteTask", mock.Anything, taskID.String(), int64(1), adminUser.ID, mock.AnythingOfType("time.Time")).Return(deleted, nil)
+	suite.Require().NoError(suite.taskUseCase.DeleteTaskById(asUser(adminUser), taskID.String(), 0))
+
+	suite.Require().Len(suite.history, 3)
+	for _, entry := range suite.history {
+		suite.Equal(taskID.String(), entry.TaskID)
+		suite.Equal(adminUser.ID, entry.ActorID)
+		suite.False(entry.At.IsZero())
+	}
+	suite.Equal(domain.TaskActionCreated, suite.history[0].Action)
+	suite.Len(suite.history[0].Changes, 6)
+	suite.Equal(domain.FieldChange{Field: "title", Before: nil, After: taskTitle}, suite.history[0].Changes[0])
+
+	suite.Equal(domain.TaskActionUpdated, suite.history[1].Action)
+	suite.Equal(int64(2), suite.history[1].Version)
+	suite.Equal([]domain.FieldChange{{Field: "due_date", Before: "2024-06-01T12:00:00Z", After: "2024-06-02T12:00:00Z"}}, suite.history[1].Changes)
+
+	suite.Equal(domain.TaskActionDeleted, suite.history[2].Action)
+	suite.Equal(int64(2), suite.history[2].Version)
+	suite.Equal(domain.FieldChange{Field: "status", Before: domain.TaskStatusTodo, After: nil}, suite.history[2].Changes[3])
+}
+
+// TestGetTaskHistory tests that users can only read the history of the tasks they can see,
+// that admins can read the history of any task and that the page window is validated.
+func (suite *TaskUseCaseSuite) TestGetTaskHistory() {
+	task := domain.Task{ID: taskID, CreatedBy: adminUser.ID, AssigneeIDs: []string{regularUser.ID}}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(task, nil)
+	page := domain.TaskHistoryPage{Entries: []domain.TaskHistoryEntry{{TaskID: taskID.String(), Action: domain.TaskActionCreated}}}
+	expected := domain.TaskHistoryQuery{TaskID: taskID.String(), Limit: domain.DefaultTaskPageSize}
+	suite.mockHistoryRepo.On("FindTaskHistory", mock.Anything, expected).Return(page, nil)
+
+	result, err := suite.taskUseCase.GetTaskHistory(asUser(regularUser), taskID.String(), domain.TaskHistoryQuery{})
+	suite.Require().NoError(err)
+	suite.Equal(page, result)
+
+	deleted := domain.NewID()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, deleted.String()).Return(domain.Task{}, domain.ErrTaskNotFound)
+	suite.mockTaskRepo.On("FindDeletedTaskById", mock.Anything, deleted.String()).Return(domain.Task{ID: deleted, CreatedBy: adminUser.ID}, nil)
+	suite.mockHistoryRepo.On("FindTaskHistory", mock.Anything, domain.TaskHistoryQuery{TaskID: deleted.String(), Limit: 5}).Return(domain.TaskHistoryPage{}, nil)
+	_, err = suite.taskUseCase.GetTaskHistory(asUser(adminUser), deleted.String(), domain.TaskHistoryQuery{Limit: 5})
+	suite.NoError(err, "admins can read the history of deleted tasks")
+	_, err = suite.taskUseCase.GetTaskHistory(asUser(regularUser), deleted.String(), domain.TaskHistoryQuery{})
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	_, err = suite.taskUseCase.GetTaskHistory(asUser(stranger), taskID.String(), domain.TaskHistoryQuery{})
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	_, err = suite.taskUseCase.GetTaskHistory(asUser(adminUser), taskID.String(), domain.TaskHistoryQuery{Limit: domain.MaxTaskPageSize + 1})
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.taskUseCase.GetTaskHistory(asUser(adminUser), taskID.String(), domain.TaskHistoryQuery{Cursor: "garbage"})
+	suite.ErrorIs(err, domain.ErrValidation)
+	_, err = suite.taskUseCase.GetTaskHistory(context.Background(), taskID.String(), domain.TaskHistoryQuery{})
+	suite.ErrorIs(err, domain.ErrUnauthenticated)
+	suite.mockHistoryRepo.AssertExpectations(suite.T())
+}
+
+// TestGetTaskHistory_Organizations tests that the history of a task of another organization is not read,
+// even by an admin, whether the task is live or in the trash.
+func (suite *TaskUseCaseSuite) TestGetTaskHistory_Organizations() {
+	inLegal := mock.MatchedBy(func(ctx context.Context) bool {
+		org, ok := domain.OrganizationFromContext(ctx)
+		return ok && org == "legal"
+	})
+	suite.mockTaskRepo.On("FindTaskById", inLegal, taskID.String()).Return(domain.Task{}, domain.ErrTaskNotFound)
+	suite.mockTaskRepo.On("FindDeletedTaskById", inLegal, taskID.String()).Return(domain.Task{}, domain.ErrTaskNotFound)
+
+	legalAdmin := adminUser
+	legalAdmin.OrgID = "legal"
+	_, err := suite.taskUseCase.GetTaskHistory(asUser(legalAdmin), taskID.String(), domain.TaskHistoryQuery{})
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+	suite.mockHistoryRepo.AssertNotCalled(suite.T(), "FindTaskHistory", mock.Anything, mock.Anything)
+}
+
+// TestTrash tests that users only list the deleted tasks they could see, that a restore is recorded in the history
+// and that the purge deletes the tasks deleted before the retention with their comments.
+func (suite *TaskUseCaseSuite) TestTrash() {
+	expected := domain.TaskQuery{Deleted: true, VisibleTo: regularUser.ID, Limit: domain.DefaultTaskPageSize, LabelMatch: domain.LabelMatchAny}
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, expected).Return(domain.TaskPage{}, nil)
+	_, err := suite.taskUseCase.GetDeletedTasks(asUser(regularUser), domain.TaskQuery{})
+	suite.Require().NoError(err)
+	_, err = suite.taskUseCase.GetDeletedTasks(asUser(adminUser), domain.TaskQuery{Sort: "priority"})
+	suite.ErrorIs(err, domain.ErrInvalidTaskQuery)
+
+	restored := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusTodo, AssigneeIDs: []string{}, Version: 3}
+	suite.mockTaskRepo.On("RestoreTask", mock.Anything, taskID.String()).Return(restored, nil)
+	task, err := suite.taskUseCase.RestoreTaskById(asUser(adminUser), taskID.String())
+	suite.Require().NoError(err)
+	suite.Equal(restored, task)
+	suite.Require().Len(suite.history, 1)
+	suite.Equal(domain.TaskActionRestored, suite.history[0].Action)
+	suite.Equal(int64(3), suite.history[0].Version)
+	suite.Equal(adminUser.ID, suite.history[0].ActorID)
+
+	missing := domain.NewID().String()
+	suite.mockTaskRepo.On("RestoreTask", mock.Anything, missing).Return(domain.Task{}, domain.ErrTaskNotFound)
+	_, err = suite.taskUseCase.RestoreTaskById(asUser(adminUser), missing)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	suite.Len(suite.history, 1)
+
+	start := time.Now()
+	suite.mockTaskRepo.On("StartPurge", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return !before.Before(start.Add(-time.Hour)) && !before.After(time.Now().Add(-time.Hour))
+	})).Return([]string{"a", "b"}, nil)
+	suite.mockCommentRepo.On("DeleteTaskComments", mock.Anything, []string{"a", "b"}).Return(nil)
+	suite.mockChecklists.On("DeleteTaskChecklists", mock.Anything, []string{"a", "b"}).Return(nil)
+	suite.mockDeps.On("DeleteTaskDependencies", mock.Anything, []string{"a", "b"}).Return(nil)
+	suite.mockTaskRepo.On("PurgeTasks", mock.Anything, []string{"a", "b"}).Return(nil)
+	n, err := suite.taskUseCase.PurgeDeletedTasks(context.Background(), time.Hour)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), n)
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+	suite.mockCommentRepo.AssertExpectations(suite.T())
+	suite.mockChecklists.AssertExpectations(suite.T())
+	suite.mockDeps.AssertExpectations(suite.T())
+}
+
+// TestPurgeHooks tests that the purge hooks get the IDs of the purged tasks and that their failure fails the purge.
+func (suite *TaskUseCaseSuite) TestPurgeHooks() {
+	suite.mockTaskRepo.On("StartPurge", mock.Anything, mock.Anything).Return([]string{"a"}, nil)
+	suite.mockCommentRepo.On("DeleteTaskComments", mock.Anything, []string{"a"}).Return(nil)
+	suite.mockChecklists.On("DeleteTaskChecklists", mock.Anything, []string{"a"}).Return(nil)
+	suite.mockDeps.On("DeleteTaskDependencies", mock.Anything, []string{"a"}).Return(nil)
+	suite.mockTaskRepo.On("PurgeTasks", mock.Anything, []string{"a"}).Return(nil)
+	var cleaned [][]string
+	suite.taskUseCase.OnPurge(func(ctx context.Context, taskIds []string) error {
+		cleaned = append(cleaned, taskIds)
+		return nil
+	})
+
+	n, err := suite.taskUseCase.PurgeDeletedTasks(context.Background(), time.Hour)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n)
+	suite.Equal([][]string{{"a"}}, cleaned)
+
+	suite.taskUseCase.OnPurge(func(ctx context.Context, taskIds []string) error {
+		return errors.New("blob store down")
+	})
+	_, err = suite.taskUseCase.PurgeDeletedTasks(context.Background(), time.Hour)
+	suite.ErrorContains(err, "blob store down")
+	suite.mockTaskRepo.AssertNumberOfCalls(suite.T(), "PurgeTasks", 1)
+}
+
+// TestPurgeDeletedTasks_Retried tests that the tasks are only deleted once everything belonging to them is,
+// so that a purge failing halfway is finished by the next one.
+func (suite *TaskUseCaseSuite) TestPurgeDeletedTasks_Retried() {
+	suite.mockTaskRepo.On("StartPurge", mock.Anything, mock.Anything).Return([]string{"a", "b"}, nil)
+	suite.mockCommentRepo.On("DeleteTaskComments", mock.Anything, []string{"a", "b"}).Return(nil)
+	suite.mockChecklists.On("DeleteTaskChecklists", mock.Anything, []string{"a", "b"}).Return(errors.New("database is down")).Once()
+	suite.mockChecklists.On("DeleteTaskChecklists", mock.Anything, []string{"a", "b"}).Return(nil)
+	suite.mockDeps.On("DeleteTaskDependencies", mock.Anything, []string{"a", "b"}).Return(nil)
+	suite.mockTaskRepo.On("PurgeTasks", mock.Anything, []string{"a", "b"}).Return(nil)
+
+	_, err := suite.taskUseCase.PurgeDeletedTasks(context.Background(), time.Hour)
+	suite.ErrorContains(err, "database is down")
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "PurgeTasks", mock.Anything, mock.Anything)
+
+	n, err := suite.taskUseCase.PurgeDeletedTasks(context.Background(), time.Hour)
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), n)
+	suite.mockCommentRepo.AssertNumberOfCalls(suite.T(), "DeleteTaskComments", 2)
+	suite.mockTaskRepo.AssertNumberOfCalls(suite.T(), "PurgeTasks", 1)
+}
+
+// TestCommentCounts tests that the listed and read tasks carry the number of their comments.
+func (suite *TaskUseCaseSuite) TestCommentCounts() {
+	other := domain.NewID()
+	page := domain.TaskPage{Tasks: []domain.Task{{ID: taskID}, {ID: other}}}
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, mock.Anything).Return(page, nil)
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, taskID.String()).Return(domain.Task{ID: taskID}, nil)
+	counts := new(mocks.CommentRepository)
+	counts.On("CountComments", mock.Anything, []string{taskID.String(), other.String()}).Return(map[string]int{taskID.String(): 3}, nil)
+	counts.On("CountComments", mock.Anything, []string{taskID.String()}).Return(map[string]int{taskID.String(): 3}, nil)
+	suite.taskUseCase.commentRepository = counts
+
+	result, err := suite.taskUseCase.GetTasks(asUser(adminUser), domain.TaskQuery{})
+	suite.Require().NoError(err)
+	suite.Equal(3, result.Tasks[0].CommentCount)
+	suite.Equal(0, result.Tasks[1].CommentCount)
+
+	task, err := suite.taskUseCase.GetTaskByID(asUser(adminUser), taskID.String())
+	suite.Require().NoError(err)
+	suite.Equal(3, task.CommentCount)
+	counts.AssertExpectations(suite.T())
+}
+
+// TestAddNewTask_Parent tests that a subtask can only be created under an existing task the caller may change.
+func (suite *TaskUseCaseSuite) TestAddNewTask_Parent() {
+	parent := domain.NewID().String()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, parent).Return(domain.Task{}, nil)
+	suite.mockTaskRepo.On("CreateTask", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, task domain.Task) domain.Task { return task }, nil,
+	)
+
+	task, err := suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle, ParentID: parent})
+	suite.Require().NoError(err)
+	suite.Equal(parent, task.ParentID)
+
+	missing := domain.NewID().String()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, missing).Return(domain.Task{}, domain.ErrTaskNotFound)
+	_, err = suite.taskUseCase.AddNewTask(asUser(adminUser), domain.Task{Title: taskTitle, ParentID: missing})
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	others := domain.NewID().String()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, others).Return(domain.Task{CreatedBy: adminUser.ID}, nil)
+	_, err = suite.taskUseCase.AddNewTask(asUser(regularUser), domain.Task{Title: taskTitle, ParentID: others})
+	suite.ErrorIs(err, domain.ErrTaskNotFound, "a user cannot add a subtask to a task they cannot change")
+
+	own := domain.NewID().String()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, own).Return(domain.Task{CreatedBy: regularUser.ID}, nil)
+	_, err = suite.taskUseCase.AddNewTask(asUser(regularUser), domain.Task{Title: taskTitle, ParentID: own})
+	suite.NoError(err)
+	suite.mockTaskRepo.AssertNumberOfCalls(suite.T(), "CreateTask", 2)
+}
+
+// TestProgress tests that the progress of a task counts its checklist items and its subtasks,
+// leaving out the cancelled subtasks.
+func (suite *TaskUseCaseSuite) TestProgress() {
+	id := taskID.String()
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, id).Return(domain.Task{ID: taskID}, nil)
+	checklists := new(mocks.ChecklistRepository)
+	checklists.On("CountChecklists", mock.Anything, []string{id}).Return(map[string]domain.TaskSteps{id: {Total: 2, Done: 1, OpenRequired: 1}}, nil)
+	suite.taskUseCase.checklistRepository = checklists
+	subtasks := new(mocks.TaskRepository)
+	subtasks.On("FindTaskById", mock.Anything, id).Return(domain.Task{ID: taskID}, nil)
+	subtasks.On("CountSubtasks", mock.Anything, []string{id}).Return(map[string]map[string]int{
+		id: {domain.TaskStatusDone: 1, domain.TaskStatusTodo: 1, domain.TaskStatusCancelled: 3},
+	}, nil)
+	suite.taskUseCase.taskRepository = subtasks
+
+	task, err := suite.taskUseCase.GetTaskByID(asUser(adminUser), id)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(task.Progress)
+	suite.Equal(50, *task.Progress)
+
+	suite.taskUseCase.taskRepository = suite.mockTaskRepo
+	suite.taskUseCase.checklistRepository = suite.mockChecklists
+	task, err = suite.taskUseCase.GetTaskByID(asUser(adminUser), id)
+	suite.Require().NoError(err)
+	suite.Nil(task.Progress)
+}
+
+// TestTransitionTask_OpenSteps tests that a task cannot move to DONE while required checklist items
+// or subtasks are open, and that other moves are not affected.
+func (suite *TaskUseCaseSuite) TestTransitionTask_OpenSteps() {
+	id := taskID.String()
+	task := domain.Task{ID: taskID, Status: domain.TaskStatusReview, CreatedBy: adminUser.ID}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, id).Return(task, nil)
+	suite.mockTaskRepo.On("UpdateTaskById", mock.Anything, mock.Anything, id).Return(
+		func(ctx context.Context, task domain.Task, id string) domain.Task { return task }, nil,
+	)
+	open := map[string]domain.TaskSteps{id: {Total: 1, OpenRequired: 1}}
+	checklists := new(mocks.ChecklistRepository)
+	checklists.On("CountChecklists", mock.Anything, []string{id}).Return(
+		func(ctx context.Context, taskIds []string) map[string]domain.TaskSteps { return open }, nil,
+	)
+	suite.taskUseCase.checklistRepository = checklists
+
+	_, err := suite.taskUseCase.TransitionTask(asUser(adminUser), id, domain.TaskStatusDone)
+	suite.ErrorIs(err, domain.ErrOpenSteps)
+	done := domain.TaskStatusDone
+	_, err = suite.taskUseCase.PatchTaskById(asUser(adminUser), domain.TaskPatch{Status: &done}, id)
+	suite.ErrorIs(err, domain.ErrOpenSteps)
+
+	moved, err := suite.taskUseCase.TransitionTask(asUser(adminUser), id, domain.TaskStatusInProgress)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusInProgress, moved.Status)
+
+	open = map[string]domain.TaskSteps{id: {Total: 1, Done: 1}}
+	moved, err = suite.taskUseCase.TransitionTask(asUser(adminUser), id, domain.TaskStatusDone)
+	suite.Require().NoError(err)
+	suite.Equal(domain.TaskStatusDone, moved.Status)
+}
+
+// TestProjectTasks tests that the members of a project read all of its tasks, that only its editors and owners
+// create and change them, and that the other users cannot tell the tasks exist.
+func (suite *TaskUseCaseSuite) TestProjectTasks() {
+	pid := domain.NewID().String()
+	viewer := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	editor := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	stranger := domain.AuthUser{ID: domain.NewID().String(), Role: domain.RoleUser}
+	suite.mockProjects.On("FindMember", mock.Anything, pid, viewer.ID).Return(domain.ProjectMember{ProjectID: pid, UserID: viewer.ID, Role: domain.ProjectRoleViewer}, nil)
+	suite.mockProjects.On("FindMember", mock.Anything, pid, editor.ID).Return(domain.ProjectMember{ProjectID: pid, UserID: editor.ID, Role: domain.ProjectRoleEditor}, nil)
+	suite.mockProjects.On("FindMember", mock.Anything, pid, mock.Anything).Return(domain.ProjectMember{}, domain.ErrMemberNotFound)
+
+	id := taskID.String()
+	task := domain.Task{ID: taskID, Title: taskTitle, Status: domain.TaskStatusTodo, CreatedBy: adminUser.ID, ProjectID: pid, Version: 1}
+	suite.mockTaskRepo.On("FindTaskById", mock.Anything, id).Return(task, nil)
+	_, err := suite.taskUseCase.GetTaskByID(asUser(viewer), id)
+	suite.NoError(err, "the members of the project read its tasks")
+	_, err = suite.taskUseCase.GetTaskByID(asUser(stranger), id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+
+	title := "renamed"
+	suite.mockTaskRepo.On("PatchTaskById", mock.Anything, domain.TaskPatch{Title: &title, Version: 1}, id).Return(task, nil).Once()
+	_, err = suite.taskUseCase.PatchTaskById(asUser(viewer), domain.TaskPatch{Title: &title}, id)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	_, err = suite.taskUseCase.PatchTaskById(asUser(stranger), domain.TaskPatch{Title: &title}, id)
+	suite.ErrorIs(err, domain.ErrTaskNotFound)
+	_, err = suite.taskUseCase.PatchTaskById(asUser(editor), domain.TaskPatch{Title: &title}, id)
+	suite.NoError(err)
+	suite.ErrorIs(suite.taskUseCase.DeleteTaskById(asUser(viewer), id, 0), domain.ErrProjectRole)
+	_, err = suite.taskUseCase.TransitionTask(asUser(viewer), id, domain.TaskStatusInProgress)
+	suite.ErrorIs(err, domain.ErrProjectRole)
+
+	query := domain.TaskQuery{ProjectID: pid, Limit: domain.DefaultTaskPageSize, LabelMatch: domain.LabelMatchAny}
+	suite.mockTaskRepo.On("FindTasks", mock.Anything, query).Return(domain.TaskPage{Tasks: []domain.Task{task}}, nil).Once()
+	page, err := suite.taskUseCase.GetTasks(asUser(viewer), domain.TaskQuery{ProjectID: pid, VisibleTo: viewer.ID})
+	suite.Require().NoError(err)
+	suite.Len(page.Tasks, 1, "the members of the project list every task of the project")
+	_, err = suite.taskUseCase.GetTasks(asUser(stranger), domain.TaskQuery{ProjectID: pid})
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+
+	created := domain.Task{Title: taskTitle, Status: domain.TaskStatusTodo, CreatedBy: editor.ID, ProjectID: pid, AssigneeIDs: []string{}, Labels: []string{}}
+	suite.mockTaskRepo.On("CreateTask", mock.Anything, created).Return(created, nil).Once()
+	_, err = suite.taskUseCase.AddNewTask(asUser(editor), domain.Task{Title: taskTitle, ProjectID: pid})
+	suite.NoError(err)
+	_, err = suite.taskUseCase.AddNewTask(asUser(viewer), domain.Task{Title: taskTitle, ProjectID: pid})
+	suite.ErrorIs(err, domain.ErrProjectRole)
+	_, err = suite.taskUseCase.AddNewTask(asUser(stranger), domain.Task{Title: taskTitle, ProjectID: pid})
+	suite.ErrorIs(err, domain.ErrProjectNotFound)
+	_, err = suite.taskUseCase.AddNewTask(asUser(editor), domain.Task{Title: taskTitle, ParentID: id})
+	suite.ErrorIs(err, domain.ErrValidation, "a subtask is in the project of its parent")
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+}
+
 func TestTaskUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(TaskUseCaseSuite))
 }
\ No newline at end of file
@@ -2,32 +2,184 @@ package usecases
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 	domain "example/go-clean-architecture/Domain"
 )
 
 
 // taskUseCase represents the use case for managing tasks.
+// The status of every task follows the workflow, and every change is recorded in the history of the task.
+// The tasks it returns carry the number of their comments and their progress.
+// A task cannot move to DONE while required items of its checklist or its subtasks are open,
+// and its status follows its dependencies on other tasks. Tasks only get labels of the label catalog.
+// The members of the project of a task can read it, and its editors and owners can change it.
 type taskUseCase struct {
-	taskRepository domain.TaskRepository
-	contextTimeout time.Duration
+	taskRepository       domain.TaskRepository
+	historyRepository    domain.TaskHistoryRepository
+	commentRepository    domain.CommentRepository
+	checklistRepository  domain.ChecklistRepository
+	dependencyRepository domain.DependencyRepository
+	labelRepository      domain.LabelRepository
+	projectRepository    domain.ProjectRepository
+	workflow             domain.TaskWorkflow
+	contextTimeout       time.Duration
+	purgeHooks           []PurgeHook
 }
+
+// PurgeHook deletes what belongs to the tasks purged from the trash and is not kept by the task repositories.
+type PurgeHook func(ctx context.Context, taskIds []string) error
+
 var _ domain.TaskUseCase = &taskUseCase{}
-func NewTaskUsecase(taskRepository domain.TaskRepository, timeout time.Duration) *taskUseCase {
+func NewTaskUsecase(taskRepository domain.TaskRepository, historyRepository domain.TaskHistoryRepository, commentRepository domain.CommentRepository, checklistRepository domain.ChecklistRepository, dependencyRepository domain.DependencyRepository, labelRepository domain.LabelRepository, projectRepository domain.ProjectRepository, workflow domain.TaskWorkflow, timeout time.Duration) *taskUseCase {
 	return &taskUseCase{
-		taskRepository: taskRepository,
-		contextTimeout: timeout,
+		taskRepository:       taskRepository,
+		historyRepository:    historyRepository,
+		commentRepository:    commentRepository,
+		checklistRepository:  checklistRepository,
+		dependencyRepository: dependencyRepository,
+		labelRepository:      labelRepository,
+		projectRepository:    projectRepository,
+		workflow:             workflow,
+		contextTimeout:       timeout,
 	}
 }
 
-// GetAllTasks retrieves all tasks from the task repository.
-// It takes a context as input and returns a slice of domain.Task and an error.
-// The context is used to control the execution timeout.
-func (tu *taskUseCase) GetAllTasks(c context.Context) ([]domain.Task, error){
+// GetTasks retrieves one page of tasks matching the given query.
+// A zero limit falls back to domain.DefaultTaskPageSize; the sort key and cursor
+// are validated before the repository is queried.
+// Callers without domain.PermissionTaskReadAll only get the tasks they created or are assigned to,
+// or every task of the project the query is restricted to if they are one of its members.
+func (tu *taskUseCase) GetTasks(c context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
 	ctx, close := context.WithTimeout(c, tu.contextTimeout)
 	defer close()
 
-	return tu.taskRepository.FindAlltasks(ctx)
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.TaskPage{}, domain.ErrUnauthenticated
+	}
+	query, err := normalizeTaskQuery(query)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	if query, err = tu.scopeTaskQuery(ctx, query, user); err != nil {
+		return domain.TaskPage{}, err
+	}
+	return tu.findTasks(ctx, query)
+}
+
+// scopeTaskQuery restricts a query to the tasks the user may read. A query restricted to a project
+// is only allowed to its members, who read every task of the project; domain.ErrProjectNotFound is returned
+// to the other users.
+func (tu *taskUseCase) scopeTaskQuery(ctx context.Context, query domain.TaskQuery, user domain.AuthUser) (domain.TaskQuery, error) {
+	query.VisibleTo = ""
+	if query.ProjectID != "" {
+		member, err := projectMember(ctx, tu.projectRepository, query.ProjectID, user)
+		if err != nil {
+			return domain.TaskQuery{}, err
+		}
+		query.ProjectID = member.ProjectID
+		return query, nil
+	}
+	if !user.Can(domain.PermissionTaskReadAll) {
+		query.VisibleTo = user.ID
+	}
+	return query, nil
+}
+
+// findTasks retrieves one page of tasks with the number of their comments and their progress.
+func (tu *taskUseCase) findTasks(ctx context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	page, err := tu.taskRepository.FindTasks(ctx, query)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	tasks := make([]*domain.Task, len(page.Tasks))
+	for i := range page.Tasks {
+		tasks[i] = &page.Tasks[i]
+	}
+	if err := tu.computeFields(ctx, tasks...); err != nil {
+		return domain.TaskPage{}, err
+	}
+	return page, nil
+}
+
+// computeFields sets the number of comments and the progress of the tasks.
+func (tu *taskUseCase) computeFields(ctx context.Context, tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID.String()
+	}
+	counts, err := tu.commentRepository.CountComments(ctx, ids)
+	if err != nil {
+		return err
+	}
+	steps, err := tu.countSteps(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.CommentCount = counts[task.ID.String()]
+		task.Progress = steps[task.ID.String()].Progress()
+	}
+	return nil
+}
+
+// countSteps counts the checklist items and subtasks of the given tasks.
+func (tu *taskUseCase) countSteps(ctx context.Context, ids []string) (map[string]domain.TaskSteps, error) {
+	steps, err := tu.checklistRepository.CountChecklists(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	subtasks, err := tu.taskRepository.CountSubtasks(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for id, statuses := range subtasks {
+		steps[id] = steps[id].Add(domain.SubtaskSteps(statuses))
+	}
+	return steps, nil
+}
+
+// normalizeTaskQuery applies the default page size, normalizes the status and label filters and rejects
+// invalid sort keys, cursors, limits and label matches.
+func normalizeTaskQuery(query domain.TaskQuery) (domain.TaskQuery, error) {
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultTaskPageSize
+	}
+	query.Status = domain.NormalizeTaskStatus(query.Status)
+	if len(query.Labels) > 0 {
+		labels, err := domain.NormalizeLabels(query.Labels)
+		if err != nil {
+			return domain.TaskQuery{}, fmt.Errorf("%w: %v", domain.ErrInvalidTaskQuery, err)
+		}
+		query.Labels = labels
+	}
+	switch query.LabelMatch {
+	case "":
+		query.LabelMatch = domain.LabelMatchAny
+	case domain.LabelMatchAny, domain.LabelMatchAll:
+	default:
+		return domain.TaskQuery{}, fmt.Errorf("%w: label_match must be %q or %q", domain.ErrInvalidTaskQuery, domain.LabelMatchAny, domain.LabelMatchAll)
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxTaskPageSize {
+		return domain.TaskQuery{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidTaskQuery, domain.MaxTaskPageSize)
+	}
+	if _, _, err := domain.ParseTaskSort(query.Sort); err != nil {
+		return domain.TaskQuery{}, err
+	}
+	if query.Cursor != "" {
+		if _, err := domain.DecodeTaskCursor(query.Cursor, query.Sort); err != nil {
+			return domain.TaskQuery{}, err
+		}
+	}
+	if query.DueFrom != nil && query.DueTo != nil && query.DueTo.Before(*query.DueFrom) {
+		return domain.TaskQuery{}, fmt.Errorf("%w: due_to is before due_from", domain.ErrInvalidTaskQuery)
+	}
+	return query, nil
 }
 
 // GetTaskByID retrieves a task by its ID.
@@ -36,41 +188,484 @@ func (tu *taskUseCase) GetAllTasks(c context.Context) ([]domain.Task, error){
 // The context.Context is used for managing the execution context of the function.
 // The taskId is the unique identifier of the task to be retrieved.
 // The function returns the found task and any error that occurred during the retrieval process.
+// Tasks the caller may not see are reported as domain.ErrTaskNotFound so their existence is not leaked.
 func (tu *taskUseCase) GetTaskByID(c context.Context, taskId string) (domain.Task, error){
 	ctx, close := context.WithTimeout(c, tu.contextTimeout)
 	defer close()
 
-	return tu.taskRepository.FindTaskById(ctx , taskId )
+	task, _, err := readableTask(ctx, tu.taskRepository, tu.projectRepository, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.computeFields(ctx, &task); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
 }
 
 
 // AddNewTask adds a new task to the system.
 // It takes a context and a task as input parameters and returns the created task and an error (if any).
+// The task is recorded as created by the authenticated caller.
+// A task without a status starts in the initial status of the workflow; any other status must be part of the workflow.
+// A subtask names its parent in ParentID, which must be a task that is not in the trash, in the same project,
+// that the caller may change; domain.ErrTaskNotFound is returned otherwise.
+// The labels must be in the label catalog.
+// A task created in a project, named by ProjectID, stays in it; only the editors and owners of the project
+// create its tasks, and domain.ErrProjectNotFound is returned to the users who are not members of the project.
 func (tu *taskUseCase) AddNewTask(c context.Context, task domain.Task) (domain.Task, error){
     ctx, close := context.WithTimeout(c, tu.contextTimeout)
 	defer close()
 
-	return tu.taskRepository.CreateTask(ctx, task)
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.Task{}, domain.ErrUnauthenticated
+	}
+	if task.Status == "" {
+		task.Status = tu.workflow.Initial
+	} else {
+		status, err := tu.workflow.ParseStatus(task.Status)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		task.Status = status
+	}
+	if task.ProjectID != "" {
+		member, err := projectMember(ctx, tu.projectRepository, task.ProjectID, user)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		if !member.CanEdit() {
+			return domain.Task{}, domain.ErrProjectRole
+		}
+		task.ProjectID = member.ProjectID
+	}
+	if task.ParentID != "" {
+		// a subtask changes the progress of its parent, so the caller must be able to change the parent
+		parent, _, err := editableTask(ctx, tu.taskRepository, tu.projectRepository, task.ParentID, domain.PermissionTaskUpdate)
+		if errors.Is(err, domain.ErrTaskNotFound) || errors.Is(err, domain.ErrProjectRole) {
+			return domain.Task{}, fmt.Errorf("%w: the parent task %s does not exist or cannot be changed by you", domain.ErrTaskNotFound, task.ParentID)
+		} else if err != nil {
+			return domain.Task{}, err
+		}
+		if parent.ProjectID != task.ProjectID {
+			return domain.Task{}, fmt.Errorf("%w: a subtask must be in the project of its parent", domain.ErrValidation)
+		}
+	}
+	labels, err := tu.checkLabels(ctx, task.Labels, nil)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	task.Labels = labels
+	task.CreatedBy = user.ID
+	task.DeletedAt, task.DeletedBy = nil, ""
+	if task.AssigneeIDs == nil {
+		task.AssigneeIDs = []string{}
+	}
+	created, err := tu.taskRepository.CreateTask(ctx, task)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	return created, tu.recordHistory(ctx, domain.TaskActionCreated, nil, &created)
 }
 
 
 // ModifyTaskById modifies a task by its ID.
 // It takes a context.Context, a task domain.Task, and a taskId string as parameters.
 // It returns the modified task and an error, if any.
+// The creator and the project of the task are never changed.
+// A task without a status keeps its current one; a new status must be reachable from the current one in the workflow.
+// The labels replace the ones of the task, and those it did not have must be in the label catalog.
+// The task is only modified if it is still at task.Version, or whatever its version if task.Version is zero;
+// otherwise ErrVersionMismatch is returned.
 func (tu *taskUseCase) ModifyTaskById(c context.Context, task domain.Task,  taskId string) (domain.Task, error){
 	ctx, close := context.WithTimeout(c, tu.contextTimeout)
 	defer close()
 
-	return tu.taskRepository.UpdateTaskById(ctx, task, taskId)
+	current, err := tu.taskRepository.FindTaskById(ctx, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.checkCallerEditable(ctx, current, domain.PermissionTaskUpdate); err != nil {
+		return domain.Task{}, err
+	}
+	if task.Version, err = expectedVersion(current, task.Version); err != nil {
+		return domain.Task{}, err
+	}
+	if task.Status == "" {
+		task.Status = current.Status
+	} else if task.Status, err = tu.nextStatus(ctx, current, task.Status); err != nil {
+		return domain.Task{}, err
+	}
+
+	if task.AssigneeIDs == nil {
+		task.AssigneeIDs = []string{}
+	}
+	if task.Labels, err = tu.checkLabels(ctx, task.Labels, current.Labels); err != nil {
+		return domain.Task{}, err
+	}
+	updated, err := tu.taskRepository.UpdateTaskById(ctx, task, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.computeFields(ctx, &updated); err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.recordHistory(ctx, domain.TaskActionUpdated, &current, &updated); err != nil {
+		return updated, err
+	}
+	return updated, tu.statusChanged(ctx, current, updated)
 }
 
+// PatchTaskById changes only the fields of a task set in the patch and returns the updated task.
+// The fields are validated first; a new status must be reachable from the current one in the workflow,
+// and new labels must be in the label catalog.
+// The creator and the project of the task are never changed. Like ModifyTaskById, the patch only applies to patch.Version unless it is zero.
+func (tu *taskUseCase) PatchTaskById(c context.Context, patch domain.TaskPatch, taskId string) (domain.Task, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
 
-// DeleteTaskById deletes a task by its ID.
-// It takes a context.Context and a taskId string as parameters.
-// It returns an error if the task deletion fails.
-func (tu *taskUseCase) DeleteTaskById(c context.Context, taskId string)error{
+	if err := patch.Validate(); err != nil {
+		return domain.Task{}, err
+	}
+	current, err := tu.taskRepository.FindTaskById(ctx, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.checkCallerEditable(ctx, current, domain.PermissionTaskUpdate); err != nil {
+		return domain.Task{}, err
+	}
+	if patch.Version, err = expectedVersion(current, patch.Version); err != nil {
+		return domain.Task{}, err
+	}
+	if patch.Status != nil {
+		status, err := tu.nextStatus(ctx, current, *patch.Status)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		patch.Status = &status
+	}
+	if patch.AssigneeIDs != nil && *patch.AssigneeIDs == nil {
+		patch.AssigneeIDs = &[]string{}
+	}
+	if patch.Labels != nil {
+		labels, err := tu.checkLabels(ctx, *patch.Labels, current.Labels)
+		if err != nil {
+			return domain.Task{}, err
+		}
+		patch.Labels = &labels
+	}
+	updated, err := tu.taskRepository.PatchTaskById(ctx, patch, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.computeFields(ctx, &updated); err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.recordHistory(ctx, domain.TaskActionUpdated, &current, &updated); err != nil {
+		return updated, err
+	}
+	return updated, tu.statusChanged(ctx, current, updated)
+}
+
+// TransitionTask moves a task to another status of the workflow and returns the updated task.
+// Users with domain.PermissionTaskUpdate can move any task, other users the tasks they created or are assigned to
+// and the tasks of the projects they are an editor or owner of.
+// It returns an ErrUnknownTaskStatus error for a status outside the workflow and an
+// ErrIllegalTransition error if the workflow does not allow the move.
+func (tu *taskUseCase) TransitionTask(c context.Context, taskId string, status string) (domain.Task, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	task, _, err := editableTask(ctx, tu.taskRepository, tu.projectRepository, taskId, domain.PermissionTaskUpdate)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	current := task
+	if task.Status, err = tu.nextStatus(ctx, task, status); err != nil {
+		return domain.Task{}, err
+	}
+	updated, err := tu.taskRepository.UpdateTaskById(ctx, task, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.computeFields(ctx, &updated); err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.recordHistory(ctx, domain.TaskActionTransitioned, &current, &updated); err != nil {
+		return updated, err
+	}
+	return updated, tu.statusChanged(ctx, current, updated)
+}
+
+// checkCallerEditable checks that the authenticated caller, if any, may make a change to the task needing
+// the given permission. Without a caller the change is made by the service itself, like the purge of the trash.
+func (tu *taskUseCase) checkCallerEditable(ctx context.Context, task domain.Task, permission string) error {
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return checkEditable(ctx, tu.projectRepository, task, user, permission)
+}
+
+// checkLabels normalizes the labels given to a task and checks that those it does not have yet, according to
+// current, are in the label catalog. It returns an ErrValidation error naming the first unknown label.
+func (tu *taskUseCase) checkLabels(ctx context.Context, labels []string, current []string) ([]string, error) {
+	labels, err := domain.NormalizeLabels(labels)
+	if err != nil {
+		return nil, err
+	}
+	kept := make(map[string]bool, len(current))
+	for _, label := range current {
+		kept[label] = true
+	}
+	for _, label := range labels {
+		if kept[label] {
+			continue
+		}
+		if _, err := tu.labelRepository.FindLabelByName(ctx, label); errors.Is(err, domain.ErrLabelNotFound) {
+			return nil, fmt.Errorf("%w: unknown label %q", domain.ErrValidation, label)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return labels, nil
+}
+
+// expectedVersion returns the version a write must apply to: the one the caller read,
+// or the current version of the task if the caller gave none.
+// It returns ErrVersionMismatch if the task has changed since the caller read it.
+func expectedVersion(current domain.Task, version int64) (int64, error) {
+	if version == 0 {
+		return current.Version, nil
+	}
+	if version != current.Version {
+		return 0, domain.ErrVersionMismatch
+	}
+	return version, nil
+}
+
+// nextStatus normalizes the status a task is moved to and checks the workflow allows the move.
+// A task only moves to DONE once the required items of its checklist and its subtasks are done,
+// otherwise it returns an ErrOpenSteps error, and a task with unresolved dependencies only moves to BLOCKED
+// or CANCELLED, otherwise it returns an ErrTaskBlocked error.
+func (tu *taskUseCase) nextStatus(ctx context.Context, task domain.Task, status string) (string, error) {
+	next, err := tu.workflow.ParseStatus(status)
+	if err != nil {
+		return "", err
+	}
+	if err := tu.workflow.CheckTransition(task.Status, next); err != nil {
+		return "", err
+	}
+	if next != task.Status && next != domain.TaskStatusBlocked && next != domain.TaskStatusCancelled {
+		open, err := tu.openDependencies(ctx, task.ID.String())
+		if err != nil {
+			return "", err
+		}
+		if open > 0 {
+			return "", fmt.Errorf("%w: %d still open", domain.ErrTaskBlocked, open)
+		}
+	}
+	if next == domain.TaskStatusDone && task.Status != domain.TaskStatusDone {
+		steps, err := tu.countSteps(ctx, []string{task.ID.String()})
+		if err != nil {
+			return "", err
+		}
+		if open := steps[task.ID.String()].OpenRequired; open > 0 {
+			return "", fmt.Errorf("%w: %d still open", domain.ErrOpenSteps, open)
+		}
+	}
+	return next, nil
+}
+
+
+// DeleteTaskById moves a task to the trash, from which it can be restored until it is purged.
+// It takes a context.Context, a taskId string and the version of the task the caller read, zero for any, as parameters.
+// It returns an error if the task deletion fails, ErrVersionMismatch if the task has changed since it was read.
+// The tasks blocked by the deleted task are unblocked if it was their last unresolved dependency.
+func (tu *taskUseCase) DeleteTaskById(c context.Context, taskId string, version int64)error{
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	current, err := tu.taskRepository.FindTaskById(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	if err := tu.checkCallerEditable(ctx, current, domain.PermissionTaskDelete); err != nil {
+		return err
+	}
+	if current.Version, err = expectedVersion(current, version); err != nil {
+		return err
+	}
+	var deletedBy string
+	if user, ok := domain.AuthUserFromContext(ctx); ok {
+		deletedBy = user.ID
+	}
+	deleted, err := tu.taskRepository.DeleteTask(ctx, taskId, current.Version, deletedBy, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	current.Version = deleted.Version
+	if err := tu.recordHistory(ctx, domain.TaskActionDeleted, &current, nil); err != nil {
+		return err
+	}
+	if domain.IsTaskResolved(current.Status) {
+		return nil
+	}
+	return tu.updateDependents(ctx, current, true)
+}
+
+// GetDeletedTasks retrieves one page of the tasks in the trash matching the given query.
+// The query is validated and restricted like the one of GetTasks.
+func (tu *taskUseCase) GetDeletedTasks(c context.Context, query domain.TaskQuery) (domain.TaskPage, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.TaskPage{}, domain.ErrUnauthenticated
+	}
+	query, err := normalizeTaskQuery(query)
+	if err != nil {
+		return domain.TaskPage{}, err
+	}
+	query.Deleted = true
+	if query, err = tu.scopeTaskQuery(ctx, query, user); err != nil {
+		return domain.TaskPage{}, err
+	}
+	return tu.findTasks(ctx, query)
+}
+
+// RestoreTaskById takes a task out of the trash and returns it, at a new version.
+// It returns domain.ErrTaskNotFound if the task is not in the trash, including when it has already been purged.
+// The restore is recorded in the history of the task, and the restored task and the tasks depending on it
+// are blocked again if they have unresolved dependencies.
+func (tu *taskUseCase) RestoreTaskById(c context.Context, taskId string) (domain.Task, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	restored, err := tu.taskRepository.RestoreTask(ctx, taskId)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if err := tu.recordHistory(ctx, domain.TaskActionRestored, nil, &restored); err != nil {
+		return domain.Task{}, err
+	}
+	if restored, err = tu.updateBlocked(ctx, restored, false); err != nil {
+		return domain.Task{}, err
+	}
+	if !domain.IsTaskResolved(restored.Status) {
+		if err := tu.updateDependents(ctx, restored, false); err != nil {
+			return domain.Task{}, err
+		}
+	}
+	return restored, tu.computeFields(ctx, &restored)
+}
+
+// OnPurge registers a hook run with the IDs of the tasks PurgeDeletedTasks deletes, after their comments are deleted
+// and before the tasks are. A failed purge is run again with the same tasks, so the hook must accept tasks
+// it already cleaned up. It is not safe to call while tasks are purged.
+func (tu *taskUseCase) OnPurge(hook PurgeHook) {
+	tu.purgeHooks = append(tu.purgeHooks, hook)
+}
+
+// PurgeDeletedTasks permanently deletes the tasks that have been in the trash for longer than retention,
+// with their comments, their checklists, their dependencies and whatever the OnPurge hooks delete, and returns how many were deleted. Their history is kept.
+// The tasks are marked as being purged first and deleted last, so that nothing of a task is lost while it can be restored
+// and the next purge finishes the tasks of a purge that failed. Their subtasks become top-level tasks.
+func (tu *taskUseCase) PurgeDeletedTasks(c context.Context, retention time.Duration) (int64, error) {
 	ctx, close := context.WithTimeout(c, tu.contextTimeout)
 	defer close()
 
-	return tu.taskRepository.DeleteTask(ctx , taskId)
+	purged, err := tu.taskRepository.StartPurge(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	if len(purged) == 0 {
+		return 0, nil
+	}
+	if err := tu.commentRepository.DeleteTaskComments(ctx, purged); err != nil {
+		return 0, fmt.Errorf("deleting the comments of the purged tasks: %w", err)
+	}
+	if err := tu.checklistRepository.DeleteTaskChecklists(ctx, purged); err != nil {
+		return 0, fmt.Errorf("deleting the checklists of the purged tasks: %w", err)
+	}
+	if err := tu.dependencyRepository.DeleteTaskDependencies(ctx, purged); err != nil {
+		return 0, fmt.Errorf("deleting the dependencies of the purged tasks: %w", err)
+	}
+	for _, hook := range tu.purgeHooks {
+		if err := hook(ctx, purged); err != nil {
+			return 0, fmt.Errorf("cleaning up the purged tasks: %w", err)
+		}
+	}
+	if err := tu.taskRepository.PurgeTasks(ctx, purged); err != nil {
+		return 0, fmt.Errorf("deleting the purged tasks: %w", err)
+	}
+	return int64(len(purged)), nil
+}
+
+// recordHistory appends a change of a task to its history, as made by the authenticated caller, if any, at the current time.
+// A nil before or after stands for a task that does not exist on that side of the change.
+func (tu *taskUseCase) recordHistory(ctx context.Context, action string, before, after *domain.Task) error {
+	entry := domain.TaskHistoryEntry{
+		Action:  action,
+		At:      time.Now().UTC(),
+		Changes: domain.DiffTasks(before, after),
+	}
+	if user, ok := domain.AuthUserFromContext(ctx); ok {
+		entry.ActorID = user.ID
+	}
+	if after != nil {
+		entry.TaskID, entry.Version = after.ID.String(), after.Version
+	} else {
+		entry.TaskID, entry.Version = before.ID.String(), before.Version
+	}
+	if _, err := tu.historyRepository.AppendTaskHistory(ctx, entry); err != nil {
+		return fmt.Errorf("recording the history of task %s: %w", entry.TaskID, err)
+	}
+	return nil
+}
+
+// GetTaskHistory returns one page of the history of a task, oldest changes first.
+// A zero limit falls back to domain.DefaultTaskPageSize.
+// Users with domain.PermissionTaskReadAll can read the history of any task of their organization, including
+// the ones in the trash; other users only the history of the tasks they can see.
+func (tu *taskUseCase) GetTaskHistory(c context.Context, taskId string, query domain.TaskHistoryQuery) (domain.TaskHistoryPage, error) {
+	ctx, close := context.WithTimeout(c, tu.contextTimeout)
+	defer close()
+
+	user, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.TaskHistoryPage{}, domain.ErrUnauthenticated
+	}
+	id, err := domain.ParseID(taskId)
+	if err != nil {
+		return domain.TaskHistoryPage{}, err
+	}
+	task, err := tu.taskRepository.FindTaskById(ctx, taskId)
+	if errors.Is(err, domain.ErrTaskNotFound) && user.Can(domain.PermissionTaskReadAll) {
+		task, err = tu.taskRepository.FindDeletedTaskById(ctx, taskId)
+	}
+	if err != nil {
+		return domain.TaskHistoryPage{}, err
+	}
+	if readable, err := canReadTask(ctx, tu.projectRepository, task, user); err != nil {
+		return domain.TaskHistoryPage{}, err
+	} else if !readable {
+		return domain.TaskHistoryPage{}, domain.ErrTaskNotFound
+	}
+
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultTaskPageSize
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxTaskPageSize {
+		return domain.TaskHistoryPage{}, fmt.Errorf("%w: limit must be between 1 and %d", domain.ErrInvalidTaskQuery, domain.MaxTaskPageSize)
+	}
+	if query.Cursor != "" {
+		if _, err := domain.ParseID(query.Cursor); err != nil {
+			return domain.TaskHistoryPage{}, fmt.Errorf("%w: malformed cursor", domain.ErrInvalidTaskQuery)
+		}
+	}
+	query.TaskID = id.String()
+	return tu.historyRepository.FindTaskHistory(ctx, query)
 }
\ No newline at end of file
@@ -13,13 +13,16 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
-	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type UserUseCaseSuite struct {
 	suite.Suite
-	mockUserRepo *mocks.UserRepository
-	userUseCase  Domain.UserUseCase
+	mockUserRepo         *mocks.UserRepository
+	mockRoleRepo         *mocks.RoleRepository
+	mockTaskRepo         *mocks.TaskRepository
+	mockProjectRepo      *mocks.ProjectRepository
+	mockRefreshTokenRepo *mocks.RefreshTokenRepository
+	userUseCase          Domain.UserUseCase
 }
 
 var userName = "johndoe"
@@ -29,7 +32,14 @@ var userRole = "ADMIN"
 
 func (suite *UserUseCaseSuite) SetupTest() {
 	suite.mockUserRepo = new(mocks.UserRepository)
-	suite.userUseCase = NewUserUsecase(suite.mockUserRepo, time.Second*2)
+	suite.mockRoleRepo = new(mocks.RoleRepository)
+	suite.mockTaskRepo = new(mocks.TaskRepository)
+	suite.mockProjectRepo = new(mocks.ProjectRepository)
+	suite.mockRefreshTokenRepo = new(mocks.RefreshTokenRepository)
+	keys, err := infrastructure.NewEphemeralKeyManager()
+	suite.Require().NoError(err)
+	tokens := infrastructure.NewJWTService(keys, infrastructure.DefaultAccessTokenTTL, infrastructure.DefaultRefreshTokenTTL)
+	suite.userUseCase = NewUserUsecase(suite.mockUserRepo, suite.mockRoleRepo, suite.mockTaskRepo, suite.mockProjectRepo, suite.mockRefreshTokenRepo, tokens, time.Second*2)
 }
 
 // TestAuthenticateUser_Success tests the successful authentication of a user.
@@ -44,7 +54,8 @@ func (suite *UserUseCaseSuite) SetupTest() {
 // It calls the AuthenticateUser method of the user use case with the username and password.
 //
 // It asserts that there is no error returned from the AuthenticateUser method.
-// It asserts that the username of the returned user matches the username of the mock user.
+// It asserts that the username of the returned user matches the username of the mock user
+// and that a refresh token was stored for the new session.
 // It asserts that all expectations of the mock user repository are met.
 func (suite *UserUseCaseSuite) TestAuthenticateUser_Success() {
 	// Arrange
@@ -52,42 +63,48 @@ func (suite *UserUseCaseSuite) TestAuthenticateUser_Success() {
 	assert.Equal(suite.T(), nil, err, "failed to hash password")
 
 	mockUser := Domain.User{
-		ID: primitive.NewObjectID(),
+		ID: Domain.NewID(),
 		Username: userName,
 		Role: userRole, 
 	}
 
 	mockUser.Password = hashedPassword
 	suite.mockUserRepo.On("FindUser", mock.Anything, "johndoe").Return(mockUser, nil)
+	suite.mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token Domain.RefreshToken) bool {
+		return token.UserID == mockUser.ID.String() && token.FamilyID != "" && token.ExpiresAt.After(time.Now())
+	})).Return(Domain.RefreshToken{}, nil)
 
 	
 	isValid := infrastructure.VerifyPassword(password, hashedPassword)
 	assert.Equal(suite.T(), true, isValid)
 
-	user, _, err := suite.userUseCase.AuthenticateUser(context.Background(), "johndoe", password)
+	user, tokens, err := suite.userUseCase.AuthenticateUser(context.Background(), "", "johndoe", password)
 
 	// Assert
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), mockUser.Username, user.Username)
+	assert.NotEmpty(suite.T(), tokens.AccessToken)
+	assert.NotEmpty(suite.T(), tokens.RefreshToken)
 	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
 }
 
 // TestAuthenticateUser_UserNotFound tests the scenario where the user is not found during authentication.
 //
 // It arranges a mock user repository to return an error when trying to find the user with the given username.
 // Then it calls the AuthenticateUser method of the user use case with the username and password.
-// Finally, it asserts that an error occurred and the error message matches the expected "user not found".
+// Finally, it asserts that an unauthorized error occurred and the error message matches the expected "user not found".
 //
 // This test ensures that the user use case handles the case when the user is not found during authentication.
 func (suite *UserUseCaseSuite) TestAuthenticateUser_UserNotFound() {
 	// Arrange
-	suite.mockUserRepo.On("FindUser", mock.Anything, "johndoe").Return(Domain.User{}, errors.New("user not found"))
+	suite.mockUserRepo.On("FindUser", mock.Anything, "johndoe").Return(Domain.User{}, Domain.ErrUserNotFound)
 
 	// Act
-	_, _, err := suite.userUseCase.AuthenticateUser(context.Background(), "johndoe", "password")
+	_, _, err := suite.userUseCase.AuthenticateUser(context.Background(), "", "johndoe", "password")
 
 	// Assert
-	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, Domain.ErrUnauthorized)
 	assert.Equal(suite.T(), "user not found", err.Error())
 	suite.mockUserRepo.AssertExpectations(suite.T())
 }
@@ -99,7 +116,7 @@ func (suite *UserUseCaseSuite) TestAuthenticateUser_UserNotFound() {
 // 2. Sets up the mock user repository to return the mock user when FindUser is called.
 // 3. Verifies the validity of the provided password against the hashed password.
 // 4. Calls the AuthenticateUser method with the username and a wrong password.
-// 5. Asserts that an error occurred and the error message is "wrong password".
+// 5. Asserts that an unauthorized error occurred and the error message is "wrong password".
 // 6. Asserts that all expectations on the mock user repository were met.
 func (suite *UserUseCaseSuite) TestAuthenticateUser_WrongPassword() {
 	// Arrange
@@ -120,10 +137,10 @@ func (suite *UserUseCaseSuite) TestAuthenticateUser_WrongPassword() {
 
 	wrongPassword := "wrongpassword"
 	
-	_, _, err = suite.userUseCase.AuthenticateUser(context.Background(), "johndoe", wrongPassword)
+	_, _, err = suite.userUseCase.AuthenticateUser(context.Background(), "", "johndoe", wrongPassword)
 
 	// Assert
-	assert.Error(suite.T(), err)
+	assert.ErrorIs(suite.T(), err, Domain.ErrUnauthorized)
 	assert.Equal(suite.T(), "wrong password", err.Error())
 	suite.mockUserRepo.AssertExpectations(suite.T())
 }
@@ -131,7 +148,7 @@ func (suite *UserUseCaseSuite) TestAuthenticateUser_WrongPassword() {
 // TestCreateAccount_Success tests the successful creation of a user account.
 //
 // It arranges a mock user with the given username, password, and role.
-// Then it mocks the CreateNewUser method of the user repository to return the mock user and no error.
+// Then it mocks the CreateOrganization method of the user repository to return the mock user and no error.
 //
 // The test acts by calling the CreateAccount method of the user use case with the mock user.
 // It expects no error to occur during the account creation.
@@ -146,7 +163,7 @@ func (suite *UserUseCaseSuite) TestCreateAccount_Success() {
 		Role: userRole, 
 	}
 
-	suite.mockUserRepo.On("CreateNewUser", mock.Anything, mockUser).Return(*mockUser, nil)	
+	suite.mockUserRepo.On("CreateOrganization", mock.Anything, mockUser).Return(*mockUser, nil)	
 
 	// Act
 	user, err := suite.userUseCase.CreateAccount(context.Background(), mockUser)
@@ -158,22 +175,114 @@ func (suite *UserUseCaseSuite) TestCreateAccount_Success() {
 	suite.mockUserRepo.AssertExpectations(suite.T())
 }
 
+// TestCreateAccount_Organization tests that an account is created in the organization it names, normalized,
+// and that an invalid organization is rejected before anything is stored.
+func (suite *UserUseCaseSuite) TestCreateAccount_Organization() {
+	inSales := mock.MatchedBy(func(ctx context.Context) bool {
+		org, ok := Domain.OrganizationFromContext(ctx)
+		return ok && org == "sales"
+	})
+	suite.mockUserRepo.On("CreateOrganization", inSales, mock.Anything).Return(func(ctx context.Context, user *Domain.User) (Domain.User, error) {
+		return *user, nil
+	})
+
+	user, err := suite.userUseCase.CreateAccount(context.Background(), &Domain.User{OrgID: " Sales ", Username: userName, Password: password})
+	suite.Require().NoError(err)
+	suite.Equal("sales", user.OrgID)
+
+	_, err = suite.userUseCase.CreateAccount(context.Background(), &Domain.User{OrgID: "sales/legal", Username: userName, Password: password})
+	suite.ErrorIs(err, Domain.ErrValidation)
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "CreateOrganization", 1)
+}
+
+// TestCreateUser tests that an account created by an admin is a regular user of the organization of the caller,
+// whatever organization and role it names, and that its password is hashed.
+func (suite *UserUseCaseSuite) TestCreateUser() {
+	suite.mockUserRepo.On("CreateNewUser", mock.Anything, mock.Anything).Return(func(ctx context.Context, user *Domain.User) (Domain.User, error) {
+		user.Role = Domain.RoleUser
+		return *user, nil
+	})
+
+	user, err := suite.userUseCase.CreateUser(asCaller(Domain.RoleAdmin), &Domain.User{OrgID: "legal", Username: "bob", Password: password, Role: Domain.RoleAdmin})
+	suite.Require().NoError(err)
+	suite.Equal("sales", user.OrgID)
+	suite.Equal(Domain.RoleUser, user.Role)
+	suite.True(infrastructure.VerifyPassword(password, user.Password))
+
+	_, err = suite.userUseCase.CreateUser(context.Background(), &Domain.User{Username: "bob", Password: password})
+	suite.ErrorIs(err, Domain.ErrUnauthenticated)
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "CreateNewUser", 1)
+}
+
+// TestAuthenticateUser_Organization tests that a user is looked up in the organization given at login,
+// and that the access token carries the organization of the user.
+func (suite *UserUseCaseSuite) TestAuthenticateUser_Organization() {
+	hashedPassword, err := infrastructure.HashPassword(password)
+	suite.Require().NoError(err)
+	inLegal := mock.MatchedBy(func(ctx context.Context) bool {
+		org, ok := Domain.OrganizationFromContext(ctx)
+		return ok && org == "legal"
+	})
+	mockUser := Domain.User{ID: Domain.NewID(), OrgID: "legal", Username: userName, Password: hashedPassword, Role: Domain.RoleUser}
+	suite.mockUserRepo.On("FindUser", inLegal, userName).Return(mockUser, nil)
+	suite.mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(Domain.RefreshToken{}, nil)
+
+	_, tokens, err := suite.userUseCase.AuthenticateUser(context.Background(), "legal", userName, password)
+	suite.Require().NoError(err)
+	claims, err := suite.userUseCase.(*userUseCase).tokenService.ParseToken(tokens.AccessToken)
+	suite.Require().NoError(err)
+	suite.Equal("legal", claims.OrgID)
+	suite.Equal([]string{}, claims.Permissions)
+	suite.mockUserRepo.AssertExpectations(suite.T())
+}
+
+// TestAuthenticateUser_RolePermissions tests that the access token grants the permissions of the role of the user,
+// looked up in its organization, and none for a role that no longer exists.
+func (suite *UserUseCaseSuite) TestAuthenticateUser_RolePermissions() {
+	hashedPassword, err := infrastructure.HashPassword(password)
+	suite.Require().NoError(err)
+	inLegal := mock.MatchedBy(func(ctx context.Context) bool {
+		org, ok := Domain.OrganizationFromContext(ctx)
+		return ok && org == "legal"
+	})
+	triage := Domain.User{ID: Domain.NewID(), OrgID: "legal", Username: "triage", Password: hashedPassword, Role: "TRIAGE"}
+	gone := Domain.User{ID: Domain.NewID(), OrgID: "legal", Username: "gone", Password: hashedPassword, Role: "GONE"}
+	suite.mockUserRepo.On("FindUser", inLegal, "triage").Return(triage, nil)
+	suite.mockUserRepo.On("FindUser", inLegal, "gone").Return(gone, nil)
+	suite.mockRoleRepo.On("FindRoleByName", inLegal, "TRIAGE").Return(Domain.Role{Name: "TRIAGE", OrgID: "legal", Permissions: []string{Domain.PermissionTaskReadAll}}, nil)
+	suite.mockRoleRepo.On("FindRoleByName", inLegal, "GONE").Return(Domain.Role{}, Domain.ErrRoleNotFound)
+	suite.mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(Domain.RefreshToken{}, nil)
+
+	for name, expected := range map[string][]string{"triage": {Domain.PermissionTaskReadAll}, "gone": {}} {
+		_, tokens, err := suite.userUseCase.AuthenticateUser(context.Background(), "legal", name, password)
+		suite.Require().NoError(err)
+		claims, err := suite.userUseCase.(*userUseCase).tokenService.ParseToken(tokens.AccessToken)
+		suite.Require().NoError(err)
+		suite.Equal(expected, claims.Permissions, name)
+	}
+	suite.mockRoleRepo.AssertExpectations(suite.T())
+}
+
 // TestUpdateUserRole_Success tests the successful update of a user's role.
 //
 // It sets up a dummy user ID and mocks the PromoteUser method of the user repository to return nil.
 // Then, it calls the UpdateUserRole method of the user use case with the dummy user ID.
-// Finally, it asserts that no error occurred during the update and verifies that all expectations on the mocked user repository were met.
+// Finally, it asserts that no error occurred during the update, that the sessions of the user were revoked
+// and verifies that all expectations on the mocked user repository were met.
 func (suite *UserUseCaseSuite) TestUpdateUserRole_Success() {
 	// Arrange
 	userId := "dummyId"
+	suite.mockUserRepo.On("FindUserById", mock.Anything, userId).Return(Domain.User{Role: Domain.RoleUser}, nil)
 	suite.mockUserRepo.On("PromoteUser", mock.Anything, userId).Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeUserTokens", mock.Anything, userId, mock.Anything).Return(nil)
 
 	// Act
-	err := suite.userUseCase.UpdateUserRole(context.Background(), userId)
+	err := suite.userUseCase.UpdateUserRole(asCaller(Domain.RoleAdmin), userId)
 
 	// Assert
 	assert.NoError(suite.T(), err)
 	suite.mockUserRepo.AssertExpectations(suite.T())
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
 }
 
 // TestUpdateUserRole_Failure tests the failure scenario of updating a user's role.
@@ -186,17 +295,339 @@ func (suite *UserUseCaseSuite) TestUpdateUserRole_Success() {
 func (suite *UserUseCaseSuite) TestUpdateUserRole_Failure() {
 	// Arrange
 	userId := "some-id"
+	suite.mockUserRepo.On("FindUserById", mock.Anything, userId).Return(Domain.User{Role: Domain.RoleUser}, nil)
 	suite.mockUserRepo.On("PromoteUser", mock.Anything, userId).Return(errors.New("some error"))
 
 	// Act
-	err := suite.userUseCase.UpdateUserRole(context.Background(), userId)
+	err := suite.userUseCase.UpdateUserRole(asCaller(Domain.RoleAdmin), userId)
 
 	// Assert
 	assert.Error(suite.T(), err)
 	suite.mockUserRepo.AssertExpectations(suite.T())
 }
 
+// TestUpdateUserRole_NotGrantable tests that only a caller having every permission can promote a user to admin.
+func (suite *UserUseCaseSuite) TestUpdateUserRole_NotGrantable() {
+	err := suite.userUseCase.UpdateUserRole(asCaller("PROMOTER", Domain.PermissionUserPromote), "some-id")
+
+	suite.ErrorIs(err, Domain.ErrPermissionNotHeld)
+	suite.ErrorIs(err, Domain.ErrForbidden)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "PromoteUser", mock.Anything, mock.Anything)
+}
+
+// TestAssignUserRole tests that a role of the organization is given to a user, whose sessions are revoked,
+// that unknown roles are rejected, and that the caller can neither grant nor take away permissions it does not have.
+func (suite *UserUseCaseSuite) TestAssignUserRole() {
+	target := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "bob", Role: Domain.RoleUser}
+	suite.mockUserRepo.On("FindUserById", mock.Anything, target.ID.String()).Return(target, nil)
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "TRIAGE").Return(Domain.Role{Name: "TRIAGE", Permissions: []string{Domain.PermissionTaskReadAll}}, nil)
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "ROOT").Return(Domain.Role{}, Domain.ErrRoleNotFound)
+	suite.mockUserRepo.On("SetUserRole", mock.Anything, target.ID.String(), "TRIAGE").Return(nil)
+	suite.mockUserRepo.On("SetUserRole", mock.Anything, target.ID.String(), Domain.RoleUser).Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeUserTokens", mock.Anything, target.ID.String(), mock.Anything).Return(nil)
+
+	promoter := asCaller("PROMOTER", Domain.PermissionUserPromote, Domain.PermissionTaskReadAll)
+	user, err := suite.userUseCase.AssignUserRole(promoter, target.ID.String(), " triage")
+	suite.Require().NoError(err)
+	suite.Equal("TRIAGE", user.Role)
+	suite.Equal(target.ID, user.ID)
+	suite.mockRefreshTokenRepo.AssertNumberOfCalls(suite.T(), "RevokeUserTokens", 1)
+	_, err = suite.userUseCase.AssignUserRole(promoter, target.ID.String(), Domain.RoleUser)
+	suite.Require().NoError(err)
+	suite.mockRefreshTokenRepo.AssertNumberOfCalls(suite.T(), "RevokeUserTokens", 1)
+
+	_, err = suite.userUseCase.AssignUserRole(promoter, target.ID.String(), Domain.RoleAdmin)
+	suite.ErrorIs(err, Domain.ErrPermissionNotHeld, "a caller cannot grant more than it has")
+	_, err = suite.userUseCase.AssignUserRole(promoter, target.ID.String(), "root")
+	suite.ErrorIs(err, Domain.ErrValidation)
+
+	admin := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "alice", Role: Domain.RoleAdmin}
+	suite.mockUserRepo.On("FindUserById", mock.Anything, admin.ID.String()).Return(admin, nil)
+	_, err = suite.userUseCase.AssignUserRole(promoter, admin.ID.String(), Domain.RoleUser)
+	suite.ErrorIs(err, Domain.ErrPermissionNotHeld, "a caller cannot demote a user having more than it has")
+
+	suite.mockUserRepo.On("SetUserRole", mock.Anything, admin.ID.String(), Domain.RoleUser).Return(Domain.ErrLastAdmin)
+	_, err = suite.userUseCase.AssignUserRole(asCaller(Domain.RoleAdmin), admin.ID.String(), Domain.RoleUser)
+	suite.ErrorIs(err, Domain.ErrLastAdmin)
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "SetUserRole", 3)
+}
+
+// TestAuthenticateUser_Deactivated tests that a deactivated user cannot log in, even with the right password,
+// and that its sessions cannot be refreshed.
+func (suite *UserUseCaseSuite) TestAuthenticateUser_Deactivated() {
+	hashedPassword, err := infrastructure.HashPassword(password)
+	suite.Require().NoError(err)
+	deactivatedAt := time.Now()
+	user := Domain.User{ID: Domain.NewID(), Username: userName, Password: hashedPassword, Role: Domain.RoleUser, DeactivatedAt: &deactivatedAt}
+	suite.mockUserRepo.On("FindUser", mock.Anything, userName).Return(user, nil)
+
+	_, _, err = suite.userUseCase.AuthenticateUser(context.Background(), "", userName, "wrongpassword")
+	suite.Equal("wrong password", err.Error(), "the state of the account is only told to who knows the password")
+	_, _, err = suite.userUseCase.AuthenticateUser(context.Background(), "", userName, password)
+	suite.ErrorIs(err, Domain.ErrUserDeactivated)
+
+	raw, stored := storedRefreshToken(user.ID.String())
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, stored.TokenHash).Return(stored, nil)
+	suite.mockRefreshTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, stored.ID.String(), mock.Anything).Return(nil)
+	suite.mockUserRepo.On("FindUserById", mock.MatchedBy(Domain.IsWithoutTenant), user.ID.String()).Return(user, nil)
+	_, err = suite.userUseCase.RefreshSession(context.Background(), raw)
+	suite.ErrorIs(err, Domain.ErrUserDeactivated)
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+// TestAssignUserRole_Deactivated tests that deactivated users cannot be given a role.
+func (suite *UserUseCaseSuite) TestAssignUserRole_Deactivated() {
+	deactivatedAt := time.Now()
+	user := Domain.User{ID: Domain.NewID(), OrgID: "sales", Role: Domain.RoleUser, DeactivatedAt: &deactivatedAt}
+	suite.mockUserRepo.On("FindUserById", mock.Anything, user.ID.String()).Return(user, nil)
+
+	_, err := suite.userUseCase.AssignUserRole(asCaller(Domain.RoleAdmin), user.ID.String(), Domain.RoleAdmin)
+	suite.ErrorIs(err, Domain.ErrUserDeactivated)
+	suite.ErrorIs(suite.userUseCase.UpdateUserRole(asCaller(Domain.RoleAdmin), user.ID.String()), Domain.ErrUserDeactivated)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "SetUserRole", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "PromoteUser", mock.Anything, mock.Anything)
+}
+
+// asCaller returns a context authenticated as a user of the sales organization with the given role,
+// having the permissions of the built-in role of that name, or else the given ones.
+func asCaller(role string, permissions ...string) context.Context {
+	if builtin, ok := Domain.BuiltinRole(role); ok {
+		permissions = builtin.Permissions
+	}
+	return Domain.ContextWithAuthUser(context.Background(), Domain.AuthUser{ID: Domain.NewID().String(), Role: role, OrgID: "sales", Permissions: permissions})
+}
+
+
+// storedRefreshToken returns a raw refresh token together with its stored record for the given user.
+func storedRefreshToken(userId string) (string, Domain.RefreshToken) {
+	raw, hash, _ := infrastructure.GenerateRefreshToken()
+	return raw, Domain.RefreshToken{
+		ID:        Domain.NewID(),
+		UserID:    userId,
+		FamilyID:  "family-1",
+		TokenHash: hash,
+		CreatedAt: time.Now(),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+// TestRefreshSession_Success tests that a valid refresh token is marked used and exchanged
+// for a new token pair belonging to the same session.
+func (suite *UserUseCaseSuite) TestRefreshSession_Success() {
+	user := Domain.User{ID: Domain.NewID(), Username: userName, Role: userRole}
+	raw, stored := storedRefreshToken(user.ID.String())
+
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, stored.TokenHash).Return(stored, nil)
+	suite.mockRefreshTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, stored.ID.String(), mock.Anything).Return(nil)
+	suite.mockUserRepo.On("FindUserById", mock.Anything, user.ID.String()).Return(user, nil)
+	suite.mockRefreshTokenRepo.On("CreateRefreshToken", mock.Anything, mock.MatchedBy(func(token Domain.RefreshToken) bool {
+		return token.FamilyID == stored.FamilyID && token.TokenHash != stored.TokenHash
+	})).Return(Domain.RefreshToken{}, nil)
+
+	tokens, err := suite.userUseCase.RefreshSession(context.Background(), raw)
+
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), tokens.AccessToken)
+	assert.NotEqual(suite.T(), raw, tokens.RefreshToken)
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+	suite.mockUserRepo.AssertExpectations(suite.T())
+}
+
+// TestRefreshSession_Reuse tests that presenting an already used refresh token revokes its whole session.
+func (suite *UserUseCaseSuite) TestRefreshSession_Reuse() {
+	raw, stored := storedRefreshToken(Domain.NewID().String())
+	usedAt := time.Now().Add(-time.Minute)
+	stored.UsedAt = &usedAt
 
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, stored.TokenHash).Return(stored, nil)
+	suite.mockRefreshTokenRepo.On("RevokeTokenFamily", mock.Anything, stored.FamilyID, mock.Anything).Return(nil)
+
+	_, err := suite.userUseCase.RefreshSession(context.Background(), raw)
+
+	assert.ErrorIs(suite.T(), err, Domain.ErrRefreshTokenReused)
+	assert.ErrorIs(suite.T(), err, Domain.ErrUnauthorized)
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "CreateRefreshToken", mock.Anything, mock.Anything)
+}
+
+// TestRefreshSession_ConcurrentReuse tests that losing the race to mark a token used is treated as reuse.
+func (suite *UserUseCaseSuite) TestRefreshSession_ConcurrentReuse() {
+	raw, stored := storedRefreshToken(Domain.NewID().String())
+
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, stored.TokenHash).Return(stored, nil)
+	suite.mockRefreshTokenRepo.On("MarkRefreshTokenUsed", mock.Anything, stored.ID.String(), mock.Anything).Return(Domain.ErrRefreshTokenReused)
+	suite.mockRefreshTokenRepo.On("RevokeTokenFamily", mock.Anything, stored.FamilyID, mock.Anything).Return(nil)
+
+	_, err := suite.userUseCase.RefreshSession(context.Background(), raw)
+
+	assert.ErrorIs(suite.T(), err, Domain.ErrRefreshTokenReused)
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+}
+
+// TestRefreshSession_Expired tests that expired and revoked refresh tokens are rejected.
+func (suite *UserUseCaseSuite) TestRefreshSession_Expired() {
+	expiredRaw, expired := storedRefreshToken(Domain.NewID().String())
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	revokedRaw, revoked := storedRefreshToken(Domain.NewID().String())
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, expired.TokenHash).Return(expired, nil)
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, revoked.TokenHash).Return(revoked, nil)
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, infrastructure.HashRefreshToken("unknown")).Return(Domain.RefreshToken{}, Domain.ErrRefreshTokenNotFound)
+
+	_, err := suite.userUseCase.RefreshSession(context.Background(), expiredRaw)
+	assert.ErrorIs(suite.T(), err, Domain.ErrRefreshTokenExpired)
+
+	_, err = suite.userUseCase.RefreshSession(context.Background(), revokedRaw)
+	assert.ErrorIs(suite.T(), err, Domain.ErrSessionRevoked)
+
+	_, err = suite.userUseCase.RefreshSession(context.Background(), "unknown")
+	assert.ErrorIs(suite.T(), err, Domain.ErrInvalidRefreshToken)
+
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "MarkRefreshTokenUsed", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestLogout_Success tests that logging out revokes the session of the refresh token.
+func (suite *UserUseCaseSuite) TestLogout_Success() {
+	raw, stored := storedRefreshToken(Domain.NewID().String())
+
+	suite.mockRefreshTokenRepo.On("FindRefreshToken", mock.Anything, stored.TokenHash).Return(stored, nil)
+	suite.mockRefreshTokenRepo.On("RevokeTokenFamily", mock.Anything, stored.FamilyID, mock.Anything).Return(nil)
+
+	err := suite.userUseCase.Logout(context.Background(), raw)
+
+	assert.NoError(suite.T(), err)
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+}
+
+// TestGetUsers tests that the query is normalized before it reaches the repository and that invalid ones are rejected.
+func (suite *UserUseCaseSuite) TestGetUsers() {
+	page := Domain.UserPage{Users: []Domain.User{{ID: Domain.NewID(), Username: "bob"}}}
+	suite.mockUserRepo.On("FindUsers", mock.Anything, Domain.UserQuery{Search: "b", Role: "TRIAGE", Limit: Domain.DefaultUserPageSize}).Return(page, nil)
+
+	users, err := suite.userUseCase.GetUsers(asCaller(Domain.RoleAdmin), Domain.UserQuery{Search: "b", Role: "triage"})
+	suite.Require().NoError(err)
+	suite.Equal(page, users)
+
+	for _, query := range []Domain.UserQuery{{Limit: -1}, {Limit: Domain.MaxUserPageSize + 1}, {Cursor: "nope"}, {Role: "no role"}} {
+		_, err := suite.userUseCase.GetUsers(asCaller(Domain.RoleAdmin), query)
+		suite.ErrorIs(err, Domain.ErrValidation, query)
+	}
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "FindUsers", 1)
+}
+
+// TestDeactivateUser tests that a deactivated user has its sessions revoked and that admins, users having permissions
+// the caller does not have and the caller itself cannot be deactivated.
+func (suite *UserUseCaseSuite) TestDeactivateUser() {
+	user := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "bob", Role: Domain.RoleUser}
+	admin := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "alice", Role: Domain.RoleAdmin}
+	triager := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "carol", Role: "TRIAGE"}
+	for _, u := range []Domain.User{user, admin, triager} {
+		suite.mockUserRepo.On("FindUserById", mock.Anything, u.ID.String()).Return(u, nil)
+	}
+	suite.mockRoleRepo.On("FindRoleByName", mock.Anything, "TRIAGE").Return(Domain.Role{Name: "TRIAGE", Permissions: []string{Domain.PermissionTaskReadAll}}, nil)
+	suite.mockUserRepo.On("SetUserDeactivated", mock.Anything, user.ID.String(), mock.AnythingOfType("*time.Time")).Return(nil)
+	suite.mockRefreshTokenRepo.On("RevokeUserTokens", mock.Anything, user.ID.String(), mock.Anything).Return(nil)
+
+	manager := asCaller("MANAGER", Domain.PermissionUserManage)
+	deactivated, err := suite.userUseCase.DeactivateUser(manager, user.ID.String())
+	suite.Require().NoError(err)
+	suite.NotNil(deactivated.DeactivatedAt)
+	suite.mockRefreshTokenRepo.AssertExpectations(suite.T())
+
+	_, err = suite.userUseCase.DeactivateUser(asCaller(Domain.RoleAdmin), admin.ID.String())
+	suite.ErrorIs(err, Domain.ErrUserIsAdmin)
+	_, err = suite.userUseCase.DeactivateUser(manager, triager.ID.String())
+	suite.ErrorIs(err, Domain.ErrPermissionNotHeld)
+	self := Domain.ContextWithAuthUser(context.Background(), Domain.AuthUser{ID: user.ID.String(), OrgID: "sales", Permissions: []string{Domain.PermissionUserManage}})
+	_, err = suite.userUseCase.DeactivateUser(self, user.ID.String())
+	suite.ErrorIs(err, Domain.ErrValidation)
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "SetUserDeactivated", 1)
+}
+
+// TestActivateUser tests that a deactivated user is activated again and that activating an active user changes nothing.
+func (suite *UserUseCaseSuite) TestActivateUser() {
+	deactivatedAt := time.Now()
+	deactivated := Domain.User{ID: Domain.NewID(), OrgID: "sales", Role: Domain.RoleUser, DeactivatedAt: &deactivatedAt}
+	active := Domain.User{ID: Domain.NewID(), OrgID: "sales", Role: Domain.RoleUser}
+	suite.mockUserRepo.On("FindUserById", mock.Anything, deactivated.ID.String()).Return(deactivated, nil)
+	suite.mockUserRepo.On("FindUserById", mock.Anything, active.ID.String()).Return(active, nil)
+	suite.mockUserRepo.On("SetUserDeactivated", mock.Anything, deactivated.ID.String(), (*time.Time)(nil)).Return(nil)
+
+	user, err := suite.userUseCase.ActivateUser(asCaller(Domain.RoleAdmin), deactivated.ID.String())
+	suite.Require().NoError(err)
+	suite.Nil(user.DeactivatedAt)
+	_, err = suite.userUseCase.ActivateUser(asCaller(Domain.RoleAdmin), active.ID.String())
+	suite.Require().NoError(err)
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "SetUserDeactivated", 1)
+}
+
+// TestDeleteUser tests that a deleted user is replaced on its tasks by the caller or the given user,
+// leaves its projects and has its sessions revoked, and that its tasks cannot go to itself or a deactivated user.
+func (suite *UserUseCaseSuite) TestDeleteUser() {
+	caller := Domain.AuthUser{ID: Domain.NewID().String(), OrgID: "sales", Permissions: []string{Domain.PermissionUserManage}}
+	ctx := Domain.ContextWithAuthUser(context.Background(), caller)
+	deactivatedAt := time.Now()
+	user := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "bob", Role: Domain.RoleUser}
+	heir := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "carol", Role: Domain.RoleUser}
+	gone := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "dave", Role: Domain.RoleUser, DeactivatedAt: &deactivatedAt}
+	for _, u := range []Domain.User{user, heir, gone, {ID: Domain.ID(caller.ID), OrgID: "sales", Role: "MANAGER"}} {
+		suite.mockUserRepo.On("FindUserById", mock.Anything, u.ID.String()).Return(u, nil)
+	}
+	project := Domain.Project{ID: Domain.NewID()}
+	suite.mockRefreshTokenRepo.On("RevokeUserTokens", mock.Anything, user.ID.String(), mock.Anything).Return(nil)
+	suite.mockTaskRepo.On("ReassignTasks", mock.Anything, user.ID.String(), caller.ID).Return(int64(2), nil).Once()
+	suite.mockTaskRepo.On("ReassignTasks", mock.Anything, user.ID.String(), heir.ID.String()).Return(int64(0), nil).Once()
+	suite.mockProjectRepo.On("FindMemberProjects", mock.Anything, user.ID.String()).Return([]Domain.Project{project}, nil)
+	suite.mockProjectRepo.On("FindMember", mock.Anything, project.ID.String(), user.ID.String()).Return(Domain.ProjectMember{ProjectID: project.ID.String(), UserID: user.ID.String(), Role: Domain.ProjectRoleEditor}, nil)
+	suite.mockProjectRepo.On("RemoveMember", mock.Anything, project.ID.String(), user.ID.String()).Return(nil)
+	suite.mockUserRepo.On("DeleteUser", mock.Anything, user.ID.String()).Return(nil)
+
+	suite.Require().NoError(suite.userUseCase.DeleteUser(ctx, user.ID.String(), ""))
+	suite.Require().NoError(suite.userUseCase.DeleteUser(ctx, user.ID.String(), heir.ID.String()))
+	suite.mockTaskRepo.AssertExpectations(suite.T())
+	suite.mockProjectRepo.AssertExpectations(suite.T())
+
+	suite.ErrorIs(suite.userUseCase.DeleteUser(ctx, user.ID.String(), user.ID.String()), Domain.ErrValidation)
+	suite.ErrorIs(suite.userUseCase.DeleteUser(ctx, user.ID.String(), gone.ID.String()), Domain.ErrValidation)
+	suite.mockUserRepo.On("FindUserById", mock.Anything, "unknown").Return(Domain.User{}, Domain.ErrUserNotFound)
+	suite.ErrorIs(suite.userUseCase.DeleteUser(ctx, user.ID.String(), "unknown"), Domain.ErrValidation)
+	suite.mockUserRepo.AssertNumberOfCalls(suite.T(), "DeleteUser", 2)
+}
+
+// TestDeleteUser_LastOwner tests that the last owner of a project cannot be deleted, and that nothing is changed
+// when the deletion is refused, while a project with another owner does not stop it.
+func (suite *UserUseCaseSuite) TestDeleteUser_LastOwner() {
+	ctx := asCaller("MANAGER", Domain.PermissionUserManage)
+	user := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "bob", Role: Domain.RoleUser}
+	heir := Domain.User{ID: Domain.NewID(), OrgID: "sales", Username: "carol", Role: Domain.RoleUser}
+	for _, u := range []Domain.User{user, heir} {
+		suite.mockUserRepo.On("FindUserById", mock.Anything, u.ID.String()).Return(u, nil)
+	}
+	shared, owned := Domain.Project{ID: Domain.NewID(), Name: "website"}, Domain.Project{ID: Domain.NewID(), Name: "mobile"}
+	suite.mockProjectRepo.On("FindMemberProjects", mock.Anything, user.ID.String()).Return([]Domain.Project{shared, owned}, nil)
+	for _, project := range []Domain.Project{shared, owned} {
+		owner := Domain.ProjectMember{ProjectID: project.ID.String(), UserID: user.ID.String(), Role: Domain.ProjectRoleOwner}
+		suite.mockProjectRepo.On("FindMember", mock.Anything, project.ID.String(), user.ID.String()).Return(owner, nil)
+	}
+	suite.mockProjectRepo.On("FindMembers", mock.Anything, shared.ID.String()).Return([]Domain.ProjectMember{
+		{ProjectID: shared.ID.String(), UserID: user.ID.String(), Role: Domain.ProjectRoleOwner},
+		{ProjectID: shared.ID.String(), UserID: heir.ID.String(), Role: Domain.ProjectRoleOwner},
+	}, nil)
+	suite.mockProjectRepo.On("FindMembers", mock.Anything, owned.ID.String()).Return([]Domain.ProjectMember{
+		{ProjectID: owned.ID.String(), UserID: user.ID.String(), Role: Domain.ProjectRoleOwner},
+		{ProjectID: owned.ID.String(), UserID: heir.ID.String(), Role: Domain.ProjectRoleViewer},
+	}, nil)
+
+	err := suite.userUseCase.DeleteUser(ctx, user.ID.String(), heir.ID.String())
+	suite.ErrorIs(err, Domain.ErrLastOwner)
+	suite.Contains(err.Error(), "mobile")
+	suite.mockRefreshTokenRepo.AssertNotCalled(suite.T(), "RevokeUserTokens", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockTaskRepo.AssertNotCalled(suite.T(), "ReassignTasks", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockProjectRepo.AssertNotCalled(suite.T(), "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	suite.mockUserRepo.AssertNotCalled(suite.T(), "DeleteUser", mock.Anything, mock.Anything)
+}
 
 func TestUserUseCaseSuite(t *testing.T) {
 	suite.Run(t, new(UserUseCaseSuite))
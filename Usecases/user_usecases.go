@@ -3,66 +3,210 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	domain "example/go-clean-architecture/Domain"
 	infrastructure "example/go-clean-architecture/Infrastructure"
+
 )
 
 // userUseCase represents the use case for managing user entities.
 type userUseCase struct {
-	userRepository domain.UserRepository
-	contextTimeout time.Duration
+	userRepository         domain.UserRepository
+	roleRepository         domain.RoleRepository
+	taskRepository         domain.TaskRepository
+	projectRepository      domain.ProjectRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	tokenService           *infrastructure.JWTService
+	contextTimeout         time.Duration
 }
 
 var _ domain.UserUseCase = &userUseCase{}
 
 // NewUserUsecase creates a new instance of the UserUseCase interface.
-// It takes a userRepository of type domain.UserRepository and a timeout of type time.Duration as parameters.
+// It takes a userRepository of type domain.UserRepository, the roleRepository the permissions of the roles are read from,
+// the taskRepository and projectRepository a deleted user is removed from, the refreshTokenRepository storing the sessions,
+// the tokenService issuing access tokens and a timeout of type time.Duration as parameters.
 // It returns a pointer to a userUseCase struct that implements the UserUseCase interface.
-func NewUserUsecase(userRepository domain.UserRepository, timeout time.Duration) domain.UserUseCase {
+func NewUserUsecase(userRepository domain.UserRepository, roleRepository domain.RoleRepository, taskRepository domain.TaskRepository, projectRepository domain.ProjectRepository, refreshTokenRepository domain.RefreshTokenRepository, tokenService *infrastructure.JWTService, timeout time.Duration) domain.UserUseCase {
 	return &userUseCase{
-		userRepository: userRepository,
-		contextTimeout: timeout,
+		userRepository:         userRepository,
+		roleRepository:         roleRepository,
+		taskRepository:         taskRepository,
+		projectRepository:      projectRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		tokenService:           tokenService,
+		contextTimeout:         timeout,
 	}
 }
 
 // AuthenticateUser authenticates a user by verifying their username and password.
-// It takes a context.Context, userName string, and password string as input parameters.
-// It returns a domain.User, a token string, and an error.
+// It takes a context.Context, the organization of the user, userName string, and password string as input parameters.
+// A blank organization is the default one.
+// It returns a domain.User, a domain.TokenPair, and an error.
 // The domain.User represents the authenticated user.
-// The token string is a generated token for the authenticated user.
-// The error is returned if there is an issue with the authentication process.
-func (ur *userUseCase) AuthenticateUser(c context.Context, userName string, password string) (domain.User, string, error) {
+// The domain.TokenPair holds the access token and the refresh token of the new session.
+// The error is returned if there is an issue with the authentication process, and is domain.ErrUserDeactivated
+// if the password is right but the user is deactivated.
+func (ur *userUseCase) AuthenticateUser(c context.Context, orgId string, userName string, password string) (domain.User, domain.TokenPair, error) {
 	ctx, close := context.WithTimeout(c, ur.contextTimeout)
 	defer close()
-	
-	user, err := ur.userRepository.FindUser(ctx, userName)
+
+	orgId, err := domain.NormalizeOrganizationID(orgId)
+	if err != nil {
+		return domain.User{}, domain.TokenPair{}, err
+	}
+	user, err := ur.userRepository.FindUser(domain.ContextWithOrganization(ctx, orgId), userName)
 	if err != nil{
-		return domain.User{}, "", errors.New("user not found")
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.User{}, domain.TokenPair{}, domain.NewError(domain.ErrUnauthorized, "user not found")
+		}
+		return domain.User{}, domain.TokenPair{}, err
 	}
 
 	//verify the password
 	isValidPassword := infrastructure.VerifyPassword(password, user.Password)
 	if !isValidPassword {
-		return domain.User{}, "", errors.New("wrong password")
+		return domain.User{}, domain.TokenPair{}, domain.NewError(domain.ErrUnauthorized, "wrong password")
+	}
+	if user.DeactivatedAt != nil {
+		return domain.User{}, domain.TokenPair{}, domain.ErrUserDeactivated
+	}
+	//start a new session
+	tokens, err := ur.issueTokens(ctx, user, domain.NewID().String())
+	if err != nil {
+		return domain.User{}, domain.TokenPair{}, err
+	}
+	return user, tokens, nil
+}
+
+// RefreshSession exchanges a refresh token for a new token pair of the same session.
+// Every refresh token can be used once. Presenting a token that was already used means it leaked,
+// so the whole session is revoked and the caller has to log in again.
+// The sessions of a deactivated user are revoked, and refreshing one that was not yet returns domain.ErrUserDeactivated.
+func (ur *userUseCase) RefreshSession(c context.Context, refreshToken string) (domain.TokenPair, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	token, err := ur.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	now := time.Now()
+	if token.RevokedAt != nil {
+		return domain.TokenPair{}, domain.ErrSessionRevoked
+	}
+	if token.UsedAt != nil {
+		return domain.TokenPair{}, ur.revokeReusedSession(ctx, token)
+	}
+	if now.After(token.ExpiresAt) {
+		return domain.TokenPair{}, domain.ErrRefreshTokenExpired
+	}
+	if err := ur.refreshTokenRepository.MarkRefreshTokenUsed(ctx, token.ID.String(), now); err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return domain.TokenPair{}, ur.revokeReusedSession(ctx, token)
+		}
+		return domain.TokenPair{}, err
+	}
+
+	// the request is not authenticated, so the user is looked up in every organization
+	user, err := ur.userRepository.FindUserById(domain.ContextWithoutTenant(ctx), token.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.TokenPair{}, domain.ErrInvalidRefreshToken
+		}
+		return domain.TokenPair{}, err
+	}
+	if user.DeactivatedAt != nil {
+		return domain.TokenPair{}, domain.ErrUserDeactivated
+	}
+	return ur.issueTokens(ctx, user, token.FamilyID)
+}
+
+// Logout revokes the session the refresh token belongs to.
+// Access tokens of the session are rejected from then on, even before they expire.
+func (ur *userUseCase) Logout(c context.Context, refreshToken string) error {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	token, err := ur.findRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+	return ur.refreshTokenRepository.RevokeTokenFamily(ctx, token.FamilyID, time.Now())
+}
+
+// findRefreshToken looks up the stored refresh token matching the raw token of a client.
+func (ur *userUseCase) findRefreshToken(ctx context.Context, refreshToken string) (domain.RefreshToken, error) {
+	token, err := ur.refreshTokenRepository.FindRefreshToken(ctx, infrastructure.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.RefreshToken{}, domain.ErrInvalidRefreshToken
+		}
+		return domain.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// revokeReusedSession revokes the session of a refresh token that was presented twice.
+func (ur *userUseCase) revokeReusedSession(ctx context.Context, token domain.RefreshToken) error {
+	if err := ur.refreshTokenRepository.RevokeTokenFamily(ctx, token.FamilyID, time.Now()); err != nil {
+		return err
+	}
+	return domain.ErrRefreshTokenReused
+}
+
+// issueTokens generates an access token, granting the current permissions of the role of the user,
+// and a stored refresh token for the given session.
+func (ur *userUseCase) issueTokens(ctx context.Context, user domain.User, sessionID string) (domain.TokenPair, error) {
+	permissions, err := rolePermissions(ctx, ur.roleRepository, user.OrgID, user.Role)
+	if err != nil {
+		return domain.TokenPair{}, err
 	}
-	//generate the token
-	token, err := infrastructure.GenerateToken(user)
+	accessToken, err := ur.tokenService.GenerateToken(user, permissions, sessionID)
 	if err != nil {
-		return domain.User{}, "", err
+		return domain.TokenPair{}, err
 	}
-	return user, token, nil
+	refreshToken, hash, err := infrastructure.GenerateRefreshToken()
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	now := time.Now()
+	_, err = ur.refreshTokenRepository.CreateRefreshToken(ctx, domain.RefreshToken{
+		UserID:    user.ID.String(),
+		FamilyID:  sessionID,
+		TokenHash: hash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ur.tokenService.RefreshTokenTTL),
+	})
+	if err != nil {
+		return domain.TokenPair{}, err
+	}
+	return domain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(ur.tokenService.AccessTokenTTL / time.Second),
+	}, nil
 }
 
 // CreateAccount creates a new user account.
 // It takes a context.Context and a *domain.User as input parameters.
+// The user creates the organization named by its OrgID, the default one if blank, and becomes its admin.
+// Signing up cannot join an organization that already has users: it returns domain.ErrOrganizationExists,
+// and the admins of the organization add its users with CreateUser.
 // The function hashes the user's password and sends it to the database.
 // It returns the created domain.User and an error if any.
 func (ur *userUseCase) CreateAccount(c context.Context, user *domain.User) (domain.User, error) {
 	ctx, close := context.WithTimeout(c, ur.contextTimeout)
 	defer close()
 
+	orgId, err := domain.NormalizeOrganizationID(user.OrgID)
+	if err != nil {
+		return domain.User{}, err
+	}
+	user.OrgID = orgId
+
 	//hash the password and send to database
 	hashedPassword, err := infrastructure.HashPassword(user.Password)
 	if err != nil {
@@ -72,14 +216,280 @@ func (ur *userUseCase) CreateAccount(c context.Context, user *domain.User) (doma
 	user.CreatedAt = time.Now()
 	user.UpdatedAt = time.Now()
 
+	return ur.userRepository.CreateOrganization(domain.ContextWithOrganization(ctx, orgId), user)
+}
+
+// CreateUser creates an account for a regular user in the organization of the caller and returns it.
+// The organization and the role of the given user are ignored.
+// It returns domain.ErrUsernameTaken if the username is already used in the organization.
+func (ur *userUseCase) CreateUser(c context.Context, user *domain.User) (domain.User, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	caller, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.User{}, domain.ErrUnauthenticated
+	}
+	hashedPassword, err := infrastructure.HashPassword(user.Password)
+	if err != nil {
+		return domain.User{}, err
+	}
+	now := time.Now()
+	user.OrgID, user.Password = caller.OrgID, hashedPassword
+	user.CreatedAt, user.UpdatedAt = now, now
 	return ur.userRepository.CreateNewUser(ctx, user)
 }
 
-// UpdateUserRole updates the role of a user identified by the given userId.
+// UpdateUserRole gives the ADMIN role to the user identified by the given userId.
 // It takes a context.Context as the first argument and the userId as the second argument.
+// Only a caller having every permission, like an admin, can promote a user to admin,
+// and deactivated users cannot be promoted. The sessions of a promoted user are revoked like in AssignUserRole.
 // It returns an error if the operation fails.
 func (ur *userUseCase) UpdateUserRole(c context.Context, userId string) error {
 	ctx, close := context.WithTimeout(c, ur.contextTimeout)
 	defer close()
-	return ur.userRepository.PromoteUser(ctx, userId)
-}
\ No newline at end of file
+
+	admin, _ := domain.BuiltinRole(domain.RoleAdmin)
+	if err := checkGrantable(ctx, admin.Permissions); err != nil {
+		return err
+	}
+	user, err := ur.userRepository.FindUserById(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if user.DeactivatedAt != nil {
+		return domain.ErrUserDeactivated
+	}
+	if err := ur.userRepository.PromoteUser(ctx, userId); err != nil {
+		return err
+	}
+	if user.Role == domain.RoleAdmin {
+		return nil
+	}
+	return ur.refreshTokenRepository.RevokeUserTokens(ctx, userId, time.Now())
+}
+
+// AssignUserRole gives a built-in role or a role of the organization of the caller to the user with the given ID
+// and returns the user. The caller must have every permission of both the current and the new role of the user,
+// so it can neither grant nor take away more than it has.
+// It returns an ErrValidation error if the role does not exist, domain.ErrUserDeactivated if the user is deactivated
+// and domain.ErrLastAdmin if the user is the last admin of the organization. A user whose role changes has its sessions
+// revoked, which also rejects its access tokens, so it does not keep the permissions of its former role.
+func (ur *userUseCase) AssignUserRole(c context.Context, userId string, role string) (domain.User, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	caller, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.User{}, domain.ErrUnauthenticated
+	}
+	role, err := domain.NormalizeRoleName(role)
+	if err != nil {
+		return domain.User{}, err
+	}
+	granted, ok := domain.BuiltinRole(role)
+	if !ok {
+		granted, err = ur.roleRepository.FindRoleByName(ctx, role)
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			return domain.User{}, fmt.Errorf("%w: the role %s does not exist", domain.ErrValidation, role)
+		}
+		if err != nil {
+			return domain.User{}, err
+		}
+	}
+	user, err := ur.userRepository.FindUserById(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if user.DeactivatedAt != nil {
+		return domain.User{}, domain.ErrUserDeactivated
+	}
+	current, err := rolePermissions(ctx, ur.roleRepository, caller.OrgID, user.Role)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if err := checkGrantable(ctx, append(current, granted.Permissions...)); err != nil {
+		return domain.User{}, err
+	}
+	if err := ur.userRepository.SetUserRole(ctx, userId, role); err != nil {
+		return domain.User{}, err
+	}
+	if role != user.Role {
+		if err := ur.refreshTokenRepository.RevokeUserTokens(ctx, user.ID.String(), time.Now()); err != nil {
+			return domain.User{}, err
+		}
+	}
+	user.Role = role
+	return user, nil
+}
+
+// GetUsers returns one page of the users of the organization of the caller, in the order they signed up.
+// A zero limit falls back to domain.DefaultUserPageSize, and a role filter is matched by its normalized name.
+func (ur *userUseCase) GetUsers(c context.Context, query domain.UserQuery) (domain.UserPage, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	if query.Limit == 0 {
+		query.Limit = domain.DefaultUserPageSize
+	}
+	if query.Limit < 0 || query.Limit > domain.MaxUserPageSize {
+		return domain.UserPage{}, domain.NewError(domain.ErrValidation, "limit must be between 1 and %d", domain.MaxUserPageSize)
+	}
+	if query.Cursor != "" {
+		if _, err := domain.ParseID(query.Cursor); err != nil {
+			return domain.UserPage{}, domain.NewError(domain.ErrValidation, "malformed cursor")
+		}
+	}
+	if query.Role != "" {
+		role, err := domain.NormalizeRoleName(query.Role)
+		if err != nil {
+			return domain.UserPage{}, err
+		}
+		query.Role = role
+	}
+	return ur.userRepository.FindUsers(ctx, query)
+}
+
+// GetUser returns the user of the organization of the caller with the given ID.
+func (ur *userUseCase) GetUser(c context.Context, userId string) (domain.User, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	return ur.userRepository.FindUserById(ctx, userId)
+}
+
+// DeactivateUser deactivates the user with the given ID and revokes its sessions, which also rejects its access tokens,
+// and returns the user. Deactivating a deactivated user changes nothing.
+// Admins, users with a permission the caller does not have and the caller itself cannot be deactivated.
+func (ur *userUseCase) DeactivateUser(c context.Context, userId string) (domain.User, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	user, err := ur.manageableUser(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if user.DeactivatedAt != nil {
+		return user, nil
+	}
+	now := time.Now()
+	if err := ur.userRepository.SetUserDeactivated(ctx, userId, &now); err != nil {
+		return domain.User{}, err
+	}
+	if err := ur.refreshTokenRepository.RevokeUserTokens(ctx, user.ID.String(), now); err != nil {
+		return domain.User{}, err
+	}
+	user.DeactivatedAt = &now
+	return user, nil
+}
+
+// ActivateUser lets the deactivated user with the given ID log in again and returns it.
+// Activating an active user changes nothing.
+func (ur *userUseCase) ActivateUser(c context.Context, userId string) (domain.User, error) {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	user, err := ur.manageableUser(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if user.DeactivatedAt == nil {
+		return user, nil
+	}
+	if err := ur.userRepository.SetUserDeactivated(ctx, userId, nil); err != nil {
+		return domain.User{}, err
+	}
+	user.DeactivatedAt = nil
+	return user, nil
+}
+
+// DeleteUser deletes the user with the given ID. Its sessions are revoked, the user with the ID reassignTo,
+// or the caller if blank, replaces it as the creator and an assignee of its tasks, and it leaves its projects.
+// Its comments and attachments are kept. The steps are ordered so that a failed deletion can be retried.
+// Admins, users with a permission the caller does not have and the caller itself cannot be deleted,
+// and the tasks can only be reassigned to another active user of the organization.
+// It returns domain.ErrLastOwner, before changing anything, while the user is the last owner of a project.
+func (ur *userUseCase) DeleteUser(c context.Context, userId string, reassignTo string) error {
+	ctx, close := context.WithTimeout(c, ur.contextTimeout)
+	defer close()
+
+	user, err := ur.manageableUser(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if reassignTo == "" {
+		caller, _ := domain.AuthUserFromContext(ctx)
+		reassignTo = caller.ID
+	}
+	heir, err := ur.userRepository.FindUserById(ctx, reassignTo)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return fmt.Errorf("%w: the user %s to reassign the tasks to does not exist", domain.ErrValidation, reassignTo)
+	}
+	if err != nil {
+		return err
+	}
+	if heir.ID == user.ID {
+		return domain.NewError(domain.ErrValidation, "the tasks cannot be reassigned to the deleted user")
+	}
+	if heir.DeactivatedAt != nil {
+		return domain.NewError(domain.ErrValidation, "the tasks cannot be reassigned to a deactivated user")
+	}
+
+	projects, err := ur.projectRepository.FindMemberProjects(ctx, user.ID.String())
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		member, err := ur.projectRepository.FindMember(ctx, project.ID.String(), user.ID.String())
+		if errors.Is(err, domain.ErrMemberNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := keepOwner(ctx, ur.projectRepository, member); err != nil {
+			return fmt.Errorf("%w: the user is the last owner of the project %s", err, project.Name)
+		}
+	}
+
+	if err := ur.refreshTokenRepository.RevokeUserTokens(ctx, user.ID.String(), time.Now()); err != nil {
+		return err
+	}
+	if _, err := ur.taskRepository.ReassignTasks(ctx, user.ID.String(), heir.ID.String()); err != nil {
+		return err
+	}
+	for _, project := range projects {
+		err := ur.projectRepository.RemoveMember(ctx, project.ID.String(), user.ID.String())
+		if err != nil && !errors.Is(err, domain.ErrMemberNotFound) {
+			return err
+		}
+	}
+	return ur.userRepository.DeleteUser(ctx, user.ID.String())
+}
+
+// manageableUser loads a user the caller may deactivate, activate or delete: another user, not an admin,
+// whose role has no permission the caller does not have.
+func (ur *userUseCase) manageableUser(ctx context.Context, userId string) (domain.User, error) {
+	caller, ok := domain.AuthUserFromContext(ctx)
+	if !ok {
+		return domain.User{}, domain.ErrUnauthenticated
+	}
+	user, err := ur.userRepository.FindUserById(ctx, userId)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if user.ID.String() == caller.ID {
+		return domain.User{}, domain.NewError(domain.ErrValidation, "you cannot deactivate or delete your own account")
+	}
+	if user.Role == domain.RoleAdmin {
+		return domain.User{}, domain.ErrUserIsAdmin
+	}
+	permissions, err := rolePermissions(ctx, ur.roleRepository, caller.OrgID, user.Role)
+	if err != nil {
+		return domain.User{}, err
+	}
+	if err := checkGrantable(ctx, permissions); err != nil {
+		return domain.User{}, err
+	}
+	return user, nil
+}
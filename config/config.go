@@ -0,0 +1,487 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+	infrastructure "example/go-clean-architecture/Infrastructure"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the configuration of the application.
+// It is assembled by Load from, in increasing order of precedence, the defaults,
+// a YAML or TOML file, environment variables and command-line flags.
+type Config struct {
+	Server      ServerConfig      `yaml:"server" toml:"server"`
+	Database    DatabaseConfig    `yaml:"database" toml:"database"`
+	JWT         JWTConfig         `yaml:"jwt" toml:"jwt"`
+	Workflow    WorkflowConfig    `yaml:"workflow" toml:"workflow"`
+	Trash       TrashConfig       `yaml:"trash" toml:"trash"`
+	Attachments AttachmentsConfig `yaml:"attachments" toml:"attachments"`
+}
+
+// ServerConfig holds the settings of the HTTP server.
+type ServerConfig struct {
+	// Address is the host:port the server listens on.
+	Address string `yaml:"address" toml:"address"`
+	// Mode is the gin mode: debug, release or test.
+	Mode string `yaml:"mode" toml:"mode"`
+	// ContextTimeout bounds the time a use case may spend on a request.
+	ContextTimeout time.Duration `yaml:"context_timeout" toml:"context_timeout"`
+	// DrainTimeout bounds the graceful shutdown: in-flight requests, background workers
+	// and the database client get this long to finish once a shutdown signal is received.
+	DrainTimeout time.Duration `yaml:"drain_timeout" toml:"drain_timeout"`
+	// ShutdownDelay is how long the server keeps serving, reported as not ready, before it starts draining.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay" toml:"shutdown_delay"`
+	// ConnectTimeout bounds the time spent connecting to the database at startup.
+	ConnectTimeout time.Duration `yaml:"connect_timeout" toml:"connect_timeout"`
+	// HealthCheckTimeout bounds each dependency check of the readiness endpoint.
+	HealthCheckTimeout time.Duration `yaml:"health_check_timeout" toml:"health_check_timeout"`
+}
+
+// Storage backends the repositories can be built on.
+const (
+	BackendMongoDB  = "mongodb"
+	BackendPostgres = "postgres"
+	BackendSQLite   = "sqlite"
+	BackendMemory   = "memory"
+)
+
+// DatabaseConfig holds the settings of the storage backend.
+// URI and Name are the MongoDB connection string and database name, DSN the PostgreSQL connection string
+// and Path the file of the SQLite database.
+type DatabaseConfig struct {
+	Backend string `yaml:"backend" toml:"backend"`
+	URI     string `yaml:"uri" toml:"uri"`
+	Name    string `yaml:"name" toml:"name"`
+	DSN     string `yaml:"dsn" toml:"dsn"`
+	Path    string `yaml:"path" toml:"path"`
+}
+
+// JWTConfig holds the token signing keys and the lifetimes of the issued credentials.
+// Without keys, tokens are signed with an ephemeral key.
+type JWTConfig struct {
+	SigningKeyID    string        `yaml:"signing_key_id" toml:"signing_key_id"`
+	Keys            []KeyFile     `yaml:"keys" toml:"keys"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" toml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" toml:"refresh_token_ttl"`
+}
+
+// KeyFile is a PEM key file and the key id it is published under.
+type KeyFile struct {
+	ID   string `yaml:"id" toml:"id"`
+	File string `yaml:"file" toml:"file"`
+}
+
+// WorkflowConfig holds the state machine the status of a task follows, set in the config file only.
+// Transitions maps every status to the statuses a task in that status may move to and new tasks start in Initial.
+// Without transitions, the default workflow of domain.DefaultTaskWorkflow applies, and Initial must be empty.
+type WorkflowConfig struct {
+	Initial     string              `yaml:"initial" toml:"initial"`
+	Transitions map[string][]string `yaml:"transitions" toml:"transitions"`
+}
+
+// TrashConfig holds the settings of the trash deleted tasks are moved to.
+type TrashConfig struct {
+	// Retention is how long a deleted task stays in the trash before it is purged.
+	Retention time.Duration `yaml:"retention" toml:"retention"`
+	// PurgeInterval is how often the tasks past their retention are purged.
+	PurgeInterval time.Duration `yaml:"purge_interval" toml:"purge_interval"`
+}
+
+// Blob stores the content of the attachments can be kept in.
+const (
+	StorageLocal  = "local"
+	StorageGridFS = "gridfs"
+)
+
+// AttachmentsConfig holds the settings of the files attached to the tasks.
+type AttachmentsConfig struct {
+	// Storage is where the content of the attachments is kept: local, in Dir, or gridfs,
+	// in the MongoDB database, which requires the mongodb backend.
+	Storage string `yaml:"storage" toml:"storage"`
+	Dir     string `yaml:"dir" toml:"dir"`
+	// MaxSize is the maximum size of an attachment in bytes.
+	MaxSize int64 `yaml:"max_size" toml:"max_size"`
+	// AllowedTypes are the media types attachments may have, as detected from their content.
+	AllowedTypes []string `yaml:"allowed_types" toml:"allowed_types"`
+}
+
+// Default returns the configuration used when nothing else is configured.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:            "localhost:8080",
+			Mode:               "debug",
+			ContextTimeout:     100 * time.Second,
+			DrainTimeout:       15 * time.Second,
+			ConnectTimeout:     10 * time.Second,
+			HealthCheckTimeout: 2 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Backend: BackendMongoDB,
+			URI:     "mongodb://localhost:27017",
+			Name:    "User_Task_Manager",
+			Path:    "task_manager.db",
+		},
+		JWT: JWTConfig{
+			AccessTokenTTL:  infrastructure.DefaultAccessTokenTTL,
+			RefreshTokenTTL: infrastructure.DefaultRefreshTokenTTL,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+		},
+		Attachments: AttachmentsConfig{
+			Storage: StorageLocal,
+			Dir:     "attachments",
+			MaxSize: 10 << 20,
+			AllowedTypes: []string{
+				"image/png", "image/jpeg", "image/gif", "image/webp",
+				"application/pdf", "application/zip", "text/plain", "text/csv",
+			},
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the config file, the environment and the command-line arguments.
+// The config file is named by the -config flag or the CONFIG_FILE environment variable, and is read as TOML when
+// its extension is .toml and as YAML otherwise.
+// It returns an error if a source cannot be read or the resulting configuration is invalid.
+func Load(args []string, getenv func(string) string) (Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("task-manager", flag.ContinueOnError)
+	file := fs.String("config", getenv("CONFIG_FILE"), "path to a YAML or TOML config file")
+	var flags Config
+	fs.StringVar(&flags.Server.Address, "addr", "", "address the server listens on")
+	fs.StringVar(&flags.Server.Mode, "mode", "", "gin mode: debug, release or test")
+	fs.DurationVar(&flags.Server.ContextTimeout, "context-timeout", 0, "timeout of a use case call")
+	fs.DurationVar(&flags.Server.DrainTimeout, "drain-timeout", 0, "time given to in-flight work on shutdown")
+	fs.DurationVar(&flags.Server.ShutdownDelay, "shutdown-delay", 0, "time the server reports not ready before draining")
+	fs.DurationVar(&flags.Server.ConnectTimeout, "connect-timeout", 0, "timeout of the database connection at startup")
+	fs.DurationVar(&flags.Server.HealthCheckTimeout, "health-check-timeout", 0, "timeout of each readiness check")
+	fs.StringVar(&flags.Database.Backend, "db-backend", "", "storage backend: mongodb, postgres, sqlite or memory")
+	fs.StringVar(&flags.Database.URI, "db-uri", "", "MongoDB connection string")
+	fs.StringVar(&flags.Database.Name, "db-name", "", "MongoDB database name")
+	fs.StringVar(&flags.Database.DSN, "db-dsn", "", "PostgreSQL connection string")
+	fs.StringVar(&flags.Database.Path, "db-path", "", "SQLite database file")
+	fs.StringVar(&flags.JWT.SigningKeyID, "jwt-signing-key-id", "", "id of the key tokens are signed with")
+	keyFiles := fs.String("jwt-key-files", "", "comma separated kid=path list of PEM key files")
+	fs.DurationVar(&flags.JWT.AccessTokenTTL, "jwt-access-token-ttl", 0, "lifetime of access tokens")
+	fs.DurationVar(&flags.JWT.RefreshTokenTTL, "jwt-refresh-token-ttl", 0, "lifetime of refresh tokens")
+	fs.DurationVar(&flags.Trash.Retention, "trash-retention", 0, "time deleted tasks stay in the trash")
+	fs.DurationVar(&flags.Trash.PurgeInterval, "trash-purge-interval", 0, "interval between purges of the trash")
+	fs.StringVar(&flags.Attachments.Storage, "attachments-storage", "", "blob store of the attachments: local or gridfs")
+	fs.StringVar(&flags.Attachments.Dir, "attachments-dir", "", "directory of the local blob store")
+	fs.Int64Var(&flags.Attachments.MaxSize, "attachments-max-size", 0, "maximum size of an attachment in bytes")
+	allowedTypes := fs.String("attachments-allowed-types", "", "comma separated list of the media types attachments may have")
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if *file != "" {
+		if err := loadFile(*file, &cfg); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := applyEnv(getenv, &cfg); err != nil {
+		return Config{}, err
+	}
+
+	var err error
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "addr":
+			cfg.Server.Address = flags.Server.Address
+		case "mode":
+			cfg.Server.Mode = flags.Server.Mode
+		case "context-timeout":
+			cfg.Server.ContextTimeout = flags.Server.ContextTimeout
+		case "drain-timeout":
+			cfg.Server.DrainTimeout = flags.Server.DrainTimeout
+		case "shutdown-delay":
+			cfg.Server.ShutdownDelay = flags.Server.ShutdownDelay
+		case "connect-timeout":
+			cfg.Server.ConnectTimeout = flags.Server.ConnectTimeout
+		case "health-check-timeout":
+			cfg.Server.HealthCheckTimeout = flags.Server.HealthCheckTimeout
+		case "db-backend":
+			cfg.Database.Backend = flags.Database.Backend
+		case "db-uri":
+			cfg.Database.URI = flags.Database.URI
+		case "db-name":
+			cfg.Database.Name = flags.Database.Name
+		case "db-dsn":
+			cfg.Database.DSN = flags.Database.DSN
+		case "db-path":
+			cfg.Database.Path = flags.Database.Path
+		case "jwt-signing-key-id":
+			cfg.JWT.SigningKeyID = flags.JWT.SigningKeyID
+		case "jwt-key-files":
+			if cfg.JWT.Keys, err = parseKeyFiles(*keyFiles); err != nil {
+				err = fmt.Errorf("flag -jwt-key-files: %w", err)
+			}
+		case "jwt-access-token-ttl":
+			cfg.JWT.AccessTokenTTL = flags.JWT.AccessTokenTTL
+		case "jwt-refresh-token-ttl":
+			cfg.JWT.RefreshTokenTTL = flags.JWT.RefreshTokenTTL
+		case "trash-retention":
+			cfg.Trash.Retention = flags.Trash.Retention
+		case "trash-purge-interval":
+			cfg.Trash.PurgeInterval = flags.Trash.PurgeInterval
+		case "attachments-storage":
+			cfg.Attachments.Storage = flags.Attachments.Storage
+		case "attachments-dir":
+			cfg.Attachments.Dir = flags.Attachments.Dir
+		case "attachments-max-size":
+			cfg.Attachments.MaxSize = flags.Attachments.MaxSize
+		case "attachments-allowed-types":
+			cfg.Attachments.AllowedTypes = splitList(*allowedTypes)
+		}
+	})
+	if err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// loadFile decodes the config file over cfg, so settings missing from the file keep their current value.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		err = toml.Unmarshal(data, cfg)
+	default:
+		err = yaml.Unmarshal(data, cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overrides cfg with the settings present in the environment.
+func applyEnv(getenv func(string) string, cfg *Config) error {
+	values := map[string]*string{
+		"SERVER_ADDRESS":      &cfg.Server.Address,
+		"GIN_MODE":            &cfg.Server.Mode,
+		"DATABASE_BACKEND":    &cfg.Database.Backend,
+		"MONGODB_URI":         &cfg.Database.URI,
+		"DATABASE_NAME":       &cfg.Database.Name,
+		"DATABASE_DSN":        &cfg.Database.DSN,
+		"DATABASE_PATH":       &cfg.Database.Path,
+		"JWT_SIGNING_KEY_ID":  &cfg.JWT.SigningKeyID,
+		"ATTACHMENTS_STORAGE": &cfg.Attachments.Storage,
+		"ATTACHMENTS_DIR":     &cfg.Attachments.Dir,
+	}
+	for name, field := range values {
+		if value := getenv(name); value != "" {
+			*field = value
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"CONTEXT_TIMEOUT":       &cfg.Server.ContextTimeout,
+		"DRAIN_TIMEOUT":         &cfg.Server.DrainTimeout,
+		"SHUTDOWN_DELAY":        &cfg.Server.ShutdownDelay,
+		"CONNECT_TIMEOUT":       &cfg.Server.ConnectTimeout,
+		"HEALTH_CHECK_TIMEOUT":  &cfg.Server.HealthCheckTimeout,
+		"JWT_ACCESS_TOKEN_TTL":  &cfg.JWT.AccessTokenTTL,
+		"JWT_REFRESH_TOKEN_TTL": &cfg.JWT.RefreshTokenTTL,
+		"TRASH_RETENTION":       &cfg.Trash.Retention,
+		"TRASH_PURGE_INTERVAL":  &cfg.Trash.PurgeInterval,
+	}
+	for name, field := range durations {
+		value := getenv(name)
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		*field = d
+	}
+
+	if value := getenv("ATTACHMENTS_MAX_SIZE"); value != "" {
+		size, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("ATTACHMENTS_MAX_SIZE: %w", err)
+		}
+		cfg.Attachments.MaxSize = size
+	}
+	if value := getenv("ATTACHMENTS_ALLOWED_TYPES"); value != "" {
+		cfg.Attachments.AllowedTypes = splitList(value)
+	}
+
+	if value := getenv("JWT_KEY_FILES"); value != "" {
+		keys, err := parseKeyFiles(value)
+		if err != nil {
+			return fmt.Errorf("JWT_KEY_FILES: %w", err)
+		}
+		cfg.JWT.Keys = keys
+	}
+	return nil
+}
+
+// parseKeyFiles parses a comma separated list of kid=path pairs.
+func parseKeyFiles(value string) ([]KeyFile, error) {
+	var keys []KeyFile
+	for _, pair := range strings.Split(value, ",") {
+		kid, path, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected kid=path", pair)
+		}
+		keys = append(keys, KeyFile{ID: kid, File: path})
+	}
+	return keys, nil
+}
+
+// splitList parses a comma separated list, dropping the empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate checks that the configuration is complete and consistent.
+// It returns all the problems found, joined in a single error.
+func (c Config) Validate() error {
+	var errs []error
+	if c.Server.Address == "" {
+		errs = append(errs, errors.New("server.address is required"))
+	}
+	switch c.Server.Mode {
+	case "debug", "release", "test":
+	default:
+		errs = append(errs, fmt.Errorf("server.mode %q must be one of debug, release or test", c.Server.Mode))
+	}
+	if c.Server.ContextTimeout <= 0 {
+		errs = append(errs, errors.New("server.context_timeout must be positive"))
+	}
+	if c.Server.DrainTimeout <= 0 {
+		errs = append(errs, errors.New("server.drain_timeout must be positive"))
+	}
+	if c.Server.ShutdownDelay < 0 {
+		errs = append(errs, errors.New("server.shutdown_delay must not be negative"))
+	}
+	if c.Server.ConnectTimeout <= 0 {
+		errs = append(errs, errors.New("server.connect_timeout must be positive"))
+	}
+	if c.Server.HealthCheckTimeout <= 0 {
+		errs = append(errs, errors.New("server.health_check_timeout must be positive"))
+	}
+	switch c.Database.Backend {
+	case BackendMongoDB:
+		if !strings.HasPrefix(c.Database.URI, "mongodb://") && !strings.HasPrefix(c.Database.URI, "mongodb+srv://") {
+			errs = append(errs, fmt.Errorf("database.uri %q must be a mongodb:// or mongodb+srv:// connection string", c.Database.URI))
+		}
+		if c.Database.Name == "" {
+			errs = append(errs, errors.New("database.name is required"))
+		}
+	case BackendPostgres:
+		if c.Database.DSN == "" {
+			errs = append(errs, errors.New("database.dsn is required by the postgres backend"))
+		}
+	case BackendSQLite:
+		if c.Database.Path == "" {
+			errs = append(errs, errors.New("database.path is required by the sqlite backend"))
+		}
+	case BackendMemory:
+	default:
+		errs = append(errs, fmt.Errorf("database.backend %q must be one of mongodb, postgres, sqlite or memory", c.Database.Backend))
+	}
+	if c.JWT.AccessTokenTTL <= 0 {
+		errs = append(errs, errors.New("jwt.access_token_ttl must be positive"))
+	}
+	if c.JWT.RefreshTokenTTL < c.JWT.AccessTokenTTL {
+		errs = append(errs, errors.New("jwt.refresh_token_ttl must not be shorter than jwt.access_token_ttl"))
+	}
+	if len(c.JWT.Keys) > 0 {
+		found := false
+		for _, key := range c.JWT.Keys {
+			if key.ID == "" || key.File == "" {
+				errs = append(errs, errors.New("jwt.keys entries need an id and a file"))
+			}
+			found = found || key.ID == c.JWT.SigningKeyID
+		}
+		if !found {
+			errs = append(errs, fmt.Errorf("jwt.signing_key_id %q does not name one of jwt.keys", c.JWT.SigningKeyID))
+		}
+	} else if c.JWT.SigningKeyID != "" {
+		errs = append(errs, errors.New("jwt.signing_key_id is set but jwt.keys is empty"))
+	}
+	if c.Trash.Retention <= 0 {
+		errs = append(errs, errors.New("trash.retention must be positive"))
+	}
+	if c.Trash.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("trash.purge_interval must be positive"))
+	}
+	switch c.Attachments.Storage {
+	case StorageLocal:
+		if c.Attachments.Dir == "" {
+			errs = append(errs, errors.New("attachments.dir is required by the local storage"))
+		}
+	case StorageGridFS:
+		if c.Database.Backend != BackendMongoDB {
+			errs = append(errs, errors.New("attachments.storage gridfs requires the mongodb database backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("attachments.storage %q must be one of local or gridfs", c.Attachments.Storage))
+	}
+	if c.Attachments.MaxSize <= 0 {
+		errs = append(errs, errors.New("attachments.max_size must be positive"))
+	}
+	if len(c.Attachments.AllowedTypes) == 0 {
+		errs = append(errs, errors.New("attachments.allowed_types must not be empty"))
+	}
+	if c.Workflow.Initial != "" && len(c.Workflow.Transitions) == 0 {
+		errs = append(errs, errors.New("workflow.initial is set but workflow.transitions is empty"))
+	} else if err := c.Workflow.TaskWorkflow().Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("workflow: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+// TaskWorkflow returns the configured workflow, or the default one if none is configured.
+func (c WorkflowConfig) TaskWorkflow() domain.TaskWorkflow {
+	if len(c.Transitions) == 0 {
+		return domain.DefaultTaskWorkflow()
+	}
+	return domain.TaskWorkflow{Initial: c.Initial, Transitions: c.Transitions}
+}
+
+// Limits returns the limits of the attachments as the use case expects them.
+func (c AttachmentsConfig) Limits() domain.AttachmentLimits {
+	return domain.AttachmentLimits{MaxSize: c.MaxSize, AllowedTypes: c.AllowedTypes}
+}
+
+// KeyConfigs returns the configured key files as the KeyManager expects them.
+func (c JWTConfig) KeyConfigs() []infrastructure.KeyConfig {
+	configs := make([]infrastructure.KeyConfig, 0, len(c.Keys))
+	for _, key := range c.Keys {
+		configs = append(configs, infrastructure.KeyConfig{ID: key.ID, File: key.File})
+	}
+	return configs
+}
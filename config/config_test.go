@@ -0,0 +1,273 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	domain "example/go-clean-architecture/Domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// env returns a getenv function reading from the given map.
+func env(vars map[string]string) func(string) string {
+	return func(name string) string {
+		return vars[name]
+	}
+}
+
+// writeFile writes a config file to a temporary directory and returns its path.
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+// TestLoad_Defaults tests that the defaults are used when nothing is configured.
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := Load(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, Default(), cfg)
+	assert.Equal(t, "localhost:8080", cfg.Server.Address)
+	assert.Equal(t, "mongodb://localhost:27017", cfg.Database.URI)
+	assert.Equal(t, "User_Task_Manager", cfg.Database.Name)
+	assert.Equal(t, 100*time.Second, cfg.Server.ContextTimeout)
+	assert.Equal(t, 15*time.Second, cfg.Server.DrainTimeout)
+}
+
+// TestLoad_YAMLFile tests that a YAML file overrides the defaults it sets and keeps the others.
+func TestLoad_YAMLFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  address: ":9000"
+  context_timeout: 5s
+database:
+  name: tasks
+jwt:
+  signing_key_id: k1
+  keys:
+    - id: k1
+      file: /keys/k1.pem
+  access_token_ttl: 10m
+`)
+	cfg, err := Load([]string{"-config", path}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, ":9000", cfg.Server.Address)
+	assert.Equal(t, 5*time.Second, cfg.Server.ContextTimeout)
+	assert.Equal(t, "mongodb://localhost:27017", cfg.Database.URI)
+	assert.Equal(t, "tasks", cfg.Database.Name)
+	assert.Equal(t, []KeyFile{{ID: "k1", File: "/keys/k1.pem"}}, cfg.JWT.Keys)
+	assert.Equal(t, 10*time.Minute, cfg.JWT.AccessTokenTTL)
+	assert.Equal(t, Default().JWT.RefreshTokenTTL, cfg.JWT.RefreshTokenTTL)
+}
+
+// TestLoad_TOMLFile tests that files with a .toml extension are read as TOML.
+func TestLoad_TOMLFile(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[server]
+address = ":9000"
+context_timeout = "5s"
+
+[database]
+uri = "mongodb+srv://cluster.example.com"
+
+[[jwt.keys]]
+id = "k1"
+file = "/keys/k1.pem"
+
+[jwt]
+signing_key_id = "k1"
+`)
+	cfg, err := Load(nil, env(map[string]string{"CONFIG_FILE": path}))
+	require.NoError(t, err)
+	assert.Equal(t, ":9000", cfg.Server.Address)
+	assert.Equal(t, 5*time.Second, cfg.Server.ContextTimeout)
+	assert.Equal(t, "mongodb+srv://cluster.example.com", cfg.Database.URI)
+	assert.Equal(t, "k1", cfg.JWT.SigningKeyID)
+	assert.Equal(t, []KeyFile{{ID: "k1", File: "/keys/k1.pem"}}, cfg.JWT.Keys)
+}
+
+// TestLoad_Precedence tests that environment variables override the file and flags override both.
+func TestLoad_Precedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+server:
+  address: ":9000"
+  context_timeout: 5s
+database:
+  name: from-file
+`)
+	vars := map[string]string{
+		"CONFIG_FILE":        path,
+		"SERVER_ADDRESS":     ":9100",
+		"CONTEXT_TIMEOUT":    "7s",
+		"JWT_KEY_FILES":      "k1=/keys/k1.pem, k0=/keys/k0.pem",
+		"JWT_SIGNING_KEY_ID": "k1",
+	}
+	cfg, err := Load([]string{"-addr", ":9200", "-jwt-signing-key-id", "k0"}, env(vars))
+	require.NoError(t, err)
+	assert.Equal(t, ":9200", cfg.Server.Address)
+	assert.Equal(t, 7*time.Second, cfg.Server.ContextTimeout)
+	assert.Equal(t, "from-file", cfg.Database.Name)
+	assert.Equal(t, "k0", cfg.JWT.SigningKeyID)
+	assert.Equal(t, []KeyFile{{ID: "k1", File: "/keys/k1.pem"}, {ID: "k0", File: "/keys/k0.pem"}}, cfg.JWT.Keys)
+}
+
+// TestLoad_Errors tests that unreadable sources and invalid settings are reported at startup.
+func TestLoad_Errors(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		vars map[string]string
+	}{
+		"missing file":             {args: []string{"-config", filepath.Join(t.TempDir(), "missing.yaml")}},
+		"malformed file":           {args: []string{"-config", writeFile(t, "bad.yaml", "server: [")}},
+		"unknown flag":             {args: []string{"-port", "80"}},
+		"malformed duration":       {vars: map[string]string{"CONTEXT_TIMEOUT": "soon"}},
+		"malformed key files":      {vars: map[string]string{"JWT_KEY_FILES": "k1"}},
+		"non positive timeout":     {args: []string{"-context-timeout", "0s"}},
+		"non positive drain":       {vars: map[string]string{"DRAIN_TIMEOUT": "-1s"}},
+		"negative shutdown delay":  {args: []string{"-shutdown-delay", "-1s"}},
+		"invalid mode":             {args: []string{"-mode", "verbose"}},
+		"invalid database uri":     {args: []string{"-db-uri", "localhost:27017"}},
+		"unknown backend":          {vars: map[string]string{"DATABASE_BACKEND": "redis"}},
+		"empty database name":      {vars: map[string]string{"DATABASE_NAME": ""}, args: []string{"-db-name", ""}},
+		"postgres without dsn":     {args: []string{"-db-backend", "postgres"}},
+		"sqlite without path":      {args: []string{"-db-backend", "sqlite", "-db-path", ""}},
+		"refresh before access":    {args: []string{"-jwt-refresh-token-ttl", "1m"}},
+		"unknown signing key":      {vars: map[string]string{"JWT_KEY_FILES": "k1=/keys/k1.pem", "JWT_SIGNING_KEY_ID": "k2"}},
+		"signing key without keys": {vars: map[string]string{"JWT_SIGNING_KEY_ID": "k1"}},
+		"non positive retention":   {args: []string{"-trash-retention", "0s"}},
+		"non positive purge":       {vars: map[string]string{"TRASH_PURGE_INTERVAL": "-1m"}},
+		"unknown storage":          {args: []string{"-attachments-storage", "s3"}},
+		"gridfs without mongodb":   {args: []string{"-db-backend", "memory", "-attachments-storage", "gridfs"}},
+		"local without dir":        {vars: map[string]string{"ATTACHMENTS_DIR": ""}, args: []string{"-attachments-dir", ""}},
+		"malformed max size":       {vars: map[string]string{"ATTACHMENTS_MAX_SIZE": "10MB"}},
+		"non positive max size":    {args: []string{"-attachments-max-size", "0"}},
+		"no allowed types":         {args: []string{"-attachments-allowed-types", " , "}},
+	}
+	for name, tc := range cases {
+		_, err := Load(tc.args, env(tc.vars))
+		assert.Error(t, err, name)
+	}
+}
+
+// TestLoad_MemoryBackend tests that the MongoDB settings are not required by the in-memory backend.
+func TestLoad_MemoryBackend(t *testing.T) {
+	cfg, err := Load([]string{"-db-backend", "memory", "-db-uri", ""}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, BackendMemory, cfg.Database.Backend)
+}
+
+// TestLoad_PostgresBackend tests that the PostgreSQL backend only needs a connection string.
+func TestLoad_PostgresBackend(t *testing.T) {
+	vars := map[string]string{"DATABASE_BACKEND": "postgres", "DATABASE_DSN": "postgres://localhost/tasks"}
+	cfg, err := Load([]string{"-db-uri", ""}, env(vars))
+	require.NoError(t, err)
+	assert.Equal(t, BackendPostgres, cfg.Database.Backend)
+	assert.Equal(t, "postgres://localhost/tasks", cfg.Database.DSN)
+}
+
+// TestLoad_SQLiteBackend tests that the SQLite backend only needs a file path.
+func TestLoad_SQLiteBackend(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+database:
+  backend: sqlite
+  uri: ""
+  path: /var/lib/tasks/tasks.db
+`)
+	cfg, err := Load([]string{"-config", path}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, BackendSQLite, cfg.Database.Backend)
+	assert.Equal(t, "/var/lib/tasks/tasks.db", cfg.Database.Path)
+}
+
+// TestLoad_Workflow tests that the workflow of the config file replaces the default one and is validated.
+func TestLoad_Workflow(t *testing.T) {
+	cfg, err := Load(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultTaskWorkflow(), cfg.Workflow.TaskWorkflow())
+
+	path := writeFile(t, "config.yaml", `
+workflow:
+  initial: OPEN
+  transitions:
+    OPEN: [CLOSED]
+    CLOSED: []
+`)
+	cfg, err = Load([]string{"-config", path}, env(nil))
+	require.NoError(t, err)
+	workflow := cfg.Workflow.TaskWorkflow()
+	assert.Equal(t, "OPEN", workflow.Initial)
+	assert.Equal(t, []string{"CLOSED", "OPEN"}, workflow.Statuses())
+
+	path = writeFile(t, "bad.yaml", `
+workflow:
+  initial: OPEN
+  transitions:
+    OPEN: [DONE]
+`)
+	_, err = Load([]string{"-config", path}, env(nil))
+	assert.ErrorContains(t, err, "workflow")
+
+	path = writeFile(t, "initial.yaml", `
+workflow:
+  initial: OPEN
+`)
+	_, err = Load([]string{"-config", path}, env(nil))
+	assert.ErrorContains(t, err, "workflow.initial is set but workflow.transitions is empty")
+}
+
+// TestLoad_Trash tests the trash settings, from the defaults to the flags.
+func TestLoad_Trash(t *testing.T) {
+	cfg, err := Load(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, 720*time.Hour, cfg.Trash.Retention)
+	assert.Equal(t, time.Hour, cfg.Trash.PurgeInterval)
+
+	path := writeFile(t, "config.toml", `
+[trash]
+retention = "168h"
+purge_interval = "30m"
+`)
+	vars := map[string]string{"TRASH_PURGE_INTERVAL": "10m"}
+	cfg, err = Load([]string{"-config", path, "-trash-retention", "48h"}, env(vars))
+	require.NoError(t, err)
+	assert.Equal(t, 48*time.Hour, cfg.Trash.Retention)
+	assert.Equal(t, 10*time.Minute, cfg.Trash.PurgeInterval)
+}
+
+// TestLoad_Attachments tests the attachment settings, from the defaults to the flags.
+func TestLoad_Attachments(t *testing.T) {
+	cfg, err := Load(nil, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, StorageLocal, cfg.Attachments.Storage)
+	assert.Equal(t, int64(10<<20), cfg.Attachments.MaxSize)
+	assert.Contains(t, cfg.Attachments.AllowedTypes, "application/pdf")
+
+	path := writeFile(t, "config.yaml", `
+attachments:
+  storage: gridfs
+  max_size: 1024
+  allowed_types: [image/png]
+`)
+	vars := map[string]string{"ATTACHMENTS_MAX_SIZE": "2048"}
+	cfg, err = Load([]string{"-config", path, "-attachments-allowed-types", "image/png, text/plain"}, env(vars))
+	require.NoError(t, err)
+	assert.Equal(t, StorageGridFS, cfg.Attachments.Storage)
+	assert.Equal(t, int64(2048), cfg.Attachments.MaxSize)
+	assert.Equal(t, []string{"image/png", "text/plain"}, cfg.Attachments.AllowedTypes)
+	assert.Equal(t, int64(2048), cfg.Attachments.Limits().MaxSize)
+}
+
+// TestValidate_ReportsAllProblems tests that every invalid setting is reported, not only the first one.
+func TestValidate_ReportsAllProblems(t *testing.T) {
+	cfg := Default()
+	cfg.Server.Address = ""
+	cfg.Database.Name = ""
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server.address")
+	assert.Contains(t, err.Error(), "database.name")
+}
@@ -2,10 +2,12 @@ package db
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 var Client *mongo.Client
@@ -13,29 +15,43 @@ var Client *mongo.Client
 // ConnectDB establishes a connection to the MongoDB database using the provided URI.
 // It returns a pointer to the mongo.Database object representing the connected database.
 // The URI parameter specifies the connection string for the MongoDB server.
-// The function logs a fatal error and terminates the program if it fails to connect to the database.
+// The name parameter specifies the database to use.
+// The function returns an error if it fails to connect to the database or the server does not answer a ping
+// before ctx is done.
 // The function also sets the global variable Client to the connected client for future use.
-// The function assumes that the database name is "User_Task_Manager".
-func ConnectDB(uri string) *mongo.Database {
+func ConnectDB(ctx context.Context, uri string, name string) (*mongo.Database, error) {
 	clientOptions := options.Client().ApplyURI(uri)
-	client, err := mongo.Connect(context.TODO(), clientOptions)
+	client, err := mongo.Connect(ctx, clientOptions)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
-	if err = client.Ping(context.TODO(), nil); err != nil {
-		log.Fatal("failed to connect")
+	if err = client.Ping(ctx, nil); err != nil {
+		client.Disconnect(context.Background())
+		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
-	
+
 	Client = client
-	return client.Database("User_Task_Manager") // name of the database
+	return client.Database(name), nil
 }
 
-
 // DisconnectDB disconnects the database connection.
-// It calls the Disconnect method on the Client object and logs an error if it occurs.
-func DisconnectDB(){
-	if err := Client.Disconnect(context.TODO()); err != nil{
-		log.Fatal("failed to disconnect database")
+// It calls the Disconnect method on the Client object, waiting for in-use connections until ctx is done,
+// and returns an error if it occurs.
+func DisconnectDB(ctx context.Context) error {
+	if Client == nil {
+		return errors.New("database is not connected")
 	}
-	
-}
\ No newline at end of file
+	if err := Client.Disconnect(ctx); err != nil {
+		return fmt.Errorf("failed to disconnect database: %w", err)
+	}
+	return nil
+}
+
+// Ping checks that the MongoDB server answers before ctx is done.
+// It returns an error if the database is not connected or the server cannot be reached.
+func Ping(ctx context.Context) error {
+	if Client == nil {
+		return errors.New("database is not connected")
+	}
+	return Client.Ping(ctx, readpref.Primary())
+}
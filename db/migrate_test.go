@@ -0,0 +1,49 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadMigrations tests that the embedded migrations load in version order without gaps, alike for every dialect.
+func TestLoadMigrations(t *testing.T) {
+	postgres, err := LoadMigrations(Postgres)
+	require.NoError(t, err)
+	require.NotEmpty(t, postgres)
+	sqlite, err := LoadMigrations(SQLite)
+	require.NoError(t, err)
+	require.Len(t, sqlite, len(postgres), "every dialect has the same migrations")
+	for i := range postgres {
+		assert.Equal(t, i+1, postgres[i].Version)
+		assert.Equal(t, postgres[i].Name, sqlite[i].Name)
+		assert.NotEmpty(t, splitStatements(postgres[i].SQL), postgres[i].Name)
+	}
+
+	_, err = LoadMigrations(Dialect("oracle"))
+	assert.Error(t, err)
+}
+
+// TestSplitStatements tests that a script is split on the semicolons ending a line and comments are dropped.
+func TestSplitStatements(t *testing.T) {
+	script := `-- a comment
+CREATE TABLE a (
+    id INTEGER -- trailing comments stay in the statement
+);
+
+CREATE INDEX a_idx ON a (id);
+SELECT 1`
+	assert.Equal(t, []string{
+		"CREATE TABLE a (\n    id INTEGER -- trailing comments stay in the statement\n);",
+		"CREATE INDEX a_idx ON a (id);",
+		"SELECT 1",
+	}, splitStatements(script))
+}
+
+// TestRebind tests that placeholders are only rewritten for PostgreSQL.
+func TestRebind(t *testing.T) {
+	query := "SELECT id FROM tasks WHERE status = ? AND id > ? LIMIT ?"
+	assert.Equal(t, "SELECT id FROM tasks WHERE status = $1 AND id > $2 LIMIT $3", Postgres.Rebind(query))
+	assert.Equal(t, query, Dialect("other").Rebind(query))
+}
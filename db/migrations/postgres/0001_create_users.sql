@@ -0,0 +1,9 @@
+-- Users of the task manager. The first user to sign up is an admin.
+CREATE TABLE users (
+    id CHAR(24) PRIMARY KEY,
+    username TEXT NOT NULL UNIQUE,
+    password TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
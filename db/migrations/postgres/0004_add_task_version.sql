@@ -0,0 +1,2 @@
+-- Version of a task, incremented on every write for optimistic concurrency control.
+ALTER TABLE tasks ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,13 @@
+-- History of the changes made to the tasks. Entries outlive the tasks they describe.
+-- changes holds the JSON array of the changed fields with their values before and after.
+CREATE TABLE task_history (
+    id CHAR(24) PRIMARY KEY,
+    task_id CHAR(24) NOT NULL,
+    action TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    at TIMESTAMPTZ NOT NULL,
+    version BIGINT NOT NULL,
+    changes TEXT NOT NULL
+);
+
+CREATE INDEX task_history_task_id_idx ON task_history (task_id, id);
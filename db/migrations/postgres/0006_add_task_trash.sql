@@ -0,0 +1,5 @@
+-- Deleted tasks stay in the trash, with the time and author of the deletion, until they are purged.
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN deleted_by TEXT;
+
+CREATE INDEX tasks_deleted_at_idx ON tasks (deleted_at);
@@ -0,0 +1,11 @@
+-- Comments posted on the tasks, deleted with their task when it is purged from the trash.
+CREATE TABLE task_comments (
+    id CHAR(24) PRIMARY KEY,
+    task_id CHAR(24) NOT NULL,
+    author_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX task_comments_task_id_idx ON task_comments (task_id, id);
@@ -0,0 +1,12 @@
+-- Files attached to the tasks. The content is kept in the blob store under the id of the attachment.
+CREATE TABLE task_attachments (
+    id CHAR(24) PRIMARY KEY,
+    task_id CHAR(24) NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    uploaded_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX task_attachments_task_id_idx ON task_attachments (task_id, id);
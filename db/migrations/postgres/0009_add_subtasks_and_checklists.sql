@@ -0,0 +1,18 @@
+-- Tasks can be subtasks of another task, and have an ordered checklist of steps.
+ALTER TABLE tasks ADD COLUMN parent_id CHAR(24);
+
+CREATE INDEX tasks_parent_id_idx ON tasks (parent_id);
+
+CREATE TABLE task_checklist_items (
+    id CHAR(24) PRIMARY KEY,
+    task_id CHAR(24) NOT NULL,
+    title TEXT NOT NULL,
+    required BOOLEAN NOT NULL,
+    position INTEGER NOT NULL,
+    done BOOLEAN NOT NULL,
+    done_by TEXT,
+    done_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX task_checklist_items_task_id_idx ON task_checklist_items (task_id, position, id);
@@ -0,0 +1,10 @@
+-- Dependencies between tasks: task_id is blocked by depends_on_id until it is resolved.
+CREATE TABLE task_dependencies (
+    task_id CHAR(24) NOT NULL,
+    depends_on_id CHAR(24) NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (task_id, depends_on_id)
+);
+
+CREATE INDEX task_dependencies_depends_on_id_idx ON task_dependencies (depends_on_id);
@@ -0,0 +1,16 @@
+-- The label catalog and the labels of the tasks.
+-- Label names are normalized by the application and compare bytes, like MongoDB does.
+CREATE TABLE labels (
+    name TEXT COLLATE "C" PRIMARY KEY,
+    color CHAR(7) NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE task_labels (
+    task_id CHAR(24) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    label TEXT COLLATE "C" NOT NULL,
+    PRIMARY KEY (task_id, label)
+);
+
+CREATE INDEX task_labels_label_idx ON task_labels (label, task_id);
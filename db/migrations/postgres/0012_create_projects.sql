@@ -0,0 +1,22 @@
+-- Projects grouping tasks, and their members with their role in the project.
+CREATE TABLE projects (
+    id CHAR(24) PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE project_members (
+    project_id CHAR(24) NOT NULL REFERENCES projects (id) ON DELETE CASCADE,
+    user_id TEXT COLLATE "C" NOT NULL,
+    role TEXT NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX project_members_user_id_idx ON project_members (user_id);
+
+ALTER TABLE tasks ADD COLUMN project_id CHAR(24);
+
+CREATE INDEX tasks_project_id_idx ON tasks (project_id);
@@ -0,0 +1,11 @@
+-- Every user, task and project belongs to an organization; the existing ones to the default organization.
+-- Usernames are unique within an organization.
+ALTER TABLE users ADD COLUMN org_id TEXT COLLATE "C" NOT NULL DEFAULT 'default';
+ALTER TABLE users DROP CONSTRAINT users_username_key;
+ALTER TABLE users ADD CONSTRAINT users_org_id_username_key UNIQUE (org_id, username);
+
+ALTER TABLE tasks ADD COLUMN org_id TEXT COLLATE "C" NOT NULL DEFAULT 'default';
+ALTER TABLE projects ADD COLUMN org_id TEXT COLLATE "C" NOT NULL DEFAULT 'default';
+
+CREATE INDEX tasks_org_id_idx ON tasks (org_id);
+CREATE INDEX projects_org_id_idx ON projects (org_id);
@@ -0,0 +1,13 @@
+-- The roles of the organizations besides the built-in ADMIN and USER, as named sets of permissions.
+-- Role names are normalized by the application and compare bytes, like MongoDB does.
+-- The permissions are stored space separated, since they never hold spaces.
+CREATE TABLE roles (
+    org_id TEXT COLLATE "C" NOT NULL,
+    name TEXT COLLATE "C" NOT NULL,
+    description TEXT NOT NULL,
+    permissions TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (org_id, name)
+);
+
+CREATE INDEX users_org_id_role_idx ON users (org_id, role);
@@ -0,0 +1,5 @@
+-- Deactivated users keep their account but cannot log in until they are activated again.
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMPTZ;
+
+-- The sessions of a user are revoked together when it is deactivated or deleted.
+CREATE INDEX refresh_tokens_user_id_idx ON refresh_tokens (user_id);
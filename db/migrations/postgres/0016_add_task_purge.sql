@@ -0,0 +1,5 @@
+-- Tasks being purged are marked first, so that they can no longer be restored while what belongs to them
+-- is deleted, and a purge that fails in between is finished by the next one.
+ALTER TABLE tasks ADD COLUMN purge_started_at TIMESTAMPTZ;
+
+CREATE INDEX tasks_purge_started_at_idx ON tasks (purge_started_at);
@@ -0,0 +1,20 @@
+-- Every organization has its own label catalog, keyed by organization and name.
+-- The labels of the shared catalog are copied into every organization, whose tasks may already have them.
+ALTER TABLE labels ADD COLUMN org_id TEXT COLLATE "C" NOT NULL DEFAULT 'default';
+ALTER TABLE labels DROP CONSTRAINT labels_pkey;
+ALTER TABLE labels ADD PRIMARY KEY (org_id, name);
+ALTER TABLE labels ALTER COLUMN org_id DROP DEFAULT;
+
+INSERT INTO labels (org_id, name, color, description, created_at)
+SELECT organizations.org_id, labels.name, labels.color, labels.description, labels.created_at
+FROM labels, (SELECT org_id FROM users UNION SELECT org_id FROM tasks) AS organizations
+WHERE labels.org_id = 'default' AND organizations.org_id <> 'default';
+
+-- The labels of a task belong to the organization of the task.
+ALTER TABLE task_labels ADD COLUMN org_id TEXT COLLATE "C" NOT NULL DEFAULT 'default';
+
+UPDATE task_labels SET org_id = tasks.org_id FROM tasks WHERE tasks.id = task_labels.task_id;
+
+DROP INDEX task_labels_label_idx;
+
+CREATE INDEX task_labels_label_idx ON task_labels (org_id, label, task_id);
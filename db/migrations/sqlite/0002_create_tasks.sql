@@ -0,0 +1,24 @@
+-- Tasks and the users assigned to them.
+-- Text columns compare bytes with the default BINARY collation, like MongoDB does.
+CREATE TABLE tasks (
+    id TEXT PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    due_date TIMESTAMP NOT NULL,
+    status TEXT NOT NULL,
+    created_by TEXT NOT NULL
+);
+
+CREATE INDEX tasks_due_date_idx ON tasks (due_date, id);
+CREATE INDEX tasks_title_idx ON tasks (title, id);
+CREATE INDEX tasks_status_idx ON tasks (status, id);
+CREATE INDEX tasks_created_by_idx ON tasks (created_by);
+
+CREATE TABLE task_assignees (
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    PRIMARY KEY (task_id, position)
+);
+
+CREATE INDEX task_assignees_user_id_idx ON task_assignees (user_id);
@@ -0,0 +1,16 @@
+-- The label catalog and the labels of the tasks.
+-- Label names are normalized by the application.
+CREATE TABLE labels (
+    name TEXT PRIMARY KEY,
+    color TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE task_labels (
+    task_id TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    label TEXT NOT NULL,
+    PRIMARY KEY (task_id, label)
+);
+
+CREATE INDEX task_labels_label_idx ON task_labels (label, task_id);
@@ -0,0 +1,25 @@
+-- Every user, task and project belongs to an organization; the existing ones to the default organization.
+-- Usernames are unique within an organization, so the users table is rebuilt without its global constraint.
+CREATE TABLE users_by_organization (
+    id TEXT PRIMARY KEY,
+    org_id TEXT NOT NULL DEFAULT 'default',
+    username TEXT NOT NULL,
+    password TEXT NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (org_id, username)
+);
+
+INSERT INTO users_by_organization (id, username, password, role, created_at, updated_at)
+SELECT id, username, password, role, created_at, updated_at FROM users;
+
+DROP TABLE users;
+
+ALTER TABLE users_by_organization RENAME TO users;
+
+ALTER TABLE tasks ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE projects ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default';
+
+CREATE INDEX tasks_org_id_idx ON tasks (org_id);
+CREATE INDEX projects_org_id_idx ON projects (org_id);
@@ -0,0 +1,13 @@
+-- The roles of the organizations besides the built-in ADMIN and USER, as named sets of permissions.
+-- Role names are normalized by the application.
+-- The permissions are stored space separated, since they never hold spaces.
+CREATE TABLE roles (
+    org_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL,
+    permissions TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, name)
+);
+
+CREATE INDEX users_org_id_role_idx ON users (org_id, role);
@@ -0,0 +1,27 @@
+-- Every organization has its own label catalog, so the labels table is rebuilt keyed by organization and name.
+-- The labels of the shared catalog are copied into every organization, whose tasks may already have them.
+CREATE TABLE labels_by_organization (
+    org_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    color TEXT NOT NULL,
+    description TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (org_id, name)
+);
+
+INSERT INTO labels_by_organization (org_id, name, color, description, created_at)
+SELECT organizations.org_id, labels.name, labels.color, labels.description, labels.created_at
+FROM labels, (SELECT 'default' AS org_id UNION SELECT org_id FROM users UNION SELECT org_id FROM tasks) AS organizations;
+
+DROP TABLE labels;
+
+ALTER TABLE labels_by_organization RENAME TO labels;
+
+-- The labels of a task belong to the organization of the task.
+ALTER TABLE task_labels ADD COLUMN org_id TEXT NOT NULL DEFAULT 'default';
+
+UPDATE task_labels SET org_id = (SELECT tasks.org_id FROM tasks WHERE tasks.id = task_labels.task_id);
+
+DROP INDEX task_labels_label_idx;
+
+CREATE INDEX task_labels_label_idx ON task_labels (org_id, label, task_id);
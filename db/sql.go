@@ -0,0 +1,83 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	// registers the "pgx" database/sql driver
+	_ "github.com/jackc/pgx/v5/stdlib"
+	// registers the "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// Dialect is the SQL database a *sql.DB talks to.
+// Queries are written with ? placeholders and rewritten for the dialect by Rebind.
+type Dialect string
+
+// Supported SQL dialects.
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// driverNames maps the dialects to their database/sql driver.
+var driverNames = map[Dialect]string{
+	Postgres: "pgx",
+	SQLite:   "sqlite",
+}
+
+// Rebind rewrites the ? placeholders of query into the placeholders of the dialect.
+// Queries must not contain ? anywhere else, such as in string literals.
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// OpenSQL opens a database of the given dialect and checks that it answers before ctx is done.
+// The dsn parameter is the connection string of the driver.
+func OpenSQL(ctx context.Context, dialect Dialect, dsn string) (*sql.DB, error) {
+	driver, ok := driverNames[dialect]
+	if !ok {
+		return nil, fmt.Errorf("unsupported SQL dialect %q", dialect)
+	}
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	if dialect == SQLite {
+		// SQLite allows a single writer; one connection serializes the transactions of the process
+		// instead of failing them with SQLITE_BUSY, and keeps a :memory: database alive.
+		conn.SetMaxOpenConns(1)
+		conn.SetConnMaxIdleTime(0)
+		conn.SetConnMaxLifetime(0)
+	}
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	return conn, nil
+}
+
+// SQLiteDSN returns the connection string of the SQLite database stored in the file at path,
+// which is created if it does not exist. The path :memory: opens a database that lives as long as the connection.
+// Times are written in the SQLite format, which sorts chronologically for the UTC times the repositories store,
+// and transactions take the write lock when they begin, so other processes sharing the file wait for it.
+func SQLiteDSN(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)" +
+		"&_time_format=sqlite&_txlock=immediate"
+}
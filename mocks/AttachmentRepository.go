@@ -0,0 +1,151 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	Domain "example/go-clean-architecture/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AttachmentRepository is an autogenerated mock type for the AttachmentRepository type
+type AttachmentRepository struct {
+	mock.Mock
+}
+
+// CreateAttachment provides a mock function with given fields: ctx, attachment
+func (_m *AttachmentRepository) CreateAttachment(ctx context.Context, attachment Domain.Attachment) (Domain.Attachment, error) {
+	ret := _m.Called(ctx, attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAttachment")
+	}
+
+	var r0 Domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Attachment) (Domain.Attachment, error)); ok {
+		return rf(ctx, attachment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Attachment) Domain.Attachment); ok {
+		r0 = rf(ctx, attachment)
+	} else {
+		r0 = ret.Get(0).(Domain.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.Attachment) error); ok {
+		r1 = rf(ctx, attachment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, attachmentId
+func (_m *AttachmentRepository) DeleteAttachment(ctx context.Context, attachmentId string) error {
+	ret := _m.Called(ctx, attachmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, attachmentId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTaskAttachments provides a mock function with given fields: ctx, taskIds
+func (_m *AttachmentRepository) DeleteTaskAttachments(ctx context.Context, taskIds []string) error {
+	ret := _m.Called(ctx, taskIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTaskAttachments")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, taskIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAttachmentById provides a mock function with given fields: ctx, attachmentId
+func (_m *AttachmentRepository) FindAttachmentById(ctx context.Context, attachmentId string) (Domain.Attachment, error) {
+	ret := _m.Called(ctx, attachmentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAttachmentById")
+	}
+
+	var r0 Domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.Attachment, error)); ok {
+		return rf(ctx, attachmentId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.Attachment); ok {
+		r0 = rf(ctx, attachmentId)
+	} else {
+		r0 = ret.Get(0).(Domain.Attachment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, attachmentId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTaskAttachments provides a mock function with given fields: ctx, taskId
+func (_m *AttachmentRepository) FindTaskAttachments(ctx context.Context, taskId string) ([]Domain.Attachment, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTaskAttachments")
+	}
+
+	var r0 []Domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]Domain.Attachment, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []Domain.Attachment); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAttachmentRepository creates a new instance of AttachmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAttachmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AttachmentRepository {
+	mock := &AttachmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,131 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	Domain "example/go-clean-architecture/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CommentUseCase is an autogenerated mock type for the CommentUseCase type
+type CommentUseCase struct {
+	mock.Mock
+}
+
+// AddComment provides a mock function with given fields: ctx, taskId, body
+func (_m *CommentUseCase) AddComment(ctx context.Context, taskId string, body string) (Domain.Comment, error) {
+	ret := _m.Called(ctx, taskId, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddComment")
+	}
+
+	var r0 Domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Domain.Comment, error)); ok {
+		return rf(ctx, taskId, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) Domain.Comment); ok {
+		r0 = rf(ctx, taskId, body)
+	} else {
+		r0 = ret.Get(0).(Domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteComment provides a mock function with given fields: ctx, taskId, commentId
+func (_m *CommentUseCase) DeleteComment(ctx context.Context, taskId string, commentId string) error {
+	ret := _m.Called(ctx, taskId, commentId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskId, commentId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditComment provides a mock function with given fields: ctx, taskId, commentId, body
+func (_m *CommentUseCase) EditComment(ctx context.Context, taskId string, commentId string, body string) (Domain.Comment, error) {
+	ret := _m.Called(ctx, taskId, commentId, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditComment")
+	}
+
+	var r0 Domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (Domain.Comment, error)); ok {
+		return rf(ctx, taskId, commentId, body)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) Domain.Comment); ok {
+		r0 = rf(ctx, taskId, commentId, body)
+	} else {
+		r0 = ret.Get(0).(Domain.Comment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, taskId, commentId, body)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetComments provides a mock function with given fields: ctx, taskId, query
+func (_m *CommentUseCase) GetComments(ctx context.Context, taskId string, query Domain.CommentQuery) (Domain.CommentPage, error) {
+	ret := _m.Called(ctx, taskId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 Domain.CommentPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Domain.CommentQuery) (Domain.CommentPage, error)); ok {
+		return rf(ctx, taskId, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, Domain.CommentQuery) Domain.CommentPage); ok {
+		r0 = rf(ctx, taskId, query)
+	} else {
+		r0 = ret.Get(0).(Domain.CommentPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, Domain.CommentQuery) error); ok {
+		r1 = rf(ctx, taskId, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCommentUseCase creates a new instance of CommentUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCommentUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CommentUseCase {
+	mock := &CommentUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
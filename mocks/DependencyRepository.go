@@ -0,0 +1,143 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	Domain "example/go-clean-architecture/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DependencyRepository is an autogenerated mock type for the DependencyRepository type
+type DependencyRepository struct {
+	mock.Mock
+}
+
+// AddDependency provides a mock function with given fields: ctx, dependency
+func (_m *DependencyRepository) AddDependency(ctx context.Context, dependency Domain.TaskDependency) error {
+	ret := _m.Called(ctx, dependency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskDependency) error); ok {
+		r0 = rf(ctx, dependency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTaskDependencies provides a mock function with given fields: ctx, taskIds
+func (_m *DependencyRepository) DeleteTaskDependencies(ctx context.Context, taskIds []string) error {
+	ret := _m.Called(ctx, taskIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTaskDependencies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, taskIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindDependencies provides a mock function with given fields: ctx, taskIds
+func (_m *DependencyRepository) FindDependencies(ctx context.Context, taskIds []string) ([]Domain.TaskDependency, error) {
+	ret := _m.Called(ctx, taskIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDependencies")
+	}
+
+	var r0 []Domain.TaskDependency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]Domain.TaskDependency, error)); ok {
+		return rf(ctx, taskIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []Domain.TaskDependency); ok {
+		r0 = rf(ctx, taskIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Domain.TaskDependency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, taskIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDependents provides a mock function with given fields: ctx, taskIds
+func (_m *DependencyRepository) FindDependents(ctx context.Context, taskIds []string) ([]Domain.TaskDependency, error) {
+	ret := _m.Called(ctx, taskIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDependents")
+	}
+
+	var r0 []Domain.TaskDependency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]Domain.TaskDependency, error)); ok {
+		return rf(ctx, taskIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []Domain.TaskDependency); ok {
+		r0 = rf(ctx, taskIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Domain.TaskDependency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, taskIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDependency provides a mock function with given fields: ctx, taskId, dependsOnId
+func (_m *DependencyRepository) RemoveDependency(ctx context.Context, taskId string, dependsOnId string) error {
+	ret := _m.Called(ctx, taskId, dependsOnId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, taskId, dependsOnId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDependencyRepository creates a new instance of DependencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDependencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DependencyRepository {
+	mock := &DependencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	Domain "example/go-clean-architecture/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LabelRepository is an autogenerated mock type for the LabelRepository type
+type LabelRepository struct {
+	mock.Mock
+}
+
+// CreateLabel provides a mock function with given fields: ctx, label
+func (_m *LabelRepository) CreateLabel(ctx context.Context, label Domain.Label) (Domain.Label, error) {
+	ret := _m.Called(ctx, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLabel")
+	}
+
+	var r0 Domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Label) (Domain.Label, error)); ok {
+		return rf(ctx, label)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Label) Domain.Label); ok {
+		r0 = rf(ctx, label)
+	} else {
+		r0 = ret.Get(0).(Domain.Label)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.Label) error); ok {
+		r1 = rf(ctx, label)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLabel provides a mock function with given fields: ctx, name
+func (_m *LabelRepository) DeleteLabel(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLabel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindLabelByName provides a mock function with given fields: ctx, name
+func (_m *LabelRepository) FindLabelByName(ctx context.Context, name string) (Domain.Label, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLabelByName")
+	}
+
+	var r0 Domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.Label, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.Label); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(Domain.Label)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindLabels provides a mock function with given fields: ctx
+func (_m *LabelRepository) FindLabels(ctx context.Context) ([]Domain.Label, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindLabels")
+	}
+
+	var r0 []Domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Domain.Label, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Domain.Label); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Domain.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLabel provides a mock function with given fields: ctx, label
+func (_m *LabelRepository) UpdateLabel(ctx context.Context, label Domain.Label) (Domain.Label, error) {
+	ret := _m.Called(ctx, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLabel")
+	}
+
+	var r0 Domain.Label
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Label) (Domain.Label, error)); ok {
+		return rf(ctx, label)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Label) Domain.Label); ok {
+		r0 = rf(ctx, label)
+	} else {
+		r0 = ret.Get(0).(Domain.Label)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.Label) error); ok {
+		r1 = rf(ctx, label)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewLabelRepository creates a new instance of LabelRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLabelRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LabelRepository {
+	mock := &LabelRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	Domain "example/go-clean-architecture/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RoleUseCase is an autogenerated mock type for the RoleUseCase type
+type RoleUseCase struct {
+	mock.Mock
+}
+
+// CreateRole provides a mock function with given fields: ctx, role
+func (_m *RoleUseCase) CreateRole(ctx context.Context, role Domain.Role) (Domain.Role, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRole")
+	}
+
+	var r0 Domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Role) (Domain.Role, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.Role) Domain.Role); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(Domain.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.Role) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteRole provides a mock function with given fields: ctx, name
+func (_m *RoleUseCase) DeleteRole(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRole provides a mock function with given fields: ctx, name
+func (_m *RoleUseCase) GetRole(ctx context.Context, name string) (Domain.Role, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRole")
+	}
+
+	var r0 Domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.Role, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.Role); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(Domain.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRoles provides a mock function with given fields: ctx
+func (_m *RoleUseCase) GetRoles(ctx context.Context) ([]Domain.Role, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRoles")
+	}
+
+	var r0 []Domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Domain.Role, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Domain.Role); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Domain.Role)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRole provides a mock function with given fields: ctx, name, role
+func (_m *RoleUseCase) UpdateRole(ctx context.Context, name string, role Domain.Role) (Domain.Role, error) {
+	ret := _m.Called(ctx, name, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRole")
+	}
+
+	var r0 Domain.Role
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Domain.Role) (Domain.Role, error)); ok {
+		return rf(ctx, name, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, Domain.Role) Domain.Role); ok {
+		r0 = rf(ctx, name, role)
+	} else {
+		r0 = ret.Get(0).(Domain.Role)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, Domain.Role) error); ok {
+		r1 = rf(ctx, name, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewRoleUseCase creates a new instance of RoleUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRoleUseCase(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RoleUseCase {
+	mock := &RoleUseCase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,85 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	Domain "example/go-clean-architecture/Domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TaskHistoryRepository is an autogenerated mock type for the TaskHistoryRepository type
+type TaskHistoryRepository struct {
+	mock.Mock
+}
+
+// AppendTaskHistory provides a mock function with given fields: ctx, entry
+func (_m *TaskHistoryRepository) AppendTaskHistory(ctx context.Context, entry Domain.TaskHistoryEntry) (Domain.TaskHistoryEntry, error) {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendTaskHistory")
+	}
+
+	var r0 Domain.TaskHistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskHistoryEntry) (Domain.TaskHistoryEntry, error)); ok {
+		return rf(ctx, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskHistoryEntry) Domain.TaskHistoryEntry); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Get(0).(Domain.TaskHistoryEntry)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskHistoryEntry) error); ok {
+		r1 = rf(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindTaskHistory provides a mock function with given fields: ctx, query
+func (_m *TaskHistoryRepository) FindTaskHistory(ctx context.Context, query Domain.TaskHistoryQuery) (Domain.TaskHistoryPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTaskHistory")
+	}
+
+	var r0 Domain.TaskHistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskHistoryQuery) (Domain.TaskHistoryPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskHistoryQuery) Domain.TaskHistoryPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(Domain.TaskHistoryPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskHistoryQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTaskHistoryRepository creates a new instance of TaskHistoryRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTaskHistoryRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TaskHistoryRepository {
+	mock := &TaskHistoryRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 	Domain "example/go-clean-architecture/Domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TaskRepository is an autogenerated mock type for the TaskRepository type
@@ -14,6 +16,36 @@ type TaskRepository struct {
 	mock.Mock
 }
 
+// CountSubtasks provides a mock function with given fields: ctx, parentIds
+func (_m *TaskRepository) CountSubtasks(ctx context.Context, parentIds []string) (map[string]map[string]int, error) {
+	ret := _m.Called(ctx, parentIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSubtasks")
+	}
+
+	var r0 map[string]map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]map[string]int, error)); ok {
+		return rf(ctx, parentIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]map[string]int); ok {
+		r0 = rf(ctx, parentIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, parentIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTask provides a mock function with given fields: ctx, task
 func (_m *TaskRepository) CreateTask(ctx context.Context, task Domain.Task) (Domain.Task, error) {
 	ret := _m.Called(ctx, task)
@@ -42,22 +74,32 @@ func (_m *TaskRepository) CreateTask(ctx context.Context, task Domain.Task) (Dom
 	return r0, r1
 }
 
-// DeleteTask provides a mock function with given fields: ctx, taskId
-func (_m *TaskRepository) DeleteTask(ctx context.Context, taskId string) error {
-	ret := _m.Called(ctx, taskId)
+// DeleteTask provides a mock function with given fields: ctx, taskId, version, deletedBy, deletedAt
+func (_m *TaskRepository) DeleteTask(ctx context.Context, taskId string, version int64, deletedBy string, deletedAt time.Time) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId, version, deletedBy, deletedAt)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTask")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, taskId)
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, time.Time) (Domain.Task, error)); ok {
+		return rf(ctx, taskId, version, deletedBy, deletedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, string, time.Time) Domain.Task); ok {
+		r0 = rf(ctx, taskId, version, deletedBy, deletedAt)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(Domain.Task)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, string, time.Time) error); ok {
+		r1 = rf(ctx, taskId, version, deletedBy, deletedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // FindAlltasks provides a mock function with given fields: ctx
@@ -90,6 +132,34 @@ func (_m *TaskRepository) FindAlltasks(ctx context.Context) ([]Domain.Task, erro
 	return r0, r1
 }
 
+// FindDeletedTaskById provides a mock function with given fields: ctx, taskId
+func (_m *TaskRepository) FindDeletedTaskById(ctx context.Context, taskId string) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDeletedTaskById")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.Task, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.Task); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindTaskById provides a mock function with given fields: ctx, taskId
 func (_m *TaskRepository) FindTaskById(ctx context.Context, taskId string) (Domain.Task, error) {
 	ret := _m.Called(ctx, taskId)
@@ -118,6 +188,166 @@ func (_m *TaskRepository) FindTaskById(ctx context.Context, taskId string) (Doma
 	return r0, r1
 }
 
+// FindTasks provides a mock function with given fields: ctx, query
+func (_m *TaskRepository) FindTasks(ctx context.Context, query Domain.TaskQuery) (Domain.TaskPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTasks")
+	}
+
+	var r0 Domain.TaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskQuery) (Domain.TaskPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskQuery) Domain.TaskPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(Domain.TaskPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchTaskById provides a mock function with given fields: ctx, patch, id
+func (_m *TaskRepository) PatchTaskById(ctx context.Context, patch Domain.TaskPatch, id string) (Domain.Task, error) {
+	ret := _m.Called(ctx, patch, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTaskById")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskPatch, string) (Domain.Task, error)); ok {
+		return rf(ctx, patch, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskPatch, string) Domain.Task); ok {
+		r0 = rf(ctx, patch, id)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskPatch, string) error); ok {
+		r1 = rf(ctx, patch, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTasks provides a mock function with given fields: ctx, taskIds
+func (_m *TaskRepository) PurgeTasks(ctx context.Context, taskIds []string) error {
+	ret := _m.Called(ctx, taskIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, taskIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReassignTasks provides a mock function with given fields: ctx, fromUserId, toUserId
+func (_m *TaskRepository) ReassignTasks(ctx context.Context, fromUserId string, toUserId string) (int64, error) {
+	ret := _m.Called(ctx, fromUserId, toUserId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, fromUserId, toUserId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, fromUserId, toUserId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, fromUserId, toUserId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreTask provides a mock function with given fields: ctx, taskId
+func (_m *TaskRepository) RestoreTask(ctx context.Context, taskId string) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.Task, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.Task); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartPurge provides a mock function with given fields: ctx, deletedBefore
+func (_m *TaskRepository) StartPurge(ctx context.Context, deletedBefore time.Time) ([]string, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartPurge")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]string, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []string); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateTaskById provides a mock function with given fields: ctx, task, id
 func (_m *TaskRepository) UpdateTaskById(ctx context.Context, task Domain.Task, id string) (Domain.Task, error) {
 	ret := _m.Called(ctx, task, id)
@@ -7,6 +7,8 @@ import (
 	Domain "example/go-clean-architecture/Domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TaskUseCase is an autogenerated mock type for the TaskUseCase type
@@ -14,6 +16,34 @@ type TaskUseCase struct {
 	mock.Mock
 }
 
+// AddDependency provides a mock function with given fields: ctx, taskId, dependsOnId
+func (_m *TaskUseCase) AddDependency(ctx context.Context, taskId string, dependsOnId string) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId, dependsOnId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddDependency")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Domain.Task, error)); ok {
+		return rf(ctx, taskId, dependsOnId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) Domain.Task); ok {
+		r0 = rf(ctx, taskId, dependsOnId)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, dependsOnId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddNewTask provides a mock function with given fields: ctx, task
 func (_m *TaskUseCase) AddNewTask(ctx context.Context, task Domain.Task) (Domain.Task, error) {
 	ret := _m.Called(ctx, task)
@@ -42,17 +72,17 @@ func (_m *TaskUseCase) AddNewTask(ctx context.Context, task Domain.Task) (Domain
 	return r0, r1
 }
 
-// DeleteTaskById provides a mock function with given fields: ctx, taskId
-func (_m *TaskUseCase) DeleteTaskById(ctx context.Context, taskId string) error {
-	ret := _m.Called(ctx, taskId)
+// DeleteTaskById provides a mock function with given fields: ctx, taskId, version
+func (_m *TaskUseCase) DeleteTaskById(ctx context.Context, taskId string, version int64) error {
+	ret := _m.Called(ctx, taskId, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTaskById")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, taskId)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, taskId, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -60,29 +90,27 @@ func (_m *TaskUseCase) DeleteTaskById(ctx context.Context, taskId string) error
 	return r0
 }
 
-// GetAllTasks provides a mock function with given fields: ctx
-func (_m *TaskUseCase) GetAllTasks(ctx context.Context) ([]Domain.Task, error) {
-	ret := _m.Called(ctx)
+// GetDeletedTasks provides a mock function with given fields: ctx, query
+func (_m *TaskUseCase) GetDeletedTasks(ctx context.Context, query Domain.TaskQuery) (Domain.TaskPage, error) {
+	ret := _m.Called(ctx, query)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetAllTasks")
+		panic("no return value specified for GetDeletedTasks")
 	}
 
-	var r0 []Domain.Task
+	var r0 Domain.TaskPage
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]Domain.Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskQuery) (Domain.TaskPage, error)); ok {
+		return rf(ctx, query)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []Domain.Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskQuery) Domain.TaskPage); ok {
+		r0 = rf(ctx, query)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Domain.Task)
-		}
+		r0 = ret.Get(0).(Domain.TaskPage)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskQuery) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -118,6 +146,90 @@ func (_m *TaskUseCase) GetTaskByID(ctx context.Context, taskId string) (Domain.T
 	return r0, r1
 }
 
+// GetTaskGraph provides a mock function with given fields: ctx, taskId
+func (_m *TaskUseCase) GetTaskGraph(ctx context.Context, taskId string) (Domain.TaskGraph, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskGraph")
+	}
+
+	var r0 Domain.TaskGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.TaskGraph, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.TaskGraph); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		r0 = ret.Get(0).(Domain.TaskGraph)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTaskHistory provides a mock function with given fields: ctx, taskId, query
+func (_m *TaskUseCase) GetTaskHistory(ctx context.Context, taskId string, query Domain.TaskHistoryQuery) (Domain.TaskHistoryPage, error) {
+	ret := _m.Called(ctx, taskId, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskHistory")
+	}
+
+	var r0 Domain.TaskHistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, Domain.TaskHistoryQuery) (Domain.TaskHistoryPage, error)); ok {
+		return rf(ctx, taskId, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, Domain.TaskHistoryQuery) Domain.TaskHistoryPage); ok {
+		r0 = rf(ctx, taskId, query)
+	} else {
+		r0 = ret.Get(0).(Domain.TaskHistoryPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, Domain.TaskHistoryQuery) error); ok {
+		r1 = rf(ctx, taskId, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTasks provides a mock function with given fields: ctx, query
+func (_m *TaskUseCase) GetTasks(ctx context.Context, query Domain.TaskQuery) (Domain.TaskPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasks")
+	}
+
+	var r0 Domain.TaskPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskQuery) (Domain.TaskPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskQuery) Domain.TaskPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(Domain.TaskPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ModifyTaskById provides a mock function with given fields: ctx, task, taskId
 func (_m *TaskUseCase) ModifyTaskById(ctx context.Context, task Domain.Task, taskId string) (Domain.Task, error) {
 	ret := _m.Called(ctx, task, taskId)
@@ -146,6 +258,146 @@ func (_m *TaskUseCase) ModifyTaskById(ctx context.Context, task Domain.Task, tas
 	return r0, r1
 }
 
+// PatchTaskById provides a mock function with given fields: ctx, patch, taskId
+func (_m *TaskUseCase) PatchTaskById(ctx context.Context, patch Domain.TaskPatch, taskId string) (Domain.Task, error) {
+	ret := _m.Called(ctx, patch, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchTaskById")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskPatch, string) (Domain.Task, error)); ok {
+		return rf(ctx, patch, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.TaskPatch, string) Domain.Task); ok {
+		r0 = rf(ctx, patch, taskId)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.TaskPatch, string) error); ok {
+		r1 = rf(ctx, patch, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedTasks provides a mock function with given fields: ctx, retention
+func (_m *TaskUseCase) PurgeDeletedTasks(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (int64, error)); ok {
+		return rf(ctx, retention)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveDependency provides a mock function with given fields: ctx, taskId, dependsOnId
+func (_m *TaskUseCase) RemoveDependency(ctx context.Context, taskId string, dependsOnId string) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId, dependsOnId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDependency")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Domain.Task, error)); ok {
+		return rf(ctx, taskId, dependsOnId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) Domain.Task); ok {
+		r0 = rf(ctx, taskId, dependsOnId)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, dependsOnId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreTaskById provides a mock function with given fields: ctx, taskId
+func (_m *TaskUseCase) RestoreTaskById(ctx context.Context, taskId string) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTaskById")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.Task, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.Task); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionTask provides a mock function with given fields: ctx, taskId, status
+func (_m *TaskUseCase) TransitionTask(ctx context.Context, taskId string, status string) (Domain.Task, error) {
+	ret := _m.Called(ctx, taskId, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionTask")
+	}
+
+	var r0 Domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Domain.Task, error)); ok {
+		return rf(ctx, taskId, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) Domain.Task); ok {
+		r0 = rf(ctx, taskId, status)
+	} else {
+		r0 = ret.Get(0).(Domain.Task)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTaskUseCase creates a new instance of TaskUseCase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTaskUseCase(t interface {
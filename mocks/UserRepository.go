@@ -7,6 +7,8 @@ import (
 	Domain "example/go-clean-architecture/Domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -14,6 +16,34 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CountUsersWithRole provides a mock function with given fields: ctx, role
+func (_m *UserRepository) CountUsersWithRole(ctx context.Context, role string) (int64, error) {
+	ret := _m.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsersWithRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNewUser provides a mock function with given fields: ctx, user
 func (_m *UserRepository) CreateNewUser(ctx context.Context, user *Domain.User) (Domain.User, error) {
 	ret := _m.Called(ctx, user)
@@ -42,14 +72,88 @@ func (_m *UserRepository) CreateNewUser(ctx context.Context, user *Domain.User)
 	return r0, r1
 }
 
-// FindUser provides a mock function with given fields: ctx, userId
-func (_m *UserRepository) FindUser(ctx context.Context, userId string) (Domain.User, error) {
+// CreateOrganization provides a mock function with given fields: ctx, admin
+func (_m *UserRepository) CreateOrganization(ctx context.Context, admin *Domain.User) (Domain.User, error) {
+	ret := _m.Called(ctx, admin)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrganization")
+	}
+
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Domain.User) (Domain.User, error)); ok {
+		return rf(ctx, admin)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Domain.User) Domain.User); ok {
+		r0 = rf(ctx, admin)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Domain.User) error); ok {
+		r1 = rf(ctx, admin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: ctx, userId
+func (_m *UserRepository) DeleteUser(ctx context.Context, userId string) error {
 	ret := _m.Called(ctx, userId)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindUser provides a mock function with given fields: ctx, username
+func (_m *UserRepository) FindUser(ctx context.Context, username string) (Domain.User, error) {
+	ret := _m.Called(ctx, username)
+
 	if len(ret) == 0 {
 		panic("no return value specified for FindUser")
 	}
 
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindUserById provides a mock function with given fields: ctx, userId
+func (_m *UserRepository) FindUserById(ctx context.Context, userId string) (Domain.User, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUserById")
+	}
+
 	var r0 Domain.User
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.User, error)); ok {
@@ -70,6 +174,34 @@ func (_m *UserRepository) FindUser(ctx context.Context, userId string) (Domain.U
 	return r0, r1
 }
 
+// FindUsers provides a mock function with given fields: ctx, query
+func (_m *UserRepository) FindUsers(ctx context.Context, query Domain.UserQuery) (Domain.UserPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUsers")
+	}
+
+	var r0 Domain.UserPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.UserQuery) (Domain.UserPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.UserQuery) Domain.UserPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(Domain.UserPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.UserQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PromoteUser provides a mock function with given fields: ctx, userId
 func (_m *UserRepository) PromoteUser(ctx context.Context, userId string) error {
 	ret := _m.Called(ctx, userId)
@@ -88,6 +220,42 @@ func (_m *UserRepository) PromoteUser(ctx context.Context, userId string) error
 	return r0
 }
 
+// SetUserDeactivated provides a mock function with given fields: ctx, userId, deactivatedAt
+func (_m *UserRepository) SetUserDeactivated(ctx context.Context, userId string, deactivatedAt *time.Time) error {
+	ret := _m.Called(ctx, userId, deactivatedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserDeactivated")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *time.Time) error); ok {
+		r0 = rf(ctx, userId, deactivatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetUserRole provides a mock function with given fields: ctx, userId, role
+func (_m *UserRepository) SetUserRole(ctx context.Context, userId string, role string) error {
+	ret := _m.Called(ctx, userId, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUserRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
@@ -14,34 +14,90 @@ type UserUseCase struct {
 	mock.Mock
 }
 
-// AuthenticateUser provides a mock function with given fields: ctx, userName, password
-func (_m *UserUseCase) AuthenticateUser(ctx context.Context, userName string, password string) (Domain.User, string, error) {
-	ret := _m.Called(ctx, userName, password)
+// ActivateUser provides a mock function with given fields: ctx, id
+func (_m *UserUseCase) ActivateUser(ctx context.Context, id string) (Domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ActivateUser")
+	}
+
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AssignUserRole provides a mock function with given fields: ctx, id, role
+func (_m *UserUseCase) AssignUserRole(ctx context.Context, id string, role string) (Domain.User, error) {
+	ret := _m.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignUserRole")
+	}
+
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Domain.User, error)); ok {
+		return rf(ctx, id, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) Domain.User); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthenticateUser provides a mock function with given fields: ctx, orgId, userName, password
+func (_m *UserUseCase) AuthenticateUser(ctx context.Context, orgId string, userName string, password string) (Domain.User, Domain.TokenPair, error) {
+	ret := _m.Called(ctx, orgId, userName, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AuthenticateUser")
 	}
 
 	var r0 Domain.User
-	var r1 string
+	var r1 Domain.TokenPair
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (Domain.User, string, error)); ok {
-		return rf(ctx, userName, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (Domain.User, Domain.TokenPair, error)); ok {
+		return rf(ctx, orgId, userName, password)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) Domain.User); ok {
-		r0 = rf(ctx, userName, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) Domain.User); ok {
+		r0 = rf(ctx, orgId, userName, password)
 	} else {
 		r0 = ret.Get(0).(Domain.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) string); ok {
-		r1 = rf(ctx, userName, password)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) Domain.TokenPair); ok {
+		r1 = rf(ctx, orgId, userName, password)
 	} else {
-		r1 = ret.Get(1).(string)
+		r1 = ret.Get(1).(Domain.TokenPair)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
-		r2 = rf(ctx, userName, password)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, string) error); ok {
+		r2 = rf(ctx, orgId, userName, password)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -77,6 +133,182 @@ func (_m *UserUseCase) CreateAccount(ctx context.Context, user *Domain.User) (Do
 	return r0, r1
 }
 
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *UserUseCase) CreateUser(ctx context.Context, user *Domain.User) (Domain.User, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *Domain.User) (Domain.User, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *Domain.User) Domain.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *Domain.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateUser provides a mock function with given fields: ctx, id
+func (_m *UserUseCase) DeactivateUser(ctx context.Context, id string) (Domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeactivateUser")
+	}
+
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteUser provides a mock function with given fields: ctx, id, reassignTo
+func (_m *UserUseCase) DeleteUser(ctx context.Context, id string, reassignTo string) error {
+	ret := _m.Called(ctx, id, reassignTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, reassignTo)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetUser provides a mock function with given fields: ctx, id
+func (_m *UserUseCase) GetUser(ctx context.Context, id string) (Domain.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 Domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Domain.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsers provides a mock function with given fields: ctx, query
+func (_m *UserUseCase) GetUsers(ctx context.Context, query Domain.UserQuery) (Domain.UserPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsers")
+	}
+
+	var r0 Domain.UserPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.UserQuery) (Domain.UserPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, Domain.UserQuery) Domain.UserPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(Domain.UserPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, Domain.UserQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, refreshToken
+func (_m *UserUseCase) Logout(ctx context.Context, refreshToken string) error {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Logout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshSession provides a mock function with given fields: ctx, refreshToken
+func (_m *UserUseCase) RefreshSession(ctx context.Context, refreshToken string) (Domain.TokenPair, error) {
+	ret := _m.Called(ctx, refreshToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshSession")
+	}
+
+	var r0 Domain.TokenPair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Domain.TokenPair, error)); ok {
+		return rf(ctx, refreshToken)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Domain.TokenPair); ok {
+		r0 = rf(ctx, refreshToken)
+	} else {
+		r0 = ret.Get(0).(Domain.TokenPair)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, refreshToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateUserRole provides a mock function with given fields: ctx, id
 func (_m *UserUseCase) UpdateUserRole(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)